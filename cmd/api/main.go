@@ -16,27 +16,25 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/gofiber/fiber/v2/middleware/recover"
-	"github.com/gofiber/contrib/websocket"
 	"github.com/joho/godotenv"
-	"github.com/swaggo/fiber-swagger"
-	
-	_ "github.com/nabiilNajm26/go-bank/docs"
-	"github.com/nabiilNajm26/go-bank/internal/delivery/http"
-	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/fx"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/cache"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/database"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/redis"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/s3"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/session"
+	"github.com/nabiilNajm26/go-bank/internal/jobs"
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
 	"github.com/nabiilNajm26/go-bank/internal/repository/cached"
 	"github.com/nabiilNajm26/go-bank/internal/repository/postgres"
+	"github.com/nabiilNajm26/go-bank/internal/risk"
+	"github.com/nabiilNajm26/go-bank/internal/screening"
+	"github.com/nabiilNajm26/go-bank/internal/server"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
 	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
 )
 
 func main() {
@@ -84,7 +82,22 @@ func main() {
 	// Initialize repositories (with caching if Redis available)
 	userRepoBase := postgres.NewUserRepository(db)
 	accountRepoBase := postgres.NewAccountRepository(db)
+	accountMemberRepo := postgres.NewAccountMemberRepository(db)
 	transactionRepo := postgres.NewTransactionRepository(db)
+	payeeRepo := postgres.NewPayeeRepository(db)
+	limitRepo := postgres.NewLimitRepository(db)
+	reviewRepo := postgres.NewReviewRepository(db)
+	deviceRepo := postgres.NewDeviceRepository(db)
+	holdRepo := postgres.NewHoldRepository(db)
+	screeningAlertRepo := postgres.NewScreeningAlertRepository(db)
+	interestRepo := postgres.NewInterestRepository(db)
+	termDepositRepo := postgres.NewTermDepositRepository(db)
+	feeRepo := postgres.NewFeeRepository(db)
+	transferApprovalRepo := postgres.NewTransferApprovalRepository(db)
+	organizationRepo := postgres.NewOrganizationRepository(db)
+	paymentDraftRepo := postgres.NewPaymentDraftRepository(db)
+	potRepo := postgres.NewPotRepository(db)
+	pocketRepo := postgres.NewPocketRepository(db)
 
 	var userRepo repository.UserRepository
 	var accountRepo repository.AccountRepository
@@ -98,6 +111,13 @@ func main() {
 		accountRepo = accountRepoBase
 	}
 
+	// Balance writers run in a unit of work, which drops the cached copies
+	// of every account they touched once they commit
+	unitOfWork := postgres.NewUnitOfWork(db)
+	if cacheService != nil {
+		unitOfWork.OnAccountsChanged(cacheService.InvalidateAccounts)
+	}
+
 	// Initialize JWT manager
 	jwtManager := utils.NewJWTManager(
 		getEnv("JWT_ACCESS_SECRET", "access-secret-key"),
@@ -112,17 +132,80 @@ func main() {
 		sessionService = session.NewSessionService(cacheService)
 	}
 
-	// Initialize use cases
-	var authUseCase *usecase.AuthUseCase
-	if sessionService != nil {
-		authUseCase = usecase.NewAuthUseCase(userRepo, jwtManager, sessionService)
-	} else {
-		authUseCase = usecase.NewAuthUseCase(userRepo, jwtManager, nil)
+	// Transfer limits (defaults can be overridden with a JSON policy file)
+	limitPolicy := usecase.DefaultLimitPolicy()
+	if path := os.Getenv("LIMITS_CONFIG"); path != "" {
+		limitPolicy, err = usecase.LoadLimitPolicy(path)
+		if err != nil {
+			log.Fatal("Failed to load limit policy:", err)
+		}
+	}
+
+	// Interest products and term deposits (YAML or JSON, defaults if unset)
+	interestPolicy := usecase.DefaultInterestPolicy()
+	if path := os.Getenv("INTEREST_PRODUCTS"); path != "" {
+		interestPolicy, err = usecase.LoadInterestPolicy(path)
+		if err != nil {
+			log.Fatal("Failed to load interest products:", err)
+		}
+	}
+
+	// Account numbers: check digit algorithm and length, and optionally the
+	// country and bank code to issue IBANs for
+	defaultNumbers := accountnumber.DefaultConfig()
+	accountDigits, _ := strconv.Atoi(getEnv("ACCOUNT_NUMBER_DIGITS", strconv.Itoa(defaultNumbers.Digits)))
+	accountNumbers, err := accountnumber.New(accountnumber.Config{
+		Check:    getEnv("ACCOUNT_NUMBER_CHECK", defaultNumbers.Check),
+		Digits:   accountDigits,
+		Country:  os.Getenv("IBAN_COUNTRY"),
+		BankCode: os.Getenv("IBAN_BANK_CODE"),
+	})
+	if err != nil {
+		log.Fatal("Invalid account number scheme:", err)
+	}
+
+	// Exchange rates for currency pockets (YAML or JSON, defaults if unset)
+	// and the spread customers convert at
+	fxRates := fx.DefaultTable()
+	if path := os.Getenv("FX_RATES"); path != "" {
+		fxRates, err = fx.LoadTable(path)
+		if err != nil {
+			log.Fatal("Failed to load fx rates:", err)
+		}
+	}
+	fxSpread, err := decimal.NewFromString(getEnv("FX_SPREAD", usecase.DefaultFXSpread.String()))
+	if err != nil || fxSpread.IsNegative() || fxSpread.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		log.Fatal("Invalid FX_SPREAD: must be a fraction between 0 and 1")
+	}
+
+	// Accounts without customer activity for this many months go dormant
+	dormancyMonths, _ := strconv.Atoi(getEnv("DORMANCY_MONTHS", strconv.Itoa(usecase.DefaultDormancyMonths)))
+
+	// Risk screening rules (YAML or JSON, defaults if unset)
+	riskRules := risk.DefaultRules()
+	if path := os.Getenv("RISK_RULES"); path != "" {
+		riskRules, err = risk.LoadRules(path)
+		if err != nil {
+			log.Fatal("Failed to load risk rules:", err)
+		}
+	}
+	riskEngine := risk.NewEngine(riskRules, transactionRepo, deviceRepo)
+
+	// Sanctions screening against a local OFAC SDN CSV (disabled if unset)
+	var screener *screening.Screener
+	if path := os.Getenv("WATCHLIST_PATH"); path != "" {
+		blockThreshold, _ := strconv.ParseFloat(getEnv("WATCHLIST_BLOCK_THRESHOLD", "0"), 64)
+		reviewThreshold, _ := strconv.ParseFloat(getEnv("WATCHLIST_REVIEW_THRESHOLD", "0"), 64)
+		screener, err = screening.NewScreener(screening.Config{
+			Path:            path,
+			BlockThreshold:  blockThreshold,
+			ReviewThreshold: reviewThreshold,
+		})
+		if err != nil {
+			log.Fatal("Failed to load watchlist:", err)
+		}
+		log.Printf("✅ Watchlist loaded: %d entries", screener.Size())
 	}
-	accountUseCase := usecase.NewAccountUseCase(accountRepo, userRepo)
-	transactionUseCase := usecase.NewTransactionUseCase(transactionRepo, accountRepo, db)
-	statementUseCase := usecase.NewStatementUseCase(accountRepo, transactionRepo)
-	userUseCase := usecase.NewUserUseCase(userRepo, accountRepo)
 
 	// Initialize S3 service (optional)
 	var s3Service *s3.S3Service
@@ -138,89 +221,96 @@ func main() {
 		}
 	}
 
-	// Initialize handlers
-	authHandler := http.NewAuthHandler(authUseCase)
-	accountHandler := http.NewAccountHandler(accountUseCase)
-	transactionHandler := http.NewTransactionHandler(transactionUseCase)
-	statementHandler := http.NewStatementHandler(statementUseCase)
-	userHandler := http.NewUserHandler(userUseCase, s3Service)
-	wsHandler := http.NewWebSocketHandler()
+	// Idempotency keys live in Postgres unless Redis is asked for and up
+	idempotencyRepo := postgres.NewIdempotencyRepository(db)
+	if getEnv("IDEMPOTENCY_STORE", "postgres") == "redis" {
+		if redisClient != nil {
+			idempotencyRepo = redis.NewIdempotencyRepository(redisClient)
+			log.Println("✅ Idempotency keys stored in Redis")
+		} else {
+			log.Println("Warning: IDEMPOTENCY_STORE=redis but Redis is unavailable. Using Postgres.")
+		}
+	}
 
-	// Setup Fiber app
-	app := fiber.New(fiber.Config{
-		ErrorHandler: customErrorHandler,
-	})
+	// Rate limits are shared between replicas through Redis, falling back to
+	// per-replica counts while it is unreachable
+	rateLimits := ratelimit.DefaultPolicies()
+	if path := os.Getenv("RATE_LIMITS"); path != "" {
+		rateLimits, err = ratelimit.LoadPolicies(path)
+		if err != nil {
+			log.Fatal("Failed to load rate limits:", err)
+		}
+	}
+	var rateLimiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	if redisClient != nil {
+		rateLimiter = ratelimit.NewFallbackLimiter(redis.NewRateLimiter(redisClient), rateLimiter)
+	}
 
-	// Middleware
-	app.Use(logger.New())
-	app.Use(recover.New())
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization, Idempotency-Key",
-		AllowMethods: "GET, HEAD, PUT, PATCH, POST, DELETE",
-	}))
-	app.Use(middleware.RateLimitMiddleware())
-
-	// Swagger documentation
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
-
-	// Routes
-	api := app.Group("/api/v1")
-
-	// Auth routes (with strict rate limiting)
-	auth := api.Group("/auth")
-	auth.Use(middleware.StrictRateLimitMiddleware())
-	auth.Post("/register", authHandler.Register)
-	auth.Post("/login", authHandler.Login)
-	auth.Post("/refresh", authHandler.RefreshToken)
-
-	// Protected routes
-	protected := api.Use(middleware.AuthMiddleware(jwtManager))
-	protected.Use(middleware.IdempotencyMiddleware(db))
-
-	// Account routes
-	accounts := protected.Group("/accounts")
-	accounts.Post("/", accountHandler.CreateAccount)
-	accounts.Get("/", accountHandler.GetUserAccounts)
-	accounts.Get("/:id", accountHandler.GetAccount)
-	accounts.Put("/:id", accountHandler.UpdateAccount)
-	accounts.Delete("/:id", accountHandler.DeleteAccount)
-
-	// Transaction routes
-	transactions := protected.Group("/transactions")
-	transactions.Post("/transfer", transactionHandler.Transfer)
-	transactions.Get("/", transactionHandler.GetTransactionHistory)
-
-	// Statement routes
-	statements := protected.Group("/statements")
-	statements.Get("/:account_id/pdf", statementHandler.GeneratePDFStatement)
-	statements.Get("/:account_id/csv", statementHandler.GenerateCSVStatement)
-
-	// User routes
-	users := protected.Group("/users")
-	users.Get("/profile", userHandler.GetProfile)
-	users.Put("/profile", userHandler.UpdateProfile)
-	users.Delete("/profile", userHandler.DeleteProfile)
-	if s3Service != nil {
-		users.Use(middleware.FileUploadMiddleware())
-		users.Post("/profile/image", userHandler.UploadProfileImage)
-	}
-
-	// WebSocket route
-	app.Get("/ws", websocket.New(wsHandler.HandleConnection))
-
-	// Health check
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status": "healthy",
-			"time":   time.Now(),
-		})
+	deps := &server.Deps{
+		Users:             userRepo,
+		Accounts:          accountRepo,
+		AccountMembers:    accountMemberRepo,
+		Transactions:      transactionRepo,
+		Payees:            payeeRepo,
+		Limits:            limitRepo,
+		Reviews:           reviewRepo,
+		Devices:           deviceRepo,
+		Holds:             holdRepo,
+		Interest:          interestRepo,
+		TermDeposits:      termDepositRepo,
+		Fees:              feeRepo,
+		TransferApprovals: transferApprovalRepo,
+		Organizations:     organizationRepo,
+		PaymentDrafts:     paymentDraftRepo,
+		Pots:              potRepo,
+		Pockets:           pocketRepo,
+		ScreeningAlerts:   screeningAlertRepo,
+		Idempotency:       idempotencyRepo,
+		UnitOfWork:        unitOfWork,
+		JWT:               jwtManager,
+		Sessions:          sessionService,
+		LimitPolicy:       limitPolicy,
+		InterestPolicy:    interestPolicy,
+		AccountNumbers:    accountNumbers,
+		DormancyMonths:    dormancyMonths,
+		FXRates:           fxRates,
+		FXSpread:          decimal.NewNullDecimal(fxSpread),
+		Risk:              riskEngine,
+		S3:                s3Service,
+		RateLimiter:       rateLimiter,
+		RateLimits:        rateLimits,
+	}
+	if screener != nil {
+		deps.Screener = screener
+	}
+	srv := server.New(deps)
+
+	// Background jobs
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobRunner := jobs.NewRunner()
+	jobRunner.Add("hold-expiry", time.Minute, srv.Holds.ExpireHolds)
+	jobRunner.Add("interest-accrual", time.Hour, srv.Interest.AccrueInterest)
+	jobRunner.Add("term-deposit-maturity", time.Hour, srv.TermDeposits.ProcessMaturities)
+	jobRunner.Add("monthly-fees", time.Hour, srv.Fees.ChargeMonthlyFees)
+	jobRunner.Add("overdraft-interest", time.Hour, srv.Overdrafts.ChargeInterest)
+	jobRunner.Add("account-dormancy", time.Hour, srv.Lifecycle.MarkDormantAccounts)
+	jobRunner.Add("idempotency-purge", time.Hour, func(ctx context.Context) error {
+		purged, err := idempotencyRepo.DeleteExpired(ctx, time.Now())
+		if purged > 0 {
+			log.Printf("Purged %d expired idempotency keys", purged)
+		}
+		return err
 	})
+	if screener != nil {
+		jobRunner.Add("watchlist-reload", time.Minute, screener.ReloadIfChanged)
+	}
+	jobRunner.Start(jobCtx)
 
 	// Start server
 	port := getEnv("PORT", "8080")
 	log.Printf("Server starting on port %s", port)
-	if err := app.Listen(":" + port); err != nil {
+	if err := srv.App.Listen(":" + port); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 }
@@ -230,18 +320,4 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
-
-func customErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	message := "Internal Server Error"
-
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-		message = e.Message
-	}
-
-	return c.Status(code).JSON(fiber.Map{
-		"error": message,
-	})
 }
\ No newline at end of file
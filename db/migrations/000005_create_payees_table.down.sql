@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payees;
+DROP TYPE IF EXISTS payee_verification_status;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TYPE payee_verification_status AS ENUM ('verified', 'mismatch', 'unverified');
+
+CREATE TABLE payees (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    account_number VARCHAR(20) NOT NULL,
+    nickname VARCHAR(100) NOT NULL,
+    masked_holder_name VARCHAR(255) NOT NULL,
+    verification_status payee_verification_status NOT NULL DEFAULT 'unverified',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_payees_user_account UNIQUE (user_id, account_id)
+);
+
+CREATE INDEX idx_payees_user_id ON payees(user_id);
\ No newline at end of file
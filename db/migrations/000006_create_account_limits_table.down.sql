@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_outflows;
+DROP TABLE IF EXISTS account_limits;
+DROP TYPE IF EXISTS limit_source;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+ALTER TABLE users DROP COLUMN IF EXISTS tier;
\ No newline at end of file
@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN tier VARCHAR(20) NOT NULL DEFAULT 'standard';
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'customer';
+
+CREATE TYPE limit_source AS ENUM ('user', 'operator');
+
+CREATE TABLE account_limits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    source limit_source NOT NULL,
+    per_transaction DECIMAL(15,2) CHECK (per_transaction >= 0),
+    daily DECIMAL(15,2) CHECK (daily >= 0),
+    monthly DECIMAL(15,2) CHECK (monthly >= 0),
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_account_limits_account_source UNIQUE (account_id, source)
+);
+
+CREATE INDEX idx_transactions_outflows ON transactions(from_account_id, created_at) WHERE status IN ('pending', 'completed');
\ No newline at end of file
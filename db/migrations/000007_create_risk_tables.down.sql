@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transaction_reviews;
+DROP TYPE IF EXISTS review_status;
+DROP TABLE IF EXISTS user_devices;
\ No newline at end of file
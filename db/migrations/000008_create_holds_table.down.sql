@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS holds;
+DROP TYPE IF EXISTS hold_status;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS check_available_balance;
+ALTER TABLE accounts DROP COLUMN IF EXISTS held_balance;
\ No newline at end of file
@@ -0,0 +1,18 @@
+ALTER TABLE accounts ADD COLUMN held_balance DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (held_balance >= 0);
+ALTER TABLE accounts ADD CONSTRAINT check_available_balance CHECK (balance - held_balance >= 0);
+
+CREATE TYPE hold_status AS ENUM ('active', 'captured', 'voided', 'expired');
+
+CREATE TABLE holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    transaction_id UUID NOT NULL UNIQUE REFERENCES transactions(id) ON DELETE CASCADE,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    status hold_status NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_holds_account_id ON holds(account_id);
+CREATE INDEX idx_holds_expiring ON holds(expires_at) WHERE status = 'active' AND expires_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS screening_alerts;
+DROP TYPE IF EXISTS screening_subject;
\ No newline at end of file
@@ -0,0 +1,18 @@
+CREATE TYPE screening_subject AS ENUM ('user', 'payee', 'transfer');
+
+CREATE TABLE screening_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subject screening_subject NOT NULL,
+    subject_id UUID,
+    screened_name VARCHAR(255) NOT NULL,
+    decision VARCHAR(10) NOT NULL CHECK (decision IN ('review', 'block')),
+    matches JSONB NOT NULL DEFAULT '[]',
+    status review_status NOT NULL DEFAULT 'pending',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_screening_alerts_pending ON screening_alerts(created_at) WHERE status = 'pending';
+CREATE INDEX idx_screening_alerts_subject ON screening_alerts(subject, subject_id);
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_user_id ON idempotency_keys(user_id);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_key ON idempotency_keys(idempotency_key);
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_user_key;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_idempotency_key_key UNIQUE (idempotency_key);
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS response_headers;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS status;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS request_hash;
+DROP TYPE IF EXISTS idempotency_status;
\ No newline at end of file
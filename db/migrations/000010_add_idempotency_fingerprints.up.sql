@@ -0,0 +1,12 @@
+CREATE TYPE idempotency_status AS ENUM ('in_flight', 'completed');
+
+ALTER TABLE idempotency_keys ADD COLUMN request_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys ADD COLUMN status idempotency_status NOT NULL DEFAULT 'in_flight';
+ALTER TABLE idempotency_keys ADD COLUMN response_headers JSONB;
+UPDATE idempotency_keys SET status = 'completed' WHERE response_status IS NOT NULL;
+
+-- Keys only need to be unique per user
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_idempotency_key_key;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_user_key UNIQUE (user_id, idempotency_key);
+DROP INDEX IF EXISTS idx_idempotency_keys_key;
+DROP INDEX IF EXISTS idx_idempotency_keys_user_id;
\ No newline at end of file
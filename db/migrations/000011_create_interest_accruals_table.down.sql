@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS interest_accruals;
+
+-- Postgres can't drop enum values, so only the rows using them go
+DELETE FROM transactions WHERE type::text IN ('interest', 'withholding_tax');
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type = 'deposit' AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type = 'withdrawal' AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type = 'payment' AND from_account_id IS NOT NULL)
+);
\ No newline at end of file
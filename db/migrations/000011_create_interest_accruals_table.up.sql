@@ -0,0 +1,28 @@
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'interest';
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'withholding_tax';
+
+-- New enum values can't be used in the transaction that adds them, so the
+-- constraint compares the type as text
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type::text = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type::text IN ('deposit', 'interest') AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type::text IN ('withdrawal', 'withholding_tax') AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type::text = 'payment' AND from_account_id IS NOT NULL)
+);
+
+CREATE TABLE interest_accruals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    accrual_date DATE NOT NULL,
+    balance DECIMAL(15,2) NOT NULL,
+    rate DECIMAL(9,6) NOT NULL CHECK (rate >= 0),
+    amount DECIMAL(20,10) NOT NULL CHECK (amount >= 0),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    capitalized_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT interest_accruals_account_date UNIQUE (account_id, accrual_date)
+);
+
+CREATE INDEX idx_interest_accruals_uncapitalized ON interest_accruals(account_id, accrual_date) WHERE capitalized_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS term_deposits;
+DROP TYPE IF EXISTS maturity_instruction;
+DROP TYPE IF EXISTS term_deposit_status;
+
+DELETE FROM transactions WHERE type::text = 'fee';
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type::text = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type::text IN ('deposit', 'interest') AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type::text IN ('withdrawal', 'withholding_tax') AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type::text = 'payment' AND from_account_id IS NOT NULL)
+);
\ No newline at end of file
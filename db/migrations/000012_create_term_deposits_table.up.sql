@@ -0,0 +1,37 @@
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'fee';
+
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type::text = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type::text IN ('deposit', 'interest') AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type::text IN ('withdrawal', 'withholding_tax', 'fee') AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type::text = 'payment' AND from_account_id IS NOT NULL)
+);
+
+CREATE TYPE term_deposit_status AS ENUM ('active', 'matured', 'rolled_over', 'broken');
+CREATE TYPE maturity_instruction AS ENUM ('payout', 'rollover');
+
+CREATE TABLE term_deposits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    payout_account_id UUID NOT NULL REFERENCES accounts(id),
+    principal DECIMAL(15,2) NOT NULL CHECK (principal > 0),
+    rate DECIMAL(9,6) NOT NULL CHECK (rate >= 0),
+    term_months INTEGER NOT NULL CHECK (term_months > 0),
+    start_date DATE NOT NULL,
+    maturity_date DATE NOT NULL,
+    maturity_value DECIMAL(15,2) NOT NULL,
+    maturity_instruction maturity_instruction NOT NULL,
+    status term_deposit_status NOT NULL DEFAULT 'active',
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT check_term_dates CHECK (maturity_date > start_date),
+    CONSTRAINT check_maturity_value CHECK (maturity_value >= principal)
+);
+
+CREATE INDEX idx_term_deposits_user_id ON term_deposits(user_id);
+-- An account holds one running term at a time
+CREATE UNIQUE INDEX idx_term_deposits_active_account ON term_deposits(account_id) WHERE status = 'active';
+CREATE INDEX idx_term_deposits_maturity ON term_deposits(maturity_date) WHERE status = 'active';
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS fee_charges;
+DROP TABLE IF EXISTS fee_waivers;
+DROP TABLE IF EXISTS fee_schedules;
+DROP TYPE IF EXISTS fee_type;
\ No newline at end of file
@@ -0,0 +1,43 @@
+CREATE TYPE fee_type AS ENUM ('transfer', 'fx_markup', 'monthly_maintenance', 'below_minimum_balance');
+
+-- A NULL account type or tier matches all of them; NULLS NOT DISTINCT keeps
+-- one schedule per combination
+CREATE TABLE fee_schedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    fee_type fee_type NOT NULL,
+    account_type account_type,
+    user_tier VARCHAR(20),
+    fixed_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (fixed_amount >= 0),
+    rate DECIMAL(9,6) NOT NULL DEFAULT 0 CHECK (rate >= 0),
+    min_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (min_amount >= 0),
+    max_amount DECIMAL(15,2) CHECK (max_amount >= min_amount),
+    threshold DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (threshold >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fee_schedules_scope UNIQUE NULLS NOT DISTINCT (fee_type, account_type, user_tier)
+);
+
+CREATE TABLE fee_waivers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    fee_type fee_type,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_fee_waivers_account_id ON fee_waivers(account_id);
+
+CREATE TABLE fee_charges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    fee_type fee_type NOT NULL,
+    period DATE NOT NULL,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount >= 0),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fee_charges_account_period UNIQUE (account_id, fee_type, period)
+);
\ No newline at end of file
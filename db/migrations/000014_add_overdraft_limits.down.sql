@@ -0,0 +1,10 @@
+-- fee_type keeps the overdraft_interest value; enum values can't be dropped
+DELETE FROM fee_charges WHERE fee_type::text = 'overdraft_interest';
+DELETE FROM fee_waivers WHERE fee_type::text = 'overdraft_interest';
+DELETE FROM fee_schedules WHERE fee_type::text = 'overdraft_interest';
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS check_available_balance;
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS check_overdraft_account_type;
+ALTER TABLE accounts DROP COLUMN IF EXISTS overdraft_limit;
+ALTER TABLE accounts ADD CONSTRAINT accounts_balance_check CHECK (balance >= 0);
+ALTER TABLE accounts ADD CONSTRAINT check_available_balance CHECK (balance - held_balance >= 0);
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TABLE accounts ADD COLUMN overdraft_limit DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (overdraft_limit >= 0);
+ALTER TABLE accounts ADD CONSTRAINT check_overdraft_account_type CHECK (overdraft_limit = 0 OR account_type = 'checking');
+
+-- The balance may go below zero, but not past the arranged overdraft
+ALTER TABLE accounts DROP CONSTRAINT accounts_balance_check;
+ALTER TABLE accounts DROP CONSTRAINT check_available_balance;
+ALTER TABLE accounts ADD CONSTRAINT check_available_balance CHECK (balance - held_balance + overdraft_limit >= 0);
+
+ALTER TYPE fee_type ADD VALUE 'overdraft_interest';
\ No newline at end of file
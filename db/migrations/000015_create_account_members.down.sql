@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS transfer_approvals;
+DROP TABLE IF EXISTS account_approval_policies;
+DROP TABLE IF EXISTS account_members;
+DROP TYPE IF EXISTS transfer_approval_status;
+DROP TYPE IF EXISTS member_status;
+DROP TYPE IF EXISTS account_role;
\ No newline at end of file
@@ -0,0 +1,51 @@
+CREATE TYPE account_role AS ENUM ('owner', 'joint_holder', 'signatory', 'viewer');
+CREATE TYPE member_status AS ENUM ('invited', 'active');
+CREATE TYPE transfer_approval_status AS ENUM ('pending', 'approved', 'executed', 'rejected', 'failed');
+
+CREATE TABLE account_members (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role account_role NOT NULL,
+    status member_status NOT NULL DEFAULT 'invited',
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (account_id, user_id)
+);
+
+CREATE INDEX idx_account_members_user_id ON account_members(user_id);
+CREATE UNIQUE INDEX idx_account_members_owner ON account_members(account_id) WHERE role = 'owner';
+
+-- Every existing account's holder becomes its owner
+INSERT INTO account_members (account_id, user_id, role, status, created_at, accepted_at)
+SELECT id, user_id, 'owner', 'active', created_at, created_at FROM accounts;
+
+CREATE TABLE account_approval_policies (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+    threshold DECIMAL(15,2) NOT NULL CHECK (threshold >= 0),
+    required_approvals INTEGER NOT NULL CHECK (required_approvals > 1),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE transfer_approvals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id),
+    to_account_id UUID REFERENCES accounts(id),
+    payee_id UUID REFERENCES payees(id),
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    required_approvals INTEGER NOT NULL,
+    approved_by JSONB NOT NULL DEFAULT '[]',
+    status transfer_approval_status NOT NULL DEFAULT 'pending',
+    rejected_by UUID REFERENCES users(id),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    failure TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP WITH TIME ZONE,
+
+    CHECK ((to_account_id IS NULL) != (payee_id IS NULL))
+);
+
+CREATE INDEX idx_transfer_approvals_account_id ON transfer_approvals(account_id, created_at DESC);
\ No newline at end of file
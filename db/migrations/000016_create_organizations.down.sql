@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS payment_draft_events;
+DROP TABLE IF EXISTS payment_drafts;
+ALTER TABLE accounts DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_approval_rules;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
+DROP TYPE IF EXISTS payment_draft_action;
+DROP TYPE IF EXISTS payment_draft_status;
+DROP TYPE IF EXISTS organization_role;
\ No newline at end of file
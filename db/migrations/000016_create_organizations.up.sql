@@ -0,0 +1,68 @@
+CREATE TYPE organization_role AS ENUM ('admin', 'approver', 'maker', 'viewer');
+CREATE TYPE payment_draft_status AS ENUM ('draft', 'pending', 'approved', 'executed', 'rejected', 'cancelled', 'failed');
+CREATE TYPE payment_draft_action AS ENUM ('created', 'submitted', 'approved', 'rejected', 'cancelled', 'executed', 'failed');
+
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    registration_number VARCHAR(50),
+    created_by UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role organization_role NOT NULL,
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+CREATE TABLE organization_approval_rules (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    min_amount DECIMAL(15,2) NOT NULL CHECK (min_amount >= 0),
+    required_approvals INTEGER NOT NULL CHECK (required_approvals >= 1),
+
+    PRIMARY KEY (organization_id, min_amount)
+);
+
+ALTER TABLE accounts ADD COLUMN organization_id UUID REFERENCES organizations(id);
+CREATE INDEX idx_accounts_organization_id ON accounts(organization_id) WHERE organization_id IS NOT NULL;
+
+CREATE TABLE payment_drafts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id),
+    to_account_id UUID REFERENCES accounts(id),
+    payee_id UUID REFERENCES payees(id),
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    description TEXT NOT NULL DEFAULT '',
+    status payment_draft_status NOT NULL DEFAULT 'draft',
+    required_approvals INTEGER NOT NULL DEFAULT 1 CHECK (required_approvals >= 1),
+    approved_by JSONB NOT NULL DEFAULT '[]',
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    failure TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ((to_account_id IS NULL) != (payee_id IS NULL))
+);
+
+CREATE INDEX idx_payment_drafts_organization_id ON payment_drafts(organization_id, created_at DESC);
+
+-- The audit trail is append-only
+CREATE TABLE payment_draft_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    draft_id UUID NOT NULL REFERENCES payment_drafts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    action payment_draft_action NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payment_draft_events_draft_id ON payment_draft_events(draft_id, created_at);
\ No newline at end of file
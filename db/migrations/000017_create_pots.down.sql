@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS pot_movements;
+DROP TABLE IF EXISTS pots;
+DROP TYPE IF EXISTS pot_movement_kind;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS check_available_balance;
+ALTER TABLE accounts DROP COLUMN IF EXISTS pot_balance;
+ALTER TABLE accounts ADD CONSTRAINT check_available_balance CHECK (balance - held_balance + overdraft_limit >= 0);
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- Money in pots stays in the balance but isn't available to spend
+ALTER TABLE accounts ADD COLUMN pot_balance DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (pot_balance >= 0);
+ALTER TABLE accounts DROP CONSTRAINT check_available_balance;
+ALTER TABLE accounts ADD CONSTRAINT check_available_balance CHECK (balance - held_balance - pot_balance + overdraft_limit >= 0);
+
+CREATE TYPE pot_movement_kind AS ENUM ('deposit', 'withdrawal', 'round_up', 'sweep');
+
+CREATE TABLE pots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    balance DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (balance >= 0),
+    target_amount DECIMAL(15,2) CHECK (target_amount > 0),
+    target_date DATE,
+    round_up BOOLEAN NOT NULL DEFAULT FALSE,
+    sweep_percent DECIMAL(5,2) NOT NULL DEFAULT 0.00 CHECK (sweep_percent >= 0 AND sweep_percent <= 100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pots_account_id ON pots(account_id);
+-- Round-ups go to a single pot per account
+CREATE UNIQUE INDEX idx_pots_round_up ON pots(account_id) WHERE round_up;
+
+CREATE TABLE pot_movements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pot_id UUID NOT NULL REFERENCES pots(id) ON DELETE CASCADE,
+    kind pot_movement_kind NOT NULL,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount != 0),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pot_movements_pot_id ON pot_movements(pot_id, created_at DESC);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE payees ALTER COLUMN account_number TYPE VARCHAR(20);
+ALTER TABLE accounts ALTER COLUMN account_number TYPE VARCHAR(20);
+
+DROP SEQUENCE IF EXISTS account_number_seq;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE SEQUENCE IF NOT EXISTS account_number_seq;
+
+-- IBANs run up to 34 characters
+ALTER TABLE accounts ALTER COLUMN account_number TYPE VARCHAR(34);
+ALTER TABLE payees ALTER COLUMN account_number TYPE VARCHAR(34);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_to_account_created;
+ALTER TABLE accounts DROP COLUMN IF EXISTS status_changed_at;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_from_account_id_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_to_account_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_from_account_id_fkey FOREIGN KEY (from_account_id) REFERENCES accounts(id) ON DELETE SET NULL;
+ALTER TABLE transactions ADD CONSTRAINT transactions_to_account_id_fkey FOREIGN KEY (to_account_id) REFERENCES accounts(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Accounts are closed rather than deleted, and their history is kept
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_from_account_id_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_to_account_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_from_account_id_fkey FOREIGN KEY (from_account_id) REFERENCES accounts(id);
+ALTER TABLE transactions ADD CONSTRAINT transactions_to_account_id_fkey FOREIGN KEY (to_account_id) REFERENCES accounts(id);
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE;
+UPDATE accounts SET status_changed_at = updated_at WHERE status <> 'active';
+
+-- The dormancy job looks for customer activity into and out of accounts
+CREATE INDEX IF NOT EXISTS idx_transactions_to_account_created ON transactions(to_account_id, created_at);
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS account_pockets;
+
+DELETE FROM transactions WHERE type::text = 'conversion';
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type::text = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type::text IN ('deposit', 'interest') AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type::text IN ('withdrawal', 'withholding_tax', 'fee') AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type::text = 'payment' AND from_account_id IS NOT NULL)
+);
\ No newline at end of file
@@ -0,0 +1,23 @@
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'conversion';
+
+-- A conversion is two transactions on one account: the debit in the
+-- currency sold and the credit in the one bought
+ALTER TABLE transactions DROP CONSTRAINT check_transfer_accounts;
+ALTER TABLE transactions ADD CONSTRAINT check_transfer_accounts CHECK (
+    (type::text = 'transfer' AND from_account_id IS NOT NULL AND to_account_id IS NOT NULL AND from_account_id != to_account_id) OR
+    (type::text IN ('deposit', 'interest') AND from_account_id IS NULL AND to_account_id IS NOT NULL) OR
+    (type::text IN ('withdrawal', 'withholding_tax', 'fee') AND from_account_id IS NOT NULL AND to_account_id IS NULL) OR
+    (type::text = 'payment' AND from_account_id IS NOT NULL) OR
+    (type::text = 'conversion' AND (from_account_id IS NULL) != (to_account_id IS NULL))
+);
+
+-- Balances an account holds in currencies other than its own
+CREATE TABLE account_pockets (
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    balance DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (balance >= 0),
+    held_balance DECIMAL(15,2) NOT NULL DEFAULT 0.00 CHECK (held_balance >= 0 AND held_balance <= balance),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, currency)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS value;
\ No newline at end of file
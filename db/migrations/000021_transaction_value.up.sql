@@ -0,0 +1,3 @@
+-- What a transaction in a pocket's currency was worth in its account's own
+-- currency when it was made; limits count outflows at that value
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS value DECIMAL(15,2);
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE payment_drafts DROP CONSTRAINT IF EXISTS payment_drafts_payee_id_fkey;
+UPDATE payment_drafts SET to_account_id = NULL WHERE payee_id IS NOT NULL;
+ALTER TABLE payment_drafts ALTER COLUMN to_account_id DROP NOT NULL;
+ALTER TABLE payment_drafts ADD CONSTRAINT payment_drafts_payee_id_fkey
+    FOREIGN KEY (payee_id) REFERENCES payees(id);
+ALTER TABLE payment_drafts ADD CONSTRAINT payment_drafts_check
+    CHECK ((to_account_id IS NULL) != (payee_id IS NULL));
+
+ALTER TABLE transfer_approvals DROP CONSTRAINT IF EXISTS transfer_approvals_payee_id_fkey;
+UPDATE transfer_approvals SET to_account_id = NULL WHERE payee_id IS NOT NULL;
+ALTER TABLE transfer_approvals ALTER COLUMN to_account_id DROP NOT NULL;
+ALTER TABLE transfer_approvals ADD CONSTRAINT transfer_approvals_payee_id_fkey
+    FOREIGN KEY (payee_id) REFERENCES payees(id);
+ALTER TABLE transfer_approvals ADD CONSTRAINT transfer_approvals_check
+    CHECK ((to_account_id IS NULL) != (payee_id IS NULL));
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Approvals and drafts keep the account a payee pointed at, so deleting the
+-- payee leaves them pointing at the same account rather than failing
+UPDATE transfer_approvals a SET to_account_id = p.account_id
+FROM payees p WHERE a.payee_id = p.id AND a.to_account_id IS NULL;
+
+ALTER TABLE transfer_approvals DROP CONSTRAINT IF EXISTS transfer_approvals_check;
+ALTER TABLE transfer_approvals ALTER COLUMN to_account_id SET NOT NULL;
+ALTER TABLE transfer_approvals DROP CONSTRAINT IF EXISTS transfer_approvals_payee_id_fkey;
+ALTER TABLE transfer_approvals ADD CONSTRAINT transfer_approvals_payee_id_fkey
+    FOREIGN KEY (payee_id) REFERENCES payees(id) ON DELETE SET NULL;
+
+UPDATE payment_drafts d SET to_account_id = p.account_id
+FROM payees p WHERE d.payee_id = p.id AND d.to_account_id IS NULL;
+
+ALTER TABLE payment_drafts DROP CONSTRAINT IF EXISTS payment_drafts_check;
+ALTER TABLE payment_drafts ALTER COLUMN to_account_id SET NOT NULL;
+ALTER TABLE payment_drafts DROP CONSTRAINT IF EXISTS payment_drafts_payee_id_fkey;
+ALTER TABLE payment_drafts ADD CONSTRAINT payment_drafts_payee_id_fkey
+    FOREIGN KEY (payee_id) REFERENCES payees(id) ON DELETE SET NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE transfer_approvals DROP COLUMN IF EXISTS device_id;
+ALTER TABLE transfer_approvals DROP COLUMN IF EXISTS currency;
\ No newline at end of file
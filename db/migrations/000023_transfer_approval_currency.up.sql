@@ -0,0 +1,8 @@
+-- Approvals keep the currency the amount is in, for transfers out of a
+-- pocket, and the device they were asked for from
+ALTER TABLE transfer_approvals ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+UPDATE transfer_approvals a SET currency = acc.currency
+FROM accounts acc WHERE a.account_id = acc.id AND a.currency IS NULL;
+ALTER TABLE transfer_approvals ALTER COLUMN currency SET NOT NULL;
+
+ALTER TABLE transfer_approvals ADD COLUMN IF NOT EXISTS device_id VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,91 @@
+package accountnumber
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IBAN numbers are International Bank Account Numbers (ISO 13616) for one
+// country and bank: the country code, two check digits, then a BBAN made of
+// BankCode followed by the number Account formats.
+type IBAN struct {
+	Country  string
+	BankCode string
+	Account  Scheme
+}
+
+func (s IBAN) Format(seq int64) (string, error) {
+	account, err := s.Account.Format(seq)
+	if err != nil {
+		return "", err
+	}
+	bban := s.BankCode + account
+	return s.Country + ibanCheckDigits(s.Country, bban) + bban, nil
+}
+
+// Parse accepts the bank's own IBANs, in electronic form or grouped in fours
+// as they are printed, and returns them in electronic form.
+func (s IBAN) Parse(number string) (string, bool) {
+	iban, ok := ParseIBAN(number)
+	if !ok || iban[:2] != s.Country || !strings.HasPrefix(iban[4:], s.BankCode) {
+		return "", false
+	}
+	if _, ok := s.Account.Parse(iban[4+len(s.BankCode):]); !ok {
+		return "", false
+	}
+	return iban, true
+}
+
+// ParseIBAN checks number is a well-formed IBAN of any registered country,
+// with the right length and check digits, and returns it in electronic
+// form.
+func ParseIBAN(number string) (string, bool) {
+	iban := compact(number)
+	if len(iban) < 15 || len(iban) > 34 || !isAlphanumeric(iban) {
+		return "", false
+	}
+	if length, ok := ibanLengths[iban[:2]]; !ok || len(iban) != length {
+		return "", false
+	}
+	if !isDigits(iban[2:4]) {
+		return "", false
+	}
+	return iban, mod97(ibanDigits(iban[4:]+iban[:4])) == 1
+}
+
+func ibanCheckDigits(country, bban string) string {
+	check := 98 - mod97(ibanDigits(bban+country+"00"))
+	if check < 10 {
+		return "0" + strconv.Itoa(check)
+	}
+	return strconv.Itoa(check)
+}
+
+// ibanDigits spells letters as two digits, A as 10 up to Z as 35.
+func ibanDigits(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 'A' && c <= 'Z' {
+			b.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// ibanLengths is the length of each country's IBANs from the ISO 13616
+// registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28,
+	"CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18,
+	"GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23,
+	"IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22,
+	"MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
\ No newline at end of file
@@ -0,0 +1,189 @@
+// Package accountnumber issues account numbers from a sequence and checks
+// them. Every number carries check digits, so a mistyped number is rejected
+// before it can reach someone else's account.
+package accountnumber
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrExhausted means the sequence has outgrown the scheme's digits.
+var ErrExhausted = errors.New("account number sequence exhausted")
+
+// Scheme turns sequence numbers into account numbers and recognizes its own.
+type Scheme interface {
+	// Format returns the account number for the sequence number seq.
+	Format(seq int64) (string, error)
+	// Parse returns number in its canonical form, or false if it isn't one
+	// of the scheme's numbers.
+	Parse(number string) (string, bool)
+}
+
+// Luhn numbers are the zero-padded sequence number followed by a Luhn check
+// digit, Digits long in all. The check catches any single mistyped digit
+// and most swapped neighbours.
+type Luhn struct {
+	Digits int
+}
+
+func (s Luhn) Format(seq int64) (string, error) {
+	body, err := pad(seq, s.Digits-1)
+	if err != nil {
+		return "", err
+	}
+	return body + strconv.Itoa(luhnDigit(body)), nil
+}
+
+func (s Luhn) Parse(number string) (string, bool) {
+	number = compact(number)
+	if len(number) != s.Digits || !isDigits(number) {
+		return "", false
+	}
+	body, check := number[:len(number)-1], int(number[len(number)-1]-'0')
+	return number, luhnDigit(body) == check
+}
+
+// luhnDigit is the digit that makes body followed by it pass the Luhn check.
+func luhnDigit(body string) int {
+	sum := 0
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		// Double every other digit, starting next to the check digit
+		if (len(body)-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// Mod97 numbers are the zero-padded sequence number followed by two ISO
+// 7064 MOD 97-10 check digits, Digits long in all: the same check IBANs
+// use, which catches every single substitution and transposition.
+type Mod97 struct {
+	Digits int
+}
+
+func (s Mod97) Format(seq int64) (string, error) {
+	body, err := pad(seq, s.Digits-2)
+	if err != nil {
+		return "", err
+	}
+	return body + fmt.Sprintf("%02d", 98-mod97(body+"00")), nil
+}
+
+func (s Mod97) Parse(number string) (string, bool) {
+	number = compact(number)
+	if len(number) != s.Digits || !isDigits(number) {
+		return "", false
+	}
+	return number, mod97(number) == 1
+}
+
+// mod97 is the remainder of the decimal number digits divided by 97,
+// computed a digit at a time so it never overflows.
+func mod97(digits string) int {
+	r := 0
+	for i := 0; i < len(digits); i++ {
+		r = (r*10 + int(digits[i]-'0')) % 97
+	}
+	return r
+}
+
+// Config picks the scheme new accounts are numbered in.
+type Config struct {
+	// Check is the check digit algorithm, luhn or mod97.
+	Check string
+	// Digits is the account number's length, check digits included.
+	Digits int
+	// Country and BankCode, when Country is set, make account numbers IBANs
+	// whose BBAN is the bank code followed by the account number. The
+	// country's registered IBAN length has to fit them exactly.
+	Country  string
+	BankCode string
+}
+
+// DefaultConfig numbers accounts with ten digits ending in a Luhn check
+// digit, the same length as the random numbers accounts used to get.
+func DefaultConfig() Config {
+	return Config{Check: "luhn", Digits: 10}
+}
+
+// Default is the scheme DefaultConfig describes.
+func Default() Scheme {
+	return Luhn{Digits: 10}
+}
+
+func New(cfg Config) (Scheme, error) {
+	var account Scheme
+	switch cfg.Check {
+	case "luhn":
+		if cfg.Digits < 6 || cfg.Digits > 19 {
+			return nil, fmt.Errorf("luhn account numbers must be 6 to 19 digits, not %d", cfg.Digits)
+		}
+		account = Luhn{Digits: cfg.Digits}
+	case "mod97":
+		if cfg.Digits < 6 || cfg.Digits > 20 {
+			return nil, fmt.Errorf("mod97 account numbers must be 6 to 20 digits, not %d", cfg.Digits)
+		}
+		account = Mod97{Digits: cfg.Digits}
+	default:
+		return nil, fmt.Errorf("unknown check digit algorithm %q, want luhn or mod97", cfg.Check)
+	}
+	if cfg.Country == "" {
+		return account, nil
+	}
+
+	country := strings.ToUpper(cfg.Country)
+	length, ok := ibanLengths[country]
+	if !ok {
+		return nil, fmt.Errorf("no IBAN format registered for country %q", cfg.Country)
+	}
+	bankCode := strings.ToUpper(cfg.BankCode)
+	if !isAlphanumeric(bankCode) {
+		return nil, fmt.Errorf("bank code %q must be letters and digits", cfg.BankCode)
+	}
+	if got := 4 + len(bankCode) + cfg.Digits; got != length {
+		return nil, fmt.Errorf("%s IBANs are %d characters but the bank code and account number make %d", country, length, got)
+	}
+	return IBAN{Country: country, BankCode: bankCode, Account: account}, nil
+}
+
+// pad formats seq as exactly width digits.
+func pad(seq int64, width int) (string, error) {
+	s := strconv.FormatInt(seq, 10)
+	if seq < 0 || len(s) > width {
+		return "", ErrExhausted
+	}
+	return strings.Repeat("0", width-len(s)) + s, nil
+}
+
+// compact drops the spaces numbers are often grouped with and upper-cases
+// any letters.
+func compact(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && (s[i] < 'A' || s[i] > 'Z') {
+			return false
+		}
+	}
+	return true
+}
\ No newline at end of file
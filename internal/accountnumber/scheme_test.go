@@ -0,0 +1,100 @@
+package accountnumber
+
+import (
+	"testing"
+)
+
+func TestSchemes(t *testing.T) {
+	iban, err := New(Config{Check: "mod97", Digits: 10, Country: "de", BankCode: "37040044"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		scheme Scheme
+		seq    int64
+		want   string
+	}{
+		{"luhn", Luhn{Digits: 10}, 12345, "0000123455"},
+		{"mod97", Mod97{Digits: 10}, 12345, "0001234520"},
+		{"iban", iban, 12345, "DE41370400440001234520"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := tt.scheme.Format(tt.seq)
+			if err != nil || number != tt.want {
+				t.Fatalf("Format(%d) = %q, %v; want %q", tt.seq, number, err, tt.want)
+			}
+			if got, ok := tt.scheme.Parse(number); !ok || got != number {
+				t.Errorf("Parse(%q) = %q, %v", number, got, ok)
+			}
+
+			// Every mistyped digit is caught, and so is every swap of neighbours
+			// except 09 and 90 under Luhn
+			for i := 0; i < len(number); i++ {
+				if number[i] < '0' || number[i] > '9' {
+					continue
+				}
+				typo := []byte(number)
+				typo[i] = '0' + (number[i]-'0'+1)%10
+				if _, ok := tt.scheme.Parse(string(typo)); ok {
+					t.Errorf("Parse accepted %q", typo)
+				}
+				if i+1 < len(number) && number[i] != number[i+1] && number[i+1] >= '0' && number[i+1] <= '9' {
+					swap := []byte(number)
+					swap[i], swap[i+1] = swap[i+1], swap[i]
+					nines := swap[i]+swap[i+1] == '0'+'9'
+					if _, ok := tt.scheme.Parse(string(swap)); ok && !(tt.name == "luhn" && nines) {
+						t.Errorf("Parse accepted %q", swap)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := (Luhn{Digits: 6}).Format(100000); err != ErrExhausted {
+		t.Errorf("Format past the digits: error %v, want %v", err, ErrExhausted)
+	}
+}
+
+func TestParseIBAN(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+		ok     bool
+	}{
+		{"GB82 WEST 1234 5698 7654 32", "GB82WEST12345698765432", true},
+		{"nl91abna0417164300", "NL91ABNA0417164300", true},
+		{"DE89370400440532013000", "DE89370400440532013000", true},
+		{"DE88370400440532013000", "", false},
+		{"DE8937040044053201300", "", false},
+		{"XX89370400440532013000", "", false},
+		{"GB82-WEST-1234-5698-7654-32", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseIBAN(tt.number); ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("ParseIBAN(%q) = %q, %v; want %q, %v", tt.number, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"unknown check", Config{Check: "crc", Digits: 10}},
+		{"too short", Config{Check: "luhn", Digits: 4}},
+		{"unknown country", Config{Check: "luhn", Digits: 10, Country: "XX", BankCode: "1234"}},
+		{"wrong IBAN length", Config{Check: "luhn", Digits: 8, Country: "DE", BankCode: "37040044"}},
+		{"bad bank code", Config{Check: "luhn", Digits: 10, Country: "DE", BankCode: "3704-044"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg); err == nil {
+				t.Errorf("New(%+v) succeeded", tt.cfg)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+// Package apperror is the error type use cases return for anything a client
+// should be told about. Each error carries a stable machine-readable code and
+// the HTTP status it maps to; the delivery layer renders it as an RFC 7807
+// problem. Errors that aren't an *Error are internal and reported as 500s.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error is an application error. Errors are compared by identity, so the
+// package-level ones declared by use cases work with == and errors.Is.
+type Error struct {
+	// Code identifies the kind of error, e.g. "insufficient_balance", and
+	// never changes once published.
+	Code   string
+	Status int
+	Detail string
+	// Fields maps request fields to what is wrong with them.
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+func New(status int, code, detail string) *Error {
+	return &Error{Code: code, Status: status, Detail: detail}
+}
+
+func BadRequest(code, detail string) *Error {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+func Unauthorized(code, detail string) *Error {
+	return New(http.StatusUnauthorized, code, detail)
+}
+
+func Forbidden(code, detail string) *Error {
+	return New(http.StatusForbidden, code, detail)
+}
+
+func NotFound(code, detail string) *Error {
+	return New(http.StatusNotFound, code, detail)
+}
+
+func Conflict(code, detail string) *Error {
+	return New(http.StatusConflict, code, detail)
+}
+
+func Unprocessable(code, detail string) *Error {
+	return New(http.StatusUnprocessableEntity, code, detail)
+}
+
+// Validation reports a request that failed field validation.
+func Validation(fields map[string]string) *Error {
+	err := BadRequest("validation_failed", "Validation failed")
+	err.Fields = fields
+	return err
+}
+
+// As returns the *Error in err's chain, or nil if there isn't one.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return nil
+}
+
+// Problem is an RFC 7807 problem details body, extended with the error code,
+// the ID of the request that failed and any field errors.
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	Code      string            `json:"code"`
+	RequestID string            `json:"request_id,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// NewProblem describes err for a client. The type is about:blank, so the
+// title is the HTTP status text and Code tells errors apart.
+func NewProblem(err *Error) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(err.Status),
+		Status: err.Status,
+		Detail: err.Detail,
+		Code:   err.Code,
+		Fields: err.Fields,
+	}
+}
\ No newline at end of file
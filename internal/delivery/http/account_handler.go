@@ -3,6 +3,7 @@ package http
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
 )
@@ -26,48 +27,37 @@ func NewAccountHandler(accountUseCase *usecase.AccountUseCase) *AccountHandler {
 // @Security BearerAuth
 // @Param request body domain.CreateAccountRequest true "Account creation request"
 // @Success 201 {object} domain.Account
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /accounts [post]
 func (h *AccountHandler) CreateAccount(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 
-	var req domain.CreateAccountRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	req, err := middleware.BindBody[domain.CreateAccountRequest](c)
+	if err != nil {
+		return err
 	}
 
-	account, err := h.accountUseCase.CreateAccount(c.Context(), userID, &req)
+	account, err := h.accountUseCase.CreateAccount(c.Context(), userID, req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create account",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(account)
 }
 
 func (h *AccountHandler) GetAccount(c *fiber.Ctx) error {
-	accountID, err := uuid.Parse(c.Params("id"))
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
+		return err
 	}
 
-	account, err := h.accountUseCase.GetAccount(c.Context(), accountID)
+	account, err := h.accountUseCase.GetAccount(c.Context(), userID, accountID)
 	if err != nil {
-		if err == usecase.ErrAccountNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get account",
-		})
+		return err
 	}
 
 	return c.JSON(account)
@@ -78,9 +68,7 @@ func (h *AccountHandler) GetUserAccounts(c *fiber.Ctx) error {
 
 	accounts, err := h.accountUseCase.GetUserAccounts(c.Context(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get accounts",
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -98,97 +86,29 @@ func (h *AccountHandler) GetUserAccounts(c *fiber.Ctx) error {
 // @Param id path string true "Account ID"
 // @Param request body domain.UpdateAccountRequest true "Account update request"
 // @Success 200 {object} domain.Account
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /accounts/{id} [put]
 func (h *AccountHandler) UpdateAccount(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 	
-	accountID, err := uuid.Parse(c.Params("id"))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
-	}
-
-	var req domain.UpdateAccountRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
-	}
-
-	account, err := h.accountUseCase.UpdateAccount(c.Context(), userID, accountID, &req)
+	accountID, err := paramID(c, "id", "account")
 	if err != nil {
-		if err == usecase.ErrAccountNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		if err == usecase.ErrUnauthorized {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "You can only update your own accounts",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update account",
-		})
+		return err
 	}
 
-	return c.JSON(account)
-}
-
-// DeleteAccount godoc
-// @Summary Delete account
-// @Description Delete account if it has zero balance (safety measure)
-// @Tags accounts
-// @Produce json
-// @Security BearerAuth
-// @Param id path string true "Account ID"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 422 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /accounts/{id} [delete]
-func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
-	userID := c.Locals("userID").(uuid.UUID)
-	
-	accountID, err := uuid.Parse(c.Params("id"))
+	req, err := middleware.BindBody[domain.UpdateAccountRequest](c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
+		return err
 	}
 
-	err = h.accountUseCase.DeleteAccount(c.Context(), userID, accountID)
+	account, err := h.accountUseCase.UpdateAccount(c.Context(), userID, accountID, req)
 	if err != nil {
-		if err == usecase.ErrAccountNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		if err == usecase.ErrUnauthorized {
-			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-				"error": "You can only delete your own accounts",
-			})
-		}
-		if err == usecase.ErrAccountNotEmpty {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-				"error": "Cannot delete account with non-zero balance",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete account",
-		})
+		return err
 	}
 
-	return c.JSON(fiber.Map{
-		"message": "Account deleted successfully",
-	})
+	return c.JSON(account)
 }
\ No newline at end of file
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type AccountLifecycleHandler struct {
+	lifecycleUseCase *usecase.AccountLifecycleUseCase
+}
+
+func NewAccountLifecycleHandler(lifecycleUseCase *usecase.AccountLifecycleUseCase) *AccountLifecycleHandler {
+	return &AccountLifecycleHandler{
+		lifecycleUseCase: lifecycleUseCase,
+	}
+}
+
+// CloseAccount godoc
+// @Summary Close account
+// @Description Close the account, paying any remaining balance, pots included, out to the payout account. The account and its history are kept. Accounts can't be closed while they are overdrawn, have pending holds or back a running term deposit.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.CloseAccountRequest false "Payout account"
+// @Success 200 {object} domain.Account
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/close [post]
+func (h *AccountLifecycleHandler) CloseAccount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.CloseAccountRequest](c)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.lifecycleUseCase.CloseAccount(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
+
+// ReactivateAccount godoc
+// @Summary Reactivate a dormant account
+// @Description Accounts without activity for the dormancy period are marked inactive and can't be debited. Re-entering the password brings them back into use.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.ReactivateAccountRequest true "Password"
+// @Success 200 {object} domain.Account
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/reactivate [post]
+func (h *AccountLifecycleHandler) ReactivateAccount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.ReactivateAccountRequest](c)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.lifecycleUseCase.Reactivate(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
+
+// SetAccountStatus godoc
+// @Summary Freeze or unfreeze an account (operator)
+// @Description Nothing can be debited from a frozen account; it can still be paid into. Closed accounts can't be reopened, and dormant ones are reactivated by their customers.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.AccountStatusRequest true "Status"
+// @Success 200 {object} domain.Account
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/accounts/{id}/status [put]
+func (h *AccountLifecycleHandler) SetAccountStatus(c *fiber.Ctx) error {
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.AccountStatusRequest](c)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.lifecycleUseCase.SetStatus(c.Context(), accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
\ No newline at end of file
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type AccountMemberHandler struct {
+	memberUseCase *usecase.AccountMemberUseCase
+}
+
+func NewAccountMemberHandler(memberUseCase *usecase.AccountMemberUseCase) *AccountMemberHandler {
+	return &AccountMemberHandler{
+		memberUseCase: memberUseCase,
+	}
+}
+
+// InviteMember godoc
+// @Summary Invite a member
+// @Description Invite a user by email to the account as a joint holder, signatory or viewer. They get access once they accept.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.InviteMemberRequest true "Invitation"
+// @Success 201 {object} domain.AccountMember
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/members [post]
+func (h *AccountMemberHandler) InviteMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.InviteMemberRequest](c)
+	if err != nil {
+		return err
+	}
+
+	member, err := h.memberUseCase.InviteMember(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+func (h *AccountMemberHandler) GetMembers(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	members, err := h.memberUseCase.GetMembers(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"members": members,
+	})
+}
+
+// RemoveMember godoc
+// @Summary Remove a member
+// @Description Remove a member from the account or withdraw their invitation. Members can remove themselves; the owner can't be removed.
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param user_id path string true "Member's user ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/members/{user_id} [delete]
+func (h *AccountMemberHandler) RemoveMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+	memberID, err := paramID(c, "user_id", "member")
+	if err != nil {
+		return err
+	}
+
+	if err := h.memberUseCase.RemoveMember(c.Context(), userID, accountID, memberID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Member removed successfully",
+	})
+}
+
+func (h *AccountMemberHandler) GetApprovalPolicy(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	policy, err := h.memberUseCase.GetApprovalPolicy(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(policy)
+}
+
+// SetApprovalPolicy godoc
+// @Summary Set the transfer approval policy
+// @Description Make transfers above the threshold wait for the given number of members who can transact to approve them. One approval removes the policy.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.ApprovalPolicyRequest true "Approval policy"
+// @Success 200 {object} domain.ApprovalPolicy
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/approval-policy [put]
+func (h *AccountMemberHandler) SetApprovalPolicy(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.ApprovalPolicyRequest](c)
+	if err != nil {
+		return err
+	}
+
+	policy, err := h.memberUseCase.SetApprovalPolicy(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(policy)
+}
+
+func (h *AccountMemberHandler) GetInvitations(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	invitations, err := h.memberUseCase.GetInvitations(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"invitations": invitations,
+	})
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Accept an invitation to an account, which then shows up among the user's accounts
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param account_id path string true "Account ID"
+// @Success 200 {object} domain.AccountMember
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /account-invitations/{account_id}/accept [post]
+func (h *AccountMemberHandler) AcceptInvitation(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "account_id", "account")
+	if err != nil {
+		return err
+	}
+
+	member, err := h.memberUseCase.AcceptInvitation(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(member)
+}
+
+func (h *AccountMemberHandler) DeclineInvitation(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "account_id", "account")
+	if err != nil {
+		return err
+	}
+
+	if err := h.memberUseCase.DeclineInvitation(c.Context(), userID, accountID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Invitation declined successfully",
+	})
+}
\ No newline at end of file
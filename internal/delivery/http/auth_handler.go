@@ -2,6 +2,8 @@ package http
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
 )
@@ -24,28 +26,20 @@ func NewAuthHandler(authUseCase *usecase.AuthUseCase) *AuthHandler {
 // @Produce json
 // @Param request body domain.CreateUserRequest true "User registration request"
 // @Success 201 {object} domain.AuthResponse
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
-	var req domain.CreateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	req, err := middleware.BindBody[domain.CreateUserRequest](c)
+	if err != nil {
+		return err
 	}
 
-	response, err := h.authUseCase.Register(c.Context(), &req)
+	response, err := h.authUseCase.Register(c.Context(), req)
 	if err != nil {
-		if err == usecase.ErrEmailAlreadyExists {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to register user",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response)
@@ -59,28 +53,19 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 // @Produce json
 // @Param request body domain.LoginRequest true "User login request"
 // @Success 200 {object} domain.AuthResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
-	var req domain.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	req, err := middleware.BindBody[domain.LoginRequest](c)
+	if err != nil {
+		return err
 	}
 
-	response, err := h.authUseCase.Login(c.Context(), &req)
+	response, err := h.authUseCase.Login(c.Context(), req)
 	if err != nil {
-		if err == usecase.ErrInvalidCredentials {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to login",
-		})
+		return err
 	}
 
 	return c.JSON(response)
@@ -89,9 +74,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	refreshToken := c.Get("Authorization")
 	if refreshToken == "" {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Missing refresh token",
-		})
+		return apperror.Unauthorized("missing_token", "Missing refresh token")
 	}
 
 	// Remove "Bearer " prefix if present
@@ -101,9 +84,7 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 
 	response, err := h.authUseCase.RefreshToken(c.Context(), refreshToken)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Invalid refresh token",
-		})
+		return apperror.Unauthorized("invalid_token", "Invalid refresh token")
 	}
 
 	return c.JSON(response)
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type FeeHandler struct {
+	feeUseCase *usecase.FeeUseCase
+}
+
+func NewFeeHandler(feeUseCase *usecase.FeeUseCase) *FeeHandler {
+	return &FeeHandler{
+		feeUseCase: feeUseCase,
+	}
+}
+
+// GetFeeSchedules godoc
+// @Summary List fee schedules (operator)
+// @Description Every fee schedule, by fee type
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/fee-schedules [get]
+func (h *FeeHandler) GetFeeSchedules(c *fiber.Ctx) error {
+	schedules, err := h.feeUseCase.GetSchedules(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"fee_schedules": schedules,
+	})
+}
+
+// SetFeeSchedule godoc
+// @Summary Set a fee schedule (operator)
+// @Description Create or replace the schedule for a fee type, account type and user tier. Leave account_type or user_tier out to cover all of them; the most specific matching schedule is charged.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.FeeScheduleRequest true "Fee schedule"
+// @Success 200 {object} domain.FeeSchedule
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/fee-schedules [put]
+func (h *FeeHandler) SetFeeSchedule(c *fiber.Ctx) error {
+	req, err := middleware.BindBody[domain.FeeScheduleRequest](c)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := h.feeUseCase.SetSchedule(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(schedule)
+}
+
+// DeleteFeeSchedule godoc
+// @Summary Delete a fee schedule (operator)
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Fee schedule ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/fee-schedules/{id} [delete]
+func (h *FeeHandler) DeleteFeeSchedule(c *fiber.Ctx) error {
+	scheduleID, err := paramID(c, "id", "fee schedule")
+	if err != nil {
+		return err
+	}
+
+	if err := h.feeUseCase.DeleteSchedule(c.Context(), scheduleID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Fee schedule deleted successfully",
+	})
+}
+
+// CreateFeeWaiver godoc
+// @Summary Waive fees on an account (operator)
+// @Description Exempt an account from one fee type, or every fee if fee_type is left out, until expires_at or until the waiver is deleted
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.CreateFeeWaiverRequest true "Fee waiver"
+// @Success 201 {object} domain.FeeWaiver
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/accounts/{id}/fee-waivers [post]
+func (h *FeeHandler) CreateFeeWaiver(c *fiber.Ctx) error {
+	operatorID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.CreateFeeWaiverRequest](c)
+	if err != nil {
+		return err
+	}
+
+	waiver, err := h.feeUseCase.CreateWaiver(c.Context(), operatorID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(waiver)
+}
+
+func (h *FeeHandler) GetFeeWaivers(c *fiber.Ctx) error {
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	waivers, err := h.feeUseCase.GetAccountWaivers(c.Context(), accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"fee_waivers": waivers,
+	})
+}
+
+func (h *FeeHandler) DeleteFeeWaiver(c *fiber.Ctx) error {
+	waiverID, err := paramID(c, "id", "fee waiver")
+	if err != nil {
+		return err
+	}
+
+	if err := h.feeUseCase.DeleteWaiver(c.Context(), waiverID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Fee waiver deleted successfully",
+	})
+}
\ No newline at end of file
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type HoldHandler struct {
+	holdUseCase *usecase.HoldUseCase
+}
+
+func NewHoldHandler(holdUseCase *usecase.HoldUseCase) *HoldHandler {
+	return &HoldHandler{
+		holdUseCase: holdUseCase,
+	}
+}
+
+// AuthorizeHold godoc
+// @Summary Authorize a payment
+// @Description Reserve funds on an account for a pending payment, drawing on any arranged overdraft. The hold counts against the available balance until captured, voided or expired. Business accounts pay through payment drafts instead.
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.AuthorizeHoldRequest true "Authorization request"
+// @Success 201 {object} domain.Hold
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /holds [post]
+func (h *HoldHandler) AuthorizeHold(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.AuthorizeHoldRequest](c)
+	if err != nil {
+		return err
+	}
+
+	hold, err := h.holdUseCase.AuthorizeHold(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(hold)
+}
+
+func (h *HoldHandler) GetAccountHolds(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	holds, err := h.holdUseCase.GetAccountHolds(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"holds": holds,
+	})
+}
+
+// CaptureHold godoc
+// @Summary Capture a hold
+// @Description Debit the held account for all or part of the hold and release the remainder
+// @Tags holds
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hold ID"
+// @Param request body domain.CaptureHoldRequest false "Capture amount"
+// @Success 200 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /holds/{id}/capture [post]
+func (h *HoldHandler) CaptureHold(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	holdID, err := paramID(c, "id", "hold")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.CaptureHoldRequest](c)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := h.holdUseCase.CaptureHold(c.Context(), userID, holdID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(transaction)
+}
+
+// VoidHold godoc
+// @Summary Void a hold
+// @Description Release the reserved funds without debiting the account
+// @Tags holds
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Hold ID"
+// @Success 200 {object} domain.Hold
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /holds/{id}/void [post]
+func (h *HoldHandler) VoidHold(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	holdID, err := paramID(c, "id", "hold")
+	if err != nil {
+		return err
+	}
+
+	hold, err := h.holdUseCase.VoidHold(c.Context(), userID, holdID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(hold)
+}
\ No newline at end of file
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type InterestHandler struct {
+	interestUseCase *usecase.InterestUseCase
+}
+
+func NewInterestHandler(interestUseCase *usecase.InterestUseCase) *InterestHandler {
+	return &InterestHandler{
+		interestUseCase: interestUseCase,
+	}
+}
+
+// GetAccountInterest godoc
+// @Summary Get account interest
+// @Description Show the interest product an account earns, interest accrued since the last payout and the daily accrual history
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Success 200 {object} domain.AccountInterestResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/interest [get]
+func (h *InterestHandler) GetAccountInterest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	interest, err := h.interestUseCase.GetAccountInterest(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(interest)
+}
\ No newline at end of file
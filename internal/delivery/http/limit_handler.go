@@ -0,0 +1,126 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type LimitHandler struct {
+	limitUseCase *usecase.LimitUseCase
+}
+
+func NewLimitHandler(limitUseCase *usecase.LimitUseCase) *LimitHandler {
+	return &LimitHandler{
+		limitUseCase: limitUseCase,
+	}
+}
+
+// GetAccountLimits godoc
+// @Summary Get transfer limits
+// @Description Show the effective per-transaction, daily and monthly limits on an account and the remaining headroom
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Success 200 {object} domain.AccountLimitsResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/limits [get]
+func (h *LimitHandler) GetAccountLimits(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	limits, err := h.limitUseCase.GetAccountLimits(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(limits)
+}
+
+// UpdateAccountLimits godoc
+// @Summary Lower transfer limits
+// @Description Set your own, lower limits on an account. Null fields remove the custom limit.
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.UpdateLimitsRequest true "Limits"
+// @Success 200 {object} domain.AccountLimit
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/limits [put]
+func (h *LimitHandler) UpdateAccountLimits(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.UpdateLimitsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := h.limitUseCase.SetUserLimits(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"limit": limit,
+	})
+}
+
+// OverrideAccountLimits godoc
+// @Summary Override transfer limits (operator)
+// @Description Replace the default limits on an account. Null fields fall back to the default.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.UpdateLimitsRequest true "Limits"
+// @Success 200 {object} domain.AccountLimit
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/accounts/{id}/limits [put]
+func (h *LimitHandler) OverrideAccountLimits(c *fiber.Ctx) error {
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.UpdateLimitsRequest](c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := h.limitUseCase.SetOperatorLimits(c.Context(), accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"limit": limit,
+	})
+}
\ No newline at end of file
@@ -4,36 +4,57 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
 	"github.com/nabiilNajm26/go-bank/pkg/utils"
 )
 
+var errMissingAuthorization = apperror.Unauthorized("missing_authorization", "Missing authorization header")
+
 func AuthMiddleware(jwtManager *utils.JWTManager) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Missing authorization header",
-			})
+			return errMissingAuthorization
 		}
 
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid authorization header format",
-			})
+			return apperror.Unauthorized("invalid_authorization", "Invalid authorization header format")
 		}
 
 		token := tokenParts[1]
 		claims, err := jwtManager.VerifyAccessToken(token)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Invalid or expired token",
-			})
+			return apperror.Unauthorized("invalid_token", "Invalid or expired token")
 		}
 
 		c.Locals("userID", claims.UserID)
 		c.Locals("email", claims.Email)
 
+		return c.Next()
+	}
+}
+
+// RequireRole must run after AuthMiddleware. Roles aren't carried in the JWT,
+// so the user is looked up on every request and a demotion applies at once.
+func RequireRole(userRepo repository.UserRepository, role domain.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return errMissingAuthorization
+		}
+
+		user, err := userRepo.GetByID(c.Context(), userID)
+		if err != nil {
+			return err
+		}
+		if user == nil || user.Role != role {
+			return apperror.Forbidden("insufficient_permissions", "Insufficient permissions")
+		}
+
 		return c.Next()
 	}
 }
\ No newline at end of file
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+)
+
+const problemContentType = "application/problem+json"
+
+// ErrorHandler is the app's error handler: every error a handler or
+// middleware returns is answered here as an application/problem+json body.
+// An *apperror.Error is shown as it is, a *fiber.Error (unknown route, body
+// too large) keeps its status, and anything else is logged and reported as
+// an internal error without its details.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	appErr := apperror.As(err)
+	if appErr == nil {
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			appErr = apperror.New(fiberErr.Code, statusCode(fiberErr.Code), fiberErr.Message)
+		} else {
+			log.Printf("Request %s %s failed: %v", c.GetRespHeader(fiber.HeaderXRequestID), c.Path(), err)
+			appErr = apperror.New(fiber.StatusInternalServerError, "internal_error", "An unexpected error occurred")
+		}
+	}
+
+	problem := apperror.NewProblem(appErr)
+	problem.Instance = c.OriginalURL()
+	problem.RequestID = c.GetRespHeader(fiber.HeaderXRequestID)
+
+	return c.Status(appErr.Status).JSON(problem, problemContentType)
+}
+
+// statusCode turns a status into a code, e.g. 404 into not_found.
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(utils.StatusMessage(status)), " ", "_")
+}
\ No newline at end of file
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 )
 
 const (
@@ -25,18 +26,14 @@ func FileUploadMiddleware() fiber.Handler {
 		// Parse multipart form
 		form, err := c.MultipartForm()
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Failed to parse multipart form",
-			})
+			return apperror.BadRequest("invalid_body", "Failed to parse multipart form")
 		}
 
 		// Check all file fields
 		for fieldName, files := range form.File {
 			for _, fileHeader := range files {
 				if err := validateFile(fileHeader); err != nil {
-					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-						"error": fmt.Sprintf("Invalid file in field '%s': %v", fieldName, err),
-					})
+					return apperror.BadRequest("invalid_file", fmt.Sprintf("Invalid file in field '%s': %v", fieldName, err))
 				}
 			}
 		}
@@ -3,34 +3,65 @@ package middleware
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
 )
 
-type IdempotencyRecord struct {
-	ID             uuid.UUID  `db:"id"`
-	IdempotencyKey string     `db:"idempotency_key"`
-	UserID         uuid.UUID  `db:"user_id"`
-	RequestPath    string     `db:"request_path"`
-	RequestBody    string     `db:"request_body"`
-	ResponseStatus int        `db:"response_status"`
-	ResponseBody   string     `db:"response_body"`
-	CreatedAt      time.Time  `db:"created_at"`
-	ExpiresAt      time.Time  `db:"expires_at"`
+const (
+	// IdempotencyTTL is how long a response is kept for replay.
+	IdempotencyTTL = 24 * time.Hour
+
+	// idempotencyLockTimeout is how long a request may stay in flight before
+	// its key is treated as abandoned, e.g. by a crashed replica, and taken
+	// over by the next retry.
+	idempotencyLockTimeout = time.Minute
+
+	// idempotencyRetryAfter is the Retry-After sent with 409s for requests
+	// that are still in flight, in seconds.
+	idempotencyRetryAfter = 1
+)
+
+var (
+	errIdempotencyKeyReused      = apperror.Unprocessable("idempotency_key_reused", "Idempotency-Key has already been used for a different request")
+	errIdempotentRequestInFlight = apperror.Conflict("idempotent_request_in_flight", "A request with this Idempotency-Key is still being processed. Please retry shortly.")
+)
+
+// Headers that describe the connection or this particular request rather
+// than the response aren't stored for replay.
+var unreplayedHeaders = map[string]bool{
+	fiber.HeaderContentLength:    true,
+	fiber.HeaderDate:             true,
+	fiber.HeaderServer:           true,
+	fiber.HeaderConnection:       true,
+	fiber.HeaderTransferEncoding: true,
+	fiber.HeaderXRequestID:       true,
 }
 
-func IdempotencyMiddleware(db *sqlx.DB) fiber.Handler {
+// IdempotencyMiddleware runs each keyed request once per user. A retry with
+// the same key gets the stored response back, marked Idempotent-Replayed; a
+// retry while the first request is still running gets a 409 with
+// Retry-After; reusing a key for a different request is a 422. Server
+// errors, rate limits and concurrent update conflicts aren't stored, so
+// those can be retried with the same key.
+func IdempotencyMiddleware(idempotencyRepo repository.IdempotencyRepository) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Only apply to POST, PUT, PATCH requests
 		if c.Method() != "POST" && c.Method() != "PUT" && c.Method() != "PATCH" {
 			return c.Next()
 		}
 
-		// Get idempotency key from header
-		idempotencyKey := c.Get("Idempotency-Key")
+		// Get idempotency key from header. Fiber reuses the request buffer,
+		// so the key is copied before it is kept anywhere.
+		idempotencyKey := utils.CopyString(c.Get("Idempotency-Key"))
 		if idempotencyKey == "" {
 			// Generate one based on request content for critical endpoints
 			if isTransferEndpoint(c.Path()) {
@@ -46,57 +77,135 @@ func IdempotencyMiddleware(db *sqlx.DB) fiber.Handler {
 			return c.Next()
 		}
 
+		requestHash := fingerprintRequest(c)
+
 		// Check if request already processed
-		var record IdempotencyRecord
-		query := `SELECT * FROM idempotency_keys WHERE idempotency_key = $1 AND user_id = $2`
-		err := db.Get(&record, query, idempotencyKey, userID)
-		
-		if err == nil {
-			// Request already processed, return cached response
+		record, err := idempotencyRepo.GetByKey(c.Context(), idempotencyKey, userID)
+		if err != nil {
+			return err
+		}
+
+		if record != nil {
 			if record.ExpiresAt.After(time.Now()) {
-				c.Status(record.ResponseStatus)
-				return c.SendString(record.ResponseBody)
+				// Records from before fingerprinting have no hash to compare
+				if record.RequestHash != "" && record.RequestHash != requestHash {
+					return errIdempotencyKeyReused
+				}
+				if record.Status == domain.IdempotencyStatusCompleted {
+					return replayResponse(c, record)
+				}
+				if time.Since(record.CreatedAt) < idempotencyLockTimeout {
+					return inFlight(c)
+				}
+			}
+
+			// Expired or abandoned, delete old record
+			if err := idempotencyRepo.Delete(c.Context(), idempotencyKey, userID); err != nil {
+				return err
 			}
-			// Expired, delete old record
-			deleteQuery := `DELETE FROM idempotency_keys WHERE id = $1`
-			db.Exec(deleteQuery, record.ID)
 		}
 
-		// Store request for processing
-		requestBody := string(c.Body())
-		insertQuery := `
-			INSERT INTO idempotency_keys (idempotency_key, user_id, request_path, request_body, expires_at)
-			VALUES ($1, $2, $3, $4, $5)
-			ON CONFLICT (idempotency_key) DO NOTHING`
-		
-		expiresAt := time.Now().Add(24 * time.Hour)
-		_, err = db.Exec(insertQuery, idempotencyKey, userID, c.Path(), requestBody, expiresAt)
+		// Reserve the key for this request
+		record = &domain.IdempotencyRecord{
+			IdempotencyKey: idempotencyKey,
+			UserID:         userID,
+			RequestPath:    utils.CopyString(c.Path()),
+			RequestHash:    requestHash,
+			RequestBody:    string(c.Body()),
+			Status:         domain.IdempotencyStatusInFlight,
+			ExpiresAt:      time.Now().Add(IdempotencyTTL),
+		}
+		created, err := idempotencyRepo.Create(c.Context(), record)
 		if err != nil {
-			// Concurrent request, return conflict
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": "Request is being processed. Please retry with a different idempotency key.",
-			})
+			return err
+		}
+		if !created {
+			// A concurrent request got there first
+			return inFlight(c)
 		}
 
 		// Continue processing and capture response
 		c.Locals("idempotencyKey", idempotencyKey)
-		
-		// Process the request
-		err = c.Next()
-		
+
+		// Process the request. Errors are rendered here rather than by the
+		// app so their response can be stored like any other.
+		handlerErr := c.Next()
+		if handlerErr != nil {
+			if err := c.App().Config().ErrorHandler(c, handlerErr); err != nil {
+				return err
+			}
+		}
+
+		// Release the key rather than store a failure the client should be
+		// able to retry
+		responseStatus := c.Response().StatusCode()
+		if isTransient(responseStatus, handlerErr) {
+			if err := idempotencyRepo.Delete(c.Context(), idempotencyKey, userID); err != nil {
+				log.Printf("Failed to release idempotency key for user %s: %v", userID, err)
+			}
+			return nil
+		}
+
 		// Store the response
 		responseBody := string(c.Response().Body())
-		responseStatus := c.Response().StatusCode()
-		
-		updateQuery := `
-			UPDATE idempotency_keys 
-			SET response_status = $1, response_body = $2
-			WHERE idempotency_key = $3 AND user_id = $4`
-		
-		db.Exec(updateQuery, responseStatus, responseBody, idempotencyKey, userID)
-		
-		return err
+		record.ResponseStatus = &responseStatus
+		record.ResponseBody = &responseBody
+		record.ResponseHeaders = domain.Headers{}
+		c.Response().Header.VisitAll(func(key, value []byte) {
+			if name := string(key); !unreplayedHeaders[name] {
+				record.ResponseHeaders[name] = string(value)
+			}
+		})
+
+		if err := idempotencyRepo.Complete(c.Context(), record); err != nil {
+			log.Printf("Failed to store idempotent response for user %s: %v", userID, err)
+		}
+
+		return nil
+	}
+}
+
+func replayResponse(c *fiber.Ctx, record *domain.IdempotencyRecord) error {
+	for name, value := range record.ResponseHeaders {
+		c.Set(name, value)
 	}
+	c.Set("Idempotent-Replayed", "true")
+
+	status := fiber.StatusOK
+	if record.ResponseStatus != nil {
+		status = *record.ResponseStatus
+	}
+	c.Status(status)
+
+	if record.ResponseBody == nil {
+		return nil
+	}
+	return c.SendString(*record.ResponseBody)
+}
+
+// isTransient reports whether a response says nothing about the request
+// itself, only that it couldn't run right now.
+func isTransient(status int, err error) bool {
+	return status >= fiber.StatusInternalServerError ||
+		status == fiber.StatusTooManyRequests ||
+		errors.Is(err, repository.ErrConflict)
+}
+
+func inFlight(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(idempotencyRetryAfter))
+	return errIdempotentRequestInFlight
+}
+
+// fingerprintRequest hashes what makes two requests the same: the method,
+// the path with its query string, and the body.
+func fingerprintRequest(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.OriginalURL()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
 func generateIdempotencyKey(c *fiber.Ctx) string {
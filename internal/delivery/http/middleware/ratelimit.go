@@ -1,44 +1,56 @@
 package middleware
 
 import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
 )
 
-func RateLimitMiddleware() fiber.Handler {
-	// Default rate limiter: 100 requests per minute
-	return limiter.New(limiter.Config{
-		Max:        100,
-		Expiration: 1 * time.Minute,
-		KeyGenerator: func(c *fiber.Ctx) string {
-			// Rate limit by IP address
-			return c.IP()
-		},
-		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error": "Too many requests. Please try again later.",
-			})
-		},
-		SkipFailedRequests:     false,
-		SkipSuccessfulRequests: false,
-	})
+var errRateLimited = apperror.New(fiber.StatusTooManyRequests, "rate_limited", "Too many requests. Please try again later.")
+
+// RateLimitMiddleware counts requests against policy in a bucket named
+// group. Requests are counted per user once AuthMiddleware has run and per
+// client IP before that. Every response carries RateLimit-Limit,
+// RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy; refused ones
+// get a 429 with Retry-After.
+func RateLimitMiddleware(limiter ratelimit.Limiter, group string, policy ratelimit.Policy) fiber.Handler {
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Limit, policy.WindowSeconds)
+
+	return func(c *fiber.Ctx) error {
+		key := group + ":ip:" + c.IP()
+		if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+			key = group + ":user:" + userID.String()
+		}
+
+		result, err := limiter.Allow(c.Context(), key, policy)
+		if err != nil {
+			// Better to serve unlimited than not at all
+			log.Printf("Warning: rate limit check failed: %v", err)
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", seconds(result.ResetAfter))
+		c.Set("RateLimit-Policy", policyHeader)
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, seconds(result.RetryAfter))
+			return errRateLimited
+		}
+
+		return c.Next()
+	}
 }
 
-func StrictRateLimitMiddleware() fiber.Handler {
-	// Strict rate limiter for sensitive endpoints: 5 requests per minute
-	return limiter.New(limiter.Config{
-		Max:        5,
-		Expiration: 1 * time.Minute,
-		KeyGenerator: func(c *fiber.Ctx) string {
-			// Rate limit by IP address
-			return c.IP()
-		},
-		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error": "Too many requests. Please try again later.",
-			})
-		},
-	})
+// seconds rounds up, so a client waiting that long is never early.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
 }
\ No newline at end of file
@@ -1,11 +1,63 @@
 package middleware
 
 import (
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/shopspring/decimal"
 )
 
-var validate = validator.New()
+var validate = newValidator()
+
+// newValidator reports fields by their JSON names and adds the validators
+// request DTOs use beyond the built-in ones:
+//
+//	currency        ISO 4217 currency code
+//	account_number  6 to 20 digit account number or a valid IBAN
+//	decimal_gt=N    decimal greater than N
+//	decimal_gte=N   decimal at least N
+//	decimal_lte=N   decimal at most N
+//	decimal_scale=N decimal with at most N places
+//
+// Decimals are validated as their string form, so a null NullDecimal counts
+// as empty for omitempty and required.
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		switch d := field.Interface().(type) {
+		case decimal.Decimal:
+			return d.String()
+		case decimal.NullDecimal:
+			if d.Valid {
+				return d.Decimal.String()
+			}
+		}
+		return nil
+	}, decimal.Decimal{}, decimal.NullDecimal{})
+
+	v.RegisterAlias("currency", "iso4217")
+	v.RegisterValidation("account_number", isAccountNumber)
+	v.RegisterValidation("decimal_gt", compareDecimal(func(cmp int) bool { return cmp > 0 }))
+	v.RegisterValidation("decimal_gte", compareDecimal(func(cmp int) bool { return cmp >= 0 }))
+	v.RegisterValidation("decimal_lte", compareDecimal(func(cmp int) bool { return cmp <= 0 }))
+	v.RegisterValidation("decimal_scale", isDecimalScale)
+
+	return v
+}
 
 func ValidationMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -17,23 +69,40 @@ func ValidateRequest(payload interface{}) error {
 	return validate.Struct(payload)
 }
 
-func ErrorHandler(c *fiber.Ctx, err error) error {
-	if validationErr, ok := err.(validator.ValidationErrors); ok {
-		errors := make(map[string]string)
-		for _, err := range validationErr {
-			errors[err.Field()] = getErrorMessage(err)
+// BindBody parses the request body into a T and validates it. An empty body
+// validates T's zero value, so DTOs whose fields are all optional may be
+// left out. Errors are *apperror.Error: a body that doesn't parse is
+// invalid_body and one that doesn't validate lists the failed fields.
+func BindBody[T any](c *fiber.Ctx) (*T, error) {
+	req := new(T)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return nil, apperror.BadRequest("invalid_body", "Invalid request body")
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":  "Validation failed",
-			"fields": errors,
-		})
 	}
-	return err
+
+	if err := ValidateRequest(req); err != nil {
+		return nil, validationError(err)
+	}
+	return req, nil
+}
+
+func validationError(err error) error {
+	validationErr, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+
+	fields := make(map[string]string)
+	for _, err := range validationErr {
+		fields[err.Field()] = getErrorMessage(err)
+	}
+	return apperror.Validation(fields)
 }
 
 func getErrorMessage(err validator.FieldError) string {
 	switch err.Tag() {
-	case "required":
+	case "required", "required_without_all":
 		return "This field is required"
 	case "email":
 		return "Invalid email format"
@@ -41,11 +110,72 @@ func getErrorMessage(err validator.FieldError) string {
 		return "Value is too short"
 	case "max":
 		return "Value is too long"
+	case "len":
+		return "Value must be " + err.Param() + " characters long"
 	case "uuid":
 		return "Invalid UUID format"
-	case "gt":
+	case "gt", "decimal_gt":
 		return "Value must be greater than " + err.Param()
+	case "decimal_gte":
+		return "Value must be at least " + err.Param()
+	case "decimal_lte":
+		return "Value must be at most " + err.Param()
+	case "decimal_scale":
+		return "Value must have at most " + err.Param() + " decimal places"
+	case "oneof":
+		return "Value must be one of: " + err.Param()
+	case "e164":
+		return "Invalid phone number, use international format like +14155552671"
+	case "currency":
+		return "Invalid ISO 4217 currency code"
+	case "account_number":
+		return "Invalid account number"
 	default:
 		return "Invalid value"
 	}
+}
+
+// isAccountNumber checks the shape of the number and an IBAN's check digits.
+// The use cases check it against the bank's own scheme.
+func isAccountNumber(fl validator.FieldLevel) bool {
+	number := fl.Field().String()
+	if _, ok := accountnumber.ParseIBAN(number); ok {
+		return true
+	}
+	if len(number) < 6 || len(number) > 20 {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func compareDecimal(ok func(cmp int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value, err := decimal.NewFromString(fl.Field().String())
+		if err != nil {
+			return false
+		}
+		bound, err := decimal.NewFromString(fl.Param())
+		if err != nil {
+			return false
+		}
+		return ok(value.Cmp(bound))
+	}
+}
+
+func isDecimalScale(fl validator.FieldLevel) bool {
+	value, err := decimal.NewFromString(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	places, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+	// Trailing zeros like 1.50 don't count against the scale
+	return value.Equal(value.Round(int32(places)))
 }
\ No newline at end of file
@@ -0,0 +1,310 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type OrganizationHandler struct {
+	organizationUseCase *usecase.OrganizationUseCase
+}
+
+func NewOrganizationHandler(organizationUseCase *usecase.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUseCase,
+	}
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create a business customer with the current user as its first admin
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.CreateOrganizationRequest true "Organization"
+// @Success 201 {object} domain.Organization
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.CreateOrganizationRequest](c)
+	if err != nil {
+		return err
+	}
+
+	organization, err := h.organizationUseCase.CreateOrganization(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(organization)
+}
+
+func (h *OrganizationHandler) GetUserOrganizations(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizations, err := h.organizationUseCase.GetUserOrganizations(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"organizations": organizations,
+	})
+}
+
+func (h *OrganizationHandler) GetOrganization(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	organization, err := h.organizationUseCase.GetOrganization(c.Context(), userID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(organization)
+}
+
+// AddMember godoc
+// @Summary Add an organization member
+// @Description Add a user by email to the organization as an admin, approver, maker or viewer
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param request body domain.AddOrganizationMemberRequest true "Member"
+// @Success 201 {object} domain.OrganizationMember
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.AddOrganizationMemberRequest](c)
+	if err != nil {
+		return err
+	}
+
+	member, err := h.organizationUseCase.AddMember(c.Context(), userID, organizationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+func (h *OrganizationHandler) GetMembers(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	members, err := h.organizationUseCase.GetMembers(c.Context(), userID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"members": members,
+	})
+}
+
+// UpdateMember godoc
+// @Summary Change a member's role
+// @Description Change an organization member's role. The last admin can't be demoted, nor an approver the approval rules need.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param user_id path string true "Member's user ID"
+// @Param request body domain.UpdateOrganizationMemberRequest true "Role"
+// @Success 200 {object} domain.OrganizationMember
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations/{id}/members/{user_id} [put]
+func (h *OrganizationHandler) UpdateMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+	memberID, err := paramID(c, "user_id", "member")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.UpdateOrganizationMemberRequest](c)
+	if err != nil {
+		return err
+	}
+
+	member, err := h.organizationUseCase.UpdateMember(c.Context(), userID, organizationID, memberID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(member)
+}
+
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+	memberID, err := paramID(c, "user_id", "member")
+	if err != nil {
+		return err
+	}
+
+	if err := h.organizationUseCase.RemoveMember(c.Context(), userID, organizationID, memberID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Member removed successfully",
+	})
+}
+
+func (h *OrganizationHandler) GetApprovalRules(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.organizationUseCase.GetApprovalRules(c.Context(), userID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"rules": rules,
+	})
+}
+
+// SetApprovalRules godoc
+// @Summary Set the payment approval rules
+// @Description Replace the organization's rules for how many approvals a payment needs by amount. Payments below every rule need one approval.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param request body domain.ApprovalRulesRequest true "Approval rules"
+// @Success 200 {object} map[string][]domain.ApprovalRule
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations/{id}/approval-rules [put]
+func (h *OrganizationHandler) SetApprovalRules(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.ApprovalRulesRequest](c)
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.organizationUseCase.SetApprovalRules(c.Context(), userID, organizationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"rules": rules,
+	})
+}
+
+// CreateAccount godoc
+// @Summary Open a business account
+// @Description Open an account owned by the organization. Payments out of it go through payment drafts.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param request body domain.CreateAccountRequest true "Account"
+// @Success 201 {object} domain.Account
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations/{id}/accounts [post]
+func (h *OrganizationHandler) CreateAccount(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.CreateAccountRequest](c)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.organizationUseCase.CreateAccount(c.Context(), userID, organizationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(account)
+}
+
+func (h *OrganizationHandler) GetAccounts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	accounts, err := h.organizationUseCase.GetAccounts(c.Context(), userID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"accounts": accounts,
+	})
+}
\ No newline at end of file
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type OverdraftHandler struct {
+	overdraftUseCase *usecase.OverdraftUseCase
+}
+
+func NewOverdraftHandler(overdraftUseCase *usecase.OverdraftUseCase) *OverdraftHandler {
+	return &OverdraftHandler{
+		overdraftUseCase: overdraftUseCase,
+	}
+}
+
+// SetOverdraftLimit godoc
+// @Summary Arrange an overdraft (operator)
+// @Description Set how far below zero a checking account may go. A zero limit removes the overdraft; a limit can't be cut below what the account has already drawn. The account's overdraft_used shows how much of the limit is in use.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.OverdraftLimitRequest true "Overdraft limit"
+// @Success 200 {object} domain.Account
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/accounts/{id}/overdraft [put]
+func (h *OverdraftHandler) SetOverdraftLimit(c *fiber.Ctx) error {
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.OverdraftLimitRequest](c)
+	if err != nil {
+		return err
+	}
+
+	account, err := h.overdraftUseCase.SetLimit(c.Context(), accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(account)
+}
\ No newline at end of file
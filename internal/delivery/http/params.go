@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+)
+
+// paramID parses the UUID in path parameter param. A malformed one is an
+// invalid_id error naming what it should have identified, e.g. "account".
+func paramID(c *fiber.Ctx, param, what string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params(param))
+	if err != nil {
+		return uuid.Nil, apperror.BadRequest("invalid_id", "Invalid "+what+" ID")
+	}
+	return id, nil
+}
+
+// requiredQuery returns query parameter name, which must be present.
+func requiredQuery(c *fiber.Ctx, name string) (string, error) {
+	value := c.Query(name)
+	if value == "" {
+		return "", apperror.BadRequest("missing_parameter", name+" is required")
+	}
+	return value, nil
+}
\ No newline at end of file
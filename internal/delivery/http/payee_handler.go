@@ -0,0 +1,161 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type PayeeHandler struct {
+	payeeUseCase *usecase.PayeeUseCase
+}
+
+func NewPayeeHandler(payeeUseCase *usecase.PayeeUseCase) *PayeeHandler {
+	return &PayeeHandler{
+		payeeUseCase: payeeUseCase,
+	}
+}
+
+// LookupAccount godoc
+// @Summary Look up an account by number
+// @Description Return the masked holder name for an account number and check it against an optional holder name
+// @Tags payees
+// @Produce json
+// @Security BearerAuth
+// @Param account_number query string true "Account number"
+// @Param holder_name query string false "Expected holder name"
+// @Success 200 {object} domain.AccountLookupResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payees/lookup [get]
+func (h *PayeeHandler) LookupAccount(c *fiber.Ctx) error {
+	accountNumber, err := requiredQuery(c, "account_number")
+	if err != nil {
+		return err
+	}
+
+	result, err := h.payeeUseCase.LookupAccount(c.Context(), accountNumber, c.Query("holder_name"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(result)
+}
+
+// CreatePayee godoc
+// @Summary Save a payee
+// @Description Save an account number under a nickname, verifying the holder name if given
+// @Tags payees
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.CreatePayeeRequest true "Payee creation request"
+// @Success 201 {object} domain.Payee
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payees [post]
+func (h *PayeeHandler) CreatePayee(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.CreatePayeeRequest](c)
+	if err != nil {
+		return err
+	}
+
+	payee, err := h.payeeUseCase.CreatePayee(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(payee)
+}
+
+func (h *PayeeHandler) GetPayees(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payees, err := h.payeeUseCase.GetPayees(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"payees": payees,
+	})
+}
+
+func (h *PayeeHandler) GetPayee(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payeeID, err := paramID(c, "id", "payee")
+	if err != nil {
+		return err
+	}
+
+	payee, err := h.payeeUseCase.GetPayee(c.Context(), userID, payeeID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(payee)
+}
+
+// UpdatePayee godoc
+// @Summary Rename a payee
+// @Description Change the nickname of a saved payee
+// @Tags payees
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payee ID"
+// @Param request body domain.UpdatePayeeRequest true "Payee update request"
+// @Success 200 {object} domain.Payee
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payees/{id} [put]
+func (h *PayeeHandler) UpdatePayee(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payeeID, err := paramID(c, "id", "payee")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.UpdatePayeeRequest](c)
+	if err != nil {
+		return err
+	}
+
+	payee, err := h.payeeUseCase.UpdatePayee(c.Context(), userID, payeeID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(payee)
+}
+
+func (h *PayeeHandler) DeletePayee(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payeeID, err := paramID(c, "id", "payee")
+	if err != nil {
+		return err
+	}
+
+	if err := h.payeeUseCase.DeletePayee(c.Context(), userID, payeeID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Payee deleted successfully",
+	})
+}
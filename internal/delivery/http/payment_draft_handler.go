@@ -0,0 +1,251 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type PaymentDraftHandler struct {
+	draftUseCase *usecase.PaymentDraftUseCase
+}
+
+func NewPaymentDraftHandler(draftUseCase *usecase.PaymentDraftUseCase) *PaymentDraftHandler {
+	return &PaymentDraftHandler{
+		draftUseCase: draftUseCase,
+	}
+}
+
+// CreateDraft godoc
+// @Summary Draft a payment
+// @Description Draft a payment out of one of the organization's accounts. It is made once submitted and approved by enough other members.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param request body domain.TransferRequest true "Payment"
+// @Success 201 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations/{id}/payment-drafts [post]
+func (h *PaymentDraftHandler) CreateDraft(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.TransferRequest](c)
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.draftUseCase.CreateDraft(c.Context(), userID, organizationID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(draft)
+}
+
+func (h *PaymentDraftHandler) GetDrafts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	organizationID, err := paramID(c, "id", "organization")
+	if err != nil {
+		return err
+	}
+
+	drafts, err := h.draftUseCase.GetDrafts(c.Context(), userID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"payment_drafts": drafts,
+	})
+}
+
+// GetDraft godoc
+// @Summary Get a payment draft
+// @Description Get a payment draft with its audit trail
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment draft ID"
+// @Success 200 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payment-drafts/{id} [get]
+func (h *PaymentDraftHandler) GetDraft(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	draftID, err := paramID(c, "id", "payment draft")
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.draftUseCase.GetDraft(c.Context(), userID, draftID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
+
+// SubmitDraft godoc
+// @Summary Submit a payment draft
+// @Description Send your draft for approval. The organization's rules fix how many approvals it needs.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment draft ID"
+// @Success 200 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payment-drafts/{id}/submit [post]
+func (h *PaymentDraftHandler) SubmitDraft(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	draftID, err := paramID(c, "id", "payment draft")
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.draftUseCase.Submit(c.Context(), userID, draftID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
+
+// ApproveDraft godoc
+// @Summary Approve a payment draft
+// @Description Add your approval to a submitted payment. You can't approve your own. The approval that completes the count makes the payment.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment draft ID"
+// @Param request body domain.PaymentDraftDecisionRequest false "Note for the audit trail"
+// @Success 200 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payment-drafts/{id}/approve [post]
+func (h *PaymentDraftHandler) ApproveDraft(c *fiber.Ctx) error {
+	return h.decide(c, h.draftUseCase.Approve)
+}
+
+// ExecuteDraft godoc
+// @Summary Make an approved payment
+// @Description Make an approved payment that collided with other activity on the account or was interrupted before it was made
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment draft ID"
+// @Success 200 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payment-drafts/{id}/execute [post]
+func (h *PaymentDraftHandler) ExecuteDraft(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	draftID, err := paramID(c, "id", "payment draft")
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.draftUseCase.Execute(c.Context(), userID, draftID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
+
+// RejectDraft godoc
+// @Summary Reject a payment draft
+// @Description Reject a submitted payment so it is never made
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Payment draft ID"
+// @Param request body domain.PaymentDraftDecisionRequest false "Note for the audit trail"
+// @Success 200 {object} domain.PaymentDraft
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /payment-drafts/{id}/reject [post]
+func (h *PaymentDraftHandler) RejectDraft(c *fiber.Ctx) error {
+	return h.decide(c, h.draftUseCase.Reject)
+}
+
+func (h *PaymentDraftHandler) CancelDraft(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	draftID, err := paramID(c, "id", "payment draft")
+	if err != nil {
+		return err
+	}
+
+	draft, err := h.draftUseCase.Cancel(c.Context(), userID, draftID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
+
+type draftDecision func(ctx context.Context, userID, draftID uuid.UUID, note string) (*domain.PaymentDraft, error)
+
+func (h *PaymentDraftHandler) decide(c *fiber.Ctx, decide draftDecision) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	draftID, err := paramID(c, "id", "payment draft")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.PaymentDraftDecisionRequest](c)
+	if err != nil {
+		return err
+	}
+
+	draft, err := decide(c.Context(), userID, draftID, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(draft)
+}
\ No newline at end of file
@@ -0,0 +1,168 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type PocketHandler struct {
+	pocketUseCase *usecase.PocketUseCase
+}
+
+func NewPocketHandler(pocketUseCase *usecase.PocketUseCase) *PocketHandler {
+	return &PocketHandler{
+		pocketUseCase: pocketUseCase,
+	}
+}
+
+func (h *PocketHandler) GetPockets(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	pockets, err := h.pocketUseCase.GetPockets(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"pockets": pockets,
+	})
+}
+
+// OpenPocket godoc
+// @Summary Open a currency pocket
+// @Description Open an empty pocket holding the account's money in another currency. Pockets also open by themselves when money arrives in a new currency.
+// @Tags pockets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.OpenPocketRequest true "Currency"
+// @Success 201 {object} domain.Pocket
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/pockets [post]
+func (h *PocketHandler) OpenPocket(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.OpenPocketRequest](c)
+	if err != nil {
+		return err
+	}
+
+	pocket, err := h.pocketUseCase.OpenPocket(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(pocket)
+}
+
+func (h *PocketHandler) ClosePocket(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	currency := strings.ToUpper(c.Params("currency"))
+	if err := h.pocketUseCase.ClosePocket(c.Context(), userID, accountID, currency); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Pocket closed successfully",
+	})
+}
+
+// GetQuote godoc
+// @Summary Quote a currency conversion
+// @Description Price converting an amount from one currency into another at the current rate, after the bank's spread
+// @Tags pockets
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "Currency sold"
+// @Param to query string true "Currency bought"
+// @Param amount query string true "Amount sold"
+// @Success 200 {object} domain.FXQuote
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /fx/quote [get]
+func (h *PocketHandler) GetQuote(c *fiber.Ctx) error {
+	var params [3]string
+	for i, name := range []string{"from", "to", "amount"} {
+		value, err := requiredQuery(c, name)
+		if err != nil {
+			return err
+		}
+		params[i] = value
+	}
+
+	amount, err := decimal.NewFromString(params[2])
+	if err != nil {
+		return apperror.BadRequest("invalid_amount", "Invalid amount")
+	}
+
+	quote, err := h.pocketUseCase.Quote(c.Context(), strings.ToUpper(params[0]), strings.ToUpper(params[1]), amount)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(quote)
+}
+
+// Convert godoc
+// @Summary Convert between an account's currencies
+// @Description Sell money in one of the account's currencies for another at the quoted rate. The account's own currency can be either side; a pocket opens for the currency bought if needed.
+// @Tags pockets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.ConversionRequest true "Conversion"
+// @Success 201 {object} domain.Conversion
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /fx/conversions [post]
+func (h *PocketHandler) Convert(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.ConversionRequest](c)
+	if err != nil {
+		return err
+	}
+
+	conversion, err := h.pocketUseCase.Convert(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(conversion)
+}
\ No newline at end of file
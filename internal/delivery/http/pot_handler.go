@@ -0,0 +1,232 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+	"github.com/shopspring/decimal"
+)
+
+type PotHandler struct {
+	potUseCase *usecase.PotUseCase
+}
+
+func NewPotHandler(potUseCase *usecase.PotUseCase) *PotHandler {
+	return &PotHandler{
+		potUseCase: potUseCase,
+	}
+}
+
+// CreatePot godoc
+// @Summary Create a savings pot
+// @Description Create a named pot inside the account, optionally with a target and rules that round up transfers or sweep a share of deposits into it
+// @Tags pots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body domain.CreatePotRequest true "Pot"
+// @Success 201 {object} domain.Pot
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /accounts/{id}/pots [post]
+func (h *PotHandler) CreatePot(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.CreatePotRequest](c)
+	if err != nil {
+		return err
+	}
+
+	pot, err := h.potUseCase.CreatePot(c.Context(), userID, accountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(pot)
+}
+
+func (h *PotHandler) GetPots(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	pots, err := h.potUseCase.GetPots(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"pots": pots,
+	})
+}
+
+func (h *PotHandler) GetPot(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	potID, err := paramID(c, "id", "pot")
+	if err != nil {
+		return err
+	}
+
+	pot, err := h.potUseCase.GetPot(c.Context(), userID, potID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(pot)
+}
+
+// UpdatePot godoc
+// @Summary Update a savings pot
+// @Description Rename a pot or change its target and rules. Only one of an account's pots can collect round-ups, and their sweeps can't add up to more than 100%.
+// @Tags pots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pot ID"
+// @Param request body domain.UpdatePotRequest true "Changes"
+// @Success 200 {object} domain.Pot
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /pots/{id} [put]
+func (h *PotHandler) UpdatePot(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	potID, err := paramID(c, "id", "pot")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.UpdatePotRequest](c)
+	if err != nil {
+		return err
+	}
+
+	pot, err := h.potUseCase.UpdatePot(c.Context(), userID, potID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(pot)
+}
+
+func (h *PotHandler) DeletePot(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	potID, err := paramID(c, "id", "pot")
+	if err != nil {
+		return err
+	}
+
+	if err := h.potUseCase.DeletePot(c.Context(), userID, potID); err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Pot deleted successfully",
+	})
+}
+
+func (h *PotHandler) GetPotMovements(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	potID, err := paramID(c, "id", "pot")
+	if err != nil {
+		return err
+	}
+
+	movements, err := h.potUseCase.GetPotMovements(c.Context(), userID, potID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"movements": movements,
+	})
+}
+
+// DepositToPot godoc
+// @Summary Move money into a pot
+// @Description Set aside money from the account's available balance in the pot
+// @Tags pots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pot ID"
+// @Param request body domain.PotMoveRequest true "Amount"
+// @Success 200 {object} domain.Pot
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /pots/{id}/deposit [post]
+func (h *PotHandler) DepositToPot(c *fiber.Ctx) error {
+	return h.move(c, h.potUseCase.Deposit)
+}
+
+// WithdrawFromPot godoc
+// @Summary Move money out of a pot
+// @Description Move money from the pot back to the account's available balance
+// @Tags pots
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Pot ID"
+// @Param request body domain.PotMoveRequest true "Amount"
+// @Success 200 {object} domain.Pot
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /pots/{id}/withdraw [post]
+func (h *PotHandler) WithdrawFromPot(c *fiber.Ctx) error {
+	return h.move(c, h.potUseCase.Withdraw)
+}
+
+type potMove func(ctx context.Context, userID, potID uuid.UUID, amount decimal.Decimal) (*domain.Pot, error)
+
+func (h *PotHandler) move(c *fiber.Ctx, move potMove) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	potID, err := paramID(c, "id", "pot")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.PotMoveRequest](c)
+	if err != nil {
+		return err
+	}
+
+	pot, err := move(c.Context(), userID, potID, req.Amount)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(pot)
+}
\ No newline at end of file
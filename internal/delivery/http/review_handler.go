@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type ReviewHandler struct {
+	transactionUseCase *usecase.TransactionUseCase
+}
+
+func NewReviewHandler(transactionUseCase *usecase.TransactionUseCase) *ReviewHandler {
+	return &ReviewHandler{
+		transactionUseCase: transactionUseCase,
+	}
+}
+
+// GetPendingReviews godoc
+// @Summary List transfers awaiting review (operator)
+// @Description Transfers held by risk screening, oldest first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/reviews [get]
+func (h *ReviewHandler) GetPendingReviews(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	reviews, err := h.transactionUseCase.GetPendingReviews(c.Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"reviews": reviews,
+	})
+}
+
+// ApproveReview godoc
+// @Summary Approve a held transfer (operator)
+// @Description Complete a held transfer and credit the recipient
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Param request body domain.ReviewDecisionRequest false "Decision note"
+// @Success 200 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/reviews/{id}/approve [post]
+func (h *ReviewHandler) ApproveReview(c *fiber.Ctx) error {
+	return h.decide(c, h.transactionUseCase.ApproveReview)
+}
+
+// RejectReview godoc
+// @Summary Reject a held transfer (operator)
+// @Description Fail a held transfer and return the reserved funds to the sender
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Review ID"
+// @Param request body domain.ReviewDecisionRequest false "Decision note"
+// @Success 200 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/reviews/{id}/reject [post]
+func (h *ReviewHandler) RejectReview(c *fiber.Ctx) error {
+	return h.decide(c, h.transactionUseCase.RejectReview)
+}
+
+type reviewDecision func(ctx context.Context, operatorID, reviewID uuid.UUID, note string) (*domain.Transaction, error)
+
+func (h *ReviewHandler) decide(c *fiber.Ctx, decide reviewDecision) error {
+	operatorID := c.Locals("userID").(uuid.UUID)
+
+	reviewID, err := paramID(c, "id", "review")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.ReviewDecisionRequest](c)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := decide(c.Context(), operatorID, reviewID, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(transaction)
+}
\ No newline at end of file
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type ScreeningHandler struct {
+	screeningUseCase *usecase.ScreeningUseCase
+}
+
+func NewScreeningHandler(screeningUseCase *usecase.ScreeningUseCase) *ScreeningHandler {
+	return &ScreeningHandler{
+		screeningUseCase: screeningUseCase,
+	}
+}
+
+// GetPendingAlerts godoc
+// @Summary List sanctions screening alerts (operator)
+// @Description Watchlist hits awaiting compliance review, oldest first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/screening-alerts [get]
+func (h *ScreeningHandler) GetPendingAlerts(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	alerts, err := h.screeningUseCase.GetPendingAlerts(c.Context(), limit, offset)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"alerts": alerts,
+	})
+}
+
+// ClearAlert godoc
+// @Summary Clear a screening alert (operator)
+// @Description Mark a watchlist hit as a false positive
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Param request body domain.ReviewDecisionRequest false "Decision note"
+// @Success 200 {object} domain.ScreeningAlert
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/screening-alerts/{id}/clear [post]
+func (h *ScreeningHandler) ClearAlert(c *fiber.Ctx) error {
+	return h.resolve(c, h.screeningUseCase.ClearAlert)
+}
+
+// ConfirmAlert godoc
+// @Summary Confirm a screening alert (operator)
+// @Description Mark a watchlist hit as a true match
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Param request body domain.ReviewDecisionRequest false "Decision note"
+// @Success 200 {object} domain.ScreeningAlert
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/screening-alerts/{id}/confirm [post]
+func (h *ScreeningHandler) ConfirmAlert(c *fiber.Ctx) error {
+	return h.resolve(c, h.screeningUseCase.ConfirmAlert)
+}
+
+type alertResolution func(ctx context.Context, operatorID, alertID uuid.UUID, note string) (*domain.ScreeningAlert, error)
+
+func (h *ScreeningHandler) resolve(c *fiber.Ctx, resolve alertResolution) error {
+	operatorID := c.Locals("userID").(uuid.UUID)
+
+	alertID, err := paramID(c, "id", "alert")
+	if err != nil {
+		return err
+	}
+
+	req, err := middleware.BindBody[domain.ReviewDecisionRequest](c)
+	if err != nil {
+		return err
+	}
+
+	alert, err := resolve(c.Context(), operatorID, alertID, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(alert)
+}
\ No newline at end of file
@@ -1,10 +1,13 @@
 package http
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
 )
 
@@ -19,11 +22,11 @@ func NewStatementHandler(statementUseCase *usecase.StatementUseCase) *StatementH
 }
 
 func (h *StatementHandler) GeneratePDFStatement(c *fiber.Ctx) error {
-	accountID, err := uuid.Parse(c.Params("account_id"))
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "account_id", "account")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
+		return err
 	}
 
 	fromDateStr := c.Query("from_date", time.Now().AddDate(0, -1, 0).Format("2006-01-02"))
@@ -31,23 +34,22 @@ func (h *StatementHandler) GeneratePDFStatement(c *fiber.Ctx) error {
 
 	fromDate, err := time.Parse("2006-01-02", fromDateStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid from_date format. Use YYYY-MM-DD",
-		})
+		return apperror.BadRequest("invalid_date", "Invalid from_date format. Use YYYY-MM-DD")
 	}
 
 	toDate, err := time.Parse("2006-01-02", toDateStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid to_date format. Use YYYY-MM-DD",
-		})
+		return apperror.BadRequest("invalid_date", "Invalid to_date format. Use YYYY-MM-DD")
+	}
+
+	view, err := statementView(c)
+	if err != nil {
+		return err
 	}
 
-	pdfBytes, err := h.statementUseCase.GeneratePDFStatement(c.Context(), accountID, fromDate, toDate)
+	pdfBytes, err := h.statementUseCase.GeneratePDFStatement(c.Context(), userID, accountID, fromDate, toDate, view)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to generate PDF statement",
-		})
+		return err
 	}
 
 	c.Set("Content-Type", "application/pdf")
@@ -56,11 +58,11 @@ func (h *StatementHandler) GeneratePDFStatement(c *fiber.Ctx) error {
 }
 
 func (h *StatementHandler) GenerateCSVStatement(c *fiber.Ctx) error {
-	accountID, err := uuid.Parse(c.Params("account_id"))
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "account_id", "account")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
+		return err
 	}
 
 	fromDateStr := c.Query("from_date", time.Now().AddDate(0, -1, 0).Format("2006-01-02"))
@@ -68,26 +70,39 @@ func (h *StatementHandler) GenerateCSVStatement(c *fiber.Ctx) error {
 
 	fromDate, err := time.Parse("2006-01-02", fromDateStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid from_date format. Use YYYY-MM-DD",
-		})
+		return apperror.BadRequest("invalid_date", "Invalid from_date format. Use YYYY-MM-DD")
 	}
 
 	toDate, err := time.Parse("2006-01-02", toDateStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid to_date format. Use YYYY-MM-DD",
-		})
+		return apperror.BadRequest("invalid_date", "Invalid to_date format. Use YYYY-MM-DD")
+	}
+
+	view, err := statementView(c)
+	if err != nil {
+		return err
 	}
 
-	csvBytes, err := h.statementUseCase.GenerateCSVStatement(c.Context(), accountID, fromDate, toDate)
+	csvBytes, err := h.statementUseCase.GenerateCSVStatement(c.Context(), userID, accountID, fromDate, toDate, view)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to generate CSV statement",
-		})
+		return err
 	}
 
 	c.Set("Content-Type", "text/csv")
 	c.Set("Content-Disposition", "attachment; filename=statement.csv")
 	return c.Send(csvBytes)
+}
+
+// statementView reads which currencies the statement covers: the one in
+// currency, the account's own by default, or all of them if consolidated
+// is set.
+func statementView(c *fiber.Ctx) (domain.StatementView, error) {
+	view := domain.StatementView{
+		Currency:     strings.ToUpper(c.Query("currency")),
+		Consolidated: c.QueryBool("consolidated"),
+	}
+	if view.Consolidated && view.Currency != "" {
+		return view, apperror.BadRequest("invalid_statement_view", "A consolidated statement covers every currency; leave out currency")
+	}
+	return view, nil
 }
\ No newline at end of file
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type TermDepositHandler struct {
+	termDepositUseCase *usecase.TermDepositUseCase
+}
+
+func NewTermDepositHandler(termDepositUseCase *usecase.TermDepositUseCase) *TermDepositHandler {
+	return &TermDepositHandler{
+		termDepositUseCase: termDepositUseCase,
+	}
+}
+
+// OpenTermDeposit godoc
+// @Summary Open a term deposit
+// @Description Move funds from one of your accounts into a new deposit account, locked until maturity at the rate offered for the term. At maturity the deposit is paid out to the payout account or rolled over.
+// @Tags term-deposits
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.OpenTermDepositRequest true "Term deposit request"
+// @Success 201 {object} domain.TermDeposit
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /term-deposits [post]
+func (h *TermDepositHandler) OpenTermDeposit(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.OpenTermDepositRequest](c)
+	if err != nil {
+		return err
+	}
+
+	deposit, err := h.termDepositUseCase.Open(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(deposit)
+}
+
+func (h *TermDepositHandler) GetTermDeposits(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	deposits, err := h.termDepositUseCase.GetUserTermDeposits(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"term_deposits": deposits,
+	})
+}
+
+func (h *TermDepositHandler) GetTermDeposit(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	depositID, err := paramID(c, "id", "term deposit")
+	if err != nil {
+		return err
+	}
+
+	deposit, err := h.termDepositUseCase.GetTermDeposit(c.Context(), userID, depositID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(deposit)
+}
+
+// BreakTermDeposit godoc
+// @Summary Break a term deposit
+// @Description End a term deposit before maturity. It earns the break rate instead of the agreed rate, a break fee is charged on the principal and the rest is paid to the payout account.
+// @Tags term-deposits
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Term deposit ID"
+// @Success 200 {object} domain.BreakTermDepositResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /term-deposits/{id}/break [post]
+func (h *TermDepositHandler) BreakTermDeposit(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	depositID, err := paramID(c, "id", "term deposit")
+	if err != nil {
+		return err
+	}
+
+	result, err := h.termDepositUseCase.Break(c.Context(), userID, depositID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(result)
+}
\ No newline at end of file
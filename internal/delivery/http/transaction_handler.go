@@ -2,7 +2,10 @@ package http
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
 )
@@ -17,52 +20,61 @@ func NewTransactionHandler(transactionUseCase *usecase.TransactionUseCase) *Tran
 	}
 }
 
+// Transfer godoc
+// @Summary Transfer money
+// @Description Transfer from one of your accounts to an account ID, an account number (optionally confirming the beneficiary name) or a saved payee
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.TransferRequest true "Transfer request"
+// @Param X-Device-ID header string false "Client device identifier used by risk screening"
+// @Success 201 {object} domain.Transaction
+// @Success 202 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /transactions/transfer [post]
 func (h *TransactionHandler) Transfer(c *fiber.Ctx) error {
-	var req domain.TransferRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.TransferRequest](c)
+	if err != nil {
+		return err
 	}
+	// Copied because the device ID outlives the request buffer
+	req.DeviceID = utils.CopyString(c.Get("X-Device-ID"))
 
-	transaction, err := h.transactionUseCase.Transfer(c.Context(), &req)
+	transaction, err := h.transactionUseCase.Transfer(c.Context(), userID, req)
 	if err != nil {
-		if err == usecase.ErrInsufficientBalance {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		if err == usecase.ErrSameAccount || err == usecase.ErrInvalidAmount {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to process transfer",
-		})
+		return err
+	}
+
+	// Held for review: accepted, but not yet completed
+	if transaction.Status == domain.TransactionStatusPending {
+		return c.Status(fiber.StatusAccepted).JSON(transaction)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(transaction)
 }
 
 func (h *TransactionHandler) GetTransactionHistory(c *fiber.Ctx) error {
-	// userID can be used for additional validation if needed
-	_ = c.Locals("userID").(uuid.UUID)
+	userID := c.Locals("userID").(uuid.UUID)
 	
 	// For simplicity, we'll get transactions for the user's first account
 	// In production, you'd want to handle this differently
-	accountIDStr := c.Query("account_id")
-	if accountIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "account_id is required",
-		})
+	accountIDStr, err := requiredQuery(c, "account_id")
+	if err != nil {
+		return err
 	}
 
 	accountID, err := uuid.Parse(accountIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid account ID",
-		})
+		return apperror.BadRequest("invalid_id", "Invalid account ID")
 	}
 
 	filter := &domain.TransactionFilter{
@@ -71,14 +83,72 @@ func (h *TransactionHandler) GetTransactionHistory(c *fiber.Ctx) error {
 		Offset:    0,
 	}
 
-	transactions, err := h.transactionUseCase.GetTransactionHistory(c.Context(), accountID, filter)
+	transactions, err := h.transactionUseCase.GetTransactionHistory(c.Context(), userID, accountID, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get transactions",
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
 		"transactions": transactions,
 	})
+}
+
+// Deposit godoc
+// @Summary Record a deposit (operator)
+// @Description Credit money paid in from outside the bank, such as cash at a branch
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.DepositRequest true "Deposit request"
+// @Success 201 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/deposits [post]
+func (h *TransactionHandler) Deposit(c *fiber.Ctx) error {
+	req, err := middleware.BindBody[domain.DepositRequest](c)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := h.transactionUseCase.Deposit(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(transaction)
+}
+
+// Withdraw godoc
+// @Summary Record a withdrawal (operator)
+// @Description Debit money paid out of the bank from the account's available balance
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.WithdrawalRequest true "Withdrawal request"
+// @Success 201 {object} domain.Transaction
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/withdrawals [post]
+func (h *TransactionHandler) Withdraw(c *fiber.Ctx) error {
+	req, err := middleware.BindBody[domain.WithdrawalRequest](c)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := h.transactionUseCase.Withdraw(c.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(transaction)
 }
\ No newline at end of file
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+)
+
+type TransferApprovalHandler struct {
+	approvalUseCase *usecase.TransferApprovalUseCase
+}
+
+func NewTransferApprovalHandler(approvalUseCase *usecase.TransferApprovalUseCase) *TransferApprovalHandler {
+	return &TransferApprovalHandler{
+		approvalUseCase: approvalUseCase,
+	}
+}
+
+// RequestTransfer godoc
+// @Summary Request an approved transfer
+// @Description Ask the account's members to approve a transfer its approval policy holds back. The request counts as the first approval.
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.TransferRequest true "Transfer request"
+// @Success 201 {object} domain.TransferApproval
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /transfer-approvals [post]
+func (h *TransferApprovalHandler) RequestTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	req, err := middleware.BindBody[domain.TransferRequest](c)
+	if err != nil {
+		return err
+	}
+	// Copied because the device ID outlives the request buffer
+	req.DeviceID = utils.CopyString(c.Get("X-Device-ID"))
+
+	approval, err := h.approvalUseCase.RequestTransfer(c.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(approval)
+}
+
+func (h *TransferApprovalHandler) GetAccountApprovals(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	accountID, err := paramID(c, "id", "account")
+	if err != nil {
+		return err
+	}
+
+	approvals, err := h.approvalUseCase.GetAccountApprovals(c.Context(), userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"transfer_approvals": approvals,
+	})
+}
+
+// ApproveTransfer godoc
+// @Summary Approve a transfer
+// @Description Add your approval to a pending transfer. The approval that completes the count makes the transfer.
+// @Tags transactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer approval ID"
+// @Success 200 {object} domain.TransferApproval
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /transfer-approvals/{id}/approve [post]
+func (h *TransferApprovalHandler) ApproveTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	approvalID, err := paramID(c, "id", "transfer approval")
+	if err != nil {
+		return err
+	}
+
+	approval, err := h.approvalUseCase.Approve(c.Context(), userID, approvalID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(approval)
+}
+
+// ExecuteTransfer godoc
+// @Summary Make an approved transfer
+// @Description Make an approved transfer that collided with other activity on the account or was interrupted before it was made
+// @Tags transactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer approval ID"
+// @Success 200 {object} domain.TransferApproval
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /transfer-approvals/{id}/execute [post]
+func (h *TransferApprovalHandler) ExecuteTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	approvalID, err := paramID(c, "id", "transfer approval")
+	if err != nil {
+		return err
+	}
+
+	approval, err := h.approvalUseCase.Execute(c.Context(), userID, approvalID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(approval)
+}
+
+// RejectTransfer godoc
+// @Summary Reject a transfer
+// @Description Reject a pending transfer so it is never made
+// @Tags transactions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transfer approval ID"
+// @Success 200 {object} domain.TransferApproval
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /transfer-approvals/{id}/reject [post]
+func (h *TransferApprovalHandler) RejectTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	approvalID, err := paramID(c, "id", "transfer approval")
+	if err != nil {
+		return err
+	}
+
+	approval, err := h.approvalUseCase.Reject(c.Context(), userID, approvalID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(approval)
+}
\ No newline at end of file
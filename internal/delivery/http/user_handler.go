@@ -5,6 +5,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/s3"
 	"github.com/nabiilNajm26/go-bank/internal/usecase"
@@ -31,10 +33,10 @@ func NewUserHandler(userUseCase *usecase.UserUseCase, s3Service *s3.S3Service) *
 // @Security BearerAuth
 // @Param image formData file true "Profile image file (PNG, JPG, JPEG, max 5MB)"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 413 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 413 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /users/profile/image [post]
 func (h *UserHandler) UploadProfileImage(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
@@ -42,17 +44,13 @@ func (h *UserHandler) UploadProfileImage(c *fiber.Ctx) error {
 	// Get uploaded file
 	file, err := c.FormFile("image")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "No image file provided",
-		})
+		return apperror.BadRequest("missing_file", "No image file provided")
 	}
 
 	// Open the file
 	src, err := file.Open()
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to open uploaded file",
-		})
+		return err
 	}
 	defer src.Close()
 
@@ -62,13 +60,9 @@ func (h *UserHandler) UploadProfileImage(c *fiber.Ctx) error {
 		if strings.Contains(err.Error(), "exceeds maximum") || 
 		   strings.Contains(err.Error(), "invalid file type") ||
 		   strings.Contains(err.Error(), "maximum file limit") {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+			return apperror.BadRequest("invalid_file", err.Error())
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to upload image",
-		})
+		return err
 	}
 
 	// Update user profile image URL
@@ -76,9 +70,7 @@ func (h *UserHandler) UploadProfileImage(c *fiber.Ctx) error {
 	if err != nil {
 		// If user update fails, cleanup S3 file
 		h.s3Service.DeleteFile(c.Context(), result.Key)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update user profile",
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
@@ -96,24 +88,20 @@ func (h *UserHandler) UploadProfileImage(c *fiber.Ctx) error {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} domain.User
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /users/profile [get]
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 
 	user, err := h.userUseCase.GetByID(c.Context(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get user profile",
-		})
+		return err
 	}
 
 	if user == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "User not found",
-		})
+		return usecase.ErrUserNotFound
 	}
 
 	return c.JSON(user)
@@ -128,36 +116,23 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param request body domain.UpdateUserRequest true "User update request"
 // @Success 200 {object} domain.User
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /users/profile [put]
 func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 
-	var req domain.UpdateUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	req, err := middleware.BindBody[domain.UpdateUserRequest](c)
+	if err != nil {
+		return err
 	}
 
-	user, err := h.userUseCase.UpdateUser(c.Context(), userID, &req)
+	user, err := h.userUseCase.UpdateUser(c.Context(), userID, req)
 	if err != nil {
-		if err == usecase.ErrEmailAlreadyExists {
-			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		if err == usecase.ErrUserNotFound {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update profile",
-		})
+		return err
 	}
 
 	return c.JSON(user)
@@ -165,28 +140,21 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 
 // DeleteProfile godoc
 // @Summary Delete user profile
-// @Description Delete user account and all associated data (irreversible)
+// @Description Delete the user once all their accounts are closed (irreversible). A user with closed accounts is anonymized rather than removed, since the accounts' history is kept.
 // @Tags users
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 422 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
 // @Router /users/profile [delete]
 func (h *UserHandler) DeleteProfile(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
 
 	err := h.userUseCase.DeleteUser(c.Context(), userID)
 	if err != nil {
-		if err == usecase.ErrUserHasActiveAccounts {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
-				"error": "Cannot delete user with active accounts",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete profile",
-		})
+		return err
 	}
 
 	return c.JSON(fiber.Map{
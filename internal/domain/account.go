@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,25 +23,89 @@ const (
 )
 
 type Account struct {
-	ID            uuid.UUID       `json:"id" db:"id"`
-	UserID        uuid.UUID       `json:"user_id" db:"user_id"`
-	AccountNumber string          `json:"account_number" db:"account_number"`
-	AccountType   AccountType     `json:"account_type" db:"account_type"`
-	Balance       decimal.Decimal `json:"balance" db:"balance"`
-	Currency      string          `json:"currency" db:"currency"`
-	Status        AccountStatus   `json:"status" db:"status"`
-	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID       `json:"id" db:"id"`
+	UserID         uuid.UUID       `json:"user_id" db:"user_id"`
+	// OrganizationID is set on business accounts, which the organization's
+	// members use through payment drafts.
+	OrganizationID *uuid.UUID      `json:"organization_id,omitempty" db:"organization_id"`
+	AccountNumber  string          `json:"account_number" db:"account_number"`
+	AccountType    AccountType     `json:"account_type" db:"account_type"`
+	Balance        decimal.Decimal `json:"balance" db:"balance"`
+	HeldBalance    decimal.Decimal `json:"held_balance" db:"held_balance"`
+	// PotBalance is the part of the balance set aside in savings pots
+	PotBalance     decimal.Decimal `json:"pot_balance" db:"pot_balance"`
+	// OverdraftLimit is how far below zero the available balance may go.
+	// Only checking accounts have one.
+	OverdraftLimit decimal.Decimal `json:"overdraft_limit" db:"overdraft_limit"`
+	Currency       string          `json:"currency" db:"currency"`
+	Status         AccountStatus   `json:"status" db:"status"`
+	// StatusChangedAt is when the account was last frozen, unfrozen, marked
+	// dormant, reactivated or closed.
+	StatusChangedAt *time.Time     `json:"status_changed_at,omitempty" db:"status_changed_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// AvailableBalance is the ledger balance less funds reserved by active holds
+// and set aside in pots.
+func (a Account) AvailableBalance() decimal.Decimal {
+	return a.Balance.Sub(a.HeldBalance).Sub(a.PotBalance)
+}
+
+// SpendableBalance is the available balance plus the arranged overdraft.
+func (a Account) SpendableBalance() decimal.Decimal {
+	return a.AvailableBalance().Add(a.OverdraftLimit)
+}
+
+// OverdraftUsed is how much of the overdraft the available balance has
+// drawn on.
+func (a Account) OverdraftUsed() decimal.Decimal {
+	if available := a.AvailableBalance(); available.IsNegative() {
+		return available.Neg()
+	}
+	return decimal.Zero
+}
+
+// MarshalJSON adds available_balance next to the ledger balance, and how
+// much of the overdraft is in use.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return json.Marshal(struct {
+		account
+		AvailableBalance decimal.Decimal `json:"available_balance"`
+		OverdraftUsed    decimal.Decimal `json:"overdraft_used"`
+	}{account(a), a.AvailableBalance(), a.OverdraftUsed()})
 }
 
 type CreateAccountRequest struct {
 	AccountType AccountType `json:"account_type" validate:"required,oneof=savings checking deposit"`
-	Currency    string      `json:"currency" validate:"required,len=3"`
+	Currency    string      `json:"currency" validate:"required,currency"`
 }
 
 type UpdateAccountRequest struct {
 	AccountType *AccountType `json:"account_type,omitempty" validate:"omitempty,oneof=savings checking deposit"`
-	Status      *AccountStatus `json:"status,omitempty" validate:"omitempty,oneof=active inactive frozen"`
+}
+
+// CloseAccountRequest names the account the final balance is paid out to.
+// It is only needed while the account holds money.
+type CloseAccountRequest struct {
+	PayoutAccountID string `json:"payout_account_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// ReactivateAccountRequest re-verifies the customer with their password
+// before a dormant account can be used again.
+type ReactivateAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// AccountStatusRequest freezes or unfreezes an account. Closing and marking
+// accounts dormant have workflows of their own.
+type AccountStatusRequest struct {
+	Status AccountStatus `json:"status" validate:"required,oneof=active frozen"`
+}
+
+type OverdraftLimitRequest struct {
+	Limit decimal.Decimal `json:"limit" validate:"decimal_gte=0,decimal_scale=2"`
 }
 
 type AccountResponse struct {
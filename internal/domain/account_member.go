@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type AccountRole string
+type MemberStatus string
+type TransferApprovalStatus string
+
+const (
+	// The owner opened the account and can't be removed from it
+	AccountRoleOwner AccountRole = "owner"
+	// Joint holders share the owner's rights over the account
+	AccountRoleJointHolder AccountRole = "joint_holder"
+	// Signatories can move money but not manage the account
+	AccountRoleSignatory AccountRole = "signatory"
+	// Viewers can only see the account
+	AccountRoleViewer AccountRole = "viewer"
+
+	MemberStatusInvited MemberStatus = "invited"
+	MemberStatusActive  MemberStatus = "active"
+
+	TransferApprovalPending  TransferApprovalStatus = "pending"
+	TransferApprovalApproved TransferApprovalStatus = "approved"
+	TransferApprovalExecuted TransferApprovalStatus = "executed"
+	TransferApprovalRejected TransferApprovalStatus = "rejected"
+	TransferApprovalFailed   TransferApprovalStatus = "failed"
+)
+
+// CanView reports whether the role may see the account, its transactions
+// and statements. Every member can.
+func (r AccountRole) CanView() bool {
+	return r == AccountRoleOwner || r == AccountRoleJointHolder || r == AccountRoleSignatory || r == AccountRoleViewer
+}
+
+// CanTransact reports whether the role may move money out of the account.
+func (r AccountRole) CanTransact() bool {
+	return r == AccountRoleOwner || r == AccountRoleJointHolder || r == AccountRoleSignatory
+}
+
+// CanManage reports whether the role may change the account's settings and
+// members.
+func (r AccountRole) CanManage() bool {
+	return r == AccountRoleOwner || r == AccountRoleJointHolder
+}
+
+// AccountMember gives a user a role on an account. Invited members have no
+// access until they accept.
+type AccountMember struct {
+	AccountID  uuid.UUID    `json:"account_id" db:"account_id"`
+	UserID     uuid.UUID    `json:"user_id" db:"user_id"`
+	Role       AccountRole  `json:"role" db:"role"`
+	Status     MemberStatus `json:"status" db:"status"`
+	InvitedBy  *uuid.UUID   `json:"invited_by,omitempty" db:"invited_by"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	AcceptedAt *time.Time   `json:"accepted_at,omitempty" db:"accepted_at"`
+}
+
+type InviteMemberRequest struct {
+	Email string      `json:"email" validate:"required,email"`
+	Role  AccountRole `json:"role" validate:"required,oneof=joint_holder signatory viewer"`
+}
+
+// ApprovalPolicy makes transfers above Threshold out of an account wait
+// until RequiredApprovals members who can transact have approved them, the
+// one asking included.
+type ApprovalPolicy struct {
+	AccountID         uuid.UUID       `json:"account_id" db:"account_id"`
+	Threshold         decimal.Decimal `json:"threshold" db:"threshold"`
+	RequiredApprovals int             `json:"required_approvals" db:"required_approvals"`
+	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Requires reports whether a transfer of amount needs approval.
+func (p *ApprovalPolicy) Requires(amount decimal.Decimal) bool {
+	return p != nil && p.RequiredApprovals > 1 && amount.GreaterThan(p.Threshold)
+}
+
+// ApprovalPolicyRequest sets the policy; one approval turns it off.
+type ApprovalPolicyRequest struct {
+	Threshold         decimal.Decimal `json:"threshold" validate:"decimal_gte=0,decimal_scale=2"`
+	RequiredApprovals int             `json:"required_approvals" validate:"required,min=1,max=10"`
+}
+
+// TransferApproval is a transfer waiting for the account's members to
+// approve it. Once enough have, it is made as the member who asked for it.
+// ToAccountID is always set; PayeeID is kept alongside it when the transfer
+// was asked for to a payee, until the payee is deleted. Amount is in
+// Currency, the account's own or one of its pockets'.
+type TransferApproval struct {
+	ID                uuid.UUID              `json:"id" db:"id"`
+	AccountID         uuid.UUID              `json:"account_id" db:"account_id"`
+	RequestedBy       uuid.UUID              `json:"requested_by" db:"requested_by"`
+	ToAccountID       *uuid.UUID             `json:"to_account_id,omitempty" db:"to_account_id"`
+	PayeeID           *uuid.UUID             `json:"payee_id,omitempty" db:"payee_id"`
+	Amount            decimal.Decimal        `json:"amount" db:"amount"`
+	Currency          string                 `json:"currency" db:"currency"`
+	Description       string                 `json:"description" db:"description"`
+	DeviceID          string                 `json:"-" db:"device_id"`
+	RequiredApprovals int                    `json:"required_approvals" db:"required_approvals"`
+	ApprovedBy        IDList                 `json:"approved_by" db:"approved_by"`
+	Status            TransferApprovalStatus `json:"status" db:"status"`
+	RejectedBy        *uuid.UUID             `json:"rejected_by,omitempty" db:"rejected_by"`
+	TransactionID     *uuid.UUID             `json:"transaction_id,omitempty" db:"transaction_id"`
+	Failure           *string                `json:"failure,omitempty" db:"failure"`
+	CreatedAt         time.Time              `json:"created_at" db:"created_at"`
+	DecidedAt         *time.Time             `json:"decided_at,omitempty" db:"decided_at"`
+}
+
+// HasApproved reports whether the user has approved the transfer.
+func (a *TransferApproval) HasApproved(userID uuid.UUID) bool {
+	for _, id := range a.ApprovedBy {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type FeeType string
+
+const (
+	// Charged with each transfer out of the account
+	FeeTypeTransfer FeeType = "transfer"
+	// Charged on transfers between accounts in different currencies
+	FeeTypeFXMarkup FeeType = "fx_markup"
+	// Charged once a month for the month just ended
+	FeeTypeMonthlyMaintenance FeeType = "monthly_maintenance"
+	// Charged once a month if the balance is below the schedule's threshold
+	FeeTypeBelowMinimumBalance FeeType = "below_minimum_balance"
+	// Charged daily on an overdrawn balance; the schedule's rate is annual
+	FeeTypeOverdraftInterest FeeType = "overdraft_interest"
+)
+
+// FeeSchedule prices one fee for an account type and user tier. A schedule
+// without an account type or tier applies to all of them; the most specific
+// matching schedule wins, account type before tier.
+type FeeSchedule struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	FeeType     FeeType      `json:"fee_type" db:"fee_type"`
+	AccountType *AccountType `json:"account_type,omitempty" db:"account_type"`
+	UserTier    *UserTier    `json:"user_tier,omitempty" db:"user_tier"`
+	// The fee is FixedAmount plus Rate (a fraction) of the amount it is
+	// charged on, kept between MinAmount and MaxAmount
+	FixedAmount decimal.Decimal     `json:"fixed_amount" db:"fixed_amount"`
+	Rate        decimal.Decimal     `json:"rate" db:"rate"`
+	MinAmount   decimal.Decimal     `json:"min_amount" db:"min_amount"`
+	MaxAmount   decimal.NullDecimal `json:"max_amount" db:"max_amount"`
+	// Threshold is the minimum balance for below_minimum_balance fees
+	Threshold decimal.Decimal `json:"threshold" db:"threshold"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Matches reports whether the schedule applies to the account type and tier.
+func (s *FeeSchedule) Matches(accountType AccountType, tier UserTier) bool {
+	return (s.AccountType == nil || *s.AccountType == accountType) && (s.UserTier == nil || *s.UserTier == tier)
+}
+
+// Amount is the fee on base, rounded to the cent.
+func (s *FeeSchedule) Amount(base decimal.Decimal) decimal.Decimal {
+	fee := s.FixedAmount.Add(base.Mul(s.Rate))
+	if fee.LessThan(s.MinAmount) {
+		fee = s.MinAmount
+	}
+	if s.MaxAmount.Valid && fee.GreaterThan(s.MaxAmount.Decimal) {
+		fee = s.MaxAmount.Decimal
+	}
+	return fee.RoundBank(2)
+}
+
+// FeeWaiver exempts an account from one fee type, or every fee if FeeType is
+// nil, until it expires or is removed.
+type FeeWaiver struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	AccountID uuid.UUID  `json:"account_id" db:"account_id"`
+	FeeType   *FeeType   `json:"fee_type,omitempty" db:"fee_type"`
+	Reason    string     `json:"reason" db:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedBy uuid.UUID  `json:"created_by" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Waives reports whether the waiver covers feeType at the given time.
+func (w *FeeWaiver) Waives(feeType FeeType, at time.Time) bool {
+	return (w.FeeType == nil || *w.FeeType == feeType) && (w.ExpiresAt == nil || w.ExpiresAt.After(at))
+}
+
+// FeeCharge records a monthly fee assessed on an account so each month is
+// charged once. TransactionID is nil when the fee was waived or the account
+// had nothing to pay it from.
+type FeeCharge struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	AccountID     uuid.UUID       `json:"account_id" db:"account_id"`
+	FeeType       FeeType         `json:"fee_type" db:"fee_type"`
+	Period        time.Time       `json:"period" db:"period"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+type FeeScheduleRequest struct {
+	FeeType     FeeType             `json:"fee_type" validate:"required,oneof=transfer fx_markup monthly_maintenance below_minimum_balance overdraft_interest"`
+	AccountType *AccountType        `json:"account_type,omitempty" validate:"omitempty,oneof=savings checking deposit"`
+	UserTier    *UserTier           `json:"user_tier,omitempty" validate:"omitempty,oneof=standard premium"`
+	FixedAmount decimal.Decimal     `json:"fixed_amount" validate:"decimal_gte=0,decimal_scale=2"`
+	Rate        decimal.Decimal     `json:"rate" validate:"decimal_gte=0"`
+	MinAmount   decimal.Decimal     `json:"min_amount" validate:"decimal_gte=0,decimal_scale=2"`
+	MaxAmount   decimal.NullDecimal `json:"max_amount" validate:"omitempty,decimal_gte=0,decimal_scale=2"`
+	Threshold   decimal.Decimal     `json:"threshold" validate:"decimal_gte=0,decimal_scale=2"`
+}
+
+type CreateFeeWaiverRequest struct {
+	FeeType   *FeeType   `json:"fee_type,omitempty" validate:"omitempty,oneof=transfer fx_markup monthly_maintenance below_minimum_balance overdraft_interest"`
+	Reason    string     `json:"reason" validate:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
\ No newline at end of file
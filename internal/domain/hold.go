@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusVoided   HoldStatus = "voided"
+	HoldStatusExpired  HoldStatus = "expired"
+)
+
+// Hold reserves funds on an account for a pending transaction. While active
+// the amount counts against the available balance but not the ledger
+// balance. Holds without ExpiresAt stay until captured or voided.
+type Hold struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	AccountID     uuid.UUID       `json:"account_id" db:"account_id"`
+	TransactionID uuid.UUID       `json:"transaction_id" db:"transaction_id"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	Status        HoldStatus      `json:"status" db:"status"`
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	ReleasedAt    *time.Time      `json:"released_at,omitempty" db:"released_at"`
+}
+
+type AuthorizeHoldRequest struct {
+	AccountID        string          `json:"account_id" validate:"required,uuid"`
+	Amount           decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+	Description      string          `json:"description,omitempty" validate:"omitempty,max=500"`
+	ExpiresInMinutes int             `json:"expires_in_minutes,omitempty" validate:"omitempty,min=1,max=43200"`
+}
+
+// CaptureHoldRequest may capture less than was authorized; the rest is
+// released. A missing amount captures the full hold.
+type CaptureHoldRequest struct {
+	Amount decimal.NullDecimal `json:"amount" validate:"omitempty,decimal_gt=0,decimal_scale=2"`
+}
\ No newline at end of file
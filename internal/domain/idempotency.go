@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IdempotencyStatus string
+
+const (
+	IdempotencyStatusInFlight  IdempotencyStatus = "in_flight"
+	IdempotencyStatusCompleted IdempotencyStatus = "completed"
+)
+
+// IdempotencyRecord remembers a request made with an idempotency key and,
+// once it has completed, the response to replay for retries. RequestHash
+// fingerprints the method, path and body so a key can't be reused for a
+// different request.
+type IdempotencyRecord struct {
+	ID              uuid.UUID         `db:"id"`
+	IdempotencyKey  string            `db:"idempotency_key"`
+	UserID          uuid.UUID         `db:"user_id"`
+	RequestPath     string            `db:"request_path"`
+	RequestHash     string            `db:"request_hash"`
+	RequestBody     string            `db:"request_body"`
+	Status          IdempotencyStatus `db:"status"`
+	ResponseStatus  *int              `db:"response_status"`
+	ResponseHeaders Headers           `db:"response_headers"`
+	ResponseBody    *string           `db:"response_body"`
+	CreatedAt       time.Time         `db:"created_at"`
+	ExpiresAt       time.Time         `db:"expires_at"`
+}
\ No newline at end of file
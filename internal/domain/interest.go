@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type DayCountConvention string
+type CapitalizationFrequency string
+
+const (
+	// DayCountActual365 counts the actual days elapsed over a 365-day year.
+	DayCountActual365 DayCountConvention = "ACT/365"
+	// DayCount30360 treats every month as 30 days and the year as 360
+	// (the European 30E/360 rule), so each month earns the same interest.
+	DayCount30360 DayCountConvention = "30/360"
+
+	CapitalizeMonthly   CapitalizationFrequency = "monthly"
+	CapitalizeQuarterly CapitalizationFrequency = "quarterly"
+)
+
+// YearFraction is the part of a year between two dates under the convention.
+func (c DayCountConvention) YearFraction(from, to time.Time) decimal.Decimal {
+	if c == DayCount30360 {
+		d1, d2 := from.Day(), to.Day()
+		if d1 > 30 {
+			d1 = 30
+		}
+		if d2 > 30 {
+			d2 = 30
+		}
+		days := 360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + d2 - d1
+		return decimal.NewFromInt(int64(days)).Div(decimal.NewFromInt(360))
+	}
+
+	days := int64(to.Sub(from).Hours() / 24)
+	return decimal.NewFromInt(days).Div(decimal.NewFromInt(365))
+}
+
+// PeriodStart is the first day of the capitalization period containing date.
+func (f CapitalizationFrequency) PeriodStart(date time.Time) time.Time {
+	month := date.Month()
+	if f == CapitalizeQuarterly {
+		month = (month-1)/3*3 + 1
+	}
+	return time.Date(date.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// InterestTier sets the annual rate, as a fraction (0.025 is 2.5%), on the
+// part of the balance from From up to the next tier's From.
+type InterestTier struct {
+	From decimal.Decimal `json:"from"`
+	Rate decimal.Decimal `json:"rate"`
+}
+
+// InterestProduct is how an account type earns interest. Tiers are ordered
+// by From; balance below the first tier earns nothing. Interest accrues daily
+// and is paid on the first day of each capitalization period, less
+// WithholdingTaxRate of it.
+type InterestProduct struct {
+	DayCount           DayCountConvention      `json:"day_count"`
+	Capitalization     CapitalizationFrequency `json:"capitalization"`
+	Tiers              []InterestTier          `json:"tiers"`
+	WithholdingTaxRate decimal.Decimal         `json:"withholding_tax_rate"`
+}
+
+// AnnualInterest is what balance would earn over a year at the tier rates.
+func (p *InterestProduct) AnnualInterest(balance decimal.Decimal) decimal.Decimal {
+	interest := decimal.Zero
+	for i, tier := range p.Tiers {
+		if balance.LessThanOrEqual(tier.From) {
+			break
+		}
+		top := balance
+		if i+1 < len(p.Tiers) && p.Tiers[i+1].From.LessThan(balance) {
+			top = p.Tiers[i+1].From
+		}
+		interest = interest.Add(top.Sub(tier.From).Mul(tier.Rate))
+	}
+	return interest
+}
+
+// InterestPolicy maps account types to the interest products they earn.
+// Account types without one earn nothing. Deposit accounts holding a term
+// deposit earn its rate instead.
+type InterestPolicy struct {
+	Products     map[AccountType]*InterestProduct `json:"products"`
+	TermDeposits *TermDepositPolicy               `json:"term_deposits"`
+}
+
+// InterestAccrual is one day's interest on an account, kept unrounded until
+// the period's accruals are capitalized together.
+type InterestAccrual struct {
+	ID          uuid.UUID       `json:"id" db:"id"`
+	AccountID   uuid.UUID       `json:"account_id" db:"account_id"`
+	AccrualDate time.Time       `json:"accrual_date" db:"accrual_date"`
+	Balance     decimal.Decimal `json:"balance" db:"balance"`
+	// Rate is the blended annual rate the balance earned across tiers
+	Rate          decimal.Decimal `json:"rate" db:"rate"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty" db:"transaction_id"`
+	CapitalizedAt *time.Time      `json:"capitalized_at,omitempty" db:"capitalized_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+type AccountInterestResponse struct {
+	AccountID uuid.UUID          `json:"account_id"`
+	Product   *InterestProduct   `json:"product"`
+	Accrued   decimal.Decimal    `json:"accrued"`
+	Accruals  []*InterestAccrual `json:"accruals"`
+}
\ No newline at end of file
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type LimitSource string
+
+const (
+	LimitSourceUser     LimitSource = "user"
+	LimitSourceOperator LimitSource = "operator"
+)
+
+// LimitSet caps outflows per transaction, per calendar day and per calendar
+// month (UTC). A zero value means no money may leave under that limit.
+type LimitSet struct {
+	PerTransaction decimal.Decimal `json:"per_transaction"`
+	Daily          decimal.Decimal `json:"daily"`
+	Monthly        decimal.Decimal `json:"monthly"`
+}
+
+// LimitPolicy holds the default limits: account limits come from the account
+// type, user limits from the user's tier and apply across all their accounts.
+type LimitPolicy struct {
+	AccountTypes map[AccountType]LimitSet `json:"account_types"`
+	UserTiers    map[UserTier]LimitSet    `json:"user_tiers"`
+}
+
+// AccountLimit is a per-account adjustment to the defaults. Operator rows
+// replace the default outright; user rows can only lower what is allowed.
+type AccountLimit struct {
+	ID             uuid.UUID           `json:"id" db:"id"`
+	AccountID      uuid.UUID           `json:"account_id" db:"account_id"`
+	Source         LimitSource         `json:"source" db:"source"`
+	PerTransaction decimal.NullDecimal `json:"per_transaction" db:"per_transaction"`
+	Daily          decimal.NullDecimal `json:"daily" db:"daily"`
+	Monthly        decimal.NullDecimal `json:"monthly" db:"monthly"`
+	Reason         *string             `json:"reason,omitempty" db:"reason"`
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+type UpdateLimitsRequest struct {
+	PerTransaction decimal.NullDecimal `json:"per_transaction" validate:"omitempty,decimal_gte=0,decimal_scale=2"`
+	Daily          decimal.NullDecimal `json:"daily" validate:"omitempty,decimal_gte=0,decimal_scale=2"`
+	Monthly        decimal.NullDecimal `json:"monthly" validate:"omitempty,decimal_gte=0,decimal_scale=2"`
+	Reason         string              `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type OutflowSummary struct {
+	Count int             `json:"count" db:"count"`
+	Total decimal.Decimal `json:"total" db:"total"`
+}
+
+type LimitUsage struct {
+	Limit     decimal.Decimal `json:"limit"`
+	Used      decimal.Decimal `json:"used"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
+
+type AccountLimitsResponse struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	PerTransaction decimal.Decimal `json:"per_transaction"`
+	Daily          LimitUsage      `json:"daily"`
+	Monthly        LimitUsage      `json:"monthly"`
+	UserDaily      LimitUsage      `json:"user_daily"`
+	UserMonthly    LimitUsage      `json:"user_monthly"`
+}
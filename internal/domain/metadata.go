@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Metadata is a free-form JSONB column.
+type Metadata map[string]any
+
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *Metadata) Scan(src any) error {
+	return scanJSON(src, m)
+}
+
+// StringList is a list of strings stored as a JSONB array.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+func (l *StringList) Scan(src any) error {
+	return scanJSON(src, l)
+}
+
+// IDList is a list of IDs stored as a JSONB array.
+type IDList []uuid.UUID
+
+func (l IDList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+func (l *IDList) Scan(src any) error {
+	return scanJSON(src, l)
+}
+
+// Headers are HTTP headers stored as a JSONB object.
+type Headers map[string]string
+
+func (h Headers) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	return json.Marshal(h)
+}
+
+func (h *Headers) Scan(src any) error {
+	return scanJSON(src, h)
+}
+
+func scanJSON(src any, dest any) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", src)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type OrganizationRole string
+
+const (
+	// Admins manage the organization, its members, accounts and approval
+	// rules, and can draft and approve payments
+	OrganizationRoleAdmin OrganizationRole = "admin"
+	// Approvers approve payments other members drafted
+	OrganizationRoleApprover OrganizationRole = "approver"
+	// Makers draft payments for approval
+	OrganizationRoleMaker OrganizationRole = "maker"
+	// Viewers can only see the organization's accounts and payments
+	OrganizationRoleViewer OrganizationRole = "viewer"
+)
+
+func (r OrganizationRole) CanManage() bool {
+	return r == OrganizationRoleAdmin
+}
+
+func (r OrganizationRole) CanDraft() bool {
+	return r == OrganizationRoleAdmin || r == OrganizationRoleMaker
+}
+
+func (r OrganizationRole) CanApprove() bool {
+	return r == OrganizationRoleAdmin || r == OrganizationRoleApprover
+}
+
+// Organization is a business customer. Its accounts belong to it rather
+// than to any one member.
+type Organization struct {
+	ID                 uuid.UUID `json:"id" db:"id"`
+	Name               string    `json:"name" db:"name"`
+	RegistrationNumber *string   `json:"registration_number,omitempty" db:"registration_number"`
+	CreatedBy          uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateOrganizationRequest struct {
+	Name               string  `json:"name" validate:"required,min=2,max=255"`
+	RegistrationNumber *string `json:"registration_number,omitempty" validate:"omitempty,max=50"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID        `json:"user_id" db:"user_id"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	AddedBy        *uuid.UUID       `json:"added_by,omitempty" db:"added_by"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string           `json:"email" validate:"required,email"`
+	Role  OrganizationRole `json:"role" validate:"required,oneof=admin approver maker viewer"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role OrganizationRole `json:"role" validate:"required,oneof=admin approver maker viewer"`
+}
+
+// ApprovalRule makes payments of at least MinAmount need RequiredApprovals
+// approvals.
+type ApprovalRule struct {
+	OrganizationID    uuid.UUID       `json:"organization_id" db:"organization_id"`
+	MinAmount         decimal.Decimal `json:"min_amount" db:"min_amount"`
+	RequiredApprovals int             `json:"required_approvals" db:"required_approvals"`
+}
+
+type ApprovalRuleRequest struct {
+	MinAmount         decimal.Decimal `json:"min_amount" validate:"decimal_gte=0,decimal_scale=2"`
+	RequiredApprovals int             `json:"required_approvals" validate:"required,min=1,max=10"`
+}
+
+// ApprovalRulesRequest replaces all of an organization's rules.
+type ApprovalRulesRequest struct {
+	Rules []ApprovalRuleRequest `json:"rules" validate:"max=20,dive"`
+}
+
+// RequiredApprovals is how many approvals a payment of amount needs: that of
+// the rule with the highest minimum the amount reaches, or one if it reaches
+// none.
+func RequiredApprovals(rules []*ApprovalRule, amount decimal.Decimal) int {
+	sorted := append([]*ApprovalRule(nil), rules...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinAmount.GreaterThan(sorted[j].MinAmount)
+	})
+	for _, rule := range sorted {
+		if amount.GreaterThanOrEqual(rule.MinAmount) {
+			return rule.RequiredApprovals
+		}
+	}
+	return 1
+}
\ No newline at end of file
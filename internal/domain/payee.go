@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PayeeVerificationStatus string
+
+const (
+	PayeeVerificationVerified   PayeeVerificationStatus = "verified"
+	PayeeVerificationMismatch   PayeeVerificationStatus = "mismatch"
+	PayeeVerificationUnverified PayeeVerificationStatus = "unverified"
+)
+
+type Payee struct {
+	ID                 uuid.UUID               `json:"id" db:"id"`
+	UserID             uuid.UUID               `json:"user_id" db:"user_id"`
+	AccountID          uuid.UUID               `json:"account_id" db:"account_id"`
+	AccountNumber      string                  `json:"account_number" db:"account_number"`
+	Nickname           string                  `json:"nickname" db:"nickname"`
+	MaskedHolderName   string                  `json:"masked_holder_name" db:"masked_holder_name"`
+	VerificationStatus PayeeVerificationStatus `json:"verification_status" db:"verification_status"`
+	CreatedAt          time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+type CreatePayeeRequest struct {
+	AccountNumber string `json:"account_number" validate:"required,account_number"`
+	Nickname      string `json:"nickname" validate:"required,min=1,max=100"`
+	HolderName    string `json:"holder_name,omitempty" validate:"omitempty,max=255"`
+}
+
+type UpdatePayeeRequest struct {
+	Nickname *string `json:"nickname,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// AccountLookupResponse is what a user sees about an account they don't own:
+// the holder name is always masked, and VerificationStatus reports whether the
+// name they supplied matches the real holder.
+type AccountLookupResponse struct {
+	AccountNumber      string                  `json:"account_number"`
+	MaskedHolderName   string                  `json:"masked_holder_name"`
+	Currency           string                  `json:"currency"`
+	VerificationStatus PayeeVerificationStatus `json:"verification_status"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PaymentDraftStatus string
+type PaymentDraftAction string
+
+const (
+	// Drafts wait for their maker to submit them for approval
+	PaymentDraftStatusDraft   PaymentDraftStatus = "draft"
+	PaymentDraftStatusPending PaymentDraftStatus = "pending"
+	// Approved drafts have all their approvals and are being paid
+	PaymentDraftStatusApproved  PaymentDraftStatus = "approved"
+	PaymentDraftStatusExecuted  PaymentDraftStatus = "executed"
+	PaymentDraftStatusRejected  PaymentDraftStatus = "rejected"
+	PaymentDraftStatusCancelled PaymentDraftStatus = "cancelled"
+	PaymentDraftStatusFailed    PaymentDraftStatus = "failed"
+
+	PaymentDraftCreated   PaymentDraftAction = "created"
+	PaymentDraftSubmitted PaymentDraftAction = "submitted"
+	PaymentDraftApproved  PaymentDraftAction = "approved"
+	PaymentDraftRejected  PaymentDraftAction = "rejected"
+	PaymentDraftCancelled PaymentDraftAction = "cancelled"
+	PaymentDraftExecuted  PaymentDraftAction = "executed"
+	PaymentDraftFailed    PaymentDraftAction = "failed"
+)
+
+// PaymentDraft is a payment out of a business account drafted by one member
+// and made only once enough other members have approved it. ToAccountID is
+// always set; PayeeID is kept alongside it when the draft was made out to a
+// payee, until the payee is deleted.
+type PaymentDraft struct {
+	ID             uuid.UUID          `json:"id" db:"id"`
+	OrganizationID uuid.UUID          `json:"organization_id" db:"organization_id"`
+	AccountID      uuid.UUID          `json:"account_id" db:"account_id"`
+	CreatedBy      uuid.UUID          `json:"created_by" db:"created_by"`
+	ToAccountID    *uuid.UUID         `json:"to_account_id,omitempty" db:"to_account_id"`
+	PayeeID        *uuid.UUID         `json:"payee_id,omitempty" db:"payee_id"`
+	Amount         decimal.Decimal    `json:"amount" db:"amount"`
+	Description    string             `json:"description" db:"description"`
+	Status         PaymentDraftStatus `json:"status" db:"status"`
+	// RequiredApprovals is fixed by the organization's rules when the draft
+	// is submitted
+	RequiredApprovals int        `json:"required_approvals" db:"required_approvals"`
+	ApprovedBy        IDList     `json:"approved_by" db:"approved_by"`
+	TransactionID     *uuid.UUID `json:"transaction_id,omitempty" db:"transaction_id"`
+	Failure           *string    `json:"failure,omitempty" db:"failure"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Events is the draft's audit trail, oldest first
+	Events []*PaymentDraftEvent `json:"events,omitempty" db:"-"`
+}
+
+// HasApproved reports whether the user has approved the draft.
+func (d *PaymentDraft) HasApproved(userID uuid.UUID) bool {
+	for _, id := range d.ApprovedBy {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentDraftEvent records who did what to a draft and when.
+type PaymentDraftEvent struct {
+	ID        uuid.UUID          `json:"id" db:"id"`
+	DraftID   uuid.UUID          `json:"draft_id" db:"draft_id"`
+	UserID    uuid.UUID          `json:"user_id" db:"user_id"`
+	Action    PaymentDraftAction `json:"action" db:"action"`
+	Note      string             `json:"note,omitempty" db:"note"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+}
+
+type PaymentDraftDecisionRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Pocket holds an account's money in a currency other than its own. The
+// account's own currency stays on the account, so its pockets only hold
+// foreign currencies, at most one of each. Pockets have no overdraft or
+// savings pots.
+type Pocket struct {
+	AccountID uuid.UUID       `json:"account_id" db:"account_id"`
+	Currency  string          `json:"currency" db:"currency"`
+	Balance   decimal.Decimal `json:"balance" db:"balance"`
+	// HeldBalance is reserved by transfers out of the pocket waiting for
+	// review
+	HeldBalance decimal.Decimal `json:"held_balance" db:"held_balance"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+func (p Pocket) AvailableBalance() decimal.Decimal {
+	return p.Balance.Sub(p.HeldBalance)
+}
+
+// MarshalJSON adds available_balance next to the balance.
+func (p Pocket) MarshalJSON() ([]byte, error) {
+	type pocket Pocket
+	return json.Marshal(struct {
+		pocket
+		AvailableBalance decimal.Decimal `json:"available_balance"`
+	}{pocket(p), p.AvailableBalance()})
+}
+
+// FXQuote prices converting Amount of From into To. MidRate is the market
+// rate and Rate what the customer gets after the bank's spread, both in
+// units of To per unit of From.
+type FXQuote struct {
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Amount    decimal.Decimal `json:"amount"`
+	MidRate   decimal.Decimal `json:"mid_rate"`
+	Rate      decimal.Decimal `json:"rate"`
+	Converted decimal.Decimal `json:"converted"`
+}
+
+// Conversion is money moved between two of an account's currencies: a
+// debit in the currency sold and a credit in the one bought.
+type Conversion struct {
+	Quote  *FXQuote     `json:"quote"`
+	Debit  *Transaction `json:"debit"`
+	Credit *Transaction `json:"credit"`
+}
+
+type OpenPocketRequest struct {
+	Currency string `json:"currency" validate:"required,currency"`
+}
+
+// ConversionRequest sells Amount of FromCurrency for ToCurrency within the
+// account. Either can be the account's own currency.
+type ConversionRequest struct {
+	AccountID    string          `json:"account_id" validate:"required,uuid"`
+	FromCurrency string          `json:"from_currency" validate:"required,currency"`
+	ToCurrency   string          `json:"to_currency" validate:"required,currency"`
+	Amount       decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+}
+
+// StatementView picks the currencies a statement covers: one of them, the
+// account's own by default, or all of them consolidated into the account's
+// currency.
+type StatementView struct {
+	Currency     string
+	Consolidated bool
+}
\ No newline at end of file
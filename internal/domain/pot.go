@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type PotMovementKind string
+
+const (
+	// Deposits and withdrawals are moves the user makes between the
+	// account's main balance and the pot
+	PotMovementDeposit    PotMovementKind = "deposit"
+	PotMovementWithdrawal PotMovementKind = "withdrawal"
+	// Round-ups set aside the change up to the next whole unit of an
+	// outgoing transfer
+	PotMovementRoundUp PotMovementKind = "round_up"
+	// Sweeps set aside a share of an incoming deposit
+	PotMovementSweep PotMovementKind = "sweep"
+)
+
+// Pot is a named savings goal inside an account. Its balance stays part of
+// the account's ledger balance but can't be spent until it is moved back.
+type Pot struct {
+	ID           uuid.UUID           `json:"id" db:"id"`
+	AccountID    uuid.UUID           `json:"account_id" db:"account_id"`
+	Name         string              `json:"name" db:"name"`
+	Balance      decimal.Decimal     `json:"balance" db:"balance"`
+	TargetAmount decimal.NullDecimal `json:"target_amount" db:"target_amount"`
+	TargetDate   *time.Time          `json:"target_date,omitempty" db:"target_date"`
+	// RoundUp collects the round-ups of the account's outgoing transfers.
+	// Only one pot per account can.
+	RoundUp bool `json:"round_up" db:"round_up"`
+	// SweepPercent of every deposit into the account goes into the pot
+	SweepPercent decimal.Decimal `json:"sweep_percent" db:"sweep_percent"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Progress is how far the pot is towards its target, as a fraction that
+// reaches one when the target is met. Pots without a target have none.
+func (p Pot) Progress() *decimal.Decimal {
+	if !p.TargetAmount.Valid || !p.TargetAmount.Decimal.IsPositive() {
+		return nil
+	}
+	progress := decimal.Min(p.Balance.Div(p.TargetAmount.Decimal), decimal.NewFromInt(1)).Round(4)
+	return &progress
+}
+
+// MarshalJSON adds the progress towards the target.
+func (p Pot) MarshalJSON() ([]byte, error) {
+	type pot Pot
+	return json.Marshal(struct {
+		pot
+		Progress *decimal.Decimal `json:"progress,omitempty"`
+	}{pot(p), p.Progress()})
+}
+
+// PotMovement records money moved into a pot, with a positive Amount, or
+// out of it, with a negative one.
+type PotMovement struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	PotID         uuid.UUID       `json:"pot_id" db:"pot_id"`
+	Kind          PotMovementKind `json:"kind" db:"kind"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+type CreatePotRequest struct {
+	Name         string              `json:"name" validate:"required,min=1,max=100"`
+	TargetAmount decimal.NullDecimal `json:"target_amount" validate:"omitempty,decimal_gt=0,decimal_scale=2"`
+	TargetDate   *time.Time          `json:"target_date,omitempty"`
+	RoundUp      bool                `json:"round_up"`
+	SweepPercent decimal.Decimal     `json:"sweep_percent" validate:"decimal_gte=0,decimal_lte=100,decimal_scale=2"`
+}
+
+// UpdatePotRequest changes only the fields it sets. A zero target amount
+// removes the target, and so does clear_target_date for the date.
+type UpdatePotRequest struct {
+	Name            *string             `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	TargetAmount    decimal.NullDecimal `json:"target_amount" validate:"omitempty,decimal_gte=0,decimal_scale=2"`
+	TargetDate      *time.Time          `json:"target_date,omitempty"`
+	ClearTargetDate bool                `json:"clear_target_date,omitempty"`
+	RoundUp         *bool               `json:"round_up,omitempty"`
+	SweepPercent    decimal.NullDecimal `json:"sweep_percent" validate:"omitempty,decimal_gte=0,decimal_lte=100,decimal_scale=2"`
+}
+
+type PotMoveRequest struct {
+	Amount decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+}
\ No newline at end of file
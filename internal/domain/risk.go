@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type RiskDecision string
+type ReviewStatus string
+
+const (
+	RiskDecisionAllow  RiskDecision = "allow"
+	RiskDecisionReview RiskDecision = "review"
+	RiskDecisionBlock  RiskDecision = "block"
+
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// Severity orders decisions so the strictest of several rule outcomes wins.
+func (d RiskDecision) Severity() int {
+	switch d {
+	case RiskDecisionBlock:
+		return 2
+	case RiskDecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RiskInput is everything a RiskEvaluator sees about a transfer before it is
+// committed. PayeeCreatedAt is nil unless the transfer was made to a payee.
+type RiskInput struct {
+	UserID              uuid.UUID
+	FromAccount         *Account
+	ToAccount           *Account
+	Amount              decimal.Decimal
+	PerTransactionLimit decimal.Decimal
+	PayeeCreatedAt      *time.Time
+	DeviceID            string
+	At                  time.Time
+}
+
+type RiskAssessment struct {
+	Decision RiskDecision `json:"decision"`
+	Reasons  []string     `json:"reasons,omitempty"`
+}
+
+type TransactionReview struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	TransactionID uuid.UUID    `json:"transaction_id" db:"transaction_id"`
+	Reasons       StringList   `json:"reasons" db:"reasons"`
+	Status        ReviewStatus `json:"status" db:"status"`
+	ReviewedBy    *uuid.UUID   `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	Note          *string      `json:"note,omitempty" db:"note"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
+
+type ReviewDecisionRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningSubject string
+
+const (
+	ScreeningSubjectUser     ScreeningSubject = "user"
+	ScreeningSubjectPayee    ScreeningSubject = "payee"
+	ScreeningSubjectTransfer ScreeningSubject = "transfer"
+)
+
+// ScreeningMatch is a watchlist entry that scored above the review threshold
+// against a screened name.
+type ScreeningMatch struct {
+	EntryID string  `json:"entry_id"`
+	Name    string  `json:"name"`
+	Program string  `json:"program,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+// ScreeningMatches is stored as a JSONB array.
+type ScreeningMatches []ScreeningMatch
+
+func (m ScreeningMatches) Value() (driver.Value, error) {
+	if m == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(m)
+}
+
+func (m *ScreeningMatches) Scan(src any) error {
+	return scanJSON(src, m)
+}
+
+// ScreeningResult uses the risk decisions: allow for no match, review for a
+// possible match and block for a strong one.
+type ScreeningResult struct {
+	Decision RiskDecision     `json:"decision"`
+	Matches  ScreeningMatches `json:"matches,omitempty"`
+}
+
+// ScreeningAlert records a watchlist hit for compliance. SubjectID is the
+// user, payee or transaction that was let through for review; it is nil
+// when the hit was blocked before anything was created.
+type ScreeningAlert struct {
+	ID           uuid.UUID        `json:"id" db:"id"`
+	Subject      ScreeningSubject `json:"subject" db:"subject"`
+	SubjectID    *uuid.UUID       `json:"subject_id,omitempty" db:"subject_id"`
+	ScreenedName string           `json:"screened_name" db:"screened_name"`
+	Decision     RiskDecision     `json:"decision" db:"decision"`
+	Matches      ScreeningMatches `json:"matches" db:"matches"`
+	Status       ReviewStatus     `json:"status" db:"status"`
+	ReviewedBy   *uuid.UUID       `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	Note         *string          `json:"note,omitempty" db:"note"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	ReviewedAt   *time.Time       `json:"reviewed_at,omitempty" db:"reviewed_at"`
+}
\ No newline at end of file
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type TermDepositStatus string
+type MaturityInstruction string
+
+const (
+	TermDepositStatusActive     TermDepositStatus = "active"
+	TermDepositStatusMatured    TermDepositStatus = "matured"
+	TermDepositStatusRolledOver TermDepositStatus = "rolled_over"
+	TermDepositStatusBroken     TermDepositStatus = "broken"
+
+	// MaturityPayout pays principal and interest to the payout account;
+	// MaturityRollover reinvests both for another term at the rate then
+	// offered.
+	MaturityPayout   MaturityInstruction = "payout"
+	MaturityRollover MaturityInstruction = "rollover"
+)
+
+// TermDepositRate is the annual rate, as a fraction, offered for a term.
+type TermDepositRate struct {
+	Months int             `json:"months"`
+	Rate   decimal.Decimal `json:"rate"`
+}
+
+// TermDepositPolicy lists the terms on offer. Breaking a deposit before it
+// matures pays BreakRate instead of the agreed rate for the time it ran,
+// less a fee of BreakFeeRate of the principal.
+type TermDepositPolicy struct {
+	Terms         []TermDepositRate  `json:"terms"`
+	DayCount      DayCountConvention `json:"day_count"`
+	MinimumAmount decimal.Decimal    `json:"minimum_amount"`
+	BreakRate     decimal.Decimal    `json:"break_rate"`
+	BreakFeeRate  decimal.Decimal    `json:"break_fee_rate"`
+}
+
+// Rate returns the rate offered for a term, or false if it isn't offered.
+func (p *TermDepositPolicy) Rate(months int) (decimal.Decimal, bool) {
+	for _, term := range p.Terms {
+		if term.Months == months {
+			return term.Rate, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// TermDeposit locks Principal in its own deposit account from StartDate to
+// MaturityDate, when it is worth MaturityValue. A rollover closes the
+// deposit and opens the next term on the same account.
+type TermDeposit struct {
+	ID              uuid.UUID           `json:"id" db:"id"`
+	UserID          uuid.UUID           `json:"user_id" db:"user_id"`
+	AccountID       uuid.UUID           `json:"account_id" db:"account_id"`
+	PayoutAccountID uuid.UUID           `json:"payout_account_id" db:"payout_account_id"`
+	Principal       decimal.Decimal     `json:"principal" db:"principal"`
+	Rate            decimal.Decimal     `json:"rate" db:"rate"`
+	TermMonths      int                 `json:"term_months" db:"term_months"`
+	StartDate       time.Time           `json:"start_date" db:"start_date"`
+	MaturityDate    time.Time           `json:"maturity_date" db:"maturity_date"`
+	MaturityValue   decimal.Decimal     `json:"maturity_value" db:"maturity_value"`
+	Instruction     MaturityInstruction `json:"maturity_instruction" db:"maturity_instruction"`
+	Status          TermDepositStatus   `json:"status" db:"status"`
+	ClosedAt        *time.Time          `json:"closed_at,omitempty" db:"closed_at"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+}
+
+// OpenTermDepositRequest funds a new deposit from SourceAccountID. Without a
+// PayoutAccountID the deposit pays back to the source account.
+type OpenTermDepositRequest struct {
+	SourceAccountID     string              `json:"source_account_id" validate:"required,uuid"`
+	Amount              decimal.Decimal     `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+	TermMonths          int                 `json:"term_months" validate:"required,min=1,max=120"`
+	MaturityInstruction MaturityInstruction `json:"maturity_instruction" validate:"required,oneof=payout rollover"`
+	PayoutAccountID     string              `json:"payout_account_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// BreakTermDepositResponse shows what breaking a deposit early paid out.
+type BreakTermDepositResponse struct {
+	TermDeposit *TermDeposit    `json:"term_deposit"`
+	Interest    decimal.Decimal `json:"interest"`
+	Penalty     decimal.Decimal `json:"penalty"`
+	Payout      decimal.Decimal `json:"payout"`
+}
\ No newline at end of file
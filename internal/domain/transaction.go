@@ -16,6 +16,16 @@ const (
 	TransactionTypeWithdrawal TransactionType = "withdrawal"
 	TransactionTypePayment    TransactionType = "payment"
 
+	// Interest is paid into an account by the bank, and withholding tax on
+	// it taken back out
+	TransactionTypeInterest       TransactionType = "interest"
+	TransactionTypeWithholdingTax TransactionType = "withholding_tax"
+	// Fees and penalties the bank charges
+	TransactionTypeFee TransactionType = "fee"
+	// A conversion between an account's currencies is recorded as a debit
+	// in one and a credit in the other
+	TransactionTypeConversion TransactionType = "conversion"
+
 	TransactionStatusPending   TransactionStatus = "pending"
 	TransactionStatusCompleted TransactionStatus = "completed"
 	TransactionStatusFailed    TransactionStatus = "failed"
@@ -32,16 +42,53 @@ type Transaction struct {
 	Status        TransactionStatus `json:"status" db:"status"`
 	Reference     string            `json:"reference" db:"reference"`
 	Description   *string           `json:"description,omitempty" db:"description"`
-	Metadata      map[string]any    `json:"metadata,omitempty" db:"metadata"`
+	Metadata      Metadata          `json:"metadata,omitempty" db:"metadata"`
 	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
 	CompletedAt   *time.Time        `json:"completed_at,omitempty" db:"completed_at"`
+	// Value is what Amount was worth in the account's own currency when it
+	// was paid out of one of the account's pockets
+	Value *decimal.Decimal `json:"value,omitempty" db:"value"`
+	// Fees are the fee transactions charged with this one
+	Fees []*Transaction `json:"fees,omitempty" db:"-"`
+	// MaskedHolderName is the recipient's name as an account lookup shows
+	// it, on transfers sent by account number or to a payee
+	MaskedHolderName string `json:"masked_holder_name,omitempty" db:"-"`
 }
 
+// TransferRequest identifies the destination by exactly one of ToAccountID,
+// ToAccountNumber or PayeeID. BeneficiaryName is only checked for
+// ToAccountNumber transfers. FromCurrency sends the money from one of the
+// account's currency pockets instead of its own balance; the recipient is
+// paid in the same currency.
 type TransferRequest struct {
-	FromAccountID string          `json:"from_account_id" validate:"required,uuid"`
-	ToAccountID   string          `json:"to_account_id" validate:"required,uuid"`
-	Amount        decimal.Decimal `json:"amount" validate:"required,gt=0"`
-	Description   string          `json:"description,omitempty" validate:"omitempty,max=500"`
+	FromAccountID   string          `json:"from_account_id" validate:"required,uuid"`
+	ToAccountID     string          `json:"to_account_id,omitempty" validate:"required_without_all=ToAccountNumber PayeeID,omitempty,uuid"`
+	ToAccountNumber string          `json:"to_account_number,omitempty" validate:"omitempty,account_number"`
+	PayeeID         string          `json:"payee_id,omitempty" validate:"omitempty,uuid"`
+	FromCurrency    string          `json:"from_currency,omitempty" validate:"omitempty,currency"`
+	BeneficiaryName string          `json:"beneficiary_name,omitempty" validate:"omitempty,max=255"`
+	Amount          decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+	Description     string          `json:"description,omitempty" validate:"omitempty,max=500"`
+	DeviceID        string          `json:"-"`
+}
+
+// DepositRequest records money paid into an account from outside the bank,
+// such as cash at a branch. Money in a currency other than the account's
+// goes into its pocket for that currency.
+type DepositRequest struct {
+	AccountID   string          `json:"account_id" validate:"required,uuid"`
+	Amount      decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+	Currency    string          `json:"currency,omitempty" validate:"omitempty,currency"`
+	Description string          `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// WithdrawalRequest records money paid out of an account to outside the
+// bank, from its pocket if Currency isn't the account's own.
+type WithdrawalRequest struct {
+	AccountID   string          `json:"account_id" validate:"required,uuid"`
+	Amount      decimal.Decimal `json:"amount" validate:"required,decimal_gt=0,decimal_scale=2"`
+	Currency    string          `json:"currency,omitempty" validate:"omitempty,currency"`
+	Description string          `json:"description,omitempty" validate:"omitempty,max=500"`
 }
 
 type TransactionFilter struct {
@@ -6,6 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
+type UserTier string
+type UserRole string
+
+const (
+	UserTierStandard UserTier = "standard"
+	UserTierPremium  UserTier = "premium"
+
+	UserRoleCustomer UserRole = "customer"
+	UserRoleOperator UserRole = "operator"
+)
+
 type User struct {
 	ID              uuid.UUID  `json:"id" db:"id"`
 	Email           string     `json:"email" db:"email"`
@@ -14,10 +25,21 @@ type User struct {
 	Phone           *string    `json:"phone,omitempty" db:"phone"`
 	ProfileImageURL *string    `json:"profile_image_url,omitempty" db:"profile_image_url"`
 	IsVerified      bool       `json:"is_verified" db:"is_verified"`
+	Tier            UserTier   `json:"tier" db:"tier"`
+	Role            UserRole   `json:"role" db:"role"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// DeletedUserName replaces the name of a user who was anonymized.
+const DeletedUserName = "Deleted user"
+
+// DeletedUserEmail is the placeholder address of an anonymized user. It is
+// unique, so the address they used can be registered again.
+func DeletedUserEmail(id uuid.UUID) string {
+	return "deleted-" + id.String() + "@deleted.invalid"
+}
+
 type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
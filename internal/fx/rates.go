@@ -0,0 +1,98 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/shopspring/decimal"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrUnsupportedPair means the source has no rate between the currencies.
+var ErrUnsupportedPair = errors.New("fx: no rate for currency pair")
+
+// Source quotes mid-market exchange rates.
+type Source interface {
+	// Rate is how many units of quote one unit of base is worth.
+	Rate(ctx context.Context, base, quote string) (decimal.Decimal, error)
+}
+
+// Table is a Source of fixed rates, each in units of the currency per unit
+// of Base. Other pairs are crossed through Base.
+type Table struct {
+	Base  string                     `json:"base"`
+	Rates map[string]decimal.Decimal `json:"rates"`
+}
+
+func DefaultTable() *Table {
+	return &Table{
+		Base: "USD",
+		Rates: map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("0.92"),
+			"GBP": decimal.RequireFromString("0.79"),
+			"JPY": decimal.RequireFromString("150"),
+			"SGD": decimal.RequireFromString("1.34"),
+			"AUD": decimal.RequireFromString("1.52"),
+			"IDR": decimal.RequireFromString("15700"),
+		},
+	}
+}
+
+// LoadTable reads a rate table from a YAML or JSON file. Unlike most
+// config files it replaces the defaults rather than adding to them, so
+// stale default rates can't linger next to the file's.
+func LoadTable(path string) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fx rates: %w", err)
+	}
+
+	var table Table
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse fx rates: %w", err)
+	}
+
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+
+	return &table, nil
+}
+
+func (t *Table) validate() error {
+	if len(t.Base) != 3 {
+		return fmt.Errorf("fx rates: base must be a currency code")
+	}
+	for currency, rate := range t.Rates {
+		if len(currency) != 3 {
+			return fmt.Errorf("fx rates: %q is not a currency code", currency)
+		}
+		if !rate.IsPositive() {
+			return fmt.Errorf("fx rate %s: must be positive", currency)
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) Rate(ctx context.Context, base, quote string) (decimal.Decimal, error) {
+	from, ok := t.rate(base)
+	if !ok {
+		return decimal.Zero, ErrUnsupportedPair
+	}
+	to, ok := t.rate(quote)
+	if !ok {
+		return decimal.Zero, ErrUnsupportedPair
+	}
+	return to.DivRound(from, 8), nil
+}
+
+func (t *Table) rate(currency string) (decimal.Decimal, bool) {
+	if currency == t.Base {
+		return decimal.NewFromInt(1), true
+	}
+	rate, ok := t.Rates[currency]
+	return rate, ok
+}
\ No newline at end of file
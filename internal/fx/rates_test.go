@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTableRate(t *testing.T) {
+	table := &Table{
+		Base: "USD",
+		Rates: map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("0.8"),
+			"GBP": decimal.RequireFromString("0.5"),
+		},
+	}
+
+	tests := []struct {
+		base, quote string
+		want        string
+		wantErr     error
+	}{
+		{"USD", "USD", "1", nil},
+		{"USD", "EUR", "0.8", nil},
+		{"EUR", "USD", "1.25", nil},
+		{"EUR", "GBP", "0.625", nil},
+		{"USD", "JPY", "0", ErrUnsupportedPair},
+		{"JPY", "USD", "0", ErrUnsupportedPair},
+	}
+	for _, tt := range tests {
+		t.Run(tt.base+"/"+tt.quote, func(t *testing.T) {
+			rate, err := table.Rate(context.Background(), tt.base, tt.quote)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if !rate.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("rate %s, want %s", rate, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTable(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	table, err := LoadTable(write("rates.yaml", "base: EUR\nrates:\n  USD: 1.1\n"))
+	if err != nil {
+		t.Fatalf("LoadTable: %v", err)
+	}
+	if _, err := table.Rate(context.Background(), "EUR", "GBP"); err != ErrUnsupportedPair {
+		t.Errorf("default rate kept alongside the file's: error %v, want %v", err, ErrUnsupportedPair)
+	}
+
+	for name, content := range map[string]string{
+		"no base":       "rates:\n  USD: 1.1\n",
+		"negative rate": "base: EUR\nrates:\n  USD: -1\n",
+		"bad currency":  "base: EUR\nrates:\n  dollars: 1.1\n",
+	} {
+		if _, err := LoadTable(write("invalid.yaml", content)); err == nil {
+			t.Errorf("%s: loaded, want an error", name)
+		}
+	}
+}
\ No newline at end of file
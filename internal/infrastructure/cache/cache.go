@@ -8,16 +8,17 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
-	"github.com/nabiilNajm26/go-bank/internal/infrastructure/redis"
 )
 
 type CacheService struct {
-	redis *redis.RedisClient
+	store Store
 }
 
-func NewCacheService(redisClient *redis.RedisClient) *CacheService {
+// NewCacheService accepts a *redis.RedisClient in production or a
+// MemoryStore in tests.
+func NewCacheService(store Store) *CacheService {
 	return &CacheService{
-		redis: redisClient,
+		store: store,
 	}
 }
 
@@ -28,12 +29,12 @@ func (c *CacheService) SetUser(ctx context.Context, user *domain.User) error {
 	if err != nil {
 		return err
 	}
-	return c.redis.Set(ctx, key, data, 30*time.Minute)
+	return c.store.Set(ctx, key, data, 30*time.Minute)
 }
 
 func (c *CacheService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	key := fmt.Sprintf("user:%s", userID.String())
-	data, err := c.redis.Get(ctx, key)
+	data, err := c.store.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +48,7 @@ func (c *CacheService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.U
 
 func (c *CacheService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	key := fmt.Sprintf("user:%s", userID.String())
-	return c.redis.Del(ctx, key)
+	return c.store.Del(ctx, key)
 }
 
 // Account caching
@@ -57,12 +58,12 @@ func (c *CacheService) SetAccount(ctx context.Context, account *domain.Account)
 	if err != nil {
 		return err
 	}
-	return c.redis.Set(ctx, key, data, 15*time.Minute)
+	return c.store.Set(ctx, key, data, 15*time.Minute)
 }
 
 func (c *CacheService) GetAccount(ctx context.Context, accountID uuid.UUID) (*domain.Account, error) {
 	key := fmt.Sprintf("account:%s", accountID.String())
-	data, err := c.redis.Get(ctx, key)
+	data, err := c.store.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +77,7 @@ func (c *CacheService) GetAccount(ctx context.Context, accountID uuid.UUID) (*do
 
 func (c *CacheService) DeleteAccount(ctx context.Context, accountID uuid.UUID) error {
 	key := fmt.Sprintf("account:%s", accountID.String())
-	return c.redis.Del(ctx, key)
+	return c.store.Del(ctx, key)
 }
 
 // Transaction caching for recent transactions
@@ -86,12 +87,12 @@ func (c *CacheService) SetTransactions(ctx context.Context, accountID uuid.UUID,
 	if err != nil {
 		return err
 	}
-	return c.redis.Set(ctx, key, data, 5*time.Minute)
+	return c.store.Set(ctx, key, data, 5*time.Minute)
 }
 
 func (c *CacheService) GetTransactions(ctx context.Context, accountID uuid.UUID) ([]*domain.Transaction, error) {
 	key := fmt.Sprintf("transactions:%s", accountID.String())
-	data, err := c.redis.Get(ctx, key)
+	data, err := c.store.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -105,18 +106,18 @@ func (c *CacheService) GetTransactions(ctx context.Context, accountID uuid.UUID)
 
 func (c *CacheService) DeleteTransactions(ctx context.Context, accountID uuid.UUID) error {
 	key := fmt.Sprintf("transactions:%s", accountID.String())
-	return c.redis.Del(ctx, key)
+	return c.store.Del(ctx, key)
 }
 
 // Session management
 func (c *CacheService) SetSession(ctx context.Context, sessionID string, userID uuid.UUID) error {
 	key := fmt.Sprintf("session:%s", sessionID)
-	return c.redis.Set(ctx, key, userID.String(), 24*time.Hour)
+	return c.store.Set(ctx, key, userID.String(), 24*time.Hour)
 }
 
 func (c *CacheService) GetSession(ctx context.Context, sessionID string) (uuid.UUID, error) {
 	key := fmt.Sprintf("session:%s", sessionID)
-	data, err := c.redis.Get(ctx, key)
+	data, err := c.store.Get(ctx, key)
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -125,17 +126,31 @@ func (c *CacheService) GetSession(ctx context.Context, sessionID string) (uuid.U
 
 func (c *CacheService) DeleteSession(ctx context.Context, sessionID string) error {
 	key := fmt.Sprintf("session:%s", sessionID)
-	return c.redis.Del(ctx, key)
+	return c.store.Del(ctx, key)
 }
 
 // Cache invalidation
 func (c *CacheService) InvalidateUserCache(ctx context.Context, userID uuid.UUID) error {
 	userKey := fmt.Sprintf("user:%s", userID.String())
-	return c.redis.Del(ctx, userKey)
+	return c.store.Del(ctx, userKey)
 }
 
 func (c *CacheService) InvalidateAccountCache(ctx context.Context, accountID uuid.UUID) error {
 	accountKey := fmt.Sprintf("account:%s", accountID.String())
 	transactionKey := fmt.Sprintf("transactions:%s", accountID.String())
-	return c.redis.Del(ctx, accountKey, transactionKey)
+	return c.store.Del(ctx, accountKey, transactionKey)
+}
+
+// InvalidateAccounts drops the cached account and recent transactions for
+// every account a committed database transaction touched.
+func (c *CacheService) InvalidateAccounts(ctx context.Context, accountIDs ...uuid.UUID) error {
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, 2*len(accountIDs))
+	for _, id := range accountIDs {
+		keys = append(keys, fmt.Sprintf("account:%s", id.String()), fmt.Sprintf("transactions:%s", id.String()))
+	}
+	return c.store.Del(ctx, keys...)
 }
\ No newline at end of file
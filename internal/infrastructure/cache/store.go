@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Store is the key-value backend behind CacheService. Get reports a missing
+// key as redis.Nil, like the Redis client does.
+type Store interface {
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Get(ctx context.Context, key string) (string, error)
+	Del(ctx context.Context, keys ...string) error
+}
+
+type memoryItem struct {
+	value     string
+	expiresAt time.Time
+}
+
+// MemoryStore is an in-process Store for tests and single-node development.
+type MemoryStore struct {
+	mu    sync.Mutex
+	items map[string]memoryItem
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{items: make(map[string]memoryItem)}
+}
+
+func (s *MemoryStore) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	var str string
+	switch v := value.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		str = fmt.Sprint(v)
+	}
+
+	item := memoryItem{value: str}
+	if expiration > 0 {
+		item.expiresAt = time.Now().Add(expiration)
+	}
+
+	s.mu.Lock()
+	s.items[key] = item
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[key]
+	if !ok {
+		return "", goredis.Nil
+	}
+	if !item.expiresAt.IsZero() && time.Now().After(item.expiresAt) {
+		delete(s.items, key)
+		return "", goredis.Nil
+	}
+	return item.value, nil
+}
+
+func (s *MemoryStore) Del(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	for _, key := range keys {
+		delete(s.items, key)
+	}
+	s.mu.Unlock()
+	return nil
+}
\ No newline at end of file
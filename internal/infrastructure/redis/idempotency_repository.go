@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/redis/go-redis/v9"
+)
+
+// idempotencyRepository keeps each record as JSON under a key that expires
+// with the record, so there is nothing for DeleteExpired to do.
+type idempotencyRepository struct {
+	client *redis.Client
+}
+
+func NewIdempotencyRepository(client *RedisClient) repository.IdempotencyRepository {
+	return &idempotencyRepository{client: client.client}
+}
+
+func idempotencyKey(key string, userID uuid.UUID) string {
+	return fmt.Sprintf("idempotency:%s:%s", userID, key)
+}
+
+func (r *idempotencyRepository) GetByKey(ctx context.Context, key string, userID uuid.UUID) (*domain.IdempotencyRecord, error) {
+	data, err := r.client.Get(ctx, idempotencyKey(key, userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var record domain.IdempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *idempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	record.ID = uuid.New()
+	record.CreatedAt = time.Now()
+
+	ttl := time.Until(record.ExpiresAt)
+	if ttl <= 0 {
+		// Already expired: as good as created and purged at once
+		return true, nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, idempotencyKey(record.IdempotencyKey, record.UserID), data, ttl).Result()
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	completed := *record
+	completed.Status = domain.IdempotencyStatusCompleted
+
+	data, err := json.Marshal(&completed)
+	if err != nil {
+		return err
+	}
+
+	// Only overwrite a record that is still there, and keep its expiry
+	err = r.client.SetArgs(ctx, idempotencyKey(record.IdempotencyKey, record.UserID), data, redis.SetArgs{
+		Mode:    "XX",
+		KeepTTL: true,
+	}).Err()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
+
+func (r *idempotencyRepository) Delete(ctx context.Context, key string, userID uuid.UUID) error {
+	return r.client.Del(ctx, idempotencyKey(key, userID)).Err()
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
\ No newline at end of file
@@ -0,0 +1,23 @@
+package redis
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nabiilNajm26/go-bank/internal/repository/memory"
+	"github.com/nabiilNajm26/go-bank/internal/repository/repositorytest"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestIdempotencyContract runs against the Redis server at TEST_REDIS_ADDR.
+func TestIdempotencyContract(t *testing.T) {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set")
+	}
+
+	client := &RedisClient{client: redis.NewClient(&redis.Options{Addr: addr})}
+	defer client.Close()
+
+	repositorytest.RunIdempotency(t, memory.NewStore().Users(), NewIdempotencyRepository(client))
+}
\ No newline at end of file
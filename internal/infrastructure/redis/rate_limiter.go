@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript counts a request in the fixed window it falls in when
+// the weighted sliding window still has room, using Redis' clock so every
+// replica agrees on the window. It returns the counts the decision was made
+// from; ratelimit.Evaluate repeats the same arithmetic to fill in the result.
+var slidingWindowScript = redis.NewScript(`
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local now = redis.call('TIME')
+local nowMs = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+local index = math.floor(nowMs / window)
+local elapsed = nowMs % window
+local currentKey = KEYS[1] .. ':' .. index
+local previous = tonumber(redis.call('GET', KEYS[1] .. ':' .. (index - 1)) or '0')
+local current = tonumber(redis.call('GET', currentKey) or '0')
+if previous * (1 - elapsed / window) + current + 1 <= limit then
+	redis.call('INCR', currentKey)
+	redis.call('PEXPIRE', currentKey, window * 2)
+end
+return {previous, current, elapsed}
+`)
+
+// rateLimiter shares request counts between replicas through Redis.
+type rateLimiter struct {
+	client *redis.Client
+}
+
+func NewRateLimiter(client *RedisClient) ratelimit.Limiter {
+	return &rateLimiter{client: client.client}
+}
+
+func (l *rateLimiter) Allow(ctx context.Context, key string, policy ratelimit.Policy) (*ratelimit.Result, error) {
+	// The hash tag keeps both windows of a key on one cluster slot
+	prefix := fmt.Sprintf("ratelimit:{%s}", key)
+	counts, err := slidingWindowScript.Run(ctx, l.client, []string{prefix}, policy.Window().Milliseconds(), policy.Limit).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) != 3 {
+		return nil, fmt.Errorf("rate limit script returned %d values", len(counts))
+	}
+
+	return ratelimit.Evaluate(policy, counts[0], counts[1], time.Duration(counts[2])*time.Millisecond), nil
+}
\ No newline at end of file
@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestRateLimiter runs against the Redis server at TEST_REDIS_ADDR.
+func TestRateLimiter(t *testing.T) {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set")
+	}
+
+	client := &RedisClient{client: redis.NewClient(&redis.Options{Addr: addr})}
+	defer client.Close()
+
+	ctx := context.Background()
+	limiter := NewRateLimiter(client)
+	policy := ratelimit.Policy{Limit: 3, WindowSeconds: 3600}
+	key := uuid.NewString()
+
+	for want := 2; want >= 0; want-- {
+		result, err := limiter.Allow(ctx, key, policy)
+		if err != nil {
+			t.Fatalf("allow: %v", err)
+		}
+		if !result.Allowed || result.Remaining != want {
+			t.Fatalf("allowed %v, remaining %d, want allowed with %d left", result.Allowed, result.Remaining, want)
+		}
+	}
+
+	result, err := limiter.Allow(ctx, key, policy)
+	if err != nil {
+		t.Fatalf("allow: %v", err)
+	}
+	if result.Allowed || result.RetryAfter <= 0 {
+		t.Errorf("allowed %v, retry after %s, want refused with a wait", result.Allowed, result.RetryAfter)
+	}
+
+	// Another key has its own bucket
+	if result, err := limiter.Allow(ctx, uuid.NewString(), policy); err != nil || !result.Allowed {
+		t.Errorf("another key: allowed %v, err %v", result != nil && result.Allowed, err)
+	}
+}
\ No newline at end of file
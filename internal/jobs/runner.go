@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Func is a unit of background work. Errors are logged and the job runs again
+// on its next tick.
+type Func func(ctx context.Context) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      Func
+}
+
+// Runner runs registered jobs on fixed intervals until its context is
+// cancelled.
+type Runner struct {
+	jobs []job
+	wg   sync.WaitGroup
+}
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+func (r *Runner) Add(name string, interval time.Duration, run Func) {
+	r.jobs = append(r.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start launches every job in its own goroutine and returns immediately.
+func (r *Runner) Start(ctx context.Context) {
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, j)
+	}
+}
+
+// Wait blocks until every job has stopped after the context is cancelled.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+func (r *Runner) loop(ctx context.Context, j job) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.run(ctx); err != nil {
+				log.Printf("Job %s failed: %v", j.name, err)
+			}
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,161 @@
+// Package ratelimit counts requests per key against sliding window policies,
+// in memory or in a shared store so every replica sees the same counts.
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter counts a request against key and reports whether it fits policy.
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy Policy) (*Result, error)
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is how long until the key's quota is fully restored.
+	ResetAfter time.Duration
+	// RetryAfter is how long until a refused request would fit, zero when
+	// the request was allowed.
+	RetryAfter time.Duration
+}
+
+// Evaluate decides a request from the counts in the fixed window in progress
+// and the one before it, elapsed into the current window. The previous count
+// is weighted by how much of it still overlaps the sliding window, which
+// smooths out the burst a plain fixed window allows at its boundary.
+func Evaluate(policy Policy, previous, current int64, elapsed time.Duration) *Result {
+	window := policy.Window()
+	limit := float64(policy.Limit)
+	used := float64(previous)*(1-float64(elapsed)/float64(window)) + float64(current)
+	toNext := window - elapsed
+
+	// Requests in the current window count until the end of the next one
+	result := &Result{Limit: policy.Limit, ResetAfter: toNext + window}
+
+	if used+1 <= limit {
+		result.Allowed = true
+		result.Remaining = int(math.Floor(limit - used - 1))
+		return result
+	}
+	if current == 0 {
+		result.ResetAfter = toNext
+	}
+
+	if float64(current)+1 <= limit {
+		// Room opens up as the previous window slides out
+		wait := float64(window)*(1-(limit-1-float64(current))/float64(previous)) - float64(elapsed)
+		result.RetryAfter = time.Duration(math.Ceil(wait))
+	} else {
+		// The current window alone is full, so wait for it to become the
+		// previous one and slide out far enough
+		wait := float64(window) * (1 - (limit-1)/float64(current))
+		result.RetryAfter = toNext + time.Duration(math.Ceil(wait))
+	}
+	return result
+}
+
+type counter struct {
+	window time.Duration
+	// index numbers the current fixed window since the epoch
+	index    int64
+	previous int64
+	current  int64
+}
+
+// MemoryLimiter counts requests in this process only. It is the fallback
+// when the shared store is down and the limiter for single-replica setups.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		counters: make(map[string]*counter),
+		now:      time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, policy Policy) (*Result, error) {
+	now := l.now()
+	window := policy.Window()
+	index := now.UnixNano() / int64(window)
+	elapsed := time.Duration(now.UnixNano() % int64(window))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	c := l.counters[key]
+	if c == nil || c.window != window {
+		c = &counter{window: window, index: index}
+		l.counters[key] = c
+	}
+	switch {
+	case index == c.index+1:
+		c.previous, c.current = c.current, 0
+	case index > c.index+1:
+		c.previous, c.current = 0, 0
+	}
+	c.index = index
+
+	result := Evaluate(policy, c.previous, c.current, elapsed)
+	if result.Allowed {
+		c.current++
+	}
+
+	l.sweep(now)
+	return result, nil
+}
+
+// sweep drops counters with nothing left in their sliding window, at most
+// once a minute.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, c := range l.counters {
+		if now.UnixNano()/int64(c.window) > c.index+1 {
+			delete(l.counters, key)
+		}
+	}
+}
+
+// FallbackLimiter uses primary and switches to fallback for as long as
+// primary fails, so losing the shared store degrades limits to per replica
+// instead of failing requests or letting them all through.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	degraded atomic.Bool
+}
+
+func NewFallbackLimiter(primary, fallback Limiter) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback}
+}
+
+func (l *FallbackLimiter) Allow(ctx context.Context, key string, policy Policy) (*Result, error) {
+	result, err := l.primary.Allow(ctx, key, policy)
+	if err == nil {
+		if l.degraded.CompareAndSwap(true, false) {
+			log.Println("Rate limiter store recovered")
+		}
+		return result, nil
+	}
+
+	if l.degraded.CompareAndSwap(false, true) {
+		log.Printf("Warning: rate limiter store failed: %v. Using in-memory limits.", err)
+	}
+	return l.fallback.Allow(ctx, key, policy)
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestLimiter starts its clock at the beginning of a minute, so windows
+// line up with the steps the tests take.
+func newTestLimiter() (*MemoryLimiter, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	limiter := NewMemoryLimiter()
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func allow(t *testing.T, limiter Limiter, key string, policy Policy) *Result {
+	t.Helper()
+
+	result, err := limiter.Allow(context.Background(), key, policy)
+	if err != nil {
+		t.Fatalf("allow %s: %v", key, err)
+	}
+	return result
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	policy := Policy{Limit: 4, WindowSeconds: 60}
+	limiter, clock := newTestLimiter()
+
+	for want := 3; want >= 0; want-- {
+		result := allow(t, limiter, "alice", policy)
+		if !result.Allowed || result.Remaining != want {
+			t.Fatalf("allowed %v, remaining %d, want allowed with %d left", result.Allowed, result.Remaining, want)
+		}
+	}
+
+	refused := allow(t, limiter, "alice", policy)
+	if refused.Allowed {
+		t.Fatal("fifth request in the window was allowed")
+	}
+	// The current window is full, so the next request fits once a quarter of
+	// it has slid out of the next window
+	if want := 75 * time.Second; refused.RetryAfter != want {
+		t.Errorf("retry after %s, want %s", refused.RetryAfter, want)
+	}
+	if want := 2 * time.Minute; refused.ResetAfter != want {
+		t.Errorf("reset after %s, want %s", refused.ResetAfter, want)
+	}
+
+	// Keys don't share a bucket
+	if !allow(t, limiter, "bob", policy).Allowed {
+		t.Error("another key was refused")
+	}
+
+	// Half a window in, half of the previous window still counts
+	clock.Advance(90 * time.Second)
+	for i := 0; i < 2; i++ {
+		if !allow(t, limiter, "alice", policy).Allowed {
+			t.Fatalf("request %d half a window later was refused", i+1)
+		}
+	}
+	if allow(t, limiter, "alice", policy).Allowed {
+		t.Error("sliding window let through more than the limit")
+	}
+
+	// Two idle windows clear the key
+	clock.Advance(2 * time.Minute)
+	if result := allow(t, limiter, "alice", policy); !result.Allowed || result.Remaining != 3 {
+		t.Errorf("allowed %v, remaining %d after going idle, want a fresh bucket", result.Allowed, result.Remaining)
+	}
+}
+
+func TestRetryAfterIsExact(t *testing.T) {
+	policy := Policy{Limit: 10, WindowSeconds: 60}
+
+	for _, tt := range []struct {
+		name              string
+		previous, current int64
+		elapsed           time.Duration
+	}{
+		{"previous window sliding out", 8, 4, 15 * time.Second},
+		{"current window full", 0, 10, 30 * time.Second},
+		{"both busy", 10, 10, 45 * time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(policy, tt.previous, tt.current, tt.elapsed)
+			if result.Allowed {
+				t.Fatal("request was allowed")
+			}
+
+			// Waiting RetryAfter gets a request in, a moment less doesn't
+			wait := func(d time.Duration) bool {
+				previous, current, elapsed := tt.previous, tt.current, tt.elapsed+d
+				if elapsed >= policy.Window() {
+					previous, current, elapsed = current, 0, elapsed-policy.Window()
+				}
+				return Evaluate(policy, previous, current, elapsed).Allowed
+			}
+			if !wait(result.RetryAfter) {
+				t.Errorf("still refused after waiting %s", result.RetryAfter)
+			}
+			if wait(result.RetryAfter - time.Millisecond) {
+				t.Errorf("allowed before %s", result.RetryAfter)
+			}
+		})
+	}
+}
+
+type failingLimiter struct {
+	err error
+}
+
+func (l *failingLimiter) Allow(ctx context.Context, key string, policy Policy) (*Result, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	return &Result{Allowed: true, Limit: policy.Limit, Remaining: policy.Limit}, nil
+}
+
+func TestFallbackLimiter(t *testing.T) {
+	policy := Policy{Limit: 1, WindowSeconds: 60}
+	primary := &failingLimiter{err: errors.New("connection refused")}
+	fallback, _ := newTestLimiter()
+	limiter := NewFallbackLimiter(primary, fallback)
+
+	// While the store is down the in-memory limits apply
+	if !allow(t, limiter, "alice", policy).Allowed {
+		t.Fatal("first request refused by the fallback")
+	}
+	if allow(t, limiter, "alice", policy).Allowed {
+		t.Fatal("fallback didn't enforce the limit")
+	}
+
+	// Once it is back the store decides again
+	primary.err = nil
+	if result := allow(t, limiter, "alice", policy); !result.Allowed || result.Remaining != 1 {
+		t.Errorf("allowed %v, remaining %d, want the primary's answer", result.Allowed, result.Remaining)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Policy allows Limit requests per key in any WindowSeconds-long window.
+type Policy struct {
+	Limit         int `json:"limit"`
+	WindowSeconds int `json:"window_seconds"`
+}
+
+func (p Policy) Window() time.Duration {
+	return time.Duration(p.WindowSeconds) * time.Second
+}
+
+// Policies are the limits for each route group. Global and Auth count
+// requests per client IP; User, Payments and Lookup count them per
+// authenticated user, so clients sharing an address don't share a bucket.
+// Lookup is kept tight because account lookups confirm holder names.
+type Policies struct {
+	Global   Policy `json:"global"`
+	Auth     Policy `json:"auth"`
+	User     Policy `json:"user"`
+	Payments Policy `json:"payments"`
+	Lookup   Policy `json:"lookup"`
+}
+
+func DefaultPolicies() *Policies {
+	return &Policies{
+		Global:   Policy{Limit: 1000, WindowSeconds: 60},
+		Auth:     Policy{Limit: 5, WindowSeconds: 60},
+		User:     Policy{Limit: 100, WindowSeconds: 60},
+		Payments: Policy{Limit: 20, WindowSeconds: 60},
+		Lookup:   Policy{Limit: 30, WindowSeconds: 3600},
+	}
+}
+
+// LoadPolicies reads policies from a YAML or JSON file on top of
+// DefaultPolicies, so a file only needs the groups it changes.
+func LoadPolicies(path string) (*Policies, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limits: %w", err)
+	}
+
+	policies := DefaultPolicies()
+	if err := yaml.Unmarshal(data, policies); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limits: %w", err)
+	}
+
+	if err := policies.validate(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (p *Policies) validate() error {
+	groups := map[string]Policy{
+		"global":   p.Global,
+		"auth":     p.Auth,
+		"user":     p.User,
+		"payments": p.Payments,
+		"lookup":   p.Lookup,
+	}
+	for name, policy := range groups {
+		if policy.Limit <= 0 || policy.WindowSeconds <= 0 {
+			return fmt.Errorf("rate limit %s: limit and window_seconds must be positive", name)
+		}
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+// AccountMemberRepository keeps who can use an account and how. Accounts get
+// their owner when AccountRepository.Create makes them.
+type AccountMemberRepository interface {
+	Create(ctx context.Context, member *domain.AccountMember) error
+	// Get returns the user's membership of the account, or nil if they
+	// have none.
+	Get(ctx context.Context, accountID, userID uuid.UUID) (*domain.AccountMember, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountMember, error)
+	// GetInvitations lists the user's memberships waiting to be accepted.
+	GetInvitations(ctx context.Context, userID uuid.UUID) ([]*domain.AccountMember, error)
+	Update(ctx context.Context, member *domain.AccountMember) error
+	Delete(ctx context.Context, accountID, userID uuid.UUID) error
+
+	// GetApprovalPolicy returns the account's policy, or nil if transfers
+	// out of it need no approval.
+	GetApprovalPolicy(ctx context.Context, accountID uuid.UUID) (*domain.ApprovalPolicy, error)
+	SetApprovalPolicy(ctx context.Context, policy *domain.ApprovalPolicy) error
+	DeleteApprovalPolicy(ctx context.Context, accountID uuid.UUID) error
+}
\ No newline at end of file
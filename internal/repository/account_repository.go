@@ -2,16 +2,51 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 )
 
+// ErrAccountNumberTaken is returned by Create when another account already
+// has the account number.
+var ErrAccountNumberTaken = apperror.Conflict("account_number_taken", "could not issue a free account number, please retry")
+
 type AccountRepository interface {
+	// Create fails with ErrAccountNumberTaken, without aborting the unit of
+	// work, if the account number is taken.
 	Create(ctx context.Context, account *domain.Account) error
+	// NextAccountNumber draws the next value from the account number
+	// sequence. Values are never handed out twice, even if the unit of work
+	// rolls back.
+	NextAccountNumber(ctx context.Context) (int64, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error)
+	// GetByIDForUpdate locks the account until the unit of work ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error)
 	GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.Account, error)
+	// GetByUserID lists the accounts the user is an active member of.
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Account, error)
+	// GetByOrganizationID lists the organization's business accounts.
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Account, error)
+	// GetByType pages through accounts of a type in ID order, starting after
+	// the given ID.
+	GetByType(ctx context.Context, accountType domain.AccountType, after uuid.UUID, limit int) ([]*domain.Account, error)
+	// GetDormant pages through active accounts in ID order, starting after
+	// the given ID, that have been open and active since before since with
+	// no customer activity since: no transfer, withdrawal, payment or
+	// conversion out of them and no deposit or transfer into them. Interest
+	// and fees don't count.
+	GetDormant(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.Account, error)
+	// Update writes the account type. Statuses change through UpdateStatus.
 	Update(ctx context.Context, account *domain.Account) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// UpdateStatus writes the status and when it changed. Call it on an
+	// account locked with GetByIDForUpdate.
+	UpdateStatus(ctx context.Context, account *domain.Account) error
+	// UpdateBalances writes the ledger, held and pot balances. Call it on an
+	// account locked with GetByIDForUpdate.
+	UpdateBalances(ctx context.Context, account *domain.Account) error
+	// UpdateOverdraftLimit writes the overdraft limit. Call it on an account
+	// locked with GetByIDForUpdate.
+	UpdateOverdraftLimit(ctx context.Context, account *domain.Account) error
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package cached
 import (
 	"context"
 	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -37,6 +38,10 @@ func (r *cachedAccountRepository) Create(ctx context.Context, account *domain.Ac
 	return nil
 }
 
+func (r *cachedAccountRepository) NextAccountNumber(ctx context.Context) (int64, error) {
+	return r.repo.NextAccountNumber(ctx)
+}
+
 func (r *cachedAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
 	// Try cache first
 	account, err := r.cache.GetAccount(ctx, id)
@@ -64,11 +69,29 @@ func (r *cachedAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*d
 	return account, nil
 }
 
+// GetByIDForUpdate always goes to the database: a locked read must see the
+// committed row.
+func (r *cachedAccountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	return r.repo.GetByIDForUpdate(ctx, id)
+}
+
 func (r *cachedAccountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Account, error) {
 	// For user's accounts list, we don't cache (could change frequently)
 	return r.repo.GetByUserID(ctx, userID)
 }
 
+func (r *cachedAccountRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Account, error) {
+	return r.repo.GetByOrganizationID(ctx, organizationID)
+}
+
+func (r *cachedAccountRepository) GetByType(ctx context.Context, accountType domain.AccountType, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	return r.repo.GetByType(ctx, accountType, after, limit)
+}
+
+func (r *cachedAccountRepository) GetDormant(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	return r.repo.GetDormant(ctx, since, after, limit)
+}
+
 func (r *cachedAccountRepository) GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.Account, error) {
 	// Account number lookups bypass cache for now
 	account, err := r.repo.GetByAccountNumber(ctx, accountNumber)
@@ -100,17 +123,44 @@ func (r *cachedAccountRepository) Update(ctx context.Context, account *domain.Ac
 	return nil
 }
 
-func (r *cachedAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	err := r.repo.Delete(ctx, id)
+func (r *cachedAccountRepository) UpdateStatus(ctx context.Context, account *domain.Account) error {
+	err := r.repo.UpdateStatus(ctx, account)
 	if err != nil {
 		return err
 	}
 
 	// Invalidate cache
-	if err := r.cache.DeleteAccount(ctx, id); err != nil {
+	if err := r.cache.DeleteAccount(ctx, account.ID); err != nil {
 		log.Printf("Failed to invalidate account cache: %v", err)
 	}
 
 	return nil
 }
 
+func (r *cachedAccountRepository) UpdateBalances(ctx context.Context, account *domain.Account) error {
+	err := r.repo.UpdateBalances(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.cache.DeleteAccount(ctx, account.ID); err != nil {
+		log.Printf("Failed to invalidate account cache: %v", err)
+	}
+
+	return nil
+}
+
+func (r *cachedAccountRepository) UpdateOverdraftLimit(ctx context.Context, account *domain.Account) error {
+	err := r.repo.UpdateOverdraftLimit(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.cache.DeleteAccount(ctx, account.ID); err != nil {
+		log.Printf("Failed to invalidate account cache: %v", err)
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,150 @@
+package cached
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/cache"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/internal/repository/memory"
+	"github.com/shopspring/decimal"
+)
+
+func createAccount(t *testing.T, repo repository.AccountRepository, balance int64) *domain.Account {
+	t.Helper()
+
+	account := &domain.Account{
+		UserID:        uuid.New(),
+		AccountNumber: uuid.NewString()[:10],
+		Balance:       decimal.NewFromInt(balance),
+		Status:        domain.AccountStatusActive,
+	}
+	if err := repo.Create(context.Background(), account); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return account
+}
+
+func TestAccountReadsAreFreshAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	cacheService := cache.NewCacheService(cache.NewMemoryStore())
+	store.OnAccountsChanged(cacheService.InvalidateAccounts)
+	repo := NewCachedAccountRepository(store.Accounts(), cacheService)
+
+	from := createAccount(t, store.Accounts(), 100)
+	to := createAccount(t, store.Accounts(), 5)
+
+	// Warm the cache
+	for _, id := range []uuid.UUID{from.ID, to.ID} {
+		if _, err := repo.GetByID(ctx, id); err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+	}
+
+	// Move money behind the cache's back, the way a transfer does
+	err := store.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		from.Balance = decimal.NewFromInt(60)
+		to.Balance = decimal.NewFromInt(45)
+		if err := repos.Accounts.UpdateBalances(ctx, from); err != nil {
+			return err
+		}
+		return repos.Accounts.UpdateBalances(ctx, to)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	for _, want := range []*domain.Account{from, to} {
+		got, err := repo.GetByID(ctx, want.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if !got.Balance.Equal(want.Balance) {
+			t.Errorf("account %s: balance %s, want %s", want.ID, got.Balance, want.Balance)
+		}
+	}
+}
+
+func TestCachedBalanceIsStaleWithoutHook(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := NewCachedAccountRepository(store.Accounts(), cache.NewCacheService(cache.NewMemoryStore()))
+
+	account := createAccount(t, store.Accounts(), 100)
+	if _, err := repo.GetByID(ctx, account.ID); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	err := store.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account.Balance = decimal.NewFromInt(1)
+		return repos.Accounts.UpdateBalances(ctx, account)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	// Guards the test above: without the hook the cache really does go stale
+	got, err := repo.GetByID(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.Balance.Equal(decimal.NewFromInt(100)) {
+		t.Fatalf("balance %s, expected the stale cached 100", got.Balance)
+	}
+}
+
+func TestUpdateStatusInvalidatesCachedAccount(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	repo := NewCachedAccountRepository(store.Accounts(), cache.NewCacheService(cache.NewMemoryStore()))
+
+	account := createAccount(t, store.Accounts(), 0)
+	if _, err := repo.GetByID(ctx, account.ID); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	account.Status = domain.AccountStatusFrozen
+	if err := repo.UpdateStatus(ctx, account); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != domain.AccountStatusFrozen {
+		t.Errorf("status %s, want %s", got.Status, domain.AccountStatusFrozen)
+	}
+}
+func TestStatusChangeInUnitOfWorkInvalidatesCachedAccount(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	cacheService := cache.NewCacheService(cache.NewMemoryStore())
+	store.OnAccountsChanged(cacheService.InvalidateAccounts)
+	repo := NewCachedAccountRepository(store.Accounts(), cacheService)
+
+	account := createAccount(t, store.Accounts(), 0)
+	if _, err := repo.GetByID(ctx, account.ID); err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	// Close the account behind the cache's back, the way the lifecycle does
+	err := store.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account.Status = domain.AccountStatusClosed
+		return repos.Accounts.UpdateStatus(ctx, account)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Status != domain.AccountStatusClosed {
+		t.Errorf("status %s, want %s", got.Status, domain.AccountStatusClosed)
+	}
+}
\ No newline at end of file
@@ -106,5 +106,19 @@ func (r *cachedUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		log.Printf("Failed to invalidate user cache: %v", err)
 	}
 
+	return nil
+}
+
+func (r *cachedUserRepository) Anonymize(ctx context.Context, id uuid.UUID) error {
+	err := r.repo.Anonymize(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Invalidate cache
+	if err := r.cache.DeleteUser(ctx, id); err != nil {
+		log.Printf("Failed to invalidate user cache: %v", err)
+	}
+
 	return nil
 }
\ No newline at end of file
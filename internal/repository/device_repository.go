@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DeviceRepository remembers which devices a user has successfully moved
+// money from before.
+type DeviceRepository interface {
+	Exists(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error)
+	Touch(ctx context.Context, userID uuid.UUID, deviceID string) error
+}
\ No newline at end of file
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type FeeRepository interface {
+	GetSchedules(ctx context.Context) ([]*domain.FeeSchedule, error)
+	GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.FeeSchedule, error)
+	// UpsertSchedule creates the schedule for its fee type, account type and
+	// tier, or replaces the one already there.
+	UpsertSchedule(ctx context.Context, schedule *domain.FeeSchedule) error
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+
+	CreateWaiver(ctx context.Context, waiver *domain.FeeWaiver) error
+	GetWaiverByID(ctx context.Context, id uuid.UUID) (*domain.FeeWaiver, error)
+	GetWaiversByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.FeeWaiver, error)
+	DeleteWaiver(ctx context.Context, id uuid.UUID) error
+
+	// GetCharge returns the account's charge for the fee type and period, or
+	// nil if it hasn't been charged.
+	GetCharge(ctx context.Context, accountID uuid.UUID, feeType domain.FeeType, period time.Time) (*domain.FeeCharge, error)
+	CreateCharge(ctx context.Context, charge *domain.FeeCharge) error
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type HoldRepository interface {
+	Create(ctx context.Context, hold *domain.Hold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Hold, error)
+	GetByTransactionIDForUpdate(ctx context.Context, transactionID uuid.UUID) (*domain.Hold, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Hold, error)
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Hold, error)
+	Update(ctx context.Context, hold *domain.Hold) error
+}
\ No newline at end of file
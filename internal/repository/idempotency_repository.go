@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+// IdempotencyRepository stores requests by idempotency key so a retried
+// request is answered from the record instead of running twice. Keys are
+// scoped to the user that sent them.
+type IdempotencyRepository interface {
+	GetByKey(ctx context.Context, key string, userID uuid.UUID) (*domain.IdempotencyRecord, error)
+	// Create reserves the record's key and reports false if the user already
+	// has a record under it.
+	Create(ctx context.Context, record *domain.IdempotencyRecord) (bool, error)
+	// Complete stores the response and marks the record completed.
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) error
+	Delete(ctx context.Context, key string, userID uuid.UUID) error
+	// DeleteExpired removes records that expired before the given time and
+	// returns how many it removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
\ No newline at end of file
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type InterestRepository interface {
+	Create(ctx context.Context, accrual *domain.InterestAccrual) error
+	// GetLatest returns the account's most recent accrual, or nil if it has
+	// never accrued.
+	GetLatest(ctx context.Context, accountID uuid.UUID) (*domain.InterestAccrual, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.InterestAccrual, error)
+	// GetUncapitalized lists accruals not yet paid out dated before before,
+	// oldest first.
+	GetUncapitalized(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*domain.InterestAccrual, error)
+	// MarkCapitalized records that the accruals were paid by transactionID,
+	// which is nil when they rounded down to nothing.
+	MarkCapitalized(ctx context.Context, ids []uuid.UUID, transactionID *uuid.UUID, at time.Time) error
+}
\ No newline at end of file
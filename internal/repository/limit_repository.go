@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type LimitRepository interface {
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountLimit, error)
+	Upsert(ctx context.Context, limit *domain.AccountLimit) error
+	Delete(ctx context.Context, accountID uuid.UUID, source domain.LimitSource) error
+}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type memberKey struct {
+	accountID uuid.UUID
+	userID    uuid.UUID
+}
+
+type accountMemberRepository struct {
+	scope *scope
+}
+
+func (r *accountMemberRepository) Create(ctx context.Context, member *domain.AccountMember) error {
+	member.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		key := memberKey{member.AccountID, member.UserID}
+		if _, ok := t.accountMembers.get(key); ok {
+			return ErrUniqueViolation
+		}
+		if member.Role == domain.AccountRoleOwner && t.accountMembers.exists(key, func(row domain.AccountMember) bool {
+			return row.AccountID == member.AccountID && row.Role == domain.AccountRoleOwner
+		}) {
+			return ErrUniqueViolation
+		}
+		t.accountMembers.put(key, *member)
+		return nil
+	})
+}
+
+func (r *accountMemberRepository) Get(ctx context.Context, accountID, userID uuid.UUID) (*domain.AccountMember, error) {
+	var member *domain.AccountMember
+	r.scope.read(func(t *tables) {
+		if row, ok := t.accountMembers.get(memberKey{accountID, userID}); ok {
+			member = &row
+		}
+	})
+	return member, nil
+}
+
+func (r *accountMemberRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountMember, error) {
+	members := r.find(func(row domain.AccountMember) bool {
+		return row.AccountID == accountID
+	})
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members, nil
+}
+
+func (r *accountMemberRepository) GetInvitations(ctx context.Context, userID uuid.UUID) ([]*domain.AccountMember, error) {
+	members := r.find(func(row domain.AccountMember) bool {
+		return row.UserID == userID && row.Status == domain.MemberStatusInvited
+	})
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.After(members[j].CreatedAt)
+	})
+	return members, nil
+}
+
+func (r *accountMemberRepository) find(match func(row domain.AccountMember) bool) []*domain.AccountMember {
+	var members []*domain.AccountMember
+	r.scope.read(func(t *tables) {
+		for _, row := range t.accountMembers.rows {
+			if match(row) {
+				member := row
+				members = append(members, &member)
+			}
+		}
+	})
+	return members
+}
+
+func (r *accountMemberRepository) Update(ctx context.Context, member *domain.AccountMember) error {
+	return r.scope.write(func(t *tables) error {
+		key := memberKey{member.AccountID, member.UserID}
+		row, ok := t.accountMembers.get(key)
+		if !ok {
+			return nil
+		}
+		row.Role = member.Role
+		row.Status = member.Status
+		row.AcceptedAt = member.AcceptedAt
+		t.accountMembers.put(key, row)
+		return nil
+	})
+}
+
+func (r *accountMemberRepository) Delete(ctx context.Context, accountID, userID uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.accountMembers.delete(memberKey{accountID, userID})
+		return nil
+	})
+}
+
+func (r *accountMemberRepository) GetApprovalPolicy(ctx context.Context, accountID uuid.UUID) (*domain.ApprovalPolicy, error) {
+	var policy *domain.ApprovalPolicy
+	r.scope.read(func(t *tables) {
+		if row, ok := t.approvalPolicies.get(accountID); ok {
+			policy = &row
+		}
+	})
+	return policy, nil
+}
+
+func (r *accountMemberRepository) SetApprovalPolicy(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	if policy.Threshold.IsNegative() || policy.RequiredApprovals <= 1 {
+		return ErrCheckViolation
+	}
+	policy.UpdatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.approvalPolicies.put(policy.AccountID, *policy)
+		return nil
+	})
+}
+
+func (r *accountMemberRepository) DeleteApprovalPolicy(ctx context.Context, accountID uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.approvalPolicies.delete(accountID)
+		return nil
+	})
+}
\ No newline at end of file
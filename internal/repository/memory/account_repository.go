@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+type accountRepository struct {
+	scope *scope
+}
+
+func (r *accountRepository) Create(ctx context.Context, account *domain.Account) error {
+	if account.ID == uuid.Nil {
+		account.ID = uuid.New()
+	}
+	now := time.Now()
+	account.CreatedAt = now
+	account.UpdatedAt = now
+
+	return r.scope.write(func(t *tables) error {
+		if t.accounts.exists(account.ID, func(row domain.Account) bool { return row.AccountNumber == account.AccountNumber }) {
+			return repository.ErrAccountNumberTaken
+		}
+		// Accounts always start with nothing held and no overdraft
+		row := *account
+		row.HeldBalance = decimal.Zero
+		row.OverdraftLimit = decimal.Zero
+		if err := checkBalances(&row); err != nil {
+			return err
+		}
+		t.accounts.put(row.ID, row)
+		// The holder becomes the account's owner
+		t.accountMembers.put(memberKey{row.ID, row.UserID}, domain.AccountMember{
+			AccountID:  row.ID,
+			UserID:     row.UserID,
+			Role:       domain.AccountRoleOwner,
+			Status:     domain.MemberStatusActive,
+			CreatedAt:  now,
+			AcceptedAt: &now,
+		})
+		return nil
+	})
+}
+
+func (r *accountRepository) NextAccountNumber(ctx context.Context) (int64, error) {
+	return r.scope.data.accountNumberSeq.Add(1), nil
+}
+
+func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	var account *domain.Account
+	r.scope.read(func(t *tables) {
+		if row, ok := t.accounts.get(id); ok {
+			account = &row
+		}
+	})
+	return account, nil
+}
+
+// GetByIDForUpdate needs no lock of its own: units of work already run one
+// at a time.
+func (r *accountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *accountRepository) GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.Account, error) {
+	var account *domain.Account
+	r.scope.read(func(t *tables) {
+		for _, row := range t.accounts.rows {
+			if row.AccountNumber == accountNumber {
+				account = &row
+				return
+			}
+		}
+	})
+	return account, nil
+}
+
+func (r *accountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	r.scope.read(func(t *tables) {
+		for key, member := range t.accountMembers.rows {
+			if key.userID != userID || member.Status != domain.MemberStatusActive {
+				continue
+			}
+			if row, ok := t.accounts.get(key.accountID); ok {
+				accounts = append(accounts, &row)
+			}
+		}
+	})
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.After(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+func (r *accountRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	r.scope.read(func(t *tables) {
+		for _, row := range t.accounts.rows {
+			if row.OrganizationID != nil && *row.OrganizationID == organizationID {
+				account := row
+				accounts = append(accounts, &account)
+			}
+		}
+	})
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].CreatedAt.After(accounts[j].CreatedAt)
+	})
+	return accounts, nil
+}
+
+func (r *accountRepository) GetByType(ctx context.Context, accountType domain.AccountType, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	r.scope.read(func(t *tables) {
+		for _, row := range t.accounts.rows {
+			if row.AccountType == accountType && bytes.Compare(row.ID[:], after[:]) > 0 {
+				account := row
+				accounts = append(accounts, &account)
+			}
+		}
+	})
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].ID[:], accounts[j].ID[:]) < 0
+	})
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+	return accounts, nil
+}
+
+func (r *accountRepository) GetDormant(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	r.scope.read(func(t *tables) {
+		active := make(map[uuid.UUID]bool)
+		for _, tx := range t.transactions.rows {
+			if tx.CreatedAt.Before(since) {
+				continue
+			}
+			switch tx.Type {
+			case domain.TransactionTypeTransfer, domain.TransactionTypeWithdrawal, domain.TransactionTypePayment, domain.TransactionTypeConversion:
+				if tx.FromAccountID != nil {
+					active[*tx.FromAccountID] = true
+				}
+			}
+			switch tx.Type {
+			case domain.TransactionTypeDeposit, domain.TransactionTypeTransfer:
+				if tx.ToAccountID != nil {
+					active[*tx.ToAccountID] = true
+				}
+			}
+		}
+
+		for _, row := range t.accounts.rows {
+			if row.Status != domain.AccountStatusActive || !row.CreatedAt.Before(since) || active[row.ID] {
+				continue
+			}
+			if row.StatusChangedAt != nil && !row.StatusChangedAt.Before(since) {
+				continue
+			}
+			if bytes.Compare(row.ID[:], after[:]) > 0 {
+				account := row
+				accounts = append(accounts, &account)
+			}
+		}
+	})
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].ID[:], accounts[j].ID[:]) < 0
+	})
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+	return accounts, nil
+}
+
+// Update mirrors Postgres: balances and the status are left alone.
+func (r *accountRepository) Update(ctx context.Context, account *domain.Account) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.accounts.get(account.ID)
+		if !ok {
+			return nil
+		}
+		row.AccountType = account.AccountType
+		if err := checkBalances(&row); err != nil {
+			return err
+		}
+		row.UpdatedAt = time.Now()
+		t.accounts.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *accountRepository) UpdateStatus(ctx context.Context, account *domain.Account) error {
+	return r.update(account.ID, func(row *domain.Account) {
+		row.Status = account.Status
+		row.StatusChangedAt = account.StatusChangedAt
+	})
+}
+
+func (r *accountRepository) UpdateBalances(ctx context.Context, account *domain.Account) error {
+	return r.update(account.ID, func(row *domain.Account) {
+		row.Balance = account.Balance
+		row.HeldBalance = account.HeldBalance
+		row.PotBalance = account.PotBalance
+	})
+}
+
+func (r *accountRepository) UpdateOverdraftLimit(ctx context.Context, account *domain.Account) error {
+	return r.update(account.ID, func(row *domain.Account) {
+		row.OverdraftLimit = account.OverdraftLimit
+	})
+}
+
+// update applies a change to the row's balances or status, checked against
+// the rest of the stored row as Postgres checks it.
+func (r *accountRepository) update(id uuid.UUID, change func(row *domain.Account)) error {
+	updated := false
+	err := r.scope.write(func(t *tables) error {
+		row, ok := t.accounts.get(id)
+		if !ok {
+			return nil
+		}
+		change(&row)
+		if err := checkBalances(&row); err != nil {
+			return err
+		}
+		row.UpdatedAt = time.Now()
+		t.accounts.put(row.ID, row)
+		updated = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated && r.scope.onBalanceChange != nil {
+		r.scope.onBalanceChange(id)
+	}
+	return nil
+}
+
+// checkBalances enforces the accounts table's CHECK constraints.
+func checkBalances(account *domain.Account) error {
+	if account.HeldBalance.IsNegative() || account.PotBalance.IsNegative() || account.OverdraftLimit.IsNegative() || account.SpendableBalance().IsNegative() {
+		return ErrCheckViolation
+	}
+	if account.OverdraftLimit.IsPositive() && account.AccountType != domain.AccountTypeChecking {
+		return ErrCheckViolation
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,20 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/nabiilNajm26/go-bank/internal/repository/repositorytest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	store := NewStore()
+	repositorytest.Run(t, &repositorytest.Repositories{
+		Users:          store.Users(),
+		Accounts:       store.Accounts(),
+		Transactions:   store.Transactions(),
+		UnitOfWork:     store,
+		Idempotency:    store.Idempotency(),
+		AccountMembers: store.AccountMembers(),
+		Organizations:  store.Organizations(),
+	})
+}
\ No newline at end of file
@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type deviceRepository struct {
+	scope *scope
+}
+
+func (r *deviceRepository) Exists(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error) {
+	var exists bool
+	r.scope.read(func(t *tables) {
+		_, exists = t.devices.get(deviceKey{userID: userID, deviceID: deviceID})
+	})
+	return exists, nil
+}
+
+func (r *deviceRepository) Touch(ctx context.Context, userID uuid.UUID, deviceID string) error {
+	return r.scope.write(func(t *tables) error {
+		t.devices.put(deviceKey{userID: userID, deviceID: deviceID}, time.Now())
+		return nil
+	})
+}
\ No newline at end of file
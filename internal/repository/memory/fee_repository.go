@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type feeRepository struct {
+	scope *scope
+}
+
+func (r *feeRepository) GetSchedules(ctx context.Context) ([]*domain.FeeSchedule, error) {
+	var schedules []*domain.FeeSchedule
+	r.scope.read(func(t *tables) {
+		for _, row := range t.feeSchedules.rows {
+			schedule := row
+			schedules = append(schedules, &schedule)
+		}
+	})
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+	})
+	return schedules, nil
+}
+
+func (r *feeRepository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.FeeSchedule, error) {
+	var schedule *domain.FeeSchedule
+	r.scope.read(func(t *tables) {
+		if row, ok := t.feeSchedules.get(id); ok {
+			schedule = &row
+		}
+	})
+	return schedule, nil
+}
+
+func (r *feeRepository) UpsertSchedule(ctx context.Context, schedule *domain.FeeSchedule) error {
+	if schedule.FixedAmount.IsNegative() || schedule.Rate.IsNegative() || schedule.MinAmount.IsNegative() || schedule.Threshold.IsNegative() ||
+		(schedule.MaxAmount.Valid && schedule.MaxAmount.Decimal.LessThan(schedule.MinAmount)) {
+		return ErrCheckViolation
+	}
+
+	return r.scope.write(func(t *tables) error {
+		now := time.Now()
+		schedule.ID = uuid.New()
+		schedule.CreatedAt = now
+		for _, row := range t.feeSchedules.rows {
+			if row.FeeType == schedule.FeeType && sameValue(row.AccountType, schedule.AccountType) && sameValue(row.UserTier, schedule.UserTier) {
+				schedule.ID = row.ID
+				schedule.CreatedAt = row.CreatedAt
+				break
+			}
+		}
+		schedule.UpdatedAt = now
+		t.feeSchedules.put(schedule.ID, *schedule)
+		return nil
+	})
+}
+
+func (r *feeRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.feeSchedules.delete(id)
+		return nil
+	})
+}
+
+func (r *feeRepository) CreateWaiver(ctx context.Context, waiver *domain.FeeWaiver) error {
+	if waiver.ID == uuid.Nil {
+		waiver.ID = uuid.New()
+	}
+	waiver.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.feeWaivers.put(waiver.ID, *waiver)
+		return nil
+	})
+}
+
+func (r *feeRepository) GetWaiverByID(ctx context.Context, id uuid.UUID) (*domain.FeeWaiver, error) {
+	var waiver *domain.FeeWaiver
+	r.scope.read(func(t *tables) {
+		if row, ok := t.feeWaivers.get(id); ok {
+			waiver = &row
+		}
+	})
+	return waiver, nil
+}
+
+func (r *feeRepository) GetWaiversByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.FeeWaiver, error) {
+	var waivers []*domain.FeeWaiver
+	r.scope.read(func(t *tables) {
+		for _, row := range t.feeWaivers.rows {
+			if row.AccountID == accountID {
+				waiver := row
+				waivers = append(waivers, &waiver)
+			}
+		}
+	})
+
+	sort.Slice(waivers, func(i, j int) bool {
+		return waivers[i].CreatedAt.After(waivers[j].CreatedAt)
+	})
+	return waivers, nil
+}
+
+func (r *feeRepository) DeleteWaiver(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.feeWaivers.delete(id)
+		return nil
+	})
+}
+
+func (r *feeRepository) GetCharge(ctx context.Context, accountID uuid.UUID, feeType domain.FeeType, period time.Time) (*domain.FeeCharge, error) {
+	var charge *domain.FeeCharge
+	r.scope.read(func(t *tables) {
+		for _, row := range t.feeCharges.rows {
+			if row.AccountID == accountID && row.FeeType == feeType && row.Period.Equal(period) {
+				charge = &row
+				return
+			}
+		}
+	})
+	return charge, nil
+}
+
+func (r *feeRepository) CreateCharge(ctx context.Context, charge *domain.FeeCharge) error {
+	if charge.ID == uuid.Nil {
+		charge.ID = uuid.New()
+	}
+	if charge.Amount.IsNegative() {
+		return ErrCheckViolation
+	}
+	charge.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if t.feeCharges.exists(charge.ID, func(row domain.FeeCharge) bool {
+			return row.AccountID == charge.AccountID && row.FeeType == charge.FeeType && row.Period.Equal(charge.Period)
+		}) {
+			return ErrUniqueViolation
+		}
+		t.feeCharges.put(charge.ID, *charge)
+		return nil
+	})
+}
+
+// sameValue compares optional columns the way NULLS NOT DISTINCT does.
+func sameValue[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
\ No newline at end of file
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type holdRepository struct {
+	scope *scope
+}
+
+func (r *holdRepository) Create(ctx context.Context, hold *domain.Hold) error {
+	if hold.ID == uuid.Nil {
+		hold.ID = uuid.New()
+	}
+	if !hold.Amount.IsPositive() {
+		return ErrCheckViolation
+	}
+	hold.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if t.holds.exists(hold.ID, func(row domain.Hold) bool { return row.TransactionID == hold.TransactionID }) {
+			return ErrUniqueViolation
+		}
+		t.holds.put(hold.ID, *hold)
+		return nil
+	})
+}
+
+func (r *holdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	var hold *domain.Hold
+	r.scope.read(func(t *tables) {
+		if row, ok := t.holds.get(id); ok {
+			hold = &row
+		}
+	})
+	return hold, nil
+}
+
+func (r *holdRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *holdRepository) GetByTransactionIDForUpdate(ctx context.Context, transactionID uuid.UUID) (*domain.Hold, error) {
+	var hold *domain.Hold
+	r.scope.read(func(t *tables) {
+		for _, row := range t.holds.rows {
+			if row.TransactionID == transactionID {
+				hold = &row
+				return
+			}
+		}
+	})
+	return hold, nil
+}
+
+func (r *holdRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	r.scope.read(func(t *tables) {
+		for _, row := range t.holds.rows {
+			if row.AccountID == accountID {
+				hold := row
+				holds = append(holds, &hold)
+			}
+		}
+	})
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].CreatedAt.After(holds[j].CreatedAt)
+	})
+	return holds, nil
+}
+
+func (r *holdRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	r.scope.read(func(t *tables) {
+		for _, row := range t.holds.rows {
+			if row.Status == domain.HoldStatusActive && row.ExpiresAt != nil && !row.ExpiresAt.After(now) {
+				hold := row
+				holds = append(holds, &hold)
+			}
+		}
+	})
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].ExpiresAt.Before(*holds[j].ExpiresAt)
+	})
+	if len(holds) > limit {
+		holds = holds[:limit]
+	}
+	return holds, nil
+}
+
+func (r *holdRepository) Update(ctx context.Context, hold *domain.Hold) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.holds.get(hold.ID)
+		if !ok {
+			return nil
+		}
+		row.Status = hold.Status
+		row.ReleasedAt = hold.ReleasedAt
+		t.holds.put(row.ID, row)
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type idempotencyRepository struct {
+	scope *scope
+}
+
+func (r *idempotencyRepository) GetByKey(ctx context.Context, key string, userID uuid.UUID) (*domain.IdempotencyRecord, error) {
+	var record *domain.IdempotencyRecord
+	r.scope.read(func(t *tables) {
+		if row, ok := findIdempotencyRecord(t, key, userID); ok {
+			record = &row
+		}
+	})
+	return record, nil
+}
+
+func (r *idempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	var created bool
+	err := r.scope.write(func(t *tables) error {
+		if _, ok := findIdempotencyRecord(t, record.IdempotencyKey, record.UserID); ok {
+			return nil
+		}
+
+		record.ID = uuid.New()
+		record.CreatedAt = time.Now()
+		t.idempotency.put(record.ID, *record)
+		created = true
+		return nil
+	})
+	return created, err
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return r.scope.write(func(t *tables) error {
+		if row, ok := findIdempotencyRecord(t, record.IdempotencyKey, record.UserID); ok {
+			row.Status = domain.IdempotencyStatusCompleted
+			row.ResponseStatus = record.ResponseStatus
+			row.ResponseHeaders = record.ResponseHeaders
+			row.ResponseBody = record.ResponseBody
+			t.idempotency.put(row.ID, row)
+		}
+		return nil
+	})
+}
+
+func (r *idempotencyRepository) Delete(ctx context.Context, key string, userID uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		if row, ok := findIdempotencyRecord(t, key, userID); ok {
+			t.idempotency.delete(row.ID)
+		}
+		return nil
+	})
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.scope.write(func(t *tables) error {
+		for id, row := range t.idempotency.rows {
+			if row.ExpiresAt.Before(before) {
+				t.idempotency.delete(id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func findIdempotencyRecord(t *tables, key string, userID uuid.UUID) (domain.IdempotencyRecord, bool) {
+	for _, row := range t.idempotency.rows {
+		if row.IdempotencyKey == key && row.UserID == userID {
+			return row, true
+		}
+	}
+	return domain.IdempotencyRecord{}, false
+}
\ No newline at end of file
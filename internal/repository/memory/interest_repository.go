@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type interestRepository struct {
+	scope *scope
+}
+
+func (r *interestRepository) Create(ctx context.Context, accrual *domain.InterestAccrual) error {
+	if accrual.ID == uuid.Nil {
+		accrual.ID = uuid.New()
+	}
+	if accrual.Rate.IsNegative() || accrual.Amount.IsNegative() {
+		return ErrCheckViolation
+	}
+	accrual.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if t.accruals.exists(accrual.ID, func(row domain.InterestAccrual) bool {
+			return row.AccountID == accrual.AccountID && row.AccrualDate.Equal(accrual.AccrualDate)
+		}) {
+			return ErrUniqueViolation
+		}
+		t.accruals.put(accrual.ID, *accrual)
+		return nil
+	})
+}
+
+func (r *interestRepository) GetLatest(ctx context.Context, accountID uuid.UUID) (*domain.InterestAccrual, error) {
+	accruals, err := r.GetByAccountID(ctx, accountID, 1)
+	if err != nil || len(accruals) == 0 {
+		return nil, err
+	}
+	return accruals[0], nil
+}
+
+func (r *interestRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.InterestAccrual, error) {
+	accruals := r.find(func(row domain.InterestAccrual) bool {
+		return row.AccountID == accountID
+	})
+
+	sort.Slice(accruals, func(i, j int) bool {
+		return accruals[i].AccrualDate.After(accruals[j].AccrualDate)
+	})
+	if len(accruals) > limit {
+		accruals = accruals[:limit]
+	}
+	return accruals, nil
+}
+
+func (r *interestRepository) GetUncapitalized(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*domain.InterestAccrual, error) {
+	accruals := r.find(func(row domain.InterestAccrual) bool {
+		return row.AccountID == accountID && row.CapitalizedAt == nil && row.AccrualDate.Before(before)
+	})
+
+	sort.Slice(accruals, func(i, j int) bool {
+		return accruals[i].AccrualDate.Before(accruals[j].AccrualDate)
+	})
+	return accruals, nil
+}
+
+func (r *interestRepository) MarkCapitalized(ctx context.Context, ids []uuid.UUID, transactionID *uuid.UUID, at time.Time) error {
+	return r.scope.write(func(t *tables) error {
+		for _, id := range ids {
+			row, ok := t.accruals.get(id)
+			if !ok {
+				continue
+			}
+			row.TransactionID = transactionID
+			row.CapitalizedAt = &at
+			t.accruals.put(id, row)
+		}
+		return nil
+	})
+}
+
+func (r *interestRepository) find(match func(row domain.InterestAccrual) bool) []*domain.InterestAccrual {
+	var accruals []*domain.InterestAccrual
+	r.scope.read(func(t *tables) {
+		for _, row := range t.accruals.rows {
+			if match(row) {
+				accrual := row
+				accruals = append(accruals, &accrual)
+			}
+		}
+	})
+	return accruals
+}
\ No newline at end of file
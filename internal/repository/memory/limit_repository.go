@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type limitRepository struct {
+	scope *scope
+}
+
+func (r *limitRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountLimit, error) {
+	var limits []*domain.AccountLimit
+	r.scope.read(func(t *tables) {
+		for _, row := range t.limits.rows {
+			if row.AccountID == accountID {
+				limit := row
+				limits = append(limits, &limit)
+			}
+		}
+	})
+	return limits, nil
+}
+
+// Upsert keeps one row per account and source, like the ON CONFLICT upsert
+// in Postgres.
+func (r *limitRepository) Upsert(ctx context.Context, limit *domain.AccountLimit) error {
+	for _, value := range []struct{ valid, negative bool }{
+		{limit.PerTransaction.Valid, limit.PerTransaction.Decimal.IsNegative()},
+		{limit.Daily.Valid, limit.Daily.Decimal.IsNegative()},
+		{limit.Monthly.Valid, limit.Monthly.Decimal.IsNegative()},
+	} {
+		if value.valid && value.negative {
+			return ErrCheckViolation
+		}
+	}
+
+	return r.scope.write(func(t *tables) error {
+		now := time.Now()
+		limit.ID = uuid.New()
+		limit.CreatedAt = now
+		for id, row := range t.limits.rows {
+			if row.AccountID == limit.AccountID && row.Source == limit.Source {
+				limit.ID = id
+				limit.CreatedAt = row.CreatedAt
+				break
+			}
+		}
+		limit.UpdatedAt = now
+		t.limits.put(limit.ID, *limit)
+		return nil
+	})
+}
+
+func (r *limitRepository) Delete(ctx context.Context, accountID uuid.UUID, source domain.LimitSource) error {
+	return r.scope.write(func(t *tables) error {
+		for id, row := range t.limits.rows {
+			if row.AccountID == accountID && row.Source == source {
+				t.limits.delete(id)
+			}
+		}
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type orgMemberKey struct {
+	organizationID uuid.UUID
+	userID         uuid.UUID
+}
+
+type approvalRuleKey struct {
+	organizationID uuid.UUID
+	minAmount      string
+}
+
+type organizationRepository struct {
+	scope *scope
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	if organization.ID == uuid.Nil {
+		organization.ID = uuid.New()
+	}
+	organization.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.organizations.put(organization.ID, *organization)
+		t.orgMembers.put(orgMemberKey{organization.ID, organization.CreatedBy}, domain.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         organization.CreatedBy,
+			Role:           domain.OrganizationRoleAdmin,
+			CreatedAt:      organization.CreatedAt,
+		})
+		return nil
+	})
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var organization *domain.Organization
+	r.scope.read(func(t *tables) {
+		if row, ok := t.organizations.get(id); ok {
+			organization = &row
+		}
+	})
+	return organization, nil
+}
+
+func (r *organizationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+	r.scope.read(func(t *tables) {
+		for key := range t.orgMembers.rows {
+			if key.userID != userID {
+				continue
+			}
+			if row, ok := t.organizations.get(key.organizationID); ok {
+				organizations = append(organizations, &row)
+			}
+		}
+	})
+
+	sort.Slice(organizations, func(i, j int) bool {
+		return organizations[i].Name < organizations[j].Name
+	})
+	return organizations, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	member.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		key := orgMemberKey{member.OrganizationID, member.UserID}
+		if _, ok := t.orgMembers.get(key); ok {
+			return ErrUniqueViolation
+		}
+		t.orgMembers.put(key, *member)
+		return nil
+	})
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	var member *domain.OrganizationMember
+	r.scope.read(func(t *tables) {
+		if row, ok := t.orgMembers.get(orgMemberKey{organizationID, userID}); ok {
+			member = &row
+		}
+	})
+	return member, nil
+}
+
+func (r *organizationRepository) GetMembers(ctx context.Context, organizationID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	var members []*domain.OrganizationMember
+	r.scope.read(func(t *tables) {
+		for _, row := range t.orgMembers.rows {
+			if row.OrganizationID == organizationID {
+				member := row
+				members = append(members, &member)
+			}
+		}
+	})
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *domain.OrganizationMember) error {
+	return r.scope.write(func(t *tables) error {
+		key := orgMemberKey{member.OrganizationID, member.UserID}
+		row, ok := t.orgMembers.get(key)
+		if !ok {
+			return nil
+		}
+		row.Role = member.Role
+		t.orgMembers.put(key, row)
+		return nil
+	})
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.orgMembers.delete(orgMemberKey{organizationID, userID})
+		return nil
+	})
+}
+
+func (r *organizationRepository) GetApprovalRules(ctx context.Context, organizationID uuid.UUID) ([]*domain.ApprovalRule, error) {
+	var rules []*domain.ApprovalRule
+	r.scope.read(func(t *tables) {
+		for _, row := range t.approvalRules.rows {
+			if row.OrganizationID == organizationID {
+				rule := row
+				rules = append(rules, &rule)
+			}
+		}
+	})
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].MinAmount.LessThan(rules[j].MinAmount)
+	})
+	return rules, nil
+}
+
+func (r *organizationRepository) SetApprovalRules(ctx context.Context, organizationID uuid.UUID, rules []*domain.ApprovalRule) error {
+	for _, rule := range rules {
+		if rule.MinAmount.IsNegative() || rule.RequiredApprovals < 1 {
+			return ErrCheckViolation
+		}
+	}
+
+	return r.scope.write(func(t *tables) error {
+		for key := range t.approvalRules.rows {
+			if key.organizationID == organizationID {
+				t.approvalRules.delete(key)
+			}
+		}
+		for _, rule := range rules {
+			row := *rule
+			row.OrganizationID = organizationID
+			t.approvalRules.put(ruleKey(organizationID, rule.MinAmount), row)
+		}
+		return nil
+	})
+}
+
+// ruleKey normalizes the amount so 100 and 100.00 are the same rule, as they
+// are in the DECIMAL primary key.
+func ruleKey(organizationID uuid.UUID, minAmount decimal.Decimal) approvalRuleKey {
+	return approvalRuleKey{organizationID, minAmount.StringFixed(2)}
+}
\ No newline at end of file
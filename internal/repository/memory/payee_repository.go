@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type payeeRepository struct {
+	scope *scope
+}
+
+func (r *payeeRepository) Create(ctx context.Context, payee *domain.Payee) error {
+	if payee.ID == uuid.Nil {
+		payee.ID = uuid.New()
+	}
+	now := time.Now()
+	payee.CreatedAt = now
+	payee.UpdatedAt = now
+
+	return r.scope.write(func(t *tables) error {
+		if t.payees.exists(payee.ID, func(row domain.Payee) bool {
+			return row.UserID == payee.UserID && row.AccountID == payee.AccountID
+		}) {
+			return ErrUniqueViolation
+		}
+		t.payees.put(payee.ID, *payee)
+		return nil
+	})
+}
+
+func (r *payeeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Payee, error) {
+	var payee *domain.Payee
+	r.scope.read(func(t *tables) {
+		if row, ok := t.payees.get(id); ok {
+			payee = &row
+		}
+	})
+	return payee, nil
+}
+
+func (r *payeeRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Payee, error) {
+	var payees []*domain.Payee
+	r.scope.read(func(t *tables) {
+		for _, row := range t.payees.rows {
+			if row.UserID == userID {
+				payee := row
+				payees = append(payees, &payee)
+			}
+		}
+	})
+
+	sort.Slice(payees, func(i, j int) bool {
+		return payees[i].Nickname < payees[j].Nickname
+	})
+	return payees, nil
+}
+
+func (r *payeeRepository) GetByUserAndAccount(ctx context.Context, userID, accountID uuid.UUID) (*domain.Payee, error) {
+	var payee *domain.Payee
+	r.scope.read(func(t *tables) {
+		for _, row := range t.payees.rows {
+			if row.UserID == userID && row.AccountID == accountID {
+				payee = &row
+				return
+			}
+		}
+	})
+	return payee, nil
+}
+
+func (r *payeeRepository) Update(ctx context.Context, payee *domain.Payee) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.payees.get(payee.ID)
+		if !ok {
+			return nil
+		}
+		row.Nickname = payee.Nickname
+		row.MaskedHolderName = payee.MaskedHolderName
+		row.VerificationStatus = payee.VerificationStatus
+		row.UpdatedAt = time.Now()
+		t.payees.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *payeeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.payees.delete(id)
+		// The schema clears the payee from approvals and drafts that named it
+		for approvalID, approval := range t.transferApprovals.rows {
+			if approval.PayeeID != nil && *approval.PayeeID == id {
+				approval.PayeeID = nil
+				t.transferApprovals.put(approvalID, approval)
+			}
+		}
+		for draftID, draft := range t.paymentDrafts.rows {
+			if draft.PayeeID != nil && *draft.PayeeID == id {
+				draft.PayeeID = nil
+				t.paymentDrafts.put(draftID, draft)
+			}
+		}
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type paymentDraftRepository struct {
+	scope *scope
+}
+
+func (r *paymentDraftRepository) Create(ctx context.Context, draft *domain.PaymentDraft) error {
+	if draft.ID == uuid.Nil {
+		draft.ID = uuid.New()
+	}
+	if !draft.Amount.IsPositive() || draft.ToAccountID == nil {
+		return ErrCheckViolation
+	}
+	if draft.Status == "" {
+		draft.Status = domain.PaymentDraftStatusDraft
+	}
+	if draft.RequiredApprovals == 0 {
+		draft.RequiredApprovals = 1
+	}
+	if draft.ApprovedBy == nil {
+		draft.ApprovedBy = domain.IDList{}
+	}
+	draft.CreatedAt = time.Now()
+	draft.UpdatedAt = draft.CreatedAt
+
+	return r.scope.write(func(t *tables) error {
+		t.paymentDrafts.put(draft.ID, copyDraft(draft))
+		return nil
+	})
+}
+
+func (r *paymentDraftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error) {
+	var draft *domain.PaymentDraft
+	r.scope.read(func(t *tables) {
+		if row, ok := t.paymentDrafts.get(id); ok {
+			draft = &row
+		}
+	})
+	return draft, nil
+}
+
+func (r *paymentDraftRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *paymentDraftRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, limit int) ([]*domain.PaymentDraft, error) {
+	var drafts []*domain.PaymentDraft
+	r.scope.read(func(t *tables) {
+		for _, row := range t.paymentDrafts.rows {
+			if row.OrganizationID == organizationID {
+				draft := row
+				drafts = append(drafts, &draft)
+			}
+		}
+	})
+
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].CreatedAt.After(drafts[j].CreatedAt)
+	})
+	if len(drafts) > limit {
+		drafts = drafts[:limit]
+	}
+	return drafts, nil
+}
+
+func (r *paymentDraftRepository) Update(ctx context.Context, draft *domain.PaymentDraft) error {
+	if draft.RequiredApprovals < 1 {
+		return ErrCheckViolation
+	}
+
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.paymentDrafts.get(draft.ID)
+		if !ok {
+			return nil
+		}
+		updated := copyDraft(draft)
+		row.ApprovedBy = updated.ApprovedBy
+		row.Status = draft.Status
+		row.RequiredApprovals = draft.RequiredApprovals
+		row.TransactionID = draft.TransactionID
+		row.Failure = draft.Failure
+		row.UpdatedAt = time.Now()
+		draft.UpdatedAt = row.UpdatedAt
+		t.paymentDrafts.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *paymentDraftRepository) CreateEvent(ctx context.Context, event *domain.PaymentDraftEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	event.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.draftEvents.put(event.ID, *event)
+		return nil
+	})
+}
+
+func (r *paymentDraftRepository) GetEvents(ctx context.Context, draftID uuid.UUID) ([]*domain.PaymentDraftEvent, error) {
+	var events []*domain.PaymentDraftEvent
+	r.scope.read(func(t *tables) {
+		for _, row := range t.draftEvents.rows {
+			if row.DraftID == draftID {
+				event := row
+				events = append(events, &event)
+			}
+		}
+	})
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	return events, nil
+}
+
+// copyDraft keeps stored rows from sharing the approver list or the audit
+// trail with the caller's copy.
+func copyDraft(draft *domain.PaymentDraft) domain.PaymentDraft {
+	row := *draft
+	row.ApprovedBy = append(domain.IDList{}, draft.ApprovedBy...)
+	row.Events = nil
+	return row
+}
\ No newline at end of file
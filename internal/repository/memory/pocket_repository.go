@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type pocketKey struct {
+	accountID uuid.UUID
+	currency  string
+}
+
+type pocketRepository struct {
+	scope *scope
+}
+
+func (r *pocketRepository) Create(ctx context.Context, pocket *domain.Pocket) error {
+	if err := checkPocket(pocket); err != nil {
+		return err
+	}
+	pocket.CreatedAt = time.Now()
+	pocket.UpdatedAt = pocket.CreatedAt
+
+	return r.scope.write(func(t *tables) error {
+		key := pocketKey{pocket.AccountID, pocket.Currency}
+		if _, ok := t.pockets.get(key); ok {
+			return ErrUniqueViolation
+		}
+		t.pockets.put(key, *pocket)
+		return nil
+	})
+}
+
+func (r *pocketRepository) Get(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	var pocket *domain.Pocket
+	r.scope.read(func(t *tables) {
+		if row, ok := t.pockets.get(pocketKey{accountID, currency}); ok {
+			pocket = &row
+		}
+	})
+	return pocket, nil
+}
+
+func (r *pocketRepository) GetForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	return r.Get(ctx, accountID, currency)
+}
+
+func (r *pocketRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pocket, error) {
+	var pockets []*domain.Pocket
+	r.scope.read(func(t *tables) {
+		for _, row := range t.pockets.rows {
+			if row.AccountID == accountID {
+				pocket := row
+				pockets = append(pockets, &pocket)
+			}
+		}
+	})
+
+	sort.Slice(pockets, func(i, j int) bool {
+		return pockets[i].Currency < pockets[j].Currency
+	})
+	return pockets, nil
+}
+
+func (r *pocketRepository) UpdateBalances(ctx context.Context, pocket *domain.Pocket) error {
+	if err := checkPocket(pocket); err != nil {
+		return err
+	}
+
+	return r.scope.write(func(t *tables) error {
+		key := pocketKey{pocket.AccountID, pocket.Currency}
+		row, ok := t.pockets.get(key)
+		if !ok {
+			return nil
+		}
+		row.Balance = pocket.Balance
+		row.HeldBalance = pocket.HeldBalance
+		row.UpdatedAt = time.Now()
+		pocket.UpdatedAt = row.UpdatedAt
+		t.pockets.put(key, row)
+		return nil
+	})
+}
+
+func (r *pocketRepository) Delete(ctx context.Context, accountID uuid.UUID, currency string) error {
+	return r.scope.write(func(t *tables) error {
+		t.pockets.delete(pocketKey{accountID, currency})
+		return nil
+	})
+}
+
+// checkPocket enforces the account_pockets table's CHECK constraints.
+func checkPocket(pocket *domain.Pocket) error {
+	if pocket.Balance.IsNegative() || pocket.HeldBalance.IsNegative() || pocket.HeldBalance.GreaterThan(pocket.Balance) {
+		return ErrCheckViolation
+	}
+	return nil
+}
\ No newline at end of file
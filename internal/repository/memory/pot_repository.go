@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type potRepository struct {
+	scope *scope
+}
+
+func (r *potRepository) Create(ctx context.Context, pot *domain.Pot) error {
+	if pot.ID == uuid.Nil {
+		pot.ID = uuid.New()
+	}
+	if err := checkPot(pot); err != nil {
+		return err
+	}
+	pot.CreatedAt = time.Now()
+	pot.UpdatedAt = pot.CreatedAt
+
+	return r.scope.write(func(t *tables) error {
+		if pot.RoundUp && roundUpTaken(t, pot) {
+			return ErrUniqueViolation
+		}
+		t.pots.put(pot.ID, *pot)
+		return nil
+	})
+}
+
+func (r *potRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	var pot *domain.Pot
+	r.scope.read(func(t *tables) {
+		if row, ok := t.pots.get(id); ok {
+			pot = &row
+		}
+	})
+	return pot, nil
+}
+
+func (r *potRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *potRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pot, error) {
+	var pots []*domain.Pot
+	r.scope.read(func(t *tables) {
+		for _, row := range t.pots.rows {
+			if row.AccountID == accountID {
+				pot := row
+				pots = append(pots, &pot)
+			}
+		}
+	})
+
+	sort.Slice(pots, func(i, j int) bool {
+		return pots[i].CreatedAt.Before(pots[j].CreatedAt)
+	})
+	return pots, nil
+}
+
+func (r *potRepository) Update(ctx context.Context, pot *domain.Pot) error {
+	if err := checkPot(pot); err != nil {
+		return err
+	}
+
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.pots.get(pot.ID)
+		if !ok {
+			return nil
+		}
+		if pot.RoundUp && roundUpTaken(t, &row) {
+			return ErrUniqueViolation
+		}
+		row.Name = pot.Name
+		row.TargetAmount = pot.TargetAmount
+		row.TargetDate = pot.TargetDate
+		row.RoundUp = pot.RoundUp
+		row.SweepPercent = pot.SweepPercent
+		row.UpdatedAt = time.Now()
+		pot.UpdatedAt = row.UpdatedAt
+		t.pots.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *potRepository) UpdateBalance(ctx context.Context, pot *domain.Pot) error {
+	if pot.Balance.IsNegative() {
+		return ErrCheckViolation
+	}
+
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.pots.get(pot.ID)
+		if !ok {
+			return nil
+		}
+		row.Balance = pot.Balance
+		row.UpdatedAt = time.Now()
+		pot.UpdatedAt = row.UpdatedAt
+		t.pots.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *potRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.pots.delete(id)
+		// The schema cascades the delete to the pot's movements
+		for movementID, movement := range t.potMovements.rows {
+			if movement.PotID == id {
+				t.potMovements.delete(movementID)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *potRepository) CreateMovement(ctx context.Context, movement *domain.PotMovement) error {
+	if movement.ID == uuid.Nil {
+		movement.ID = uuid.New()
+	}
+	if movement.Amount.IsZero() {
+		return ErrCheckViolation
+	}
+	movement.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.potMovements.put(movement.ID, *movement)
+		return nil
+	})
+}
+
+func (r *potRepository) GetMovements(ctx context.Context, potID uuid.UUID, limit int) ([]*domain.PotMovement, error) {
+	var movements []*domain.PotMovement
+	r.scope.read(func(t *tables) {
+		for _, row := range t.potMovements.rows {
+			if row.PotID == potID {
+				movement := row
+				movements = append(movements, &movement)
+			}
+		}
+	})
+
+	sort.Slice(movements, func(i, j int) bool {
+		return movements[i].CreatedAt.After(movements[j].CreatedAt)
+	})
+	if len(movements) > limit {
+		movements = movements[:limit]
+	}
+	return movements, nil
+}
+
+// checkPot enforces the pots table's CHECK constraints.
+func checkPot(pot *domain.Pot) error {
+	if pot.Balance.IsNegative() || pot.SweepPercent.IsNegative() || pot.SweepPercent.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrCheckViolation
+	}
+	if pot.TargetAmount.Valid && !pot.TargetAmount.Decimal.IsPositive() {
+		return ErrCheckViolation
+	}
+	return nil
+}
+
+// roundUpTaken reports whether another of the pot's account's pots collects
+// round-ups, as the partial unique index does.
+func roundUpTaken(t *tables, pot *domain.Pot) bool {
+	return t.pots.exists(pot.ID, func(row domain.Pot) bool {
+		return row.AccountID == pot.AccountID && row.RoundUp
+	})
+}
\ No newline at end of file
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type reviewRepository struct {
+	scope *scope
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *domain.TransactionReview) error {
+	if review.ID == uuid.Nil {
+		review.ID = uuid.New()
+	}
+	review.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if t.reviews.exists(review.ID, func(row domain.TransactionReview) bool { return row.TransactionID == review.TransactionID }) {
+			return ErrUniqueViolation
+		}
+		t.reviews.put(review.ID, *review)
+		return nil
+	})
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error) {
+	var review *domain.TransactionReview
+	r.scope.read(func(t *tables) {
+		if row, ok := t.reviews.get(id); ok {
+			review = &row
+		}
+	})
+	return review, nil
+}
+
+func (r *reviewRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *reviewRepository) GetPending(ctx context.Context, limit, offset int) ([]*domain.TransactionReview, error) {
+	var reviews []*domain.TransactionReview
+	r.scope.read(func(t *tables) {
+		for _, row := range t.reviews.rows {
+			if row.Status == domain.ReviewStatusPending {
+				review := row
+				reviews = append(reviews, &review)
+			}
+		}
+	})
+
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt.Before(reviews[j].CreatedAt)
+	})
+	if offset >= len(reviews) {
+		return nil, nil
+	}
+	reviews = reviews[offset:]
+	if len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return reviews, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *domain.TransactionReview) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.reviews.get(review.ID)
+		if !ok {
+			return nil
+		}
+		row.Status = review.Status
+		row.ReviewedBy = review.ReviewedBy
+		row.Note = review.Note
+		row.ReviewedAt = review.ReviewedAt
+		t.reviews.put(row.ID, row)
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type screeningAlertRepository struct {
+	scope *scope
+}
+
+func (r *screeningAlertRepository) Create(ctx context.Context, alert *domain.ScreeningAlert) error {
+	if alert.ID == uuid.Nil {
+		alert.ID = uuid.New()
+	}
+	alert.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.alerts.put(alert.ID, *alert)
+		return nil
+	})
+}
+
+func (r *screeningAlertRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ScreeningAlert, error) {
+	var alert *domain.ScreeningAlert
+	r.scope.read(func(t *tables) {
+		if row, ok := t.alerts.get(id); ok {
+			alert = &row
+		}
+	})
+	return alert, nil
+}
+
+func (r *screeningAlertRepository) GetPending(ctx context.Context, limit, offset int) ([]*domain.ScreeningAlert, error) {
+	var alerts []*domain.ScreeningAlert
+	r.scope.read(func(t *tables) {
+		for _, row := range t.alerts.rows {
+			if row.Status == domain.ReviewStatusPending {
+				alert := row
+				alerts = append(alerts, &alert)
+			}
+		}
+	})
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].CreatedAt.Before(alerts[j].CreatedAt)
+	})
+	if offset >= len(alerts) {
+		return nil, nil
+	}
+	alerts = alerts[offset:]
+	if len(alerts) > limit {
+		alerts = alerts[:limit]
+	}
+	return alerts, nil
+}
+
+func (r *screeningAlertRepository) Update(ctx context.Context, alert *domain.ScreeningAlert) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.alerts.get(alert.ID)
+		if !ok {
+			return nil
+		}
+		row.Status = alert.Status
+		row.ReviewedBy = alert.ReviewedBy
+		row.Note = alert.Note
+		row.ReviewedAt = alert.ReviewedAt
+		t.alerts.put(row.ID, row)
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,378 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+// table is a map of rows that remembers which keys were written so a unit of
+// work can merge just its own changes back.
+type table[K comparable, T any] struct {
+	rows  map[K]T
+	dirty map[K]bool
+}
+
+func newTable[K comparable, T any]() *table[K, T] {
+	return &table[K, T]{rows: make(map[K]T)}
+}
+
+func (t *table[K, T]) get(id K) (T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *table[K, T]) put(id K, row T) {
+	t.rows[id] = row
+	if t.dirty != nil {
+		t.dirty[id] = true
+	}
+}
+
+func (t *table[K, T]) delete(id K) {
+	delete(t.rows, id)
+	if t.dirty != nil {
+		t.dirty[id] = true
+	}
+}
+
+// exists reports whether a row other than the one keyed id matches.
+func (t *table[K, T]) exists(id K, match func(row T) bool) bool {
+	for key, row := range t.rows {
+		if key != id && match(row) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *table[K, T]) snapshot() *table[K, T] {
+	rows := make(map[K]T, len(t.rows))
+	for id, row := range t.rows {
+		rows[id] = row
+	}
+	return &table[K, T]{rows: rows, dirty: make(map[K]bool)}
+}
+
+func (t *table[K, T]) merge(from *table[K, T]) {
+	for id := range from.dirty {
+		if row, ok := from.rows[id]; ok {
+			t.rows[id] = row
+		} else {
+			delete(t.rows, id)
+		}
+	}
+}
+
+type deviceKey struct {
+	userID   uuid.UUID
+	deviceID string
+}
+
+type tables struct {
+	users             *table[uuid.UUID, domain.User]
+	accounts          *table[uuid.UUID, domain.Account]
+	accountMembers    *table[memberKey, domain.AccountMember]
+	approvalPolicies  *table[uuid.UUID, domain.ApprovalPolicy]
+	transferApprovals *table[uuid.UUID, domain.TransferApproval]
+	transactions      *table[uuid.UUID, domain.Transaction]
+	holds             *table[uuid.UUID, domain.Hold]
+	reviews           *table[uuid.UUID, domain.TransactionReview]
+	payees            *table[uuid.UUID, domain.Payee]
+	limits            *table[uuid.UUID, domain.AccountLimit]
+	alerts            *table[uuid.UUID, domain.ScreeningAlert]
+	accruals          *table[uuid.UUID, domain.InterestAccrual]
+	termDeposits      *table[uuid.UUID, domain.TermDeposit]
+	feeSchedules      *table[uuid.UUID, domain.FeeSchedule]
+	feeWaivers        *table[uuid.UUID, domain.FeeWaiver]
+	feeCharges        *table[uuid.UUID, domain.FeeCharge]
+	organizations     *table[uuid.UUID, domain.Organization]
+	orgMembers        *table[orgMemberKey, domain.OrganizationMember]
+	approvalRules     *table[approvalRuleKey, domain.ApprovalRule]
+	paymentDrafts     *table[uuid.UUID, domain.PaymentDraft]
+	draftEvents       *table[uuid.UUID, domain.PaymentDraftEvent]
+	pots              *table[uuid.UUID, domain.Pot]
+	potMovements      *table[uuid.UUID, domain.PotMovement]
+	pockets           *table[pocketKey, domain.Pocket]
+	devices           *table[deviceKey, time.Time]
+	idempotency       *table[uuid.UUID, domain.IdempotencyRecord]
+	// accountNumberSeq is shared by snapshots, so like a Postgres sequence
+	// it doesn't roll back
+	accountNumberSeq *atomic.Int64
+}
+
+func newTables() *tables {
+	return &tables{
+		users:             newTable[uuid.UUID, domain.User](),
+		accounts:          newTable[uuid.UUID, domain.Account](),
+		accountMembers:    newTable[memberKey, domain.AccountMember](),
+		approvalPolicies:  newTable[uuid.UUID, domain.ApprovalPolicy](),
+		transferApprovals: newTable[uuid.UUID, domain.TransferApproval](),
+		transactions:      newTable[uuid.UUID, domain.Transaction](),
+		holds:             newTable[uuid.UUID, domain.Hold](),
+		reviews:           newTable[uuid.UUID, domain.TransactionReview](),
+		payees:            newTable[uuid.UUID, domain.Payee](),
+		limits:            newTable[uuid.UUID, domain.AccountLimit](),
+		alerts:            newTable[uuid.UUID, domain.ScreeningAlert](),
+		accruals:          newTable[uuid.UUID, domain.InterestAccrual](),
+		termDeposits:      newTable[uuid.UUID, domain.TermDeposit](),
+		feeSchedules:      newTable[uuid.UUID, domain.FeeSchedule](),
+		feeWaivers:        newTable[uuid.UUID, domain.FeeWaiver](),
+		feeCharges:        newTable[uuid.UUID, domain.FeeCharge](),
+		organizations:     newTable[uuid.UUID, domain.Organization](),
+		orgMembers:        newTable[orgMemberKey, domain.OrganizationMember](),
+		approvalRules:     newTable[approvalRuleKey, domain.ApprovalRule](),
+		paymentDrafts:     newTable[uuid.UUID, domain.PaymentDraft](),
+		draftEvents:       newTable[uuid.UUID, domain.PaymentDraftEvent](),
+		pots:              newTable[uuid.UUID, domain.Pot](),
+		potMovements:      newTable[uuid.UUID, domain.PotMovement](),
+		pockets:           newTable[pocketKey, domain.Pocket](),
+		devices:           newTable[deviceKey, time.Time](),
+		idempotency:       newTable[uuid.UUID, domain.IdempotencyRecord](),
+		accountNumberSeq:  new(atomic.Int64),
+	}
+}
+
+// snapshot copies only the tables a unit of work's repositories can reach;
+// the rest are shared with the committed data.
+func (t *tables) snapshot() *tables {
+	snapshot := *t
+	snapshot.users = t.users.snapshot()
+	snapshot.accounts = t.accounts.snapshot()
+	snapshot.accountMembers = t.accountMembers.snapshot()
+	snapshot.approvalPolicies = t.approvalPolicies.snapshot()
+	snapshot.limits = t.limits.snapshot()
+	snapshot.transferApprovals = t.transferApprovals.snapshot()
+	snapshot.transactions = t.transactions.snapshot()
+	snapshot.holds = t.holds.snapshot()
+	snapshot.reviews = t.reviews.snapshot()
+	snapshot.accruals = t.accruals.snapshot()
+	snapshot.termDeposits = t.termDeposits.snapshot()
+	snapshot.feeSchedules = t.feeSchedules.snapshot()
+	snapshot.feeWaivers = t.feeWaivers.snapshot()
+	snapshot.feeCharges = t.feeCharges.snapshot()
+	snapshot.paymentDrafts = t.paymentDrafts.snapshot()
+	snapshot.draftEvents = t.draftEvents.snapshot()
+	snapshot.pots = t.pots.snapshot()
+	snapshot.potMovements = t.potMovements.snapshot()
+	snapshot.pockets = t.pockets.snapshot()
+	return &snapshot
+}
+
+func (t *tables) merge(from *tables) {
+	t.users.merge(from.users)
+	t.accounts.merge(from.accounts)
+	t.accountMembers.merge(from.accountMembers)
+	t.approvalPolicies.merge(from.approvalPolicies)
+	t.limits.merge(from.limits)
+	t.transferApprovals.merge(from.transferApprovals)
+	t.transactions.merge(from.transactions)
+	t.holds.merge(from.holds)
+	t.reviews.merge(from.reviews)
+	t.accruals.merge(from.accruals)
+	t.termDeposits.merge(from.termDeposits)
+	t.feeSchedules.merge(from.feeSchedules)
+	t.feeWaivers.merge(from.feeWaivers)
+	t.feeCharges.merge(from.feeCharges)
+	t.paymentDrafts.merge(from.paymentDrafts)
+	t.draftEvents.merge(from.draftEvents)
+	t.pots.merge(from.pots)
+	t.potMovements.merge(from.potMovements)
+	t.pockets.merge(from.pockets)
+}
+
+// ErrUniqueViolation and ErrCheckViolation stand in for the Postgres errors
+// raised by the schema's UNIQUE and CHECK constraints. Foreign keys are not
+// enforced.
+var (
+	ErrUniqueViolation = errors.New("duplicate key value violates unique constraint")
+	ErrCheckViolation  = errors.New("new row violates check constraint")
+)
+
+// Store is an in-memory database for tests. Repositories taken from it
+// directly see committed data; Do runs one unit of work at a time against a
+// snapshot and merges the rows it wrote back on success, so a failed unit of
+// work leaves nothing behind.
+type Store struct {
+	mu   sync.RWMutex
+	data *tables
+
+	txMu            sync.Mutex
+	accountsChanged []repository.AccountsChangedFunc
+}
+
+func NewStore() *Store {
+	return &Store{data: newTables()}
+}
+
+// OnAccountsChanged registers an after-commit hook, as on the Postgres unit
+// of work.
+func (s *Store) OnAccountsChanged(fn repository.AccountsChangedFunc) {
+	s.accountsChanged = append(s.accountsChanged, fn)
+}
+
+func (s *Store) Users() repository.UserRepository {
+	return &userRepository{scope: s.committed()}
+}
+
+func (s *Store) Accounts() repository.AccountRepository {
+	return &accountRepository{scope: s.committed()}
+}
+
+func (s *Store) AccountMembers() repository.AccountMemberRepository {
+	return &accountMemberRepository{scope: s.committed()}
+}
+
+func (s *Store) Transactions() repository.TransactionRepository {
+	return &transactionRepository{scope: s.committed()}
+}
+
+func (s *Store) Holds() repository.HoldRepository {
+	return &holdRepository{scope: s.committed()}
+}
+
+func (s *Store) Reviews() repository.ReviewRepository {
+	return &reviewRepository{scope: s.committed()}
+}
+
+func (s *Store) Interest() repository.InterestRepository {
+	return &interestRepository{scope: s.committed()}
+}
+
+func (s *Store) TermDeposits() repository.TermDepositRepository {
+	return &termDepositRepository{scope: s.committed()}
+}
+
+func (s *Store) TransferApprovals() repository.TransferApprovalRepository {
+	return &transferApprovalRepository{scope: s.committed()}
+}
+
+func (s *Store) Organizations() repository.OrganizationRepository {
+	return &organizationRepository{scope: s.committed()}
+}
+
+func (s *Store) PaymentDrafts() repository.PaymentDraftRepository {
+	return &paymentDraftRepository{scope: s.committed()}
+}
+
+func (s *Store) Pots() repository.PotRepository {
+	return &potRepository{scope: s.committed()}
+}
+
+func (s *Store) Pockets() repository.PocketRepository {
+	return &pocketRepository{scope: s.committed()}
+}
+
+func (s *Store) Fees() repository.FeeRepository {
+	return &feeRepository{scope: s.committed()}
+}
+
+func (s *Store) Payees() repository.PayeeRepository {
+	return &payeeRepository{scope: s.committed()}
+}
+
+func (s *Store) Limits() repository.LimitRepository {
+	return &limitRepository{scope: s.committed()}
+}
+
+func (s *Store) Devices() repository.DeviceRepository {
+	return &deviceRepository{scope: s.committed()}
+}
+
+func (s *Store) ScreeningAlerts() repository.ScreeningAlertRepository {
+	return &screeningAlertRepository{scope: s.committed()}
+}
+
+func (s *Store) Idempotency() repository.IdempotencyRepository {
+	return &idempotencyRepository{scope: s.committed()}
+}
+
+func (s *Store) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	working := s.data.snapshot()
+	s.mu.RUnlock()
+
+	var changed []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	txScope := &scope{
+		data: working,
+		onBalanceChange: func(id uuid.UUID) {
+			if !seen[id] {
+				seen[id] = true
+				changed = append(changed, id)
+			}
+		},
+	}
+	repos := &repository.Repositories{
+		Accounts:          &accountRepository{scope: txScope},
+		Transactions:      &transactionRepository{scope: txScope},
+		Holds:             &holdRepository{scope: txScope},
+		Reviews:           &reviewRepository{scope: txScope},
+		Interest:          &interestRepository{scope: txScope},
+		TermDeposits:      &termDepositRepository{scope: txScope},
+		Fees:              &feeRepository{scope: txScope},
+		TransferApprovals: &transferApprovalRepository{scope: txScope},
+		PaymentDrafts:     &paymentDraftRepository{scope: txScope},
+		Pots:              &potRepository{scope: txScope},
+		Pockets:           &pocketRepository{scope: txScope},
+		AccountMembers:    &accountMemberRepository{scope: txScope},
+		Users:             &userRepository{scope: txScope},
+		Limits:            &limitRepository{scope: txScope},
+	}
+
+	if err := fn(ctx, repos); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.data.merge(working)
+	s.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, hook := range s.accountsChanged {
+			if err := hook(ctx, changed...); err != nil {
+				log.Printf("After-commit hook failed for accounts %v: %v", changed, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) committed() *scope {
+	return &scope{data: s.data, mu: &s.mu}
+}
+
+// scope is what a repository reads and writes: the committed tables behind
+// the store's lock, or a unit of work's private snapshot.
+type scope struct {
+	data            *tables
+	mu              *sync.RWMutex
+	onBalanceChange func(id uuid.UUID)
+}
+
+func (s *scope) read(fn func(t *tables)) {
+	if s.mu != nil {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+	fn(s.data)
+}
+
+func (s *scope) write(fn func(t *tables) error) error {
+	if s.mu != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	return fn(s.data)
+}
\ No newline at end of file
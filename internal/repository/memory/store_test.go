@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+func TestDoRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	hookCalls := 0
+	store.OnAccountsChanged(func(ctx context.Context, accountIDs ...uuid.UUID) error {
+		hookCalls++
+		return nil
+	})
+
+	user := &domain.User{Email: "owner@example.com", FullName: "Owner"}
+	if err := store.Users().Create(ctx, user); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+	account := &domain.Account{UserID: user.ID, Balance: decimal.NewFromInt(10)}
+	if err := store.Accounts().Create(ctx, account); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	errBoom := errors.New("boom")
+	err := store.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		locked, err := repos.Accounts.GetByIDForUpdate(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		locked.Balance = decimal.Zero
+		if err := repos.Accounts.UpdateBalances(ctx, locked); err != nil {
+			return err
+		}
+		if err := repos.Transactions.Create(ctx, &domain.Transaction{FromAccountID: &account.ID, Amount: decimal.NewFromInt(10), Type: domain.TransactionTypePayment, Reference: "TXN1"}); err != nil {
+			return err
+		}
+		if err := repos.Users.Update(ctx, &domain.User{ID: user.ID, Email: user.Email, FullName: "Renamed"}); err != nil {
+			return err
+		}
+		if err := repos.Limits.Upsert(ctx, &domain.AccountLimit{AccountID: account.ID, Source: domain.LimitSourceUser}); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Do error %v, want %v", err, errBoom)
+	}
+
+	got, _ := store.Accounts().GetByID(ctx, account.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("balance %s after rollback, want 10", got.Balance)
+	}
+	transactions, _ := store.Transactions().GetByAccountID(ctx, account.ID, nil)
+	if len(transactions) != 0 {
+		t.Errorf("%d transactions after rollback, want 0", len(transactions))
+	}
+	gotUser, _ := store.Users().GetByID(ctx, user.ID)
+	if gotUser.FullName != "Owner" {
+		t.Errorf("user name %q after rollback, want Owner", gotUser.FullName)
+	}
+	limits, _ := store.Limits().GetByAccountID(ctx, account.ID)
+	if len(limits) != 0 {
+		t.Errorf("%d limits after rollback, want 0", len(limits))
+	}
+	if hookCalls != 0 {
+		t.Errorf("after-commit hook ran %d times on rollback", hookCalls)
+	}
+}
+
+func TestDoCommitsAndReportsChangedAccounts(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+
+	var changed []uuid.UUID
+	store.OnAccountsChanged(func(ctx context.Context, accountIDs ...uuid.UUID) error {
+		changed = append(changed, accountIDs...)
+		return nil
+	})
+
+	account := &domain.Account{UserID: uuid.New(), Balance: decimal.NewFromInt(10)}
+	if err := store.Accounts().Create(ctx, account); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	err := store.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		// Writes inside the unit of work are visible to its own reads
+		for i := 0; i < 2; i++ {
+			locked, err := repos.Accounts.GetByIDForUpdate(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+			locked.Balance = locked.Balance.Sub(decimal.NewFromInt(3))
+			if err := repos.Accounts.UpdateBalances(ctx, locked); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	got, _ := store.Accounts().GetByID(ctx, account.ID)
+	if !got.Balance.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("balance %s, want 4", got.Balance)
+	}
+	if len(changed) != 1 || changed[0] != account.ID {
+		t.Errorf("hook saw %v, want just %s", changed, account.ID)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type termDepositRepository struct {
+	scope *scope
+}
+
+func (r *termDepositRepository) Create(ctx context.Context, deposit *domain.TermDeposit) error {
+	if deposit.ID == uuid.Nil {
+		deposit.ID = uuid.New()
+	}
+	if !deposit.Principal.IsPositive() || deposit.Rate.IsNegative() || deposit.TermMonths <= 0 ||
+		!deposit.MaturityDate.After(deposit.StartDate) || deposit.MaturityValue.LessThan(deposit.Principal) {
+		return ErrCheckViolation
+	}
+	if deposit.Status == "" {
+		deposit.Status = domain.TermDepositStatusActive
+	}
+	deposit.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if deposit.Status == domain.TermDepositStatusActive && t.termDeposits.exists(deposit.ID, func(row domain.TermDeposit) bool {
+			return row.AccountID == deposit.AccountID && row.Status == domain.TermDepositStatusActive
+		}) {
+			return ErrUniqueViolation
+		}
+		t.termDeposits.put(deposit.ID, *deposit)
+		return nil
+	})
+}
+
+func (r *termDepositRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error) {
+	var deposit *domain.TermDeposit
+	r.scope.read(func(t *tables) {
+		if row, ok := t.termDeposits.get(id); ok {
+			deposit = &row
+		}
+	})
+	return deposit, nil
+}
+
+func (r *termDepositRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *termDepositRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.TermDeposit, error) {
+	deposits := r.find(func(row domain.TermDeposit) bool {
+		return row.UserID == userID
+	})
+
+	sort.Slice(deposits, func(i, j int) bool {
+		return deposits[i].CreatedAt.After(deposits[j].CreatedAt)
+	})
+	return deposits, nil
+}
+
+func (r *termDepositRepository) GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) (*domain.TermDeposit, error) {
+	deposits := r.find(func(row domain.TermDeposit) bool {
+		return row.AccountID == accountID && row.Status == domain.TermDepositStatusActive
+	})
+	if len(deposits) == 0 {
+		return nil, nil
+	}
+	return deposits[0], nil
+}
+
+func (r *termDepositRepository) GetActiveByPayoutAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.TermDeposit, error) {
+	return r.find(func(row domain.TermDeposit) bool {
+		return row.PayoutAccountID == accountID && row.Status == domain.TermDepositStatusActive
+	}), nil
+}
+
+func (r *termDepositRepository) GetMatured(ctx context.Context, date time.Time, limit int) ([]*domain.TermDeposit, error) {
+	deposits := r.find(func(row domain.TermDeposit) bool {
+		return row.Status == domain.TermDepositStatusActive && !row.MaturityDate.After(date)
+	})
+
+	sort.Slice(deposits, func(i, j int) bool {
+		return deposits[i].MaturityDate.Before(deposits[j].MaturityDate)
+	})
+	if len(deposits) > limit {
+		deposits = deposits[:limit]
+	}
+	return deposits, nil
+}
+
+func (r *termDepositRepository) UpdateStatus(ctx context.Context, deposit *domain.TermDeposit) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.termDeposits.get(deposit.ID)
+		if !ok {
+			return nil
+		}
+		row.Status = deposit.Status
+		row.ClosedAt = deposit.ClosedAt
+		t.termDeposits.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *termDepositRepository) find(match func(row domain.TermDeposit) bool) []*domain.TermDeposit {
+	var deposits []*domain.TermDeposit
+	r.scope.read(func(t *tables) {
+		for _, row := range t.termDeposits.rows {
+			if match(row) {
+				deposit := row
+				deposits = append(deposits, &deposit)
+			}
+		}
+	})
+	return deposits
+}
\ No newline at end of file
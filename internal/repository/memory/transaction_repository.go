@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type transactionRepository struct {
+	scope *scope
+}
+
+func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	if tx.ID == uuid.Nil {
+		tx.ID = uuid.New()
+	}
+	if !tx.Amount.IsPositive() || !validAccounts(tx) {
+		return ErrCheckViolation
+	}
+	tx.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		if t.transactions.exists(tx.ID, func(row domain.Transaction) bool { return row.Reference == tx.Reference }) {
+			return ErrUniqueViolation
+		}
+		t.transactions.put(tx.ID, *tx)
+		return nil
+	})
+}
+
+func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	var tx *domain.Transaction
+	r.scope.read(func(t *tables) {
+		if row, ok := t.transactions.get(id); ok {
+			tx = &row
+		}
+	})
+	return tx, nil
+}
+
+func (r *transactionRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *transactionRepository) GetByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
+	var tx *domain.Transaction
+	r.scope.read(func(t *tables) {
+		for _, row := range t.transactions.rows {
+			if row.Reference == reference {
+				tx = &row
+				return
+			}
+		}
+	})
+	return tx, nil
+}
+
+// GetByAccountID mirrors Postgres: newest first, paged by the filter's limit
+// (default 50) and offset.
+func (r *transactionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	r.scope.read(func(t *tables) {
+		for _, row := range t.transactions.rows {
+			if involves(&row, accountID) {
+				tx := row
+				transactions = append(transactions, &tx)
+			}
+		}
+	})
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.After(transactions[j].CreatedAt)
+	})
+
+	limit := 50
+	offset := 0
+	if filter != nil {
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		offset = filter.Offset
+	}
+	if offset >= len(transactions) {
+		return nil, nil
+	}
+	transactions = transactions[offset:]
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+
+	return transactions, nil
+}
+
+func (r *transactionRepository) Update(ctx context.Context, tx *domain.Transaction) error {
+	if !tx.Amount.IsPositive() {
+		return ErrCheckViolation
+	}
+
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.transactions.get(tx.ID)
+		if !ok {
+			return nil
+		}
+		row.Amount = tx.Amount
+		row.Status = tx.Status
+		row.CompletedAt = tx.CompletedAt
+		t.transactions.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *transactionRepository) GetOutflowSummary(ctx context.Context, accountIDs []uuid.UUID, since time.Time) (*domain.OutflowSummary, error) {
+	ids := make(map[uuid.UUID]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		ids[id] = true
+	}
+
+	summary := &domain.OutflowSummary{Total: decimal.Zero}
+	r.scope.read(func(t *tables) {
+		for _, row := range t.transactions.rows {
+			if row.FromAccountID == nil || !ids[*row.FromAccountID] || row.CreatedAt.Before(since) {
+				continue
+			}
+			if row.Status != domain.TransactionStatusPending && row.Status != domain.TransactionStatusCompleted {
+				continue
+			}
+			if row.Type == domain.TransactionTypeFee || row.Type == domain.TransactionTypeWithholdingTax || row.Type == domain.TransactionTypeConversion {
+				continue
+			}
+			summary.Count++
+			if row.Value != nil {
+				summary.Total = summary.Total.Add(*row.Value)
+			} else {
+				summary.Total = summary.Total.Add(row.Amount)
+			}
+		}
+	})
+	return summary, nil
+}
+
+// validAccounts mirrors the check_transfer_accounts constraint: which of the
+// from and to accounts a transaction has depends on its type.
+func validAccounts(tx *domain.Transaction) bool {
+	from, to := tx.FromAccountID != nil, tx.ToAccountID != nil
+	switch tx.Type {
+	case domain.TransactionTypeTransfer:
+		return from && to && *tx.FromAccountID != *tx.ToAccountID
+	case domain.TransactionTypeDeposit, domain.TransactionTypeInterest:
+		return !from && to
+	case domain.TransactionTypeWithdrawal, domain.TransactionTypeWithholdingTax, domain.TransactionTypeFee:
+		return from && !to
+	case domain.TransactionTypePayment:
+		return from
+	case domain.TransactionTypeConversion:
+		return from != to
+	}
+	return false
+}
+
+func involves(tx *domain.Transaction, accountID uuid.UUID) bool {
+	return (tx.FromAccountID != nil && *tx.FromAccountID == accountID) ||
+		(tx.ToAccountID != nil && *tx.ToAccountID == accountID)
+}
\ No newline at end of file
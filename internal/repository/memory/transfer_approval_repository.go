@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type transferApprovalRepository struct {
+	scope *scope
+}
+
+func (r *transferApprovalRepository) Create(ctx context.Context, approval *domain.TransferApproval) error {
+	if approval.ID == uuid.Nil {
+		approval.ID = uuid.New()
+	}
+	if !approval.Amount.IsPositive() || approval.ToAccountID == nil {
+		return ErrCheckViolation
+	}
+	if approval.Status == "" {
+		approval.Status = domain.TransferApprovalPending
+	}
+	if approval.ApprovedBy == nil {
+		approval.ApprovedBy = domain.IDList{}
+	}
+	approval.CreatedAt = time.Now()
+
+	return r.scope.write(func(t *tables) error {
+		t.transferApprovals.put(approval.ID, copyApproval(approval))
+		return nil
+	})
+}
+
+func (r *transferApprovalRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error) {
+	var approval *domain.TransferApproval
+	r.scope.read(func(t *tables) {
+		if row, ok := t.transferApprovals.get(id); ok {
+			approval = &row
+		}
+	})
+	return approval, nil
+}
+
+func (r *transferApprovalRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *transferApprovalRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.TransferApproval, error) {
+	var approvals []*domain.TransferApproval
+	r.scope.read(func(t *tables) {
+		for _, row := range t.transferApprovals.rows {
+			if row.AccountID == accountID {
+				approval := row
+				approvals = append(approvals, &approval)
+			}
+		}
+	})
+
+	sort.Slice(approvals, func(i, j int) bool {
+		return approvals[i].CreatedAt.After(approvals[j].CreatedAt)
+	})
+	if len(approvals) > limit {
+		approvals = approvals[:limit]
+	}
+	return approvals, nil
+}
+
+func (r *transferApprovalRepository) Update(ctx context.Context, approval *domain.TransferApproval) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.transferApprovals.get(approval.ID)
+		if !ok {
+			return nil
+		}
+		updated := copyApproval(approval)
+		row.ApprovedBy = updated.ApprovedBy
+		row.Status = approval.Status
+		row.RejectedBy = approval.RejectedBy
+		row.TransactionID = approval.TransactionID
+		row.Failure = approval.Failure
+		row.DecidedAt = approval.DecidedAt
+		t.transferApprovals.put(row.ID, row)
+		return nil
+	})
+}
+
+// copyApproval keeps stored rows from sharing the approver list with the
+// caller's copy.
+func copyApproval(approval *domain.TransferApproval) domain.TransferApproval {
+	row := *approval
+	row.ApprovedBy = append(domain.IDList{}, approval.ApprovedBy...)
+	return row
+}
\ No newline at end of file
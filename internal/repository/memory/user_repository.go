@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type userRepository struct {
+	scope *scope
+}
+
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if user.Tier == "" {
+		user.Tier = domain.UserTierStandard
+	}
+	if user.Role == "" {
+		user.Role = domain.UserRoleCustomer
+	}
+	now := time.Now()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	return r.scope.write(func(t *tables) error {
+		if t.users.exists(user.ID, func(row domain.User) bool { return row.Email == user.Email }) {
+			return ErrUniqueViolation
+		}
+		t.users.put(user.ID, *user)
+		return nil
+	})
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	var user *domain.User
+	r.scope.read(func(t *tables) {
+		if row, ok := t.users.get(id); ok {
+			user = &row
+		}
+	})
+	return user, nil
+}
+
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	var user *domain.User
+	r.scope.read(func(t *tables) {
+		for _, row := range t.users.rows {
+			if row.Email == email {
+				user = &row
+				return
+			}
+		}
+	})
+	return user, nil
+}
+
+// Update mirrors Postgres: the password hash, tier and role are left alone.
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.users.get(user.ID)
+		if !ok {
+			return nil
+		}
+		if t.users.exists(user.ID, func(other domain.User) bool { return other.Email == user.Email }) {
+			return ErrUniqueViolation
+		}
+		row.Email = user.Email
+		row.FullName = user.FullName
+		row.Phone = user.Phone
+		row.ProfileImageURL = user.ProfileImageURL
+		row.IsVerified = user.IsVerified
+		row.UpdatedAt = time.Now()
+		t.users.put(row.ID, row)
+		return nil
+	})
+}
+
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		t.users.delete(id)
+		return nil
+	})
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, id uuid.UUID) error {
+	return r.scope.write(func(t *tables) error {
+		row, ok := t.users.get(id)
+		if !ok {
+			return nil
+		}
+		row.Email = domain.DeletedUserEmail(id)
+		row.PasswordHash = ""
+		row.FullName = domain.DeletedUserName
+		row.Phone = nil
+		row.ProfileImageURL = nil
+		row.IsVerified = false
+		row.UpdatedAt = time.Now()
+		t.users.put(row.ID, row)
+		return nil
+	})
+}
\ No newline at end of file
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type OrganizationRepository interface {
+	// Create makes the organization with its creator as an admin.
+	Create(ctx context.Context, organization *domain.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	// GetByUserID lists the organizations the user is a member of.
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error)
+
+	AddMember(ctx context.Context, member *domain.OrganizationMember) error
+	// GetMember returns the user's membership, or nil if they have none.
+	GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*domain.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationID uuid.UUID) ([]*domain.OrganizationMember, error)
+	UpdateMember(ctx context.Context, member *domain.OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+
+	// GetApprovalRules lists the organization's rules by minimum amount.
+	GetApprovalRules(ctx context.Context, organizationID uuid.UUID) ([]*domain.ApprovalRule, error)
+	// SetApprovalRules replaces all of the organization's rules.
+	SetApprovalRules(ctx context.Context, organizationID uuid.UUID, rules []*domain.ApprovalRule) error
+}
\ No newline at end of file
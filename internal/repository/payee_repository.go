@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type PayeeRepository interface {
+	Create(ctx context.Context, payee *domain.Payee) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Payee, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Payee, error)
+	GetByUserAndAccount(ctx context.Context, userID, accountID uuid.UUID) (*domain.Payee, error)
+	Update(ctx context.Context, payee *domain.Payee) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
\ No newline at end of file
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type PaymentDraftRepository interface {
+	Create(ctx context.Context, draft *domain.PaymentDraft) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error)
+	// GetByIDForUpdate locks the draft until the unit of work ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error)
+	// GetByOrganizationID lists the organization's drafts, newest first.
+	GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, limit int) ([]*domain.PaymentDraft, error)
+	Update(ctx context.Context, draft *domain.PaymentDraft) error
+
+	CreateEvent(ctx context.Context, event *domain.PaymentDraftEvent) error
+	// GetEvents returns the draft's audit trail, oldest first.
+	GetEvents(ctx context.Context, draftID uuid.UUID) ([]*domain.PaymentDraftEvent, error)
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type PocketRepository interface {
+	Create(ctx context.Context, pocket *domain.Pocket) error
+	Get(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error)
+	// GetForUpdate locks the pocket until the unit of work ends.
+	GetForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error)
+	// GetByAccountID lists the account's pockets by currency.
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pocket, error)
+	UpdateBalances(ctx context.Context, pocket *domain.Pocket) error
+	Delete(ctx context.Context, accountID uuid.UUID, currency string) error
+}
\ No newline at end of file
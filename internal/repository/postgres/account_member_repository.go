@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type accountMemberRepository struct {
+	db dbtx
+}
+
+func NewAccountMemberRepository(db *sqlx.DB) repository.AccountMemberRepository {
+	return &accountMemberRepository{db: db}
+}
+
+func (r *accountMemberRepository) Create(ctx context.Context, member *domain.AccountMember) error {
+	query := `
+		INSERT INTO account_members (account_id, user_id, role, status, invited_by, accepted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		member.AccountID,
+		member.UserID,
+		member.Role,
+		member.Status,
+		member.InvitedBy,
+		member.AcceptedAt,
+	).Scan(&member.CreatedAt)
+
+	return err
+}
+
+func (r *accountMemberRepository) Get(ctx context.Context, accountID, userID uuid.UUID) (*domain.AccountMember, error) {
+	var member domain.AccountMember
+	query := `SELECT * FROM account_members WHERE account_id = $1 AND user_id = $2`
+
+	err := r.db.GetContext(ctx, &member, query, accountID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *accountMemberRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountMember, error) {
+	var members []*domain.AccountMember
+	query := `SELECT * FROM account_members WHERE account_id = $1 ORDER BY created_at`
+
+	err := r.db.SelectContext(ctx, &members, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *accountMemberRepository) GetInvitations(ctx context.Context, userID uuid.UUID) ([]*domain.AccountMember, error) {
+	var members []*domain.AccountMember
+	query := `SELECT * FROM account_members WHERE user_id = $1 AND status = 'invited' ORDER BY created_at DESC`
+
+	err := r.db.SelectContext(ctx, &members, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *accountMemberRepository) Update(ctx context.Context, member *domain.AccountMember) error {
+	query := `
+		UPDATE account_members
+		SET role = $3, status = $4, accepted_at = $5
+		WHERE account_id = $1 AND user_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, member.AccountID, member.UserID, member.Role, member.Status, member.AcceptedAt)
+	return err
+}
+
+func (r *accountMemberRepository) Delete(ctx context.Context, accountID, userID uuid.UUID) error {
+	query := `DELETE FROM account_members WHERE account_id = $1 AND user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, accountID, userID)
+	return err
+}
+
+func (r *accountMemberRepository) GetApprovalPolicy(ctx context.Context, accountID uuid.UUID) (*domain.ApprovalPolicy, error) {
+	var policy domain.ApprovalPolicy
+	query := `SELECT * FROM account_approval_policies WHERE account_id = $1`
+
+	err := r.db.GetContext(ctx, &policy, query, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func (r *accountMemberRepository) SetApprovalPolicy(ctx context.Context, policy *domain.ApprovalPolicy) error {
+	query := `
+		INSERT INTO account_approval_policies (account_id, threshold, required_approvals)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id) DO UPDATE
+		SET threshold = EXCLUDED.threshold,
+		    required_approvals = EXCLUDED.required_approvals,
+		    updated_at = CURRENT_TIMESTAMP
+		RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		policy.AccountID,
+		policy.Threshold,
+		policy.RequiredApprovals,
+	).Scan(&policy.UpdatedAt)
+
+	return err
+}
+
+func (r *accountMemberRepository) DeleteApprovalPolicy(ctx context.Context, accountID uuid.UUID) error {
+	query := `DELETE FROM account_approval_policies WHERE account_id = $1`
+	_, err := r.db.ExecContext(ctx, query, accountID)
+	return err
+}
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -12,7 +13,8 @@ import (
 )
 
 type accountRepository struct {
-	db *sqlx.DB
+	db              dbtx
+	onBalanceChange func(id uuid.UUID)
 }
 
 func NewAccountRepository(db *sqlx.DB) repository.AccountRepository {
@@ -20,23 +22,42 @@ func NewAccountRepository(db *sqlx.DB) repository.AccountRepository {
 }
 
 func (r *accountRepository) Create(ctx context.Context, account *domain.Account) error {
+	// The holder becomes the account's owner in the same statement
 	query := `
-		INSERT INTO accounts (user_id, account_number, account_type, balance, currency, status)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at`
+		WITH account AS (
+			INSERT INTO accounts (user_id, organization_id, account_number, account_type, balance, currency, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (account_number) DO NOTHING
+			RETURNING id, user_id, created_at, updated_at
+		), owner AS (
+			INSERT INTO account_members (account_id, user_id, role, status, created_at, accepted_at)
+			SELECT id, user_id, 'owner', 'active', created_at, created_at FROM account
+		)
+		SELECT id, created_at, updated_at FROM account`
 
 	err := r.db.QueryRowContext(ctx, query,
 		account.UserID,
+		account.OrganizationID,
 		account.AccountNumber,
 		account.AccountType,
 		account.Balance,
 		account.Currency,
 		account.Status,
 	).Scan(&account.ID, &account.CreatedAt, &account.UpdatedAt)
+	// A taken number inserts nothing rather than aborting the transaction
+	if errors.Is(err, sql.ErrNoRows) {
+		return repository.ErrAccountNumberTaken
+	}
 
 	return err
 }
 
+func (r *accountRepository) NextAccountNumber(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.db.QueryRowContext(ctx, `SELECT nextval('account_number_seq')`).Scan(&seq)
+	return seq, err
+}
+
 func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
 	var account domain.Account
 	query := `SELECT * FROM accounts WHERE id = $1`
@@ -52,6 +73,21 @@ func (r *accountRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &account, nil
 }
 
+func (r *accountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	var account domain.Account
+	query := `SELECT * FROM accounts WHERE id = $1 FOR UPDATE`
+
+	err := r.db.GetContext(ctx, &account, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
 func (r *accountRepository) GetByAccountNumber(ctx context.Context, accountNumber string) (*domain.Account, error) {
 	var account domain.Account
 	query := `SELECT * FROM accounts WHERE account_number = $1`
@@ -69,7 +105,11 @@ func (r *accountRepository) GetByAccountNumber(ctx context.Context, accountNumbe
 
 func (r *accountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Account, error) {
 	var accounts []*domain.Account
-	query := `SELECT * FROM accounts WHERE user_id = $1 ORDER BY created_at DESC`
+	query := `
+		SELECT a.* FROM accounts a
+		JOIN account_members m ON m.account_id = a.id
+		WHERE m.user_id = $1 AND m.status = 'active'
+		ORDER BY a.created_at DESC`
 	
 	err := r.db.SelectContext(ctx, &accounts, query, userID)
 	if err != nil {
@@ -79,24 +119,122 @@ func (r *accountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 	return accounts, nil
 }
 
+func (r *accountRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	query := `SELECT * FROM accounts WHERE organization_id = $1 ORDER BY created_at DESC`
+
+	err := r.db.SelectContext(ctx, &accounts, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *accountRepository) GetByType(ctx context.Context, accountType domain.AccountType, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	query := `SELECT * FROM accounts WHERE account_type = $1 AND id > $2 ORDER BY id LIMIT $3`
+
+	err := r.db.SelectContext(ctx, &accounts, query, accountType, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *accountRepository) GetDormant(ctx context.Context, since time.Time, after uuid.UUID, limit int) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	query := `
+		SELECT * FROM accounts a
+		WHERE a.status = 'active' AND a.created_at < $1
+			AND (a.status_changed_at IS NULL OR a.status_changed_at < $1)
+			AND a.id > $2
+			AND NOT EXISTS (
+				SELECT 1 FROM transactions t
+				WHERE t.from_account_id = a.id AND t.created_at >= $1
+					AND t.type IN ('transfer', 'withdrawal', 'payment', 'conversion')
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM transactions t
+				WHERE t.to_account_id = a.id AND t.created_at >= $1
+					AND t.type IN ('deposit', 'transfer')
+			)
+		ORDER BY a.id
+		LIMIT $3`
+
+	err := r.db.SelectContext(ctx, &accounts, query, since, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// Update changes an account's details. Balances are left alone: they only
+// move inside locked transactions, and the copy passed in may have come from
+// the cache. So is the status, which has workflows of its own.
 func (r *accountRepository) Update(ctx context.Context, account *domain.Account) error {
 	query := `
 		UPDATE accounts 
-		SET account_type = $2, balance = $3, status = $4, updated_at = CURRENT_TIMESTAMP
+		SET account_type = $2, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1`
 
 	_, err := r.db.ExecContext(ctx, query,
 		account.ID,
 		account.AccountType,
-		account.Balance,
-		account.Status,
 	)
 
 	return err
 }
 
-func (r *accountRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM accounts WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+func (r *accountRepository) UpdateStatus(ctx context.Context, account *domain.Account) error {
+	query := `
+		UPDATE accounts
+		SET status = $2, status_changed_at = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, account.ID, account.Status, account.StatusChangedAt)
+	if err != nil {
+		return err
+	}
+
+	if r.onBalanceChange != nil {
+		r.onBalanceChange(account.ID)
+	}
+	return nil
+}
+
+func (r *accountRepository) UpdateBalances(ctx context.Context, account *domain.Account) error {
+	query := `
+		UPDATE accounts
+		SET balance = $2, held_balance = $3, pot_balance = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, account.ID, account.Balance, account.HeldBalance, account.PotBalance)
+	if err != nil {
+		return err
+	}
+
+	if r.onBalanceChange != nil {
+		r.onBalanceChange(account.ID)
+	}
+	return nil
+}
+
+func (r *accountRepository) UpdateOverdraftLimit(ctx context.Context, account *domain.Account) error {
+	query := `
+		UPDATE accounts
+		SET overdraft_limit = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, account.ID, account.OverdraftLimit)
+	if err != nil {
+		return err
+	}
+
+	if r.onBalanceChange != nil {
+		r.onBalanceChange(account.ID)
+	}
+	return nil
 }
\ No newline at end of file
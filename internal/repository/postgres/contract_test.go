@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/repository/repositorytest"
+)
+
+// TestRepositoryContract runs against the migrated database named by
+// TEST_DATABASE_URL.
+func TestRepositoryContract(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer db.Close()
+
+	repositorytest.Run(t, &repositorytest.Repositories{
+		Users:          NewUserRepository(db),
+		Accounts:       NewAccountRepository(db),
+		Transactions:   NewTransactionRepository(db),
+		UnitOfWork:     NewUnitOfWork(db),
+		Idempotency:    NewIdempotencyRepository(db),
+		AccountMembers: NewAccountMemberRepository(db),
+		Organizations:  NewOrganizationRepository(db),
+	})
+}
\ No newline at end of file
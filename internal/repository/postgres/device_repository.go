@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type deviceRepository struct {
+	db *sqlx.DB
+}
+
+func NewDeviceRepository(db *sqlx.DB) repository.DeviceRepository {
+	return &deviceRepository{db: db}
+}
+
+func (r *deviceRepository) Exists(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM user_devices WHERE user_id = $1 AND device_id = $2)`
+
+	err := r.db.GetContext(ctx, &exists, query, userID, deviceID)
+	return exists, err
+}
+
+func (r *deviceRepository) Touch(ctx context.Context, userID uuid.UUID, deviceID string) error {
+	query := `
+		INSERT INTO user_devices (user_id, device_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, device_id) DO UPDATE SET last_seen_at = CURRENT_TIMESTAMP`
+
+	_, err := r.db.ExecContext(ctx, query, userID, deviceID)
+	return err
+}
\ No newline at end of file
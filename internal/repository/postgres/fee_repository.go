@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type feeRepository struct {
+	db dbtx
+}
+
+func NewFeeRepository(db *sqlx.DB) repository.FeeRepository {
+	return &feeRepository{db: db}
+}
+
+func (r *feeRepository) GetSchedules(ctx context.Context) ([]*domain.FeeSchedule, error) {
+	var schedules []*domain.FeeSchedule
+	query := `SELECT * FROM fee_schedules ORDER BY created_at`
+
+	err := r.db.SelectContext(ctx, &schedules, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (r *feeRepository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*domain.FeeSchedule, error) {
+	var schedule domain.FeeSchedule
+	query := `SELECT * FROM fee_schedules WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &schedule, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *feeRepository) UpsertSchedule(ctx context.Context, schedule *domain.FeeSchedule) error {
+	query := `
+		INSERT INTO fee_schedules (fee_type, account_type, user_tier, fixed_amount, rate, min_amount, max_amount, threshold)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT ON CONSTRAINT fee_schedules_scope DO UPDATE SET
+			fixed_amount = EXCLUDED.fixed_amount,
+			rate = EXCLUDED.rate,
+			min_amount = EXCLUDED.min_amount,
+			max_amount = EXCLUDED.max_amount,
+			threshold = EXCLUDED.threshold,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		schedule.FeeType,
+		schedule.AccountType,
+		schedule.UserTier,
+		schedule.FixedAmount,
+		schedule.Rate,
+		schedule.MinAmount,
+		schedule.MaxAmount,
+		schedule.Threshold,
+	).Scan(&schedule.ID, &schedule.CreatedAt, &schedule.UpdatedAt)
+
+	return err
+}
+
+func (r *feeRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM fee_schedules WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *feeRepository) CreateWaiver(ctx context.Context, waiver *domain.FeeWaiver) error {
+	query := `
+		INSERT INTO fee_waivers (account_id, fee_type, reason, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		waiver.AccountID,
+		waiver.FeeType,
+		waiver.Reason,
+		waiver.ExpiresAt,
+		waiver.CreatedBy,
+	).Scan(&waiver.ID, &waiver.CreatedAt)
+
+	return err
+}
+
+func (r *feeRepository) GetWaiverByID(ctx context.Context, id uuid.UUID) (*domain.FeeWaiver, error) {
+	var waiver domain.FeeWaiver
+	query := `SELECT * FROM fee_waivers WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &waiver, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &waiver, nil
+}
+
+func (r *feeRepository) GetWaiversByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.FeeWaiver, error) {
+	var waivers []*domain.FeeWaiver
+	query := `SELECT * FROM fee_waivers WHERE account_id = $1 ORDER BY created_at DESC`
+
+	err := r.db.SelectContext(ctx, &waivers, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return waivers, nil
+}
+
+func (r *feeRepository) DeleteWaiver(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM fee_waivers WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *feeRepository) GetCharge(ctx context.Context, accountID uuid.UUID, feeType domain.FeeType, period time.Time) (*domain.FeeCharge, error) {
+	var charge domain.FeeCharge
+	query := `SELECT * FROM fee_charges WHERE account_id = $1 AND fee_type = $2 AND period = $3`
+
+	err := r.db.GetContext(ctx, &charge, query, accountID, feeType, period)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &charge, nil
+}
+
+func (r *feeRepository) CreateCharge(ctx context.Context, charge *domain.FeeCharge) error {
+	query := `
+		INSERT INTO fee_charges (account_id, fee_type, period, amount, transaction_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		charge.AccountID,
+		charge.FeeType,
+		charge.Period,
+		charge.Amount,
+		charge.TransactionID,
+	).Scan(&charge.ID, &charge.CreatedAt)
+
+	return err
+}
\ No newline at end of file
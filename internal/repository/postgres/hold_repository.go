@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type holdRepository struct {
+	db dbtx
+}
+
+func NewHoldRepository(db *sqlx.DB) repository.HoldRepository {
+	return &holdRepository{db: db}
+}
+
+func (r *holdRepository) Create(ctx context.Context, hold *domain.Hold) error {
+	query := `
+		INSERT INTO holds (account_id, transaction_id, amount, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		hold.AccountID,
+		hold.TransactionID,
+		hold.Amount,
+		hold.Status,
+		hold.ExpiresAt,
+	).Scan(&hold.ID, &hold.CreatedAt)
+
+	return err
+}
+
+func (r *holdRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	return r.get(ctx, `SELECT * FROM holds WHERE id = $1`, id)
+}
+
+func (r *holdRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	return r.get(ctx, `SELECT * FROM holds WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *holdRepository) GetByTransactionIDForUpdate(ctx context.Context, transactionID uuid.UUID) (*domain.Hold, error) {
+	return r.get(ctx, `SELECT * FROM holds WHERE transaction_id = $1 FOR UPDATE`, transactionID)
+}
+
+func (r *holdRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.Hold, error) {
+	var hold domain.Hold
+	err := r.db.GetContext(ctx, &hold, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+func (r *holdRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	query := `SELECT * FROM holds WHERE account_id = $1 ORDER BY created_at DESC`
+
+	err := r.db.SelectContext(ctx, &holds, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *holdRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]*domain.Hold, error) {
+	var holds []*domain.Hold
+	query := `
+		SELECT * FROM holds
+		WHERE status = 'active' AND expires_at IS NOT NULL AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &holds, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *holdRepository) Update(ctx context.Context, hold *domain.Hold) error {
+	query := `
+		UPDATE holds
+		SET status = $2, released_at = $3
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, hold.ID, hold.Status, hold.ReleasedAt)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type idempotencyRepository struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+func (r *idempotencyRepository) GetByKey(ctx context.Context, key string, userID uuid.UUID) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	query := `SELECT * FROM idempotency_keys WHERE idempotency_key = $1 AND user_id = $2`
+
+	err := r.db.GetContext(ctx, &record, query, key, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *idempotencyRepository) Create(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (idempotency_key, user_id, request_path, request_hash, request_body, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, idempotency_key) DO NOTHING
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		record.IdempotencyKey,
+		record.UserID,
+		record.RequestPath,
+		record.RequestHash,
+		record.RequestBody,
+		record.Status,
+		record.ExpiresAt,
+	).Scan(&record.ID, &record.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = $3, response_status = $4, response_headers = $5, response_body = $6
+		WHERE idempotency_key = $1 AND user_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query,
+		record.IdempotencyKey,
+		record.UserID,
+		domain.IdempotencyStatusCompleted,
+		record.ResponseStatus,
+		record.ResponseHeaders,
+		record.ResponseBody,
+	)
+	return err
+}
+
+func (r *idempotencyRepository) Delete(ctx context.Context, key string, userID uuid.UUID) error {
+	query := `DELETE FROM idempotency_keys WHERE idempotency_key = $1 AND user_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, key, userID)
+	return err
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
\ No newline at end of file
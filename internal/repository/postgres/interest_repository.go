@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type interestRepository struct {
+	db dbtx
+}
+
+func NewInterestRepository(db *sqlx.DB) repository.InterestRepository {
+	return &interestRepository{db: db}
+}
+
+func (r *interestRepository) Create(ctx context.Context, accrual *domain.InterestAccrual) error {
+	query := `
+		INSERT INTO interest_accruals (account_id, accrual_date, balance, rate, amount)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		accrual.AccountID,
+		accrual.AccrualDate,
+		accrual.Balance,
+		accrual.Rate,
+		accrual.Amount,
+	).Scan(&accrual.ID, &accrual.CreatedAt)
+
+	return err
+}
+
+func (r *interestRepository) GetLatest(ctx context.Context, accountID uuid.UUID) (*domain.InterestAccrual, error) {
+	var accrual domain.InterestAccrual
+	query := `SELECT * FROM interest_accruals WHERE account_id = $1 ORDER BY accrual_date DESC LIMIT 1`
+
+	err := r.db.GetContext(ctx, &accrual, query, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &accrual, nil
+}
+
+func (r *interestRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.InterestAccrual, error) {
+	var accruals []*domain.InterestAccrual
+	query := `SELECT * FROM interest_accruals WHERE account_id = $1 ORDER BY accrual_date DESC LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &accruals, query, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return accruals, nil
+}
+
+func (r *interestRepository) GetUncapitalized(ctx context.Context, accountID uuid.UUID, before time.Time) ([]*domain.InterestAccrual, error) {
+	var accruals []*domain.InterestAccrual
+	query := `
+		SELECT * FROM interest_accruals
+		WHERE account_id = $1 AND capitalized_at IS NULL AND accrual_date < $2
+		ORDER BY accrual_date`
+
+	err := r.db.SelectContext(ctx, &accruals, query, accountID, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return accruals, nil
+}
+
+func (r *interestRepository) MarkCapitalized(ctx context.Context, ids []uuid.UUID, transactionID *uuid.UUID, at time.Time) error {
+	query := `
+		UPDATE interest_accruals
+		SET transaction_id = $2, capitalized_at = $3
+		WHERE id = ANY($1::uuid[])`
+
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(idStrings), transactionID, at)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type limitRepository struct {
+	db dbtx
+}
+
+func NewLimitRepository(db *sqlx.DB) repository.LimitRepository {
+	return &limitRepository{db: db}
+}
+
+func (r *limitRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountLimit, error) {
+	var limits []*domain.AccountLimit
+	query := `SELECT * FROM account_limits WHERE account_id = $1`
+
+	err := r.db.SelectContext(ctx, &limits, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func (r *limitRepository) Upsert(ctx context.Context, limit *domain.AccountLimit) error {
+	query := `
+		INSERT INTO account_limits (account_id, source, per_transaction, daily, monthly, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (account_id, source) DO UPDATE
+		SET per_transaction = EXCLUDED.per_transaction,
+		    daily = EXCLUDED.daily,
+		    monthly = EXCLUDED.monthly,
+		    reason = EXCLUDED.reason,
+		    updated_at = CURRENT_TIMESTAMP
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		limit.AccountID,
+		limit.Source,
+		limit.PerTransaction,
+		limit.Daily,
+		limit.Monthly,
+		limit.Reason,
+	).Scan(&limit.ID, &limit.CreatedAt, &limit.UpdatedAt)
+
+	return err
+}
+
+func (r *limitRepository) Delete(ctx context.Context, accountID uuid.UUID, source domain.LimitSource) error {
+	query := `DELETE FROM account_limits WHERE account_id = $1 AND source = $2`
+	_, err := r.db.ExecContext(ctx, query, accountID, source)
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type organizationRepository struct {
+	db dbtx
+}
+
+func NewOrganizationRepository(db *sqlx.DB) repository.OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	// The creator becomes an admin in the same statement
+	query := `
+		WITH organization AS (
+			INSERT INTO organizations (name, registration_number, created_by)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_by, created_at
+		), admin AS (
+			INSERT INTO organization_members (organization_id, user_id, role, created_at)
+			SELECT id, created_by, 'admin', created_at FROM organization
+		)
+		SELECT id, created_at FROM organization`
+
+	err := r.db.QueryRowContext(ctx, query,
+		organization.Name,
+		organization.RegistrationNumber,
+		organization.CreatedBy,
+	).Scan(&organization.ID, &organization.CreatedAt)
+
+	return err
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var organization domain.Organization
+	query := `SELECT * FROM organizations WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &organization, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (r *organizationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+	query := `
+		SELECT o.* FROM organizations o
+		JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name`
+
+	err := r.db.SelectContext(ctx, &organizations, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *domain.OrganizationMember) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, role, added_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		member.OrganizationID,
+		member.UserID,
+		member.Role,
+		member.AddedBy,
+	).Scan(&member.CreatedAt)
+
+	return err
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*domain.OrganizationMember, error) {
+	var member domain.OrganizationMember
+	query := `SELECT * FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	err := r.db.GetContext(ctx, &member, query, organizationID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *organizationRepository) GetMembers(ctx context.Context, organizationID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	var members []*domain.OrganizationMember
+	query := `SELECT * FROM organization_members WHERE organization_id = $1 ORDER BY created_at`
+
+	err := r.db.SelectContext(ctx, &members, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *domain.OrganizationMember) error {
+	query := `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, member.OrganizationID, member.UserID, member.Role)
+	return err
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, organizationID, userID)
+	return err
+}
+
+func (r *organizationRepository) GetApprovalRules(ctx context.Context, organizationID uuid.UUID) ([]*domain.ApprovalRule, error) {
+	var rules []*domain.ApprovalRule
+	query := `SELECT * FROM organization_approval_rules WHERE organization_id = $1 ORDER BY min_amount`
+
+	err := r.db.SelectContext(ctx, &rules, query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *organizationRepository) SetApprovalRules(ctx context.Context, organizationID uuid.UUID, rules []*domain.ApprovalRule) error {
+	amounts := make([]string, len(rules))
+	approvals := make([]int64, len(rules))
+	for i, rule := range rules {
+		amounts[i] = rule.MinAmount.String()
+		approvals[i] = int64(rule.RequiredApprovals)
+	}
+
+	// One statement, so the rules are never seen half replaced. The rows
+	// deleted and upserted are disjoint.
+	query := `
+		WITH removed AS (
+			DELETE FROM organization_approval_rules
+			WHERE organization_id = $1 AND NOT (min_amount = ANY($2::decimal[]))
+		)
+		INSERT INTO organization_approval_rules (organization_id, min_amount, required_approvals)
+		SELECT $1, rule.min_amount, rule.required_approvals
+		FROM unnest($2::decimal[], $3::integer[]) AS rule(min_amount, required_approvals)
+		ON CONFLICT (organization_id, min_amount) DO UPDATE
+		SET required_approvals = EXCLUDED.required_approvals`
+
+	_, err := r.db.ExecContext(ctx, query, organizationID, pq.Array(amounts), pq.Array(approvals))
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type payeeRepository struct {
+	db *sqlx.DB
+}
+
+func NewPayeeRepository(db *sqlx.DB) repository.PayeeRepository {
+	return &payeeRepository{db: db}
+}
+
+func (r *payeeRepository) Create(ctx context.Context, payee *domain.Payee) error {
+	query := `
+		INSERT INTO payees (user_id, account_id, account_number, nickname, masked_holder_name, verification_status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		payee.UserID,
+		payee.AccountID,
+		payee.AccountNumber,
+		payee.Nickname,
+		payee.MaskedHolderName,
+		payee.VerificationStatus,
+	).Scan(&payee.ID, &payee.CreatedAt, &payee.UpdatedAt)
+
+	return err
+}
+
+func (r *payeeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Payee, error) {
+	var payee domain.Payee
+	query := `SELECT * FROM payees WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &payee, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &payee, nil
+}
+
+func (r *payeeRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Payee, error) {
+	var payees []*domain.Payee
+	query := `SELECT * FROM payees WHERE user_id = $1 ORDER BY nickname`
+
+	err := r.db.SelectContext(ctx, &payees, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payees, nil
+}
+
+func (r *payeeRepository) GetByUserAndAccount(ctx context.Context, userID, accountID uuid.UUID) (*domain.Payee, error) {
+	var payee domain.Payee
+	query := `SELECT * FROM payees WHERE user_id = $1 AND account_id = $2`
+
+	err := r.db.GetContext(ctx, &payee, query, userID, accountID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &payee, nil
+}
+
+func (r *payeeRepository) Update(ctx context.Context, payee *domain.Payee) error {
+	query := `
+		UPDATE payees
+		SET nickname = $2, masked_holder_name = $3, verification_status = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		payee.ID,
+		payee.Nickname,
+		payee.MaskedHolderName,
+		payee.VerificationStatus,
+	)
+
+	return err
+}
+
+func (r *payeeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payees WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
\ No newline at end of file
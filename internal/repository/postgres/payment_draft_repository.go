@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type paymentDraftRepository struct {
+	db dbtx
+}
+
+func NewPaymentDraftRepository(db *sqlx.DB) repository.PaymentDraftRepository {
+	return &paymentDraftRepository{db: db}
+}
+
+func (r *paymentDraftRepository) Create(ctx context.Context, draft *domain.PaymentDraft) error {
+	query := `
+		INSERT INTO payment_drafts (organization_id, account_id, created_by, to_account_id, payee_id, amount, description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, required_approvals, approved_by, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		draft.OrganizationID,
+		draft.AccountID,
+		draft.CreatedBy,
+		draft.ToAccountID,
+		draft.PayeeID,
+		draft.Amount,
+		draft.Description,
+	).Scan(&draft.ID, &draft.Status, &draft.RequiredApprovals, &draft.ApprovedBy, &draft.CreatedAt, &draft.UpdatedAt)
+
+	return err
+}
+
+func (r *paymentDraftRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error) {
+	return r.get(ctx, `SELECT * FROM payment_drafts WHERE id = $1`, id)
+}
+
+func (r *paymentDraftRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.PaymentDraft, error) {
+	return r.get(ctx, `SELECT * FROM payment_drafts WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *paymentDraftRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.PaymentDraft, error) {
+	var draft domain.PaymentDraft
+	err := r.db.GetContext(ctx, &draft, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (r *paymentDraftRepository) GetByOrganizationID(ctx context.Context, organizationID uuid.UUID, limit int) ([]*domain.PaymentDraft, error) {
+	var drafts []*domain.PaymentDraft
+	query := `SELECT * FROM payment_drafts WHERE organization_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &drafts, query, organizationID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
+
+func (r *paymentDraftRepository) Update(ctx context.Context, draft *domain.PaymentDraft) error {
+	query := `
+		UPDATE payment_drafts
+		SET status = $2, required_approvals = $3, approved_by = $4, transaction_id = $5, failure = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		draft.ID,
+		draft.Status,
+		draft.RequiredApprovals,
+		draft.ApprovedBy,
+		draft.TransactionID,
+		draft.Failure,
+	).Scan(&draft.UpdatedAt)
+}
+
+func (r *paymentDraftRepository) CreateEvent(ctx context.Context, event *domain.PaymentDraftEvent) error {
+	query := `
+		INSERT INTO payment_draft_events (draft_id, user_id, action, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		event.DraftID,
+		event.UserID,
+		event.Action,
+		event.Note,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+func (r *paymentDraftRepository) GetEvents(ctx context.Context, draftID uuid.UUID) ([]*domain.PaymentDraftEvent, error) {
+	var events []*domain.PaymentDraftEvent
+	query := `SELECT * FROM payment_draft_events WHERE draft_id = $1 ORDER BY created_at, id`
+
+	err := r.db.SelectContext(ctx, &events, query, draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type pocketRepository struct {
+	db dbtx
+}
+
+func NewPocketRepository(db *sqlx.DB) repository.PocketRepository {
+	return &pocketRepository{db: db}
+}
+
+func (r *pocketRepository) Create(ctx context.Context, pocket *domain.Pocket) error {
+	query := `
+		INSERT INTO account_pockets (account_id, currency, balance, held_balance)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		pocket.AccountID,
+		pocket.Currency,
+		pocket.Balance,
+		pocket.HeldBalance,
+	).Scan(&pocket.CreatedAt, &pocket.UpdatedAt)
+}
+
+func (r *pocketRepository) Get(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	return r.get(ctx, `SELECT * FROM account_pockets WHERE account_id = $1 AND currency = $2`, accountID, currency)
+}
+
+func (r *pocketRepository) GetForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	return r.get(ctx, `SELECT * FROM account_pockets WHERE account_id = $1 AND currency = $2 FOR UPDATE`, accountID, currency)
+}
+
+func (r *pocketRepository) get(ctx context.Context, query string, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	var pocket domain.Pocket
+	err := r.db.GetContext(ctx, &pocket, query, accountID, currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &pocket, nil
+}
+
+func (r *pocketRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pocket, error) {
+	var pockets []*domain.Pocket
+	query := `SELECT * FROM account_pockets WHERE account_id = $1 ORDER BY currency`
+
+	err := r.db.SelectContext(ctx, &pockets, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pockets, nil
+}
+
+func (r *pocketRepository) UpdateBalances(ctx context.Context, pocket *domain.Pocket) error {
+	query := `
+		UPDATE account_pockets
+		SET balance = $3, held_balance = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE account_id = $1 AND currency = $2
+		RETURNING updated_at`
+
+	return r.db.QueryRowContext(ctx, query, pocket.AccountID, pocket.Currency, pocket.Balance, pocket.HeldBalance).Scan(&pocket.UpdatedAt)
+}
+
+func (r *pocketRepository) Delete(ctx context.Context, accountID uuid.UUID, currency string) error {
+	query := `DELETE FROM account_pockets WHERE account_id = $1 AND currency = $2`
+	_, err := r.db.ExecContext(ctx, query, accountID, currency)
+	return err
+}
\ No newline at end of file
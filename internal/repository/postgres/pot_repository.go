@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type potRepository struct {
+	db dbtx
+}
+
+func NewPotRepository(db *sqlx.DB) repository.PotRepository {
+	return &potRepository{db: db}
+}
+
+func (r *potRepository) Create(ctx context.Context, pot *domain.Pot) error {
+	query := `
+		INSERT INTO pots (account_id, name, target_amount, target_date, round_up, sweep_percent)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, balance, created_at, updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		pot.AccountID,
+		pot.Name,
+		pot.TargetAmount,
+		pot.TargetDate,
+		pot.RoundUp,
+		pot.SweepPercent,
+	).Scan(&pot.ID, &pot.Balance, &pot.CreatedAt, &pot.UpdatedAt)
+}
+
+func (r *potRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	return r.get(ctx, `SELECT * FROM pots WHERE id = $1`, id)
+}
+
+func (r *potRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	return r.get(ctx, `SELECT * FROM pots WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *potRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.Pot, error) {
+	var pot domain.Pot
+	err := r.db.GetContext(ctx, &pot, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &pot, nil
+}
+
+func (r *potRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pot, error) {
+	var pots []*domain.Pot
+	query := `SELECT * FROM pots WHERE account_id = $1 ORDER BY created_at, id`
+
+	err := r.db.SelectContext(ctx, &pots, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pots, nil
+}
+
+func (r *potRepository) Update(ctx context.Context, pot *domain.Pot) error {
+	query := `
+		UPDATE pots
+		SET name = $2, target_amount = $3, target_date = $4, round_up = $5, sweep_percent = $6,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		pot.ID,
+		pot.Name,
+		pot.TargetAmount,
+		pot.TargetDate,
+		pot.RoundUp,
+		pot.SweepPercent,
+	).Scan(&pot.UpdatedAt)
+}
+
+func (r *potRepository) UpdateBalance(ctx context.Context, pot *domain.Pot) error {
+	query := `
+		UPDATE pots
+		SET balance = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	return r.db.QueryRowContext(ctx, query, pot.ID, pot.Balance).Scan(&pot.UpdatedAt)
+}
+
+func (r *potRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM pots WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *potRepository) CreateMovement(ctx context.Context, movement *domain.PotMovement) error {
+	query := `
+		INSERT INTO pot_movements (pot_id, kind, amount, transaction_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	return r.db.QueryRowContext(ctx, query,
+		movement.PotID,
+		movement.Kind,
+		movement.Amount,
+		movement.TransactionID,
+	).Scan(&movement.ID, &movement.CreatedAt)
+}
+
+func (r *potRepository) GetMovements(ctx context.Context, potID uuid.UUID, limit int) ([]*domain.PotMovement, error) {
+	var movements []*domain.PotMovement
+	query := `SELECT * FROM pot_movements WHERE pot_id = $1 ORDER BY created_at DESC, id LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &movements, query, potID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
\ No newline at end of file
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+// RetryPolicy controls how often a unit of work is re-run after Postgres
+// aborts it with a serialization failure or a deadlock.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    500 * time.Millisecond,
+}
+
+// backoff returns a random delay between zero and an exponentially growing
+// cap ("full jitter"), so transactions that collided don't collide again.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.BaseDelay << uint(attempt)
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// retry runs fn until it succeeds, fails with a non-retryable error or runs
+// out of attempts.
+func (p RetryPolicy) retry(ctx context.Context, fn func() error) error {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(p.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err = fn()
+		if !isRetryable(err) {
+			return err
+		}
+	}
+
+	log.Printf("Giving up on unit of work after %d attempts: %v", attempts, err)
+	return repository.ErrConflict
+}
+
+// isRetryable reports whether Postgres aborted the transaction only because of
+// a concurrent one (SQLSTATE 40001 serialization_failure or 40P01
+// deadlock_detected); re-running it from the start is then safe.
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "40001" || pqErr.Code == "40P01"
+}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+func TestRetry(t *testing.T) {
+	serialization := &pq.Error{Code: "40001"}
+	deadlock := &pq.Error{Code: "40P01"}
+	uniqueViolation := &pq.Error{Code: "23505"}
+	errOther := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{"succeeds first time", []error{nil}, nil, 1},
+		{"retries serialization failure", []error{serialization, nil}, nil, 2},
+		{"retries deadlock", []error{deadlock, deadlock, nil}, nil, 3},
+		{"retries wrapped error", []error{fmt.Errorf("update balance: %w", serialization), nil}, nil, 2},
+		{"returns other pq errors", []error{uniqueViolation}, uniqueViolation, 1},
+		{"returns other errors", []error{errOther}, errOther, 1},
+		{"gives up", []error{serialization, deadlock, serialization}, repository.ErrConflict, 3},
+	}
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Millisecond}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := policy.retry(context.Background(), func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+			if err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("%d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	calls := 0
+	err := policy.retry(ctx, func() error {
+		calls++
+		cancel()
+		return &pq.Error{Code: "40001"}
+	})
+	if err != context.Canceled {
+		t.Errorf("error %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+}
+
+func TestBackoffStaysUnderCap(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for attempt := 0; attempt < 64; attempt++ {
+		if d := policy.backoff(attempt); d < 0 || d >= policy.MaxDelay {
+			t.Fatalf("backoff(%d) = %s, want [0, %s)", attempt, d, policy.MaxDelay)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type reviewRepository struct {
+	db dbtx
+}
+
+func NewReviewRepository(db *sqlx.DB) repository.ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *domain.TransactionReview) error {
+	query := `
+		INSERT INTO transaction_reviews (transaction_id, reasons, status)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		review.TransactionID,
+		review.Reasons,
+		review.Status,
+	).Scan(&review.ID, &review.CreatedAt)
+
+	return err
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error) {
+	var review domain.TransactionReview
+	query := `SELECT * FROM transaction_reviews WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error) {
+	var review domain.TransactionReview
+	query := `SELECT * FROM transaction_reviews WHERE id = $1 FOR UPDATE`
+
+	err := r.db.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepository) GetPending(ctx context.Context, limit, offset int) ([]*domain.TransactionReview, error) {
+	var reviews []*domain.TransactionReview
+	query := `
+		SELECT * FROM transaction_reviews
+		WHERE status = 'pending'
+		ORDER BY created_at
+		LIMIT $1 OFFSET $2`
+
+	err := r.db.SelectContext(ctx, &reviews, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *domain.TransactionReview) error {
+	query := `
+		UPDATE transaction_reviews
+		SET status = $2, reviewed_by = $3, note = $4, reviewed_at = $5
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		review.ID,
+		review.Status,
+		review.ReviewedBy,
+		review.Note,
+		review.ReviewedAt,
+	)
+
+	return err
+}
\ No newline at end of file
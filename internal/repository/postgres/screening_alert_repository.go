@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type screeningAlertRepository struct {
+	db *sqlx.DB
+}
+
+func NewScreeningAlertRepository(db *sqlx.DB) repository.ScreeningAlertRepository {
+	return &screeningAlertRepository{db: db}
+}
+
+func (r *screeningAlertRepository) Create(ctx context.Context, alert *domain.ScreeningAlert) error {
+	query := `
+		INSERT INTO screening_alerts (subject, subject_id, screened_name, decision, matches, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		alert.Subject,
+		alert.SubjectID,
+		alert.ScreenedName,
+		alert.Decision,
+		alert.Matches,
+		alert.Status,
+	).Scan(&alert.ID, &alert.CreatedAt)
+
+	return err
+}
+
+func (r *screeningAlertRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ScreeningAlert, error) {
+	var alert domain.ScreeningAlert
+	query := `SELECT * FROM screening_alerts WHERE id = $1`
+
+	err := r.db.GetContext(ctx, &alert, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+func (r *screeningAlertRepository) GetPending(ctx context.Context, limit, offset int) ([]*domain.ScreeningAlert, error) {
+	var alerts []*domain.ScreeningAlert
+	query := `
+		SELECT * FROM screening_alerts
+		WHERE status = 'pending'
+		ORDER BY created_at
+		LIMIT $1 OFFSET $2`
+
+	err := r.db.SelectContext(ctx, &alerts, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (r *screeningAlertRepository) Update(ctx context.Context, alert *domain.ScreeningAlert) error {
+	query := `
+		UPDATE screening_alerts
+		SET status = $2, reviewed_by = $3, note = $4, reviewed_at = $5
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		alert.ID,
+		alert.Status,
+		alert.ReviewedBy,
+		alert.Note,
+		alert.ReviewedAt,
+	)
+
+	return err
+}
\ No newline at end of file
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type termDepositRepository struct {
+	db dbtx
+}
+
+func NewTermDepositRepository(db *sqlx.DB) repository.TermDepositRepository {
+	return &termDepositRepository{db: db}
+}
+
+func (r *termDepositRepository) Create(ctx context.Context, deposit *domain.TermDeposit) error {
+	query := `
+		INSERT INTO term_deposits (user_id, account_id, payout_account_id, principal, rate, term_months,
+			start_date, maturity_date, maturity_value, maturity_instruction)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, status, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		deposit.UserID,
+		deposit.AccountID,
+		deposit.PayoutAccountID,
+		deposit.Principal,
+		deposit.Rate,
+		deposit.TermMonths,
+		deposit.StartDate,
+		deposit.MaturityDate,
+		deposit.MaturityValue,
+		deposit.Instruction,
+	).Scan(&deposit.ID, &deposit.Status, &deposit.CreatedAt)
+
+	return err
+}
+
+func (r *termDepositRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error) {
+	return r.get(ctx, `SELECT * FROM term_deposits WHERE id = $1`, id)
+}
+
+func (r *termDepositRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error) {
+	return r.get(ctx, `SELECT * FROM term_deposits WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *termDepositRepository) GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) (*domain.TermDeposit, error) {
+	return r.get(ctx, `SELECT * FROM term_deposits WHERE account_id = $1 AND status = 'active'`, accountID)
+}
+
+func (r *termDepositRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.TermDeposit, error) {
+	var deposit domain.TermDeposit
+	err := r.db.GetContext(ctx, &deposit, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &deposit, nil
+}
+
+func (r *termDepositRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.TermDeposit, error) {
+	var deposits []*domain.TermDeposit
+	query := `SELECT * FROM term_deposits WHERE user_id = $1 ORDER BY created_at DESC`
+
+	err := r.db.SelectContext(ctx, &deposits, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+func (r *termDepositRepository) GetActiveByPayoutAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.TermDeposit, error) {
+	var deposits []*domain.TermDeposit
+	query := `SELECT * FROM term_deposits WHERE payout_account_id = $1 AND status = 'active'`
+
+	err := r.db.SelectContext(ctx, &deposits, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+func (r *termDepositRepository) GetMatured(ctx context.Context, date time.Time, limit int) ([]*domain.TermDeposit, error) {
+	var deposits []*domain.TermDeposit
+	query := `
+		SELECT * FROM term_deposits
+		WHERE status = 'active' AND maturity_date <= $1
+		ORDER BY maturity_date
+		LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &deposits, query, date, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return deposits, nil
+}
+
+func (r *termDepositRepository) UpdateStatus(ctx context.Context, deposit *domain.TermDeposit) error {
+	query := `
+		UPDATE term_deposits
+		SET status = $2, closed_at = $3
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, deposit.ID, deposit.Status, deposit.ClosedAt)
+	return err
+}
\ No newline at end of file
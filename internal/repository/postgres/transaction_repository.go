@@ -4,15 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
 )
 
 type transactionRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewTransactionRepository(db *sqlx.DB) repository.TransactionRepository {
@@ -21,8 +23,8 @@ func NewTransactionRepository(db *sqlx.DB) repository.TransactionRepository {
 
 func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
 	query := `
-		INSERT INTO transactions (from_account_id, to_account_id, amount, currency, type, status, reference, description)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO transactions (from_account_id, to_account_id, amount, currency, value, type, status, reference, description, metadata, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, created_at`
 
 	err := r.db.QueryRowContext(ctx, query,
@@ -30,10 +32,13 @@ func (r *transactionRepository) Create(ctx context.Context, tx *domain.Transacti
 		tx.ToAccountID,
 		tx.Amount,
 		tx.Currency,
+		tx.Value,
 		tx.Type,
 		tx.Status,
 		tx.Reference,
 		tx.Description,
+		tx.Metadata,
+		tx.CompletedAt,
 	).Scan(&tx.ID, &tx.CreatedAt)
 
 	return err
@@ -54,6 +59,21 @@ func (r *transactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return &tx, nil
 }
 
+func (r *transactionRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	var tx domain.Transaction
+	query := `SELECT * FROM transactions WHERE id = $1 FOR UPDATE`
+
+	err := r.db.GetContext(ctx, &tx, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
 func (r *transactionRepository) GetByReference(ctx context.Context, reference string) (*domain.Transaction, error) {
 	var tx domain.Transaction
 	query := `SELECT * FROM transactions WHERE reference = $1`
@@ -97,9 +117,32 @@ func (r *transactionRepository) GetByAccountID(ctx context.Context, accountID uu
 func (r *transactionRepository) Update(ctx context.Context, tx *domain.Transaction) error {
 	query := `
 		UPDATE transactions 
-		SET status = $2, completed_at = $3
+		SET amount = $2, status = $3, completed_at = $4
 		WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, tx.ID, tx.Status, tx.CompletedAt)
+	_, err := r.db.ExecContext(ctx, query, tx.ID, tx.Amount, tx.Status, tx.CompletedAt)
 	return err
+}
+
+func (r *transactionRepository) GetOutflowSummary(ctx context.Context, accountIDs []uuid.UUID, since time.Time) (*domain.OutflowSummary, error) {
+	var summary domain.OutflowSummary
+	query := `
+		SELECT COUNT(*) AS count, COALESCE(SUM(COALESCE(value, amount)), 0) AS total
+		FROM transactions
+		WHERE from_account_id = ANY($1::uuid[])
+		  AND status IN ('pending', 'completed')
+		  AND type NOT IN ('fee', 'withholding_tax', 'conversion')
+		  AND created_at >= $2`
+
+	ids := make([]string, len(accountIDs))
+	for i, id := range accountIDs {
+		ids[i] = id.String()
+	}
+
+	err := r.db.GetContext(ctx, &summary, query, pq.Array(ids), since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
 }
\ No newline at end of file
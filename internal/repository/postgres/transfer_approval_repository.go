@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+type transferApprovalRepository struct {
+	db dbtx
+}
+
+func NewTransferApprovalRepository(db *sqlx.DB) repository.TransferApprovalRepository {
+	return &transferApprovalRepository{db: db}
+}
+
+func (r *transferApprovalRepository) Create(ctx context.Context, approval *domain.TransferApproval) error {
+	query := `
+		INSERT INTO transfer_approvals (account_id, requested_by, to_account_id, payee_id, amount, currency,
+			description, device_id, required_approvals, approved_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, status, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		approval.AccountID,
+		approval.RequestedBy,
+		approval.ToAccountID,
+		approval.PayeeID,
+		approval.Amount,
+		approval.Currency,
+		approval.Description,
+		approval.DeviceID,
+		approval.RequiredApprovals,
+		approval.ApprovedBy,
+	).Scan(&approval.ID, &approval.Status, &approval.CreatedAt)
+
+	return err
+}
+
+func (r *transferApprovalRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error) {
+	return r.get(ctx, `SELECT * FROM transfer_approvals WHERE id = $1`, id)
+}
+
+func (r *transferApprovalRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error) {
+	return r.get(ctx, `SELECT * FROM transfer_approvals WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *transferApprovalRepository) get(ctx context.Context, query string, id uuid.UUID) (*domain.TransferApproval, error) {
+	var approval domain.TransferApproval
+	err := r.db.GetContext(ctx, &approval, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &approval, nil
+}
+
+func (r *transferApprovalRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.TransferApproval, error) {
+	var approvals []*domain.TransferApproval
+	query := `SELECT * FROM transfer_approvals WHERE account_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	err := r.db.SelectContext(ctx, &approvals, query, accountID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func (r *transferApprovalRepository) Update(ctx context.Context, approval *domain.TransferApproval) error {
+	query := `
+		UPDATE transfer_approvals
+		SET approved_by = $2, status = $3, rejected_by = $4, transaction_id = $5, failure = $6, decided_at = $7
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query,
+		approval.ID,
+		approval.ApprovedBy,
+		approval.Status,
+		approval.RejectedBy,
+		approval.TransactionID,
+		approval.Failure,
+		approval.DecidedAt,
+	)
+	return err
+}
\ No newline at end of file
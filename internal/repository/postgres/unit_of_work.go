@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+// dbtx is satisfied by both *sqlx.DB and *sqlx.Tx, so the same repository
+// code runs standalone or inside a unit of work.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// UnitOfWork runs work in serializable transactions, re-running it when
+// Postgres aborts it in favour of a concurrent one, and after each commit
+// tells its hooks which accounts had their balances or status changed.
+type UnitOfWork struct {
+	db              *sqlx.DB
+	retryPolicy     RetryPolicy
+	accountsChanged []repository.AccountsChangedFunc
+}
+
+func NewUnitOfWork(db *sqlx.DB) *UnitOfWork {
+	return &UnitOfWork{db: db, retryPolicy: DefaultRetryPolicy}
+}
+
+// SetRetryPolicy replaces DefaultRetryPolicy. Like hooks, it must be set
+// before the unit of work is shared between goroutines.
+func (u *UnitOfWork) SetRetryPolicy(policy RetryPolicy) {
+	u.retryPolicy = policy
+}
+
+// OnAccountsChanged registers an after-commit hook. Hooks must be registered
+// before the unit of work is shared between goroutines.
+func (u *UnitOfWork) OnAccountsChanged(fn repository.AccountsChangedFunc) {
+	u.accountsChanged = append(u.accountsChanged, fn)
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	var changes *changeSet
+	err := u.retryPolicy.retry(ctx, func() error {
+		var err error
+		changes, err = u.attempt(ctx, fn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The money has already moved, so hook failures are only logged
+	if len(changes.ids) > 0 {
+		for _, hook := range u.accountsChanged {
+			if err := hook(ctx, changes.ids...); err != nil {
+				log.Printf("After-commit hook failed for accounts %v: %v", changes.ids, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// attempt runs fn once in a fresh transaction and commits it, returning the
+// accounts whose balances or status it changed.
+func (u *UnitOfWork) attempt(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) (*changeSet, error) {
+	tx, err := u.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	changes := newChangeSet()
+	repos := &repository.Repositories{
+		Accounts:          &accountRepository{db: tx, onBalanceChange: changes.add},
+		Transactions:      &transactionRepository{db: tx},
+		Holds:             &holdRepository{db: tx},
+		Reviews:           &reviewRepository{db: tx},
+		Interest:          &interestRepository{db: tx},
+		TermDeposits:      &termDepositRepository{db: tx},
+		Fees:              &feeRepository{db: tx},
+		TransferApprovals: &transferApprovalRepository{db: tx},
+		PaymentDrafts:     &paymentDraftRepository{db: tx},
+		Pots:              &potRepository{db: tx},
+		Pockets:           &pocketRepository{db: tx},
+		AccountMembers:    &accountMemberRepository{db: tx},
+		Users:             &userRepository{db: tx},
+		Limits:            &limitRepository{db: tx},
+	}
+
+	if err := fn(ctx, repos); err != nil {
+		return nil, err
+	}
+
+	// Serialization failures can also surface here, at commit time
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+type changeSet struct {
+	ids  []uuid.UUID
+	seen map[uuid.UUID]bool
+}
+
+func newChangeSet() *changeSet {
+	return &changeSet{seen: make(map[uuid.UUID]bool)}
+}
+
+func (c *changeSet) add(id uuid.UUID) {
+	if !c.seen[id] {
+		c.seen[id] = true
+		c.ids = append(c.ids, id)
+	}
+}
\ No newline at end of file
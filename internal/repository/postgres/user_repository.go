@@ -12,7 +12,7 @@ import (
 )
 
 type userRepository struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func NewUserRepository(db *sqlx.DB) repository.UserRepository {
@@ -21,8 +21,8 @@ func NewUserRepository(db *sqlx.DB) repository.UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (email, password_hash, full_name, phone, profile_image_url)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (email, password_hash, full_name, phone, profile_image_url, tier, role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id, created_at, updated_at`
 
 	err := r.db.QueryRowContext(ctx, query,
@@ -31,6 +31,8 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		user.FullName,
 		user.Phone,
 		user.ProfileImageURL,
+		user.Tier,
+		user.Role,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	return err
@@ -89,4 +91,15 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
 	_, err := r.db.ExecContext(ctx, query, id)
 	return err
+}
+
+func (r *userRepository) Anonymize(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET email = $2, password_hash = '', full_name = $3, phone = NULL,
+		    profile_image_url = NULL, is_verified = FALSE, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, id, domain.DeletedUserEmail(id), domain.DeletedUserName)
+	return err
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type PotRepository interface {
+	Create(ctx context.Context, pot *domain.Pot) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Pot, error)
+	// GetByIDForUpdate locks the pot until the unit of work ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Pot, error)
+	// GetByAccountID lists the account's pots, oldest first.
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Pot, error)
+	// Update saves the pot's name, target and rules.
+	Update(ctx context.Context, pot *domain.Pot) error
+	UpdateBalance(ctx context.Context, pot *domain.Pot) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	CreateMovement(ctx context.Context, movement *domain.PotMovement) error
+	// GetMovements lists the pot's movements, newest first.
+	GetMovements(ctx context.Context, potID uuid.UUID, limit int) ([]*domain.PotMovement, error)
+}
\ No newline at end of file
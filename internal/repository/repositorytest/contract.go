@@ -0,0 +1,882 @@
+// Package repositorytest is a contract suite for repository implementations.
+// The in-memory repositories the use case tests run on pass the same suite
+// as the Postgres ones, so what holds against one holds against the other.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// Repositories is the set of implementations under test. They must share one
+// store, and UnitOfWork must commit to it. Idempotency, AccountMembers and
+// Organizations are optional.
+type Repositories struct {
+	Users          repository.UserRepository
+	Accounts       repository.AccountRepository
+	Transactions   repository.TransactionRepository
+	UnitOfWork     repository.UnitOfWork
+	Idempotency    repository.IdempotencyRepository
+	AccountMembers repository.AccountMemberRepository
+	Organizations  repository.OrganizationRepository
+}
+
+// Run runs the contract. The store may be shared with other tests, so every
+// row the suite creates is unique and nothing assumes an empty table.
+func Run(t *testing.T, repos *Repositories) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, repos) })
+	t.Run("Accounts", func(t *testing.T) { testAccounts(t, repos) })
+	t.Run("Transactions", func(t *testing.T) { testTransactions(t, repos) })
+	t.Run("UnitOfWork", func(t *testing.T) { testUnitOfWork(t, repos) })
+	if repos.Idempotency != nil {
+		t.Run("Idempotency", func(t *testing.T) { testIdempotency(t, repos) })
+	}
+	if repos.AccountMembers != nil {
+		t.Run("AccountMembers", func(t *testing.T) { testAccountMembers(t, repos) })
+	}
+	if repos.Organizations != nil {
+		t.Run("Organizations", func(t *testing.T) { testOrganizations(t, repos) })
+	}
+}
+
+func testUsers(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		user := newUser(t, repos)
+		if user.ID == uuid.Nil || user.CreatedAt.IsZero() {
+			t.Fatalf("Create left ID %s, CreatedAt %s", user.ID, user.CreatedAt)
+		}
+
+		byID, err := repos.Users.GetByID(ctx, user.ID)
+		if err != nil || byID == nil || byID.Email != user.Email {
+			t.Errorf("GetByID = %+v, %v", byID, err)
+		}
+		byEmail, err := repos.Users.GetByEmail(ctx, user.Email)
+		if err != nil || byEmail == nil || byEmail.ID != user.ID {
+			t.Errorf("GetByEmail = %+v, %v", byEmail, err)
+		}
+	})
+
+	t.Run("missing is nil", func(t *testing.T) {
+		byID, err := repos.Users.GetByID(ctx, uuid.New())
+		if byID != nil || err != nil {
+			t.Errorf("GetByID = %+v, %v; want nil, nil", byID, err)
+		}
+		byEmail, err := repos.Users.GetByEmail(ctx, uniqueEmail())
+		if byEmail != nil || err != nil {
+			t.Errorf("GetByEmail = %+v, %v; want nil, nil", byEmail, err)
+		}
+	})
+
+	t.Run("duplicate email", func(t *testing.T) {
+		user := newUser(t, repos)
+		duplicate := &domain.User{Email: user.Email, PasswordHash: "x", FullName: "Someone Else", Tier: domain.UserTierStandard, Role: domain.UserRoleCustomer}
+		if err := repos.Users.Create(ctx, duplicate); err == nil {
+			t.Error("Create with a taken email succeeded")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		user := newUser(t, repos)
+		phone := "+15550100"
+		user.FullName = "Renamed User"
+		user.Phone = &phone
+		user.PasswordHash = "changed"
+		if err := repos.Users.Update(ctx, user); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got, _ := repos.Users.GetByID(ctx, user.ID)
+		if got.FullName != "Renamed User" || got.Phone == nil || *got.Phone != phone {
+			t.Errorf("Update not stored: %+v", got)
+		}
+		if got.PasswordHash != "x" {
+			t.Errorf("Update changed the password hash to %q", got.PasswordHash)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		user := newUser(t, repos)
+		if err := repos.Users.Delete(ctx, user.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if got, _ := repos.Users.GetByID(ctx, user.ID); got != nil {
+			t.Errorf("GetByID after Delete = %+v", got)
+		}
+	})
+
+	t.Run("anonymize", func(t *testing.T) {
+		user := newUser(t, repos)
+		if err := repos.Users.Anonymize(ctx, user.ID); err != nil {
+			t.Fatalf("Anonymize: %v", err)
+		}
+
+		got, _ := repos.Users.GetByID(ctx, user.ID)
+		if got == nil || got.Email != domain.DeletedUserEmail(user.ID) || got.FullName != domain.DeletedUserName || got.PasswordHash != "" {
+			t.Errorf("GetByID after Anonymize = %+v", got)
+		}
+		// The address is free to register again
+		if byEmail, _ := repos.Users.GetByEmail(ctx, user.Email); byEmail != nil {
+			t.Errorf("GetByEmail(%s) after Anonymize = %+v", user.Email, byEmail)
+		}
+	})
+}
+
+func testAccounts(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.NewFromInt(25))
+		if account.ID == uuid.Nil || account.CreatedAt.IsZero() {
+			t.Fatalf("Create left ID %s, CreatedAt %s", account.ID, account.CreatedAt)
+		}
+
+		byID, err := repos.Accounts.GetByID(ctx, account.ID)
+		if err != nil || byID == nil || !byID.Balance.Equal(decimal.NewFromInt(25)) || !byID.HeldBalance.IsZero() {
+			t.Errorf("GetByID = %+v, %v", byID, err)
+		}
+		byNumber, err := repos.Accounts.GetByAccountNumber(ctx, account.AccountNumber)
+		if err != nil || byNumber == nil || byNumber.ID != account.ID {
+			t.Errorf("GetByAccountNumber = %+v, %v", byNumber, err)
+		}
+	})
+
+	t.Run("missing is nil", func(t *testing.T) {
+		byID, err := repos.Accounts.GetByID(ctx, uuid.New())
+		if byID != nil || err != nil {
+			t.Errorf("GetByID = %+v, %v; want nil, nil", byID, err)
+		}
+		byNumber, err := repos.Accounts.GetByAccountNumber(ctx, uniqueAccountNumber())
+		if byNumber != nil || err != nil {
+			t.Errorf("GetByAccountNumber = %+v, %v; want nil, nil", byNumber, err)
+		}
+	})
+
+	t.Run("duplicate account number", func(t *testing.T) {
+		user := newUser(t, repos)
+		account := newAccount(t, repos, user.ID, decimal.Zero)
+		duplicate := &domain.Account{UserID: user.ID, AccountNumber: account.AccountNumber, AccountType: domain.AccountTypeSavings, Currency: "USD", Status: domain.AccountStatusActive}
+		if err := repos.Accounts.Create(ctx, duplicate); !errors.Is(err, repository.ErrAccountNumberTaken) {
+			t.Errorf("Create with a taken account number: error %v, want %v", err, repository.ErrAccountNumberTaken)
+		}
+	})
+
+	t.Run("account number sequence", func(t *testing.T) {
+		first, err := repos.Accounts.NextAccountNumber(ctx)
+		if err != nil {
+			t.Fatalf("NextAccountNumber: %v", err)
+		}
+		second, err := repos.Accounts.NextAccountNumber(ctx)
+		if err != nil || second <= first {
+			t.Errorf("NextAccountNumber after %d = %d, %v; want more", first, second, err)
+		}
+	})
+
+	t.Run("get by user newest first", func(t *testing.T) {
+		user := newUser(t, repos)
+		older := newAccount(t, repos, user.ID, decimal.Zero)
+		time.Sleep(2 * time.Millisecond)
+		newer := newAccount(t, repos, user.ID, decimal.Zero)
+		newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+
+		accounts, err := repos.Accounts.GetByUserID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByUserID: %v", err)
+		}
+		if len(accounts) != 2 || accounts[0].ID != newer.ID || accounts[1].ID != older.ID {
+			t.Errorf("GetByUserID = %v, want [%s %s]", accountIDs(accounts), newer.ID, older.ID)
+		}
+	})
+
+	t.Run("update leaves balances and status alone", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.NewFromInt(25))
+		account.AccountType = domain.AccountTypeSavings
+		account.Status = domain.AccountStatusFrozen
+		account.Balance = decimal.NewFromInt(1000)
+		if err := repos.Accounts.Update(ctx, account); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got, _ := repos.Accounts.GetByID(ctx, account.ID)
+		if got.AccountType != domain.AccountTypeSavings {
+			t.Errorf("account type %s, want %s", got.AccountType, domain.AccountTypeSavings)
+		}
+		if got.Status != domain.AccountStatusActive {
+			t.Errorf("Update wrote status %s", got.Status)
+		}
+		if !got.Balance.Equal(decimal.NewFromInt(25)) {
+			t.Errorf("Update wrote balance %s", got.Balance)
+		}
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		changedAt := time.Now().UTC().Truncate(time.Second)
+		account.Status = domain.AccountStatusClosed
+		account.StatusChangedAt = &changedAt
+		if err := repos.Accounts.UpdateStatus(ctx, account); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		got, _ := repos.Accounts.GetByID(ctx, account.ID)
+		if got.Status != domain.AccountStatusClosed || got.StatusChangedAt == nil || !got.StatusChangedAt.Equal(changedAt) {
+			t.Errorf("status %s changed at %v, want %s at %v", got.Status, got.StatusChangedAt, domain.AccountStatusClosed, changedAt)
+		}
+	})
+
+	t.Run("update balances", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.NewFromInt(25))
+		account.Balance = decimal.NewFromInt(40)
+		account.HeldBalance = decimal.NewFromInt(15)
+		if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+			t.Fatalf("UpdateBalances: %v", err)
+		}
+
+		got, _ := repos.Accounts.GetByID(ctx, account.ID)
+		if !got.Balance.Equal(decimal.NewFromInt(40)) || !got.HeldBalance.Equal(decimal.NewFromInt(15)) {
+			t.Errorf("balance %s held %s, want 40 held 15", got.Balance, got.HeldBalance)
+		}
+	})
+
+	t.Run("balances can't go negative", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			balance, held int64
+		}{
+			{"negative balance", -1, 0},
+			{"negative held", 10, -1},
+			{"held above balance", 10, 11},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				account := newAccount(t, repos, newUser(t, repos).ID, decimal.NewFromInt(10))
+				account.Balance = decimal.NewFromInt(tt.balance)
+				account.HeldBalance = decimal.NewFromInt(tt.held)
+				if err := repos.Accounts.UpdateBalances(ctx, account); err == nil {
+					t.Error("UpdateBalances succeeded")
+				}
+			})
+		}
+	})
+
+	t.Run("overdraft", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		account.OverdraftLimit = decimal.NewFromInt(50)
+		if err := repos.Accounts.UpdateOverdraftLimit(ctx, account); err != nil {
+			t.Fatalf("UpdateOverdraftLimit: %v", err)
+		}
+
+		account.Balance = decimal.NewFromInt(-40)
+		account.HeldBalance = decimal.NewFromInt(10)
+		if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+			t.Fatalf("UpdateBalances within the overdraft: %v", err)
+		}
+		got, _ := repos.Accounts.GetByID(ctx, account.ID)
+		if !got.Balance.Equal(decimal.NewFromInt(-40)) || !got.OverdraftLimit.Equal(decimal.NewFromInt(50)) {
+			t.Errorf("balance %s overdraft %s, want -40 overdraft 50", got.Balance, got.OverdraftLimit)
+		}
+
+		account.HeldBalance = decimal.NewFromInt(11)
+		if err := repos.Accounts.UpdateBalances(ctx, account); err == nil {
+			t.Error("UpdateBalances past the overdraft succeeded")
+		}
+		account.HeldBalance = decimal.NewFromInt(10)
+		account.OverdraftLimit = decimal.NewFromInt(49)
+		if err := repos.Accounts.UpdateOverdraftLimit(ctx, account); err == nil {
+			t.Error("UpdateOverdraftLimit below what is drawn succeeded")
+		}
+
+		savings := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		savings.AccountType = domain.AccountTypeSavings
+		if err := repos.Accounts.Update(ctx, savings); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		savings.OverdraftLimit = decimal.NewFromInt(50)
+		if err := repos.Accounts.UpdateOverdraftLimit(ctx, savings); err == nil {
+			t.Error("UpdateOverdraftLimit on a savings account succeeded")
+		}
+	})
+
+	t.Run("dormant", func(t *testing.T) {
+		idle, spender := newAccountPair(t, repos)
+		received := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		paidIn := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		frozen := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+		frozen.Status = domain.AccountStatusFrozen
+		if err := repos.Accounts.UpdateStatus(ctx, frozen); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		since := time.Now()
+		// Money in counts as well as money out, e.g. a customer topping up
+		// their savings from their own checking account
+		newTransfer(t, repos, spender, received, 10, domain.TransactionStatusCompleted)
+		deposit := &domain.Transaction{
+			ToAccountID: &paidIn.ID,
+			Amount:      decimal.NewFromInt(10),
+			Currency:    "USD",
+			Type:        domain.TransactionTypeDeposit,
+			Status:      domain.TransactionStatusCompleted,
+			Reference:   uniqueReference(),
+		}
+		if err := repos.Transactions.Create(ctx, deposit); err != nil {
+			t.Fatalf("create deposit: %v", err)
+		}
+
+		dormant := make(map[uuid.UUID]bool)
+		after := uuid.Nil
+		for {
+			accounts, err := repos.Accounts.GetDormant(ctx, since, after, 100)
+			if err != nil {
+				t.Fatalf("GetDormant: %v", err)
+			}
+			for _, account := range accounts {
+				dormant[account.ID] = true
+			}
+			if len(accounts) < 100 {
+				break
+			}
+			after = accounts[len(accounts)-1].ID
+		}
+		if !dormant[idle.ID] {
+			t.Error("GetDormant missed the idle account")
+		}
+		for name, account := range map[string]*domain.Account{"spender": spender, "received": received, "paid in": paidIn, "frozen": frozen} {
+			if dormant[account.ID] {
+				t.Errorf("GetDormant returned the %s account", name)
+			}
+		}
+	})
+}
+
+func testTransactions(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("create and get", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		tx := newTransfer(t, repos, from, to, 10, domain.TransactionStatusCompleted)
+		if tx.ID == uuid.Nil || tx.CreatedAt.IsZero() {
+			t.Fatalf("Create left ID %s, CreatedAt %s", tx.ID, tx.CreatedAt)
+		}
+
+		byID, err := repos.Transactions.GetByID(ctx, tx.ID)
+		if err != nil || byID == nil || byID.Reference != tx.Reference || !byID.Amount.Equal(decimal.NewFromInt(10)) {
+			t.Errorf("GetByID = %+v, %v", byID, err)
+		}
+		byReference, err := repos.Transactions.GetByReference(ctx, tx.Reference)
+		if err != nil || byReference == nil || byReference.ID != tx.ID {
+			t.Errorf("GetByReference = %+v, %v", byReference, err)
+		}
+		if missing, err := repos.Transactions.GetByID(ctx, uuid.New()); missing != nil || err != nil {
+			t.Errorf("GetByID of a missing transaction = %+v, %v; want nil, nil", missing, err)
+		}
+	})
+
+	t.Run("rejects invalid rows", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		existing := newTransfer(t, repos, from, to, 10, domain.TransactionStatusCompleted)
+
+		tests := []struct {
+			name string
+			tx   *domain.Transaction
+		}{
+			{"duplicate reference", &domain.Transaction{FromAccountID: &from.ID, ToAccountID: &to.ID, Amount: decimal.NewFromInt(1), Currency: "USD", Type: domain.TransactionTypeTransfer, Status: domain.TransactionStatusCompleted, Reference: existing.Reference}},
+			{"zero amount", &domain.Transaction{FromAccountID: &from.ID, ToAccountID: &to.ID, Amount: decimal.Zero, Currency: "USD", Type: domain.TransactionTypeTransfer, Status: domain.TransactionStatusCompleted, Reference: uniqueReference()}},
+			{"transfer to itself", &domain.Transaction{FromAccountID: &from.ID, ToAccountID: &from.ID, Amount: decimal.NewFromInt(1), Currency: "USD", Type: domain.TransactionTypeTransfer, Status: domain.TransactionStatusCompleted, Reference: uniqueReference()}},
+			{"deposit with a source", &domain.Transaction{FromAccountID: &from.ID, ToAccountID: &to.ID, Amount: decimal.NewFromInt(1), Currency: "USD", Type: domain.TransactionTypeDeposit, Status: domain.TransactionStatusCompleted, Reference: uniqueReference()}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := repos.Transactions.Create(ctx, tt.tx); err == nil {
+					t.Error("Create succeeded")
+				}
+			})
+		}
+	})
+
+	t.Run("get by account newest first", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		var created []*domain.Transaction
+		for i := 1; i <= 3; i++ {
+			created = append(created, newTransfer(t, repos, from, to, int64(i), domain.TransactionStatusCompleted))
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		for _, account := range []*domain.Account{from, to} {
+			all, err := repos.Transactions.GetByAccountID(ctx, account.ID, nil)
+			if err != nil {
+				t.Fatalf("GetByAccountID: %v", err)
+			}
+			if len(all) != 3 || all[0].ID != created[2].ID || all[2].ID != created[0].ID {
+				t.Errorf("account %s: got %d transactions, want 3 newest first", account.ID, len(all))
+			}
+		}
+
+		page, err := repos.Transactions.GetByAccountID(ctx, from.ID, &domain.TransactionFilter{Limit: 1, Offset: 1})
+		if err != nil {
+			t.Fatalf("GetByAccountID: %v", err)
+		}
+		if len(page) != 1 || page[0].ID != created[1].ID {
+			t.Errorf("second page = %d transactions, want just %s", len(page), created[1].ID)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		tx := newTransfer(t, repos, from, to, 10, domain.TransactionStatusPending)
+
+		completedAt := time.Now().UTC().Truncate(time.Second)
+		tx.Amount = decimal.NewFromInt(7)
+		tx.Status = domain.TransactionStatusCompleted
+		tx.CompletedAt = &completedAt
+		if err := repos.Transactions.Update(ctx, tx); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got, _ := repos.Transactions.GetByID(ctx, tx.ID)
+		if !got.Amount.Equal(decimal.NewFromInt(7)) || got.Status != domain.TransactionStatusCompleted {
+			t.Errorf("amount %s status %s, want 7 completed", got.Amount, got.Status)
+		}
+		if got.CompletedAt == nil || !got.CompletedAt.Equal(completedAt) {
+			t.Errorf("completed_at %v, want %s", got.CompletedAt, completedAt)
+		}
+	})
+
+	t.Run("outflow summary", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		since := time.Now().Add(-time.Minute)
+		newTransfer(t, repos, from, to, 10, domain.TransactionStatusCompleted)
+		newTransfer(t, repos, from, to, 5, domain.TransactionStatusPending)
+		newTransfer(t, repos, from, to, 100, domain.TransactionStatusFailed)
+		newTransfer(t, repos, to, from, 1000, domain.TransactionStatusCompleted)
+
+		tests := []struct {
+			name      string
+			ids       []uuid.UUID
+			since     time.Time
+			wantCount int
+			wantTotal int64
+		}{
+			{"pending and completed debits", []uuid.UUID{from.ID}, since, 2, 15},
+			{"several accounts", []uuid.UUID{from.ID, to.ID}, since, 3, 1015},
+			{"nothing since", []uuid.UUID{from.ID}, time.Now().Add(time.Minute), 0, 0},
+			{"no accounts", nil, since, 0, 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				summary, err := repos.Transactions.GetOutflowSummary(ctx, tt.ids, tt.since)
+				if err != nil {
+					t.Fatalf("GetOutflowSummary: %v", err)
+				}
+				if summary.Count != tt.wantCount || !summary.Total.Equal(decimal.NewFromInt(tt.wantTotal)) {
+					t.Errorf("got %d totalling %s, want %d totalling %d", summary.Count, summary.Total, tt.wantCount, tt.wantTotal)
+				}
+			})
+		}
+	})
+}
+
+func testUnitOfWork(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("commits", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		var tx *domain.Transaction
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos *repository.Repositories) error {
+			tx = transfer(from, to, 10, domain.TransactionStatusCompleted)
+			if err := txRepos.Transactions.Create(ctx, tx); err != nil {
+				return err
+			}
+			return moveMoney(ctx, txRepos, from.ID, to.ID, 10)
+		})
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+
+		assertBalance(t, repos, from.ID, 90)
+		assertBalance(t, repos, to.ID, 10)
+		if got, _ := repos.Transactions.GetByID(ctx, tx.ID); got == nil {
+			t.Error("committed transaction missing")
+		}
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		errBoom := errors.New("boom")
+		var tx *domain.Transaction
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos *repository.Repositories) error {
+			tx = transfer(from, to, 10, domain.TransactionStatusCompleted)
+			if err := txRepos.Transactions.Create(ctx, tx); err != nil {
+				return err
+			}
+			if err := moveMoney(ctx, txRepos, from.ID, to.ID, 10); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("Do error %v, want %v", err, errBoom)
+		}
+
+		assertBalance(t, repos, from.ID, 100)
+		assertBalance(t, repos, to.ID, 0)
+		if got, _ := repos.Transactions.GetByID(ctx, tx.ID); got != nil {
+			t.Error("rolled back transaction was stored")
+		}
+	})
+
+	t.Run("rolls back on a constraint violation", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos *repository.Repositories) error {
+			if err := txRepos.Transactions.Create(ctx, transfer(from, to, 150, domain.TransactionStatusCompleted)); err != nil {
+				return err
+			}
+			// Overdraws the sender
+			return moveMoney(ctx, txRepos, from.ID, to.ID, 150)
+		})
+		if err == nil {
+			t.Fatal("Do succeeded")
+		}
+
+		assertBalance(t, repos, from.ID, 100)
+		assertBalance(t, repos, to.ID, 0)
+	})
+
+	t.Run("sees its own writes", func(t *testing.T) {
+		from, to := newAccountPair(t, repos)
+		err := repos.UnitOfWork.Do(ctx, func(ctx context.Context, txRepos *repository.Repositories) error {
+			for i := 0; i < 3; i++ {
+				if err := moveMoney(ctx, txRepos, from.ID, to.ID, 10); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+
+		assertBalance(t, repos, from.ID, 70)
+		assertBalance(t, repos, to.ID, 30)
+	})
+}
+
+func testAccountMembers(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("holder is the owner", func(t *testing.T) {
+		user := newUser(t, repos)
+		account := newAccount(t, repos, user.ID, decimal.Zero)
+
+		owner, err := repos.AccountMembers.Get(ctx, account.ID, user.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if owner == nil || owner.Role != domain.AccountRoleOwner || owner.Status != domain.MemberStatusActive {
+			t.Errorf("Get = %+v, want the active owner", owner)
+		}
+		if missing, err := repos.AccountMembers.Get(ctx, account.ID, uuid.New()); err != nil || missing != nil {
+			t.Errorf("Get missing = %v, %v; want nil, nil", missing, err)
+		}
+		second := &domain.AccountMember{AccountID: account.ID, UserID: newUser(t, repos).ID, Role: domain.AccountRoleOwner, Status: domain.MemberStatusActive}
+		if err := repos.AccountMembers.Create(ctx, second); err == nil {
+			t.Error("Create of a second owner succeeded")
+		}
+	})
+
+	t.Run("accounts are listed once accepted", func(t *testing.T) {
+		holder := newUser(t, repos)
+		account := newAccount(t, repos, holder.ID, decimal.Zero)
+		user := newUser(t, repos)
+
+		member := &domain.AccountMember{AccountID: account.ID, UserID: user.ID, Role: domain.AccountRoleSignatory, Status: domain.MemberStatusInvited, InvitedBy: &holder.ID}
+		if err := repos.AccountMembers.Create(ctx, member); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repos.AccountMembers.Create(ctx, member); err == nil {
+			t.Error("Create of an existing member succeeded")
+		}
+		invitations, err := repos.AccountMembers.GetInvitations(ctx, user.ID)
+		if err != nil || len(invitations) != 1 || invitations[0].AccountID != account.ID {
+			t.Errorf("GetInvitations = %v, %v; want the invitation", invitations, err)
+		}
+		if accounts, err := repos.Accounts.GetByUserID(ctx, user.ID); err != nil || len(accounts) != 0 {
+			t.Errorf("GetByUserID before accepting = %v, %v; want none", accountIDs(accounts), err)
+		}
+
+		now := time.Now()
+		member.Status = domain.MemberStatusActive
+		member.AcceptedAt = &now
+		if err := repos.AccountMembers.Update(ctx, member); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if accounts, err := repos.Accounts.GetByUserID(ctx, user.ID); err != nil || len(accounts) != 1 || accounts[0].ID != account.ID {
+			t.Errorf("GetByUserID after accepting = %v, %v; want [%s]", accountIDs(accounts), err, account.ID)
+		}
+		if members, err := repos.AccountMembers.GetByAccountID(ctx, account.ID); err != nil || len(members) != 2 {
+			t.Errorf("GetByAccountID = %d members, %v; want 2", len(members), err)
+		}
+
+		if err := repos.AccountMembers.Delete(ctx, account.ID, user.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if accounts, err := repos.Accounts.GetByUserID(ctx, user.ID); err != nil || len(accounts) != 0 {
+			t.Errorf("GetByUserID after removal = %v, %v; want none", accountIDs(accounts), err)
+		}
+	})
+
+	t.Run("approval policy", func(t *testing.T) {
+		account := newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+
+		policy := &domain.ApprovalPolicy{AccountID: account.ID, Threshold: decimal.NewFromInt(100), RequiredApprovals: 2}
+		if err := repos.AccountMembers.SetApprovalPolicy(ctx, policy); err != nil {
+			t.Fatalf("SetApprovalPolicy: %v", err)
+		}
+		policy.RequiredApprovals = 3
+		if err := repos.AccountMembers.SetApprovalPolicy(ctx, policy); err != nil {
+			t.Fatalf("SetApprovalPolicy again: %v", err)
+		}
+		got, err := repos.AccountMembers.GetApprovalPolicy(ctx, account.ID)
+		if err != nil || got == nil || got.RequiredApprovals != 3 || !got.Threshold.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("GetApprovalPolicy = %+v, %v; want 3 approvals above 100", got, err)
+		}
+
+		invalid := &domain.ApprovalPolicy{AccountID: account.ID, RequiredApprovals: 1}
+		if err := repos.AccountMembers.SetApprovalPolicy(ctx, invalid); err == nil {
+			t.Error("SetApprovalPolicy with one approval succeeded")
+		}
+
+		if err := repos.AccountMembers.DeleteApprovalPolicy(ctx, account.ID); err != nil {
+			t.Fatalf("DeleteApprovalPolicy: %v", err)
+		}
+		if got, err := repos.AccountMembers.GetApprovalPolicy(ctx, account.ID); err != nil || got != nil {
+			t.Errorf("GetApprovalPolicy after delete = %+v, %v; want nil", got, err)
+		}
+	})
+}
+
+func testOrganizations(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+
+	t.Run("creator is an admin", func(t *testing.T) {
+		creator := newUser(t, repos)
+		organization := &domain.Organization{Name: "Contract Ltd", CreatedBy: creator.ID}
+		if err := repos.Organizations.Create(ctx, organization); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if organization.ID == uuid.Nil || organization.CreatedAt.IsZero() {
+			t.Fatalf("Create left ID %s, CreatedAt %s", organization.ID, organization.CreatedAt)
+		}
+
+		admin, err := repos.Organizations.GetMember(ctx, organization.ID, creator.ID)
+		if err != nil || admin == nil || admin.Role != domain.OrganizationRoleAdmin {
+			t.Errorf("GetMember = %+v, %v; want the creator as admin", admin, err)
+		}
+		if missing, err := repos.Organizations.GetMember(ctx, organization.ID, uuid.New()); err != nil || missing != nil {
+			t.Errorf("GetMember missing = %v, %v; want nil, nil", missing, err)
+		}
+
+		maker := newUser(t, repos)
+		member := &domain.OrganizationMember{OrganizationID: organization.ID, UserID: maker.ID, Role: domain.OrganizationRoleMaker, AddedBy: &creator.ID}
+		if err := repos.Organizations.AddMember(ctx, member); err != nil {
+			t.Fatalf("AddMember: %v", err)
+		}
+		if err := repos.Organizations.AddMember(ctx, member); err == nil {
+			t.Error("AddMember of an existing member succeeded")
+		}
+		if organizations, err := repos.Organizations.GetByUserID(ctx, maker.ID); err != nil || len(organizations) != 1 || organizations[0].ID != organization.ID {
+			t.Errorf("GetByUserID = %v, %v; want the organization", organizations, err)
+		}
+
+		if err := repos.Organizations.RemoveMember(ctx, organization.ID, maker.ID); err != nil {
+			t.Fatalf("RemoveMember: %v", err)
+		}
+		if organizations, err := repos.Organizations.GetByUserID(ctx, maker.ID); err != nil || len(organizations) != 0 {
+			t.Errorf("GetByUserID after removal = %v, %v; want none", organizations, err)
+		}
+	})
+
+	t.Run("business accounts", func(t *testing.T) {
+		creator := newUser(t, repos)
+		organization := &domain.Organization{Name: "Contract Ltd", CreatedBy: creator.ID}
+		if err := repos.Organizations.Create(ctx, organization); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		account := &domain.Account{
+			UserID:         creator.ID,
+			OrganizationID: &organization.ID,
+			AccountNumber:  uniqueAccountNumber(),
+			AccountType:    domain.AccountTypeChecking,
+			Balance:        decimal.Zero,
+			Currency:       "USD",
+			Status:         domain.AccountStatusActive,
+		}
+		if err := repos.Accounts.Create(ctx, account); err != nil {
+			t.Fatalf("create account: %v", err)
+		}
+		newAccount(t, repos, creator.ID, decimal.Zero)
+
+		accounts, err := repos.Accounts.GetByOrganizationID(ctx, organization.ID)
+		if err != nil || len(accounts) != 1 || accounts[0].ID != account.ID {
+			t.Fatalf("GetByOrganizationID = %v, %v; want [%s]", accountIDs(accounts), err, account.ID)
+		}
+		if accounts[0].OrganizationID == nil || *accounts[0].OrganizationID != organization.ID {
+			t.Errorf("OrganizationID = %v, want %s", accounts[0].OrganizationID, organization.ID)
+		}
+	})
+
+	t.Run("approval rules are replaced", func(t *testing.T) {
+		organization := &domain.Organization{Name: "Contract Ltd", CreatedBy: newUser(t, repos).ID}
+		if err := repos.Organizations.Create(ctx, organization); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+
+		rule := func(minAmount int64, approvals int) *domain.ApprovalRule {
+			return &domain.ApprovalRule{OrganizationID: organization.ID, MinAmount: decimal.NewFromInt(minAmount), RequiredApprovals: approvals}
+		}
+		if err := repos.Organizations.SetApprovalRules(ctx, organization.ID, []*domain.ApprovalRule{rule(1000, 2), rule(0, 1)}); err != nil {
+			t.Fatalf("SetApprovalRules: %v", err)
+		}
+		if err := repos.Organizations.SetApprovalRules(ctx, organization.ID, []*domain.ApprovalRule{rule(1000, 3), rule(5000, 4)}); err != nil {
+			t.Fatalf("SetApprovalRules again: %v", err)
+		}
+
+		rules, err := repos.Organizations.GetApprovalRules(ctx, organization.ID)
+		if err != nil || len(rules) != 2 {
+			t.Fatalf("GetApprovalRules = %d rules, %v; want 2", len(rules), err)
+		}
+		if !rules[0].MinAmount.Equal(decimal.NewFromInt(1000)) || rules[0].RequiredApprovals != 3 || rules[1].RequiredApprovals != 4 {
+			t.Errorf("GetApprovalRules = %+v, %+v; want 3 from 1000 and 4 from 5000", rules[0], rules[1])
+		}
+
+		if err := repos.Organizations.SetApprovalRules(ctx, organization.ID, nil); err != nil {
+			t.Fatalf("SetApprovalRules with none: %v", err)
+		}
+		if rules, err := repos.Organizations.GetApprovalRules(ctx, organization.ID); err != nil || len(rules) != 0 {
+			t.Errorf("GetApprovalRules after clearing = %d rules, %v; want none", len(rules), err)
+		}
+	})
+}
+
+func newUser(t *testing.T, repos *Repositories) *domain.User {
+	t.Helper()
+
+	user := &domain.User{
+		Email:        uniqueEmail(),
+		PasswordHash: "x",
+		FullName:     "Contract User",
+		Tier:         domain.UserTierStandard,
+		Role:         domain.UserRoleCustomer,
+	}
+	if err := repos.Users.Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+func newAccount(t *testing.T, repos *Repositories, userID uuid.UUID, balance decimal.Decimal) *domain.Account {
+	t.Helper()
+
+	account := &domain.Account{
+		UserID:        userID,
+		AccountNumber: uniqueAccountNumber(),
+		AccountType:   domain.AccountTypeChecking,
+		Balance:       balance,
+		Currency:      "USD",
+		Status:        domain.AccountStatusActive,
+	}
+	if err := repos.Accounts.Create(context.Background(), account); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	return account
+}
+
+// newAccountPair creates a sender holding 100 and an empty recipient.
+func newAccountPair(t *testing.T, repos *Repositories) (*domain.Account, *domain.Account) {
+	t.Helper()
+	return newAccount(t, repos, newUser(t, repos).ID, decimal.NewFromInt(100)),
+		newAccount(t, repos, newUser(t, repos).ID, decimal.Zero)
+}
+
+func transfer(from, to *domain.Account, amount int64, status domain.TransactionStatus) *domain.Transaction {
+	return &domain.Transaction{
+		FromAccountID: &from.ID,
+		ToAccountID:   &to.ID,
+		Amount:        decimal.NewFromInt(amount),
+		Currency:      "USD",
+		Type:          domain.TransactionTypeTransfer,
+		Status:        status,
+		Reference:     uniqueReference(),
+	}
+}
+
+func newTransfer(t *testing.T, repos *Repositories, from, to *domain.Account, amount int64, status domain.TransactionStatus) *domain.Transaction {
+	t.Helper()
+
+	tx := transfer(from, to, amount, status)
+	if err := repos.Transactions.Create(context.Background(), tx); err != nil {
+		t.Fatalf("create transaction: %v", err)
+	}
+	return tx
+}
+
+func moveMoney(ctx context.Context, repos *repository.Repositories, fromID, toID uuid.UUID, amount int64) error {
+	from, err := repos.Accounts.GetByIDForUpdate(ctx, fromID)
+	if err != nil {
+		return err
+	}
+	to, err := repos.Accounts.GetByIDForUpdate(ctx, toID)
+	if err != nil {
+		return err
+	}
+
+	from.Balance = from.Balance.Sub(decimal.NewFromInt(amount))
+	to.Balance = to.Balance.Add(decimal.NewFromInt(amount))
+	if err := repos.Accounts.UpdateBalances(ctx, from); err != nil {
+		return err
+	}
+	return repos.Accounts.UpdateBalances(ctx, to)
+}
+
+func assertBalance(t *testing.T, repos *Repositories, id uuid.UUID, want int64) {
+	t.Helper()
+
+	account, err := repos.Accounts.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !account.Balance.Equal(decimal.NewFromInt(want)) {
+		t.Errorf("account %s: balance %s, want %d", id, account.Balance, want)
+	}
+}
+
+func accountIDs(accounts []*domain.Account) []uuid.UUID {
+	ids := make([]uuid.UUID, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.ID
+	}
+	return ids
+}
+
+func uniqueEmail() string {
+	return fmt.Sprintf("%s@example.com", uuid.NewString())
+}
+
+func uniqueAccountNumber() string {
+	return fmt.Sprintf("%010d", rand.Int63n(1e10))
+}
+
+func uniqueReference() string {
+	return "TEST" + uuid.NewString()
+}
\ No newline at end of file
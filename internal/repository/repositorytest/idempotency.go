@@ -0,0 +1,123 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+// RunIdempotency runs the idempotency part of the contract on its own, for
+// stores that keep nothing else. users only has to create the users that own
+// the keys.
+func RunIdempotency(t *testing.T, users repository.UserRepository, idempotency repository.IdempotencyRepository) {
+	testIdempotency(t, &Repositories{Users: users, Idempotency: idempotency})
+}
+
+func testIdempotency(t *testing.T, repos *Repositories) {
+	ctx := context.Background()
+	repo := repos.Idempotency
+
+	t.Run("reserve and complete", func(t *testing.T) {
+		record := newIdempotencyRecord(newUser(t, repos).ID, time.Hour)
+		created, err := repo.Create(ctx, record)
+		if err != nil || !created {
+			t.Fatalf("Create = %v, %v", created, err)
+		}
+
+		got, err := repo.GetByKey(ctx, record.IdempotencyKey, record.UserID)
+		if err != nil || got == nil {
+			t.Fatalf("GetByKey = %+v, %v", got, err)
+		}
+		if got.Status != domain.IdempotencyStatusInFlight || got.RequestHash != record.RequestHash || got.ResponseStatus != nil || got.CreatedAt.IsZero() {
+			t.Errorf("reserved record %+v", got)
+		}
+
+		status, body := 201, `{"id":"1"}`
+		record.ResponseStatus = &status
+		record.ResponseBody = &body
+		record.ResponseHeaders = domain.Headers{"Content-Type": "application/json"}
+		if err := repo.Complete(ctx, record); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+
+		got, _ = repo.GetByKey(ctx, record.IdempotencyKey, record.UserID)
+		if got.Status != domain.IdempotencyStatusCompleted || got.ResponseStatus == nil || *got.ResponseStatus != 201 ||
+			got.ResponseBody == nil || *got.ResponseBody != body || got.ResponseHeaders["Content-Type"] != "application/json" {
+			t.Errorf("completed record %+v", got)
+		}
+	})
+
+	t.Run("missing is nil", func(t *testing.T) {
+		got, err := repo.GetByKey(ctx, uuid.NewString(), uuid.New())
+		if got != nil || err != nil {
+			t.Errorf("GetByKey = %+v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("keys are unique per user", func(t *testing.T) {
+		record := newIdempotencyRecord(newUser(t, repos).ID, time.Hour)
+		if created, err := repo.Create(ctx, record); err != nil || !created {
+			t.Fatalf("Create = %v, %v", created, err)
+		}
+
+		again := newIdempotencyRecord(record.UserID, time.Hour)
+		again.IdempotencyKey = record.IdempotencyKey
+		if created, err := repo.Create(ctx, again); err != nil || created {
+			t.Errorf("second Create for the same user = %v, %v; want false, nil", created, err)
+		}
+
+		other := newIdempotencyRecord(newUser(t, repos).ID, time.Hour)
+		other.IdempotencyKey = record.IdempotencyKey
+		if created, err := repo.Create(ctx, other); err != nil || !created {
+			t.Errorf("Create for another user = %v, %v; want true, nil", created, err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		record := newIdempotencyRecord(newUser(t, repos).ID, time.Hour)
+		repo.Create(ctx, record)
+		if err := repo.Delete(ctx, record.IdempotencyKey, record.UserID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if got, _ := repo.GetByKey(ctx, record.IdempotencyKey, record.UserID); got != nil {
+			t.Errorf("GetByKey after Delete = %+v", got)
+		}
+		if created, err := repo.Create(ctx, record); err != nil || !created {
+			t.Errorf("Create after Delete = %v, %v; want true, nil", created, err)
+		}
+	})
+
+	t.Run("delete expired", func(t *testing.T) {
+		user := newUser(t, repos)
+		expired := newIdempotencyRecord(user.ID, -time.Minute)
+		live := newIdempotencyRecord(user.ID, time.Hour)
+		repo.Create(ctx, expired)
+		repo.Create(ctx, live)
+
+		if _, err := repo.DeleteExpired(ctx, time.Now()); err != nil {
+			t.Fatalf("DeleteExpired: %v", err)
+		}
+		if got, _ := repo.GetByKey(ctx, expired.IdempotencyKey, user.ID); got != nil {
+			t.Errorf("expired record still there: %+v", got)
+		}
+		if got, _ := repo.GetByKey(ctx, live.IdempotencyKey, user.ID); got == nil {
+			t.Error("DeleteExpired removed a live record")
+		}
+	})
+}
+
+func newIdempotencyRecord(userID uuid.UUID, ttl time.Duration) *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		IdempotencyKey: uuid.NewString(),
+		UserID:         userID,
+		RequestPath:    "/api/v1/transactions/transfer",
+		RequestHash:    uuid.NewString(),
+		RequestBody:    `{"amount":"1"}`,
+		Status:         domain.IdempotencyStatusInFlight,
+		ExpiresAt:      time.Now().Add(ttl),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *domain.TransactionReview) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransactionReview, error)
+	GetPending(ctx context.Context, limit, offset int) ([]*domain.TransactionReview, error)
+	Update(ctx context.Context, review *domain.TransactionReview) error
+}
\ No newline at end of file
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type ScreeningAlertRepository interface {
+	Create(ctx context.Context, alert *domain.ScreeningAlert) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ScreeningAlert, error)
+	GetPending(ctx context.Context, limit, offset int) ([]*domain.ScreeningAlert, error)
+	Update(ctx context.Context, alert *domain.ScreeningAlert) error
+}
\ No newline at end of file
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type TermDepositRepository interface {
+	Create(ctx context.Context, deposit *domain.TermDeposit) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error)
+	// GetByIDForUpdate locks the deposit until the unit of work ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TermDeposit, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.TermDeposit, error)
+	// GetActiveByAccountID returns the term running on the account, or nil.
+	GetActiveByAccountID(ctx context.Context, accountID uuid.UUID) (*domain.TermDeposit, error)
+	// GetActiveByPayoutAccountID lists the running terms that pay out to the
+	// account.
+	GetActiveByPayoutAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.TermDeposit, error)
+	// GetMatured lists active deposits maturing on or before date, soonest
+	// first.
+	GetMatured(ctx context.Context, date time.Time, limit int) ([]*domain.TermDeposit, error)
+	UpdateStatus(ctx context.Context, deposit *domain.TermDeposit) error
+}
\ No newline at end of file
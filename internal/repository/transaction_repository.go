@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
@@ -10,7 +11,13 @@ import (
 type TransactionRepository interface {
 	Create(ctx context.Context, tx *domain.Transaction) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
 	GetByReference(ctx context.Context, reference string) (*domain.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error)
 	Update(ctx context.Context, tx *domain.Transaction) error
+	// GetOutflowSummary counts pending and completed debits from any of the
+	// given accounts created at or after since. Fees and withholding tax the
+	// bank takes don't count, nor do conversions between an account's own
+	// currencies.
+	GetOutflowSummary(ctx context.Context, accountIDs []uuid.UUID, since time.Time) (*domain.OutflowSummary, error)
 }
\ No newline at end of file
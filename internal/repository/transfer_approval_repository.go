@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+type TransferApprovalRepository interface {
+	Create(ctx context.Context, approval *domain.TransferApproval) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error)
+	// GetByIDForUpdate locks the approval until the unit of work ends.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.TransferApproval, error)
+	// GetByAccountID lists the account's approvals, newest first.
+	GetByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]*domain.TransferApproval, error)
+	Update(ctx context.Context, approval *domain.TransferApproval) error
+}
\ No newline at end of file
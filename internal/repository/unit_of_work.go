@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+)
+
+// ErrConflict is returned by Do when the work kept colliding with concurrent
+// transactions and the unit of work gave up retrying it.
+var ErrConflict = apperror.Conflict("concurrent_update", "too much concurrent activity on these accounts, please retry")
+
+// Repositories are bound to a single unit of work: everything done through
+// them commits or rolls back together.
+type Repositories struct {
+	Accounts          AccountRepository
+	Transactions      TransactionRepository
+	Holds             HoldRepository
+	Reviews           ReviewRepository
+	Interest          InterestRepository
+	TermDeposits      TermDepositRepository
+	Fees              FeeRepository
+	TransferApprovals TransferApprovalRepository
+	PaymentDrafts     PaymentDraftRepository
+	Pots              PotRepository
+	Pockets           PocketRepository
+	AccountMembers    AccountMemberRepository
+	Users             UserRepository
+	Limits            LimitRepository
+}
+
+// UnitOfWork runs fn in one transaction. If fn returns an error the work is
+// rolled back and the error returned; otherwise it is committed. fn may be
+// run more than once, so it must not have side effects outside repos.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context, repos *Repositories) error) error
+}
+
+// AccountsChangedFunc is called after a unit of work that changed the given
+// accounts' balances or status has committed.
+type AccountsChangedFunc func(ctx context.Context, accountIDs ...uuid.UUID) error
\ No newline at end of file
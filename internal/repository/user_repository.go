@@ -13,4 +13,7 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// Anonymize erases a user's personal details and password but keeps the
+	// row for their closed accounts and transactions to refer to.
+	Anonymize(ctx context.Context, id uuid.UUID) error
 }
\ No newline at end of file
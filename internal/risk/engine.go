@@ -0,0 +1,102 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// Engine evaluates a transfer against the configured rules. The strictest
+// action among the rules it trips becomes the decision.
+type Engine struct {
+	rules           *Rules
+	transactionRepo repository.TransactionRepository
+	deviceRepo      repository.DeviceRepository
+}
+
+func NewEngine(rules *Rules, transactionRepo repository.TransactionRepository, deviceRepo repository.DeviceRepository) *Engine {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+	return &Engine{
+		rules:           rules,
+		transactionRepo: transactionRepo,
+		deviceRepo:      deviceRepo,
+	}
+}
+
+func (e *Engine) Evaluate(ctx context.Context, input *domain.RiskInput) (*domain.RiskAssessment, error) {
+	assessment := &domain.RiskAssessment{Decision: domain.RiskDecisionAllow}
+
+	trip := func(rule Rule, reason string) {
+		assessment.Reasons = append(assessment.Reasons, reason)
+		if rule.Action.Severity() > assessment.Decision.Severity() {
+			assessment.Decision = rule.Action
+		}
+	}
+
+	if r := e.rules.NewPayeeLargeAmount; r.Enabled && input.PayeeCreatedAt != nil {
+		newPayee := input.At.Sub(*input.PayeeCreatedAt) < time.Duration(r.PayeeAgeHours)*time.Hour
+		if newPayee && input.Amount.GreaterThanOrEqual(r.Amount) {
+			trip(r.Rule, fmt.Sprintf("large transfer of %s to a payee added less than %dh ago", input.Amount, r.PayeeAgeHours))
+		}
+	}
+
+	if r := e.rules.RapidSuccession; r.Enabled {
+		since := input.At.Add(-time.Duration(r.WindowMinutes) * time.Minute)
+		summary, err := e.transactionRepo.GetOutflowSummary(ctx, []uuid.UUID{input.FromAccount.ID}, since)
+		if err != nil {
+			return nil, err
+		}
+		if summary.Count >= r.MaxTransfers {
+			trip(r.Rule, fmt.Sprintf("%d transfers in the last %d minutes", summary.Count+1, r.WindowMinutes))
+		}
+	}
+
+	if r := e.rules.UnusualHour; r.Enabled {
+		location := r.location
+		if location == nil {
+			location = time.UTC
+		}
+		if inHourWindow(input.At.In(location).Hour(), r.StartHour, r.EndHour) {
+			trip(r.Rule, fmt.Sprintf("transfer made between %02d:00 and %02d:00 %s", r.StartHour, r.EndHour, location))
+		}
+	}
+
+	if r := e.rules.NearLimit; r.Enabled && input.PerTransactionLimit.IsPositive() {
+		threshold := input.PerTransactionLimit.Mul(decimal.NewFromInt(int64(r.ThresholdPercent))).Div(decimal.NewFromInt(100))
+		if input.Amount.GreaterThanOrEqual(threshold) {
+			trip(r.Rule, fmt.Sprintf("amount is at least %d%% of the per-transaction limit", r.ThresholdPercent))
+		}
+	}
+
+	if r := e.rules.NewDevice; r.Enabled {
+		if input.DeviceID == "" {
+			if r.TreatMissingAsNew {
+				trip(r.Rule, "transfer made without a device ID")
+			}
+		} else {
+			known, err := e.deviceRepo.Exists(ctx, input.UserID, input.DeviceID)
+			if err != nil {
+				return nil, err
+			}
+			if !known {
+				trip(r.Rule, "transfer made from a new device")
+			}
+		}
+	}
+
+	return assessment, nil
+}
+
+func inHourWindow(hour, start, end int) bool {
+	if start <= end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
\ No newline at end of file
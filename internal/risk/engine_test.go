@@ -0,0 +1,153 @@
+package risk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// stubOutflows reports the same number of recent transfers for any account.
+type stubOutflows struct {
+	repository.TransactionRepository
+	count int
+}
+
+func (s *stubOutflows) GetOutflowSummary(ctx context.Context, accountIDs []uuid.UUID, since time.Time) (*domain.OutflowSummary, error) {
+	return &domain.OutflowSummary{Count: s.count}, nil
+}
+
+// stubDevices knows the devices listed.
+type stubDevices map[string]bool
+
+func (s stubDevices) Exists(ctx context.Context, userID uuid.UUID, deviceID string) (bool, error) {
+	return s[deviceID], nil
+}
+
+func (s stubDevices) Touch(ctx context.Context, userID uuid.UUID, deviceID string) error {
+	s[deviceID] = true
+	return nil
+}
+
+// onlyRule returns the default rules with every rule but the one enable
+// switches on turned off.
+func onlyRule(enable func(r *Rules)) *Rules {
+	rules := DefaultRules()
+	rules.NewPayeeLargeAmount.Enabled = false
+	rules.RapidSuccession.Enabled = false
+	rules.UnusualHour.Enabled = false
+	rules.NearLimit.Enabled = false
+	rules.NewDevice.Enabled = false
+	enable(rules)
+	return rules
+}
+
+func TestEvaluate(t *testing.T) {
+	// 10:00 UTC, outside the default unusual hours
+	at := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+	hoursAgo := func(h int) *time.Time {
+		when := at.Add(-time.Duration(h) * time.Hour)
+		return &when
+	}
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	newPayee := onlyRule(func(r *Rules) { r.NewPayeeLargeAmount.Enabled = true })
+	rapid := onlyRule(func(r *Rules) { r.RapidSuccession.Enabled = true })
+	unusualHour := onlyRule(func(r *Rules) {
+		r.UnusualHour.Enabled = true
+		r.UnusualHour.location = jakarta
+	})
+	nearLimit := onlyRule(func(r *Rules) { r.NearLimit.Enabled = true })
+	newDevice := onlyRule(func(r *Rules) { r.NewDevice.Enabled = true })
+	missingDevice := onlyRule(func(r *Rules) {
+		r.NewDevice.Enabled = true
+		r.NewDevice.TreatMissingAsNew = true
+	})
+	strictest := onlyRule(func(r *Rules) {
+		r.NearLimit.Enabled = true
+		r.NewDevice.Enabled = true
+		r.NewDevice.Action = domain.RiskDecisionBlock
+	})
+
+	tests := []struct {
+		name     string
+		rules    *Rules
+		input    func(in *domain.RiskInput)
+		outflows int
+		want     domain.RiskDecision
+		reasons  int
+	}{
+		{"large amount to a new payee", newPayee, func(in *domain.RiskInput) { in.Amount, in.PayeeCreatedAt = decimal.NewFromInt(1000), hoursAgo(1) }, 0, domain.RiskDecisionReview, 1},
+		{"large amount to an old payee", newPayee, func(in *domain.RiskInput) { in.Amount, in.PayeeCreatedAt = decimal.NewFromInt(1000), hoursAgo(24) }, 0, domain.RiskDecisionAllow, 0},
+		{"small amount to a new payee", newPayee, func(in *domain.RiskInput) { in.Amount, in.PayeeCreatedAt = decimal.NewFromInt(999), hoursAgo(1) }, 0, domain.RiskDecisionAllow, 0},
+		{"large amount not to a payee", newPayee, func(in *domain.RiskInput) { in.Amount = decimal.NewFromInt(1000) }, 0, domain.RiskDecisionAllow, 0},
+		{"too many recent transfers", rapid, nil, 5, domain.RiskDecisionReview, 1},
+		{"few recent transfers", rapid, nil, 4, domain.RiskDecisionAllow, 0},
+		{"inside the hours in the rule's timezone", unusualHour, func(in *domain.RiskInput) { in.At = time.Date(2025, time.March, 3, 20, 0, 0, 0, time.UTC) }, 0, domain.RiskDecisionReview, 1},
+		{"outside the hours in the rule's timezone", unusualHour, func(in *domain.RiskInput) { in.At = time.Date(2025, time.March, 3, 2, 0, 0, 0, time.UTC) }, 0, domain.RiskDecisionAllow, 0},
+		{"near the limit", nearLimit, func(in *domain.RiskInput) { in.Amount = decimal.NewFromInt(900) }, 0, domain.RiskDecisionReview, 1},
+		{"below the threshold", nearLimit, func(in *domain.RiskInput) { in.Amount = decimal.RequireFromString("899.99") }, 0, domain.RiskDecisionAllow, 0},
+		{"no limit", nearLimit, func(in *domain.RiskInput) { in.Amount, in.PerTransactionLimit = decimal.NewFromInt(900), decimal.Zero }, 0, domain.RiskDecisionAllow, 0},
+		{"new device", newDevice, func(in *domain.RiskInput) { in.DeviceID = "tablet" }, 0, domain.RiskDecisionReview, 1},
+		{"known device", newDevice, nil, 0, domain.RiskDecisionAllow, 0},
+		{"missing device", newDevice, func(in *domain.RiskInput) { in.DeviceID = "" }, 0, domain.RiskDecisionAllow, 0},
+		{"missing device treated as new", missingDevice, func(in *domain.RiskInput) { in.DeviceID = "" }, 0, domain.RiskDecisionReview, 1},
+		{"strictest action wins", strictest, func(in *domain.RiskInput) { in.Amount, in.DeviceID = decimal.NewFromInt(950), "tablet" }, 0, domain.RiskDecisionBlock, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(tt.rules, &stubOutflows{count: tt.outflows}, stubDevices{"phone": true})
+			input := &domain.RiskInput{
+				UserID:              uuid.New(),
+				FromAccount:         &domain.Account{ID: uuid.New()},
+				ToAccount:           &domain.Account{ID: uuid.New()},
+				Amount:              decimal.NewFromInt(10),
+				PerTransactionLimit: decimal.NewFromInt(1000),
+				DeviceID:            "phone",
+				At:                  at,
+			}
+			if tt.input != nil {
+				tt.input(input)
+			}
+
+			assessment, err := engine.Evaluate(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if assessment.Decision != tt.want || len(assessment.Reasons) != tt.reasons {
+				t.Errorf("assessment %+v, want %s with %d reasons", assessment, tt.want, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestInHourWindow(t *testing.T) {
+	tests := []struct {
+		hour, start, end int
+		want             bool
+	}{
+		{0, 1, 5, false},
+		{1, 1, 5, true},
+		{4, 1, 5, true},
+		{5, 1, 5, false},
+		// Windows that wrap midnight
+		{21, 22, 6, false},
+		{22, 22, 6, true},
+		{23, 22, 6, true},
+		{0, 22, 6, true},
+		{5, 22, 6, true},
+		{6, 22, 6, false},
+		{12, 22, 6, false},
+		// An empty window never trips
+		{3, 3, 3, false},
+	}
+	for _, tt := range tests {
+		if got := inHourWindow(tt.hour, tt.start, tt.end); got != tt.want {
+			t.Errorf("inHourWindow(%d, %d, %d) = %v, want %v", tt.hour, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
\ No newline at end of file
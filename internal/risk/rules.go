@@ -0,0 +1,125 @@
+package risk
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+	"sigs.k8s.io/yaml"
+)
+
+// Rule is the part every rule shares: whether it runs and what happens to a
+// transfer that trips it.
+type Rule struct {
+	Enabled bool                `json:"enabled"`
+	Action  domain.RiskDecision `json:"action"`
+}
+
+type NewPayeeLargeAmountRule struct {
+	Rule
+	PayeeAgeHours int             `json:"payee_age_hours"`
+	Amount        decimal.Decimal `json:"amount"`
+}
+
+type RapidSuccessionRule struct {
+	Rule
+	WindowMinutes int `json:"window_minutes"`
+	MaxTransfers  int `json:"max_transfers"`
+}
+
+// UnusualHourRule trips between StartHour and EndHour in Timezone. The window
+// may wrap midnight, e.g. 23 to 5.
+type UnusualHourRule struct {
+	Rule
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+	Timezone  string `json:"timezone"`
+
+	location *time.Location
+}
+
+type NearLimitRule struct {
+	Rule
+	ThresholdPercent int `json:"threshold_percent"`
+}
+
+// NewDeviceRule trips when a transfer comes from a device that has never
+// completed one before. Requests without a device ID only trip it when
+// TreatMissingAsNew is set.
+type NewDeviceRule struct {
+	Rule
+	TreatMissingAsNew bool `json:"treat_missing_as_new"`
+}
+
+type Rules struct {
+	NewPayeeLargeAmount NewPayeeLargeAmountRule `json:"new_payee_large_amount"`
+	RapidSuccession     RapidSuccessionRule     `json:"rapid_succession"`
+	UnusualHour         UnusualHourRule         `json:"unusual_hour"`
+	NearLimit           NearLimitRule           `json:"near_limit"`
+	NewDevice           NewDeviceRule           `json:"new_device"`
+}
+
+func DefaultRules() *Rules {
+	review := Rule{Enabled: true, Action: domain.RiskDecisionReview}
+	rules := &Rules{
+		NewPayeeLargeAmount: NewPayeeLargeAmountRule{Rule: review, PayeeAgeHours: 24, Amount: decimal.NewFromInt(1000)},
+		RapidSuccession:     RapidSuccessionRule{Rule: review, WindowMinutes: 10, MaxTransfers: 5},
+		UnusualHour:         UnusualHourRule{Rule: Rule{Action: domain.RiskDecisionReview}, StartHour: 1, EndHour: 5, Timezone: "UTC"},
+		NearLimit:           NearLimitRule{Rule: review, ThresholdPercent: 90},
+		NewDevice:           NewDeviceRule{Rule: review},
+	}
+	rules.UnusualHour.location = time.UTC
+	return rules
+}
+
+// LoadRules reads rules from a YAML or JSON file on top of DefaultRules, so a
+// file only needs the settings it changes.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read risk rules: %w", err)
+	}
+
+	rules := DefaultRules()
+	if err := yaml.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to parse risk rules: %w", err)
+	}
+
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *Rules) validate() error {
+	actions := map[string]domain.RiskDecision{
+		"new_payee_large_amount": r.NewPayeeLargeAmount.Action,
+		"rapid_succession":       r.RapidSuccession.Action,
+		"unusual_hour":           r.UnusualHour.Action,
+		"near_limit":             r.NearLimit.Action,
+		"new_device":             r.NewDevice.Action,
+	}
+	for name, action := range actions {
+		if action != domain.RiskDecisionReview && action != domain.RiskDecisionBlock {
+			return fmt.Errorf("risk rule %s: action must be review or block, got %q", name, action)
+		}
+	}
+
+	if r.UnusualHour.StartHour < 0 || r.UnusualHour.StartHour > 23 || r.UnusualHour.EndHour < 0 || r.UnusualHour.EndHour > 23 {
+		return fmt.Errorf("risk rule unusual_hour: hours must be between 0 and 23")
+	}
+	location, err := time.LoadLocation(r.UnusualHour.Timezone)
+	if err != nil {
+		return fmt.Errorf("risk rule unusual_hour: %w", err)
+	}
+	r.UnusualHour.location = location
+
+	if r.NearLimit.ThresholdPercent <= 0 || r.NearLimit.ThresholdPercent > 100 {
+		return fmt.Errorf("risk rule near_limit: threshold_percent must be between 1 and 100")
+	}
+
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,54 @@
+package risk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	rules, err := LoadRules(write("rules.yaml", "rapid_succession:\n  max_transfers: 3\nunusual_hour:\n  enabled: true\n  action: block\n  timezone: UTC\n"))
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	// Settings the file leaves out keep their defaults
+	if r := rules.RapidSuccession; !r.Enabled || r.MaxTransfers != 3 || r.WindowMinutes != 10 {
+		t.Errorf("rapid_succession %+v, want the defaults with max_transfers 3", r)
+	}
+	if r := rules.UnusualHour; !r.Enabled || r.Action != domain.RiskDecisionBlock || r.StartHour != 1 || r.location != time.UTC {
+		t.Errorf("unusual_hour %+v, want enabled blocking from 1 UTC", r)
+	}
+	if !rules.NewPayeeLargeAmount.Enabled || rules.NearLimit.ThresholdPercent != 90 {
+		t.Errorf("rules %+v, want the defaults for rules the file doesn't mention", rules)
+	}
+
+	for name, content := range map[string]string{
+		"allow action":       "near_limit:\n  action: allow\n",
+		"unknown action":     "new_device:\n  action: flag\n",
+		"hour past 23":       "unusual_hour:\n  end_hour: 24\n",
+		"negative hour":      "unusual_hour:\n  start_hour: -1\n",
+		"unknown timezone":   "unusual_hour:\n  timezone: Nowhere/Special\n",
+		"zero threshold":     "near_limit:\n  threshold_percent: 0\n",
+		"threshold over 100": "near_limit:\n  threshold_percent: 101\n",
+		"not yaml":           "near_limit: [\n",
+	} {
+		if _, err := LoadRules(write("invalid.yaml", content)); err == nil {
+			t.Errorf("%s: loaded, want an error", name)
+		}
+	}
+	if _, err := LoadRules(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("missing file: loaded, want an error")
+	}
+}
\ No newline at end of file
@@ -0,0 +1,69 @@
+package screening
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 (nothing
+// in common) to 1 (identical). Common prefixes of up to four characters are
+// boosted, which suits names where typos tend to be late in the string.
+func JaroWinkler(a, b string) float64 {
+	s1, s2 := []rune(a), []rune(b)
+	if len(s1) == 0 && len(s2) == 0 {
+		return 1
+	}
+	if len(s1) == 0 || len(s2) == 0 {
+		return 0
+	}
+
+	jaro := jaro(s1, s2)
+
+	prefix := 0
+	for prefix < len(s1) && prefix < len(s2) && prefix < 4 && s1[prefix] == s2[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+func jaro(s1, s2 []rune) float64 {
+	window := max(len(s1), len(s2))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(s1))
+	matched2 := make([]bool, len(s2))
+
+	matches := 0
+	for i := range s1 {
+		lo := max(0, i-window)
+		hi := min(len(s2), i+window+1)
+		for j := lo; j < hi; j++ {
+			if matched2[j] || s1[i] != s2[j] {
+				continue
+			}
+			matched1[i] = true
+			matched2[j] = true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if s1[i] != s2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(s1)) + m/float64(len(s2)) + (m-float64(transpositions)/2)/m) / 3
+}
\ No newline at end of file
@@ -0,0 +1,35 @@
+package screening
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		// The textbook examples
+		{"martha", "marhta", 0.9611},
+		{"dwayne", "duane", 0.84},
+		{"dixon", "dicksonx", 0.8133},
+		{"john smith", "john smyth", 0.96},
+		{"crate", "trace", 0.7333},
+		{"abc", "xyz", 0},
+		{"alice", "alice", 1},
+		{"", "", 1},
+		{"alice", "", 0},
+		// Runes, not bytes, are compared
+		{"josé", "jose", 0.8833},
+	}
+	for _, tt := range tests {
+		got := JaroWinkler(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 0.0001 {
+			t.Errorf("JaroWinkler(%q, %q) = %.4f, want %.4f", tt.a, tt.b, got, tt.want)
+		}
+		if reversed := JaroWinkler(tt.b, tt.a); math.Abs(reversed-got) > 1e-9 {
+			t.Errorf("JaroWinkler(%q, %q) = %.4f, but %.4f the other way round", tt.b, tt.a, reversed, got)
+		}
+	}
+}
\ No newline at end of file
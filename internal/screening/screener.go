@@ -0,0 +1,156 @@
+package screening
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+)
+
+const (
+	DefaultBlockThreshold  = 0.97
+	DefaultReviewThreshold = 0.90
+
+	maxMatches = 5
+)
+
+// Config points the screener at a watchlist file. Names scoring at or above
+// BlockThreshold are blocked, those at or above ReviewThreshold are flagged.
+type Config struct {
+	Path            string
+	BlockThreshold  float64
+	ReviewThreshold float64
+}
+
+// Screener holds the watchlist in memory and matches names against it. The
+// list is swapped atomically on reload so screening never sees a partial
+// file.
+type Screener struct {
+	config Config
+
+	mu      sync.RWMutex
+	entries []Entry
+	modTime time.Time
+}
+
+func NewScreener(config Config) (*Screener, error) {
+	if config.BlockThreshold == 0 {
+		config.BlockThreshold = DefaultBlockThreshold
+	}
+	if config.ReviewThreshold == 0 {
+		config.ReviewThreshold = DefaultReviewThreshold
+	}
+	if config.ReviewThreshold <= 0 || config.ReviewThreshold > config.BlockThreshold || config.BlockThreshold > 1 {
+		return nil, fmt.Errorf("screening thresholds must satisfy 0 < review <= block <= 1, got review %.2f block %.2f",
+			config.ReviewThreshold, config.BlockThreshold)
+	}
+
+	s := &Screener{config: config}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Reload reads the watchlist file again. On error the current list is kept.
+func (s *Screener) Reload() error {
+	info, err := os.Stat(s.config.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	file, err := os.Open(s.config.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read watchlist: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := ParseSDN(file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.entries = entries
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ReloadIfChanged reloads the watchlist when the file's modification time
+// has moved. It is run periodically by the job runner.
+func (s *Screener) ReloadIfChanged(ctx context.Context) error {
+	info, err := os.Stat(s.config.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read watchlist: %w", err)
+	}
+
+	s.mu.RLock()
+	unchanged := info.ModTime().Equal(s.modTime)
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := s.Reload(); err != nil {
+		return err
+	}
+	log.Printf("Watchlist reloaded: %d entries", s.Size())
+	return nil
+}
+
+func (s *Screener) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// Screen scores name against every entry, comparing both the names as
+// written and with their parts sorted so word order doesn't matter.
+func (s *Screener) Screen(name string) *domain.ScreeningResult {
+	result := &domain.ScreeningResult{Decision: domain.RiskDecisionAllow}
+
+	normalized := utils.NormalizeName(name)
+	if normalized == "" {
+		return result
+	}
+	sorted := sortedTokens(normalized)
+
+	s.mu.RLock()
+	for _, entry := range s.entries {
+		score := max(JaroWinkler(normalized, entry.normalized), JaroWinkler(sorted, entry.sorted))
+		if score < s.config.ReviewThreshold {
+			continue
+		}
+
+		result.Matches = append(result.Matches, domain.ScreeningMatch{
+			EntryID: entry.ID,
+			Name:    entry.Name,
+			Program: entry.Program,
+			Score:   score,
+		})
+		if score >= s.config.BlockThreshold {
+			result.Decision = domain.RiskDecisionBlock
+		} else if result.Decision == domain.RiskDecisionAllow {
+			result.Decision = domain.RiskDecisionReview
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(result.Matches, func(i, j int) bool {
+		return result.Matches[i].Score > result.Matches[j].Score
+	})
+	if len(result.Matches) > maxMatches {
+		result.Matches = result.Matches[:maxMatches]
+	}
+
+	return result
+}
\ No newline at end of file
@@ -0,0 +1,113 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestScreener(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	if err := os.WriteFile(path, []byte(sdnSample), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	screener, err := NewScreener(Config{Path: path})
+	if err != nil {
+		t.Fatalf("NewScreener: %v", err)
+	}
+	if screener.Size() != 3 {
+		t.Fatalf("%d entries, want 3", screener.Size())
+	}
+
+	tests := []struct {
+		name string
+		want domain.RiskDecision
+	}{
+		{"Abdul Rahman Hamadi", domain.RiskDecisionBlock},
+		// Word order doesn't matter
+		{"Hamadi Abdul Rahman", domain.RiskDecisionBlock},
+		{"Aerocaribbean Airlines", domain.RiskDecisionBlock},
+		{"Abdul Rahim Hamadi", domain.RiskDecisionReview},
+		{"Aerocaribe Airlines", domain.RiskDecisionReview},
+		{"Jane Doe", domain.RiskDecisionAllow},
+		{"", domain.RiskDecisionAllow},
+	}
+	for _, tt := range tests {
+		result := screener.Screen(tt.name)
+		if result.Decision != tt.want {
+			t.Errorf("Screen(%q) = %s %+v, want %s", tt.name, result.Decision, result.Matches, tt.want)
+		}
+		if (len(result.Matches) > 0) != (tt.want != domain.RiskDecisionAllow) {
+			t.Errorf("Screen(%q) matched %+v", tt.name, result.Matches)
+		}
+	}
+
+	for name, config := range map[string]Config{
+		"review above block": {Path: path, BlockThreshold: 0.9, ReviewThreshold: 0.95},
+		"block above 1":      {Path: path, BlockThreshold: 1.1},
+		"negative review":    {Path: path, ReviewThreshold: -0.5},
+		"missing file":       {Path: filepath.Join(t.TempDir(), "missing.csv")},
+	} {
+		if _, err := NewScreener(config); err == nil {
+			t.Errorf("%s: created, want an error", name)
+		}
+	}
+}
+
+func TestScreenerReload(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sdn.csv")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loaded := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+
+	write(sdnSample, loaded)
+	screener, err := NewScreener(Config{Path: path})
+	if err != nil {
+		t.Fatalf("NewScreener: %v", err)
+	}
+
+	// A file rewritten with the same modification time isn't picked up
+	const updated = `1,"JANE DOE",-0- ,"SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-` + "\n"
+	write(updated, loaded)
+	if err := screener.ReloadIfChanged(ctx); err != nil {
+		t.Fatalf("ReloadIfChanged: %v", err)
+	}
+	if screener.Size() != 3 || screener.Screen("Jane Doe").Decision != domain.RiskDecisionAllow {
+		t.Fatalf("reloaded an unchanged file, %d entries", screener.Size())
+	}
+
+	write(updated, loaded.Add(time.Hour))
+	if err := screener.ReloadIfChanged(ctx); err != nil {
+		t.Fatalf("ReloadIfChanged: %v", err)
+	}
+	if screener.Size() != 1 || screener.Screen("Jane Doe").Decision != domain.RiskDecisionBlock {
+		t.Fatalf("changed file not reloaded, %d entries", screener.Size())
+	}
+
+	// A file that can't be read keeps the current list
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := screener.Reload(); err == nil {
+		t.Error("reloaded a missing file, want an error")
+	}
+	if err := screener.ReloadIfChanged(ctx); err == nil {
+		t.Error("reloaded a missing file if changed, want an error")
+	}
+	if screener.Size() != 1 || screener.Screen("Jane Doe").Decision != domain.RiskDecisionBlock {
+		t.Errorf("list dropped after a failed reload, %d entries", screener.Size())
+	}
+}
\ No newline at end of file
@@ -0,0 +1,100 @@
+package screening
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+)
+
+// OFAC marks empty SDN fields with -0-.
+const sdnNull = "-0-"
+
+// Entry is one name on the watchlist.
+type Entry struct {
+	ID      string
+	Name    string
+	Type    string
+	Program string
+
+	normalized string
+	sorted     string
+}
+
+// ParseSDN reads the OFAC SDN list in its CSV distribution format
+// (ent_num, SDN_Name, SDN_Type, Program, ...) without a header row.
+// Individuals are listed as "LAST, First" and are matched as "First LAST".
+func ParseSDN(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse watchlist: %w", err)
+		}
+		// The distributed file ends with a single control character line
+		if len(record) < 2 {
+			continue
+		}
+
+		name := sdnField(record[1])
+		if name == "" {
+			continue
+		}
+
+		entry := Entry{
+			ID:   strings.TrimSpace(record[0]),
+			Name: name,
+		}
+		if len(record) > 2 {
+			entry.Type = sdnField(record[2])
+		}
+		if len(record) > 3 {
+			entry.Program = sdnField(record[3])
+		}
+
+		matchName := name
+		if strings.EqualFold(entry.Type, "individual") {
+			if last, first, ok := strings.Cut(name, ","); ok {
+				matchName = first + " " + last
+			}
+		}
+		entry.normalized = utils.NormalizeName(matchName)
+		entry.sorted = sortedTokens(entry.normalized)
+		if entry.normalized == "" {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// sdnField trims a field, which the distributed file pads with spaces (an
+// empty one is written "-0- "), and returns empty fields as "".
+func sdnField(field string) string {
+	field = strings.TrimSpace(field)
+	if field == sdnNull {
+		return ""
+	}
+	return field
+}
+
+// sortedTokens orders the parts of a normalized name so "john smith" and
+// "smith john" compare equal.
+func sortedTokens(normalized string) string {
+	parts := strings.Fields(normalized)
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
\ No newline at end of file
@@ -0,0 +1,40 @@
+package screening
+
+import (
+	"strings"
+	"testing"
+)
+
+// sdnSample is laid out like the distributed sdn.csv: no header, empty
+// fields written as "-0- " and a control character on the last line.
+const sdnSample = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+2674,"HAMADI, Abdul Rahman","individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+999,-0- ,"individual","SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0-
+` + "\x1a\n"
+
+func TestParseSDN(t *testing.T) {
+	entries, err := ParseSDN(strings.NewReader(sdnSample))
+	if err != nil {
+		t.Fatalf("ParseSDN: %v", err)
+	}
+
+	want := []Entry{
+		{ID: "36", Name: "AEROCARIBBEAN AIRLINES", Program: "CUBA", normalized: "aerocaribbean airlines"},
+		{ID: "173", Name: "ANGLO-CARIBBEAN CO., LTD.", Program: "CUBA", normalized: "anglo caribbean co ltd"},
+		// Individuals are matched first name first
+		{ID: "2674", Name: "HAMADI, Abdul Rahman", Type: "individual", Program: "SDGT", normalized: "abdul rahman hamadi"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("%d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		got := entries[i]
+		if got.ID != w.ID || got.Name != w.Name || got.Type != w.Type || got.Program != w.Program || got.normalized != w.normalized {
+			t.Errorf("entry %d = %+v, want %+v", i, got, w)
+		}
+		if got.sorted != sortedTokens(w.normalized) {
+			t.Errorf("entry %d sorted %q, want %q", i, got.sorted, sortedTokens(w.normalized))
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,372 @@
+// Package server builds the HTTP API from its dependencies, so the same app
+// that cmd/api serves can be driven end to end in tests.
+package server
+
+import (
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/swaggo/fiber-swagger"
+
+	_ "github.com/nabiilNajm26/go-bank/docs"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http"
+	"github.com/nabiilNajm26/go-bank/internal/delivery/http/middleware"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/fx"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/s3"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/session"
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/internal/usecase"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
+)
+
+// Deps is everything the app is built from. The repositories, unit of work
+// and JWT manager are required; the rest switch their feature off when nil.
+type Deps struct {
+	Users             repository.UserRepository
+	Accounts          repository.AccountRepository
+	AccountMembers    repository.AccountMemberRepository
+	Transactions      repository.TransactionRepository
+	Payees            repository.PayeeRepository
+	Limits            repository.LimitRepository
+	Reviews           repository.ReviewRepository
+	Devices           repository.DeviceRepository
+	Holds             repository.HoldRepository
+	Interest          repository.InterestRepository
+	TermDeposits      repository.TermDepositRepository
+	Fees              repository.FeeRepository
+	TransferApprovals repository.TransferApprovalRepository
+	Organizations     repository.OrganizationRepository
+	PaymentDrafts     repository.PaymentDraftRepository
+	Pots              repository.PotRepository
+	Pockets           repository.PocketRepository
+	ScreeningAlerts   repository.ScreeningAlertRepository
+	Idempotency       repository.IdempotencyRepository
+	UnitOfWork        repository.UnitOfWork
+
+	JWT         *utils.JWTManager
+	Sessions    *session.SessionService
+	LimitPolicy *domain.LimitPolicy
+	Risk        usecase.RiskEvaluator
+	Screener    usecase.NameScreener
+	S3          *s3.S3Service
+
+	// InterestPolicy defaults to usecase.DefaultInterestPolicy. Its
+	// TermDeposits section sets the term deposits on offer.
+	InterestPolicy *domain.InterestPolicy
+
+	// DormancyMonths is how long accounts go without customer activity
+	// before they are marked dormant. It defaults to
+	// usecase.DefaultDormancyMonths.
+	DormancyMonths int
+
+	// FXRates quote the exchange rates conversions and consolidated
+	// statements use, and FXSpread is the bank's margin on conversions.
+	// They default to fx.DefaultTable and usecase.DefaultFXSpread.
+	FXRates  fx.Source
+	FXSpread decimal.NullDecimal
+
+	// AccountNumbers is the scheme accounts are numbered in and account
+	// numbers are checked against. It defaults to accountnumber.Default.
+	AccountNumbers accountnumber.Scheme
+
+	// RateLimiter counts requests against RateLimits. They default to an
+	// in-memory limiter and ratelimit.DefaultPolicies.
+	RateLimiter ratelimit.Limiter
+	RateLimits  *ratelimit.Policies
+
+	// DisableRateLimit turns off rate limiting, which a test client making
+	// every request from one address and user would trip.
+	DisableRateLimit bool
+}
+
+// Server is the assembled app plus the use cases background jobs need.
+type Server struct {
+	App          *fiber.App
+	Holds        *usecase.HoldUseCase
+	Interest     *usecase.InterestUseCase
+	TermDeposits *usecase.TermDepositUseCase
+	Fees         *usecase.FeeUseCase
+	Overdrafts   *usecase.OverdraftUseCase
+	Lifecycle    *usecase.AccountLifecycleUseCase
+}
+
+func New(deps *Deps) *Server {
+	// Initialize use cases
+	var screeningUseCase *usecase.ScreeningUseCase
+	if deps.Screener != nil {
+		screeningUseCase = usecase.NewScreeningUseCase(deps.Screener, deps.ScreeningAlerts)
+	}
+	authUseCase := usecase.NewAuthUseCase(deps.Users, deps.JWT, deps.Sessions, screeningUseCase)
+	accountUseCase := usecase.NewAccountUseCase(deps.Accounts, deps.AccountMembers, deps.Users, deps.AccountNumbers)
+	lifecycleUseCase := usecase.NewAccountLifecycleUseCase(deps.Accounts, deps.AccountMembers, deps.Users, deps.UnitOfWork, deps.DormancyMonths)
+	memberUseCase := usecase.NewAccountMemberUseCase(deps.AccountMembers, deps.Accounts, deps.Users)
+	limitUseCase := usecase.NewLimitUseCase(deps.Limits, deps.Accounts, deps.AccountMembers, deps.Users, deps.Transactions, deps.LimitPolicy)
+	feeUseCase := usecase.NewFeeUseCase(deps.Fees, deps.Accounts, deps.UnitOfWork)
+	pocketUseCase := usecase.NewPocketUseCase(deps.Accounts, deps.Pockets, deps.AccountMembers, deps.FXRates, deps.FXSpread, deps.UnitOfWork)
+	transactionUseCase := usecase.NewTransactionUseCase(deps.Transactions, deps.Accounts, deps.AccountMembers, deps.Payees, deps.Users, deps.Reviews, deps.Devices, limitUseCase, feeUseCase, pocketUseCase, deps.Risk, screeningUseCase, deps.UnitOfWork, deps.AccountNumbers)
+	approvalUseCase := usecase.NewTransferApprovalUseCase(deps.TransferApprovals, deps.Accounts, deps.AccountMembers, transactionUseCase, deps.UnitOfWork)
+	organizationUseCase := usecase.NewOrganizationUseCase(deps.Organizations, deps.Accounts, deps.Users, deps.AccountNumbers)
+	draftUseCase := usecase.NewPaymentDraftUseCase(deps.PaymentDrafts, deps.Organizations, deps.Accounts, transactionUseCase, deps.UnitOfWork)
+	potUseCase := usecase.NewPotUseCase(deps.Pots, deps.Accounts, deps.AccountMembers, deps.UnitOfWork)
+	overdraftUseCase := usecase.NewOverdraftUseCase(deps.Accounts, feeUseCase, deps.UnitOfWork)
+	statementUseCase := usecase.NewStatementUseCase(deps.Accounts, deps.AccountMembers, deps.Transactions, deps.Pockets, deps.FXRates)
+	userUseCase := usecase.NewUserUseCase(deps.Users, deps.Accounts, screeningUseCase)
+	payeeUseCase := usecase.NewPayeeUseCase(deps.Payees, deps.Accounts, deps.Users, screeningUseCase, deps.AccountNumbers)
+	holdUseCase := usecase.NewHoldUseCase(deps.Holds, deps.Accounts, deps.AccountMembers, limitUseCase, deps.UnitOfWork)
+	interestUseCase := usecase.NewInterestUseCase(deps.Accounts, deps.AccountMembers, deps.Interest, deps.UnitOfWork, deps.InterestPolicy)
+	termDepositUseCase := usecase.NewTermDepositUseCase(deps.Accounts, deps.TermDeposits, deps.UnitOfWork, deps.InterestPolicy, deps.AccountNumbers)
+
+	// Initialize handlers
+	authHandler := http.NewAuthHandler(authUseCase)
+	accountHandler := http.NewAccountHandler(accountUseCase)
+	lifecycleHandler := http.NewAccountLifecycleHandler(lifecycleUseCase)
+	memberHandler := http.NewAccountMemberHandler(memberUseCase)
+	transactionHandler := http.NewTransactionHandler(transactionUseCase)
+	approvalHandler := http.NewTransferApprovalHandler(approvalUseCase)
+	organizationHandler := http.NewOrganizationHandler(organizationUseCase)
+	draftHandler := http.NewPaymentDraftHandler(draftUseCase)
+	potHandler := http.NewPotHandler(potUseCase)
+	pocketHandler := http.NewPocketHandler(pocketUseCase)
+	statementHandler := http.NewStatementHandler(statementUseCase)
+	userHandler := http.NewUserHandler(userUseCase, deps.S3)
+	payeeHandler := http.NewPayeeHandler(payeeUseCase)
+	limitHandler := http.NewLimitHandler(limitUseCase)
+	reviewHandler := http.NewReviewHandler(transactionUseCase)
+	holdHandler := http.NewHoldHandler(holdUseCase)
+	interestHandler := http.NewInterestHandler(interestUseCase)
+	termDepositHandler := http.NewTermDepositHandler(termDepositUseCase)
+	feeHandler := http.NewFeeHandler(feeUseCase)
+	overdraftHandler := http.NewOverdraftHandler(overdraftUseCase)
+	screeningHandler := http.NewScreeningHandler(screeningUseCase)
+	wsHandler := http.NewWebSocketHandler()
+
+	rateLimiter := deps.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = ratelimit.NewMemoryLimiter()
+	}
+	rateLimits := deps.RateLimits
+	if rateLimits == nil {
+		rateLimits = ratelimit.DefaultPolicies()
+	}
+	rateLimit := func(router fiber.Router, group string, policy ratelimit.Policy) {
+		if !deps.DisableRateLimit {
+			router.Use(middleware.RateLimitMiddleware(rateLimiter, group, policy))
+		}
+	}
+
+	// Setup Fiber app
+	app := fiber.New(fiber.Config{
+		ErrorHandler: middleware.ErrorHandler,
+	})
+
+	// Middleware
+	app.Use(requestid.New())
+	app.Use(logger.New(logger.Config{
+		Format: "[${time}] ${respHeader:X-Request-ID} ${status} - ${latency} ${method} ${path}\n",
+	}))
+	app.Use(recover.New())
+	app.Use(cors.New(cors.Config{
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, Idempotency-Key, X-Device-ID",
+		ExposeHeaders: "RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, Idempotent-Replayed, X-Request-ID",
+		AllowMethods:  "GET, HEAD, PUT, PATCH, POST, DELETE",
+	}))
+	rateLimit(app, "global", rateLimits.Global)
+
+	// Swagger documentation
+	app.Get("/swagger/*", fiberSwagger.WrapHandler)
+
+	// Routes
+	api := app.Group("/api/v1")
+
+	// Auth routes (with strict rate limiting)
+	auth := api.Group("/auth")
+	rateLimit(auth, "auth", rateLimits.Auth)
+	auth.Post("/register", authHandler.Register)
+	auth.Post("/login", authHandler.Login)
+	auth.Post("/refresh", authHandler.RefreshToken)
+
+	// Protected routes
+	protected := api.Use(middleware.AuthMiddleware(deps.JWT))
+	rateLimit(protected, "user", rateLimits.User)
+	// Payment limits run ahead of idempotency so a 429 is never stored as
+	// the response to a key and replayed to its retries
+	for _, prefix := range []string{"/transactions", "/transfer-approvals", "/payment-drafts", "/pots", "/fx", "/holds", "/term-deposits"} {
+		rateLimit(protected.Group(prefix), "payments", rateLimits.Payments)
+	}
+	rateLimit(protected.Group("/payees/lookup"), "lookup", rateLimits.Lookup)
+	protected.Use(middleware.IdempotencyMiddleware(deps.Idempotency))
+
+	// Account routes
+	accounts := protected.Group("/accounts")
+	accounts.Post("/", accountHandler.CreateAccount)
+	accounts.Get("/", accountHandler.GetUserAccounts)
+	accounts.Get("/:id", accountHandler.GetAccount)
+	accounts.Put("/:id", accountHandler.UpdateAccount)
+	// Accounts are closed rather than deleted; DELETE is kept for clients
+	// that used it before
+	accounts.Delete("/:id", lifecycleHandler.CloseAccount)
+	accounts.Post("/:id/close", lifecycleHandler.CloseAccount)
+	accounts.Post("/:id/reactivate", lifecycleHandler.ReactivateAccount)
+	accounts.Get("/:id/limits", limitHandler.GetAccountLimits)
+	accounts.Put("/:id/limits", limitHandler.UpdateAccountLimits)
+	accounts.Get("/:id/holds", holdHandler.GetAccountHolds)
+	accounts.Get("/:id/interest", interestHandler.GetAccountInterest)
+	accounts.Post("/:id/members", memberHandler.InviteMember)
+	accounts.Get("/:id/members", memberHandler.GetMembers)
+	accounts.Delete("/:id/members/:user_id", memberHandler.RemoveMember)
+	accounts.Get("/:id/approval-policy", memberHandler.GetApprovalPolicy)
+	accounts.Put("/:id/approval-policy", memberHandler.SetApprovalPolicy)
+	accounts.Get("/:id/transfer-approvals", approvalHandler.GetAccountApprovals)
+	accounts.Post("/:id/pots", potHandler.CreatePot)
+	accounts.Get("/:id/pots", potHandler.GetPots)
+	accounts.Post("/:id/pockets", pocketHandler.OpenPocket)
+	accounts.Get("/:id/pockets", pocketHandler.GetPockets)
+	accounts.Delete("/:id/pockets/:currency", pocketHandler.ClosePocket)
+
+	// Invitations to other users' accounts
+	invitations := protected.Group("/account-invitations")
+	invitations.Get("/", memberHandler.GetInvitations)
+	invitations.Post("/:account_id/accept", memberHandler.AcceptInvitation)
+	invitations.Post("/:account_id/decline", memberHandler.DeclineInvitation)
+
+	// Transaction routes
+	transactions := protected.Group("/transactions")
+	transactions.Post("/transfer", transactionHandler.Transfer)
+	transactions.Get("/", transactionHandler.GetTransactionHistory)
+
+	// Transfer approval routes
+	approvals := protected.Group("/transfer-approvals")
+	approvals.Post("/", approvalHandler.RequestTransfer)
+	approvals.Post("/:id/approve", approvalHandler.ApproveTransfer)
+	approvals.Post("/:id/execute", approvalHandler.ExecuteTransfer)
+	approvals.Post("/:id/reject", approvalHandler.RejectTransfer)
+
+	// Organization routes
+	organizations := protected.Group("/organizations")
+	organizations.Post("/", organizationHandler.CreateOrganization)
+	organizations.Get("/", organizationHandler.GetUserOrganizations)
+	organizations.Get("/:id", organizationHandler.GetOrganization)
+	organizations.Post("/:id/members", organizationHandler.AddMember)
+	organizations.Get("/:id/members", organizationHandler.GetMembers)
+	organizations.Put("/:id/members/:user_id", organizationHandler.UpdateMember)
+	organizations.Delete("/:id/members/:user_id", organizationHandler.RemoveMember)
+	organizations.Get("/:id/approval-rules", organizationHandler.GetApprovalRules)
+	organizations.Put("/:id/approval-rules", organizationHandler.SetApprovalRules)
+	organizations.Post("/:id/accounts", organizationHandler.CreateAccount)
+	organizations.Get("/:id/accounts", organizationHandler.GetAccounts)
+	organizations.Post("/:id/payment-drafts", draftHandler.CreateDraft)
+	organizations.Get("/:id/payment-drafts", draftHandler.GetDrafts)
+
+	// Payment draft routes
+	drafts := protected.Group("/payment-drafts")
+	drafts.Get("/:id", draftHandler.GetDraft)
+	drafts.Post("/:id/submit", draftHandler.SubmitDraft)
+	drafts.Post("/:id/approve", draftHandler.ApproveDraft)
+	drafts.Post("/:id/execute", draftHandler.ExecuteDraft)
+	drafts.Post("/:id/reject", draftHandler.RejectDraft)
+	drafts.Post("/:id/cancel", draftHandler.CancelDraft)
+
+	// Pot routes
+	pots := protected.Group("/pots")
+	pots.Get("/:id", potHandler.GetPot)
+	pots.Put("/:id", potHandler.UpdatePot)
+	pots.Delete("/:id", potHandler.DeletePot)
+	pots.Get("/:id/movements", potHandler.GetPotMovements)
+	pots.Post("/:id/deposit", potHandler.DepositToPot)
+	pots.Post("/:id/withdraw", potHandler.WithdrawFromPot)
+
+	// Currency conversion routes
+	fxRoutes := protected.Group("/fx")
+	fxRoutes.Get("/quote", pocketHandler.GetQuote)
+	fxRoutes.Post("/conversions", pocketHandler.Convert)
+
+	// Hold routes
+	holds := protected.Group("/holds")
+	holds.Post("/", holdHandler.AuthorizeHold)
+	holds.Post("/:id/capture", holdHandler.CaptureHold)
+	holds.Post("/:id/void", holdHandler.VoidHold)
+
+	// Term deposit routes
+	termDeposits := protected.Group("/term-deposits")
+	termDeposits.Post("/", termDepositHandler.OpenTermDeposit)
+	termDeposits.Get("/", termDepositHandler.GetTermDeposits)
+	termDeposits.Get("/:id", termDepositHandler.GetTermDeposit)
+	termDeposits.Post("/:id/break", termDepositHandler.BreakTermDeposit)
+
+	// Payee routes
+	payees := protected.Group("/payees")
+	payees.Get("/lookup", payeeHandler.LookupAccount)
+	payees.Post("/", payeeHandler.CreatePayee)
+	payees.Get("/", payeeHandler.GetPayees)
+	payees.Get("/:id", payeeHandler.GetPayee)
+	payees.Put("/:id", payeeHandler.UpdatePayee)
+	payees.Delete("/:id", payeeHandler.DeletePayee)
+
+	// Statement routes
+	statements := protected.Group("/statements")
+	statements.Get("/:account_id/pdf", statementHandler.GeneratePDFStatement)
+	statements.Get("/:account_id/csv", statementHandler.GenerateCSVStatement)
+
+	// User routes
+	users := protected.Group("/users")
+	users.Get("/profile", userHandler.GetProfile)
+	users.Put("/profile", userHandler.UpdateProfile)
+	users.Delete("/profile", userHandler.DeleteProfile)
+	if deps.S3 != nil {
+		users.Use(middleware.FileUploadMiddleware())
+		users.Post("/profile/image", userHandler.UploadProfileImage)
+	}
+
+	// Operator routes
+	admin := protected.Group("/admin", middleware.RequireRole(deps.Users, domain.UserRoleOperator))
+	admin.Put("/accounts/:id/limits", limitHandler.OverrideAccountLimits)
+	admin.Put("/accounts/:id/overdraft", overdraftHandler.SetOverdraftLimit)
+	admin.Put("/accounts/:id/status", lifecycleHandler.SetAccountStatus)
+	admin.Post("/deposits", transactionHandler.Deposit)
+	admin.Post("/withdrawals", transactionHandler.Withdraw)
+	admin.Get("/reviews", reviewHandler.GetPendingReviews)
+	admin.Post("/reviews/:id/approve", reviewHandler.ApproveReview)
+	admin.Post("/reviews/:id/reject", reviewHandler.RejectReview)
+	admin.Get("/fee-schedules", feeHandler.GetFeeSchedules)
+	admin.Put("/fee-schedules", feeHandler.SetFeeSchedule)
+	admin.Delete("/fee-schedules/:id", feeHandler.DeleteFeeSchedule)
+	admin.Post("/accounts/:id/fee-waivers", feeHandler.CreateFeeWaiver)
+	admin.Get("/accounts/:id/fee-waivers", feeHandler.GetFeeWaivers)
+	admin.Delete("/fee-waivers/:id", feeHandler.DeleteFeeWaiver)
+	if screeningUseCase != nil {
+		admin.Get("/screening-alerts", screeningHandler.GetPendingAlerts)
+		admin.Post("/screening-alerts/:id/clear", screeningHandler.ClearAlert)
+		admin.Post("/screening-alerts/:id/confirm", screeningHandler.ConfirmAlert)
+	}
+
+	// WebSocket route
+	app.Get("/ws", websocket.New(wsHandler.HandleConnection))
+
+	// Health check
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"status": "healthy",
+			"time":   time.Now(),
+		})
+	})
+
+	return &Server{
+		App:          app,
+		Holds:        holdUseCase,
+		Interest:     interestUseCase,
+		TermDeposits: termDepositUseCase,
+		Fees:         feeUseCase,
+		Overdrafts:   overdraftUseCase,
+		Lifecycle:    lifecycleUseCase,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,653 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/cache"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/session"
+	"github.com/nabiilNajm26/go-bank/internal/ratelimit"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/internal/repository/cached"
+	"github.com/nabiilNajm26/go-bank/internal/repository/memory"
+	"github.com/nabiilNajm26/go-bank/internal/repository/postgres"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
+)
+
+// testServer drives the app through app.Test, the way a client would.
+type testServer struct {
+	*Server
+	deps *Deps
+}
+
+// forEachBackend runs fn against the in-memory store and, when
+// TEST_DATABASE_URL names a migrated database, against Postgres. Both use an
+// in-memory cache standing in for Redis.
+func forEachBackend(t *testing.T, fn func(t *testing.T, s *testServer)) {
+	t.Run("memory", func(t *testing.T) {
+		store := memory.NewStore()
+		cacheService := cache.NewCacheService(cache.NewMemoryStore())
+		store.OnAccountsChanged(cacheService.InvalidateAccounts)
+
+		fn(t, newTestServer(cacheService, &Deps{
+			Users:             store.Users(),
+			Accounts:          store.Accounts(),
+			AccountMembers:    store.AccountMembers(),
+			Transactions:      store.Transactions(),
+			Payees:            store.Payees(),
+			Limits:            store.Limits(),
+			Reviews:           store.Reviews(),
+			Devices:           store.Devices(),
+			Holds:             store.Holds(),
+			Interest:          store.Interest(),
+			TermDeposits:      store.TermDeposits(),
+			Fees:              store.Fees(),
+			TransferApprovals: store.TransferApprovals(),
+			Organizations:     store.Organizations(),
+			PaymentDrafts:     store.PaymentDrafts(),
+			Pots:              store.Pots(),
+			Pockets:           store.Pockets(),
+			ScreeningAlerts:   store.ScreeningAlerts(),
+			Idempotency:       store.Idempotency(),
+			UnitOfWork:        store,
+		}))
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		dsn := os.Getenv("TEST_DATABASE_URL")
+		if dsn == "" {
+			t.Skip("TEST_DATABASE_URL not set")
+		}
+		db, err := sqlx.Connect("postgres", dsn)
+		if err != nil {
+			t.Fatalf("connect: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		cacheService := cache.NewCacheService(cache.NewMemoryStore())
+		unitOfWork := postgres.NewUnitOfWork(db)
+		unitOfWork.OnAccountsChanged(cacheService.InvalidateAccounts)
+
+		fn(t, newTestServer(cacheService, &Deps{
+			Users:             postgres.NewUserRepository(db),
+			Accounts:          postgres.NewAccountRepository(db),
+			AccountMembers:    postgres.NewAccountMemberRepository(db),
+			Transactions:      postgres.NewTransactionRepository(db),
+			Payees:            postgres.NewPayeeRepository(db),
+			Limits:            postgres.NewLimitRepository(db),
+			Reviews:           postgres.NewReviewRepository(db),
+			Devices:           postgres.NewDeviceRepository(db),
+			Holds:             postgres.NewHoldRepository(db),
+			Interest:          postgres.NewInterestRepository(db),
+			TermDeposits:      postgres.NewTermDepositRepository(db),
+			Fees:              postgres.NewFeeRepository(db),
+			TransferApprovals: postgres.NewTransferApprovalRepository(db),
+			Organizations:     postgres.NewOrganizationRepository(db),
+			PaymentDrafts:     postgres.NewPaymentDraftRepository(db),
+			Pots:              postgres.NewPotRepository(db),
+			Pockets:           postgres.NewPocketRepository(db),
+			ScreeningAlerts:   postgres.NewScreeningAlertRepository(db),
+			Idempotency:       postgres.NewIdempotencyRepository(db),
+			UnitOfWork:        unitOfWork,
+		}))
+	})
+}
+
+// newTestServer puts the cache in front of the repositories and fills in
+// sessions and the JWT manager the way main does when Redis is available.
+func newTestServer(cacheService *cache.CacheService, deps *Deps) *testServer {
+	deps.Users = cached.NewCachedUserRepository(deps.Users, cacheService)
+	deps.Accounts = cached.NewCachedAccountRepository(deps.Accounts, cacheService)
+	deps.Sessions = session.NewSessionService(cacheService)
+	deps.JWT = utils.NewJWTManager("access-secret", "refresh-secret", time.Hour, 24*time.Hour)
+	deps.DisableRateLimit = true
+
+	return &testServer{Server: New(deps), deps: deps}
+}
+
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func (r *response) decode(t *testing.T, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(r.body, v); err != nil {
+		t.Fatalf("decode %s: %v", r.body, err)
+	}
+}
+
+// do sends a request with an optional JSON body and bearer token. Extra
+// headers are given as name, value pairs.
+func (s *testServer) do(t *testing.T, method, path, token string, body interface{}, headers ...string) *response {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+
+	resp, err := s.App.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return &response{status: resp.StatusCode, header: resp.Header, body: data}
+}
+
+// expect is do plus a status check.
+func (s *testServer) expect(t *testing.T, status int, method, path, token string, body interface{}, headers ...string) *response {
+	t.Helper()
+
+	resp := s.do(t, method, path, token, body, headers...)
+	if resp.status != status {
+		t.Fatalf("%s %s: status %d, want %d: %s", method, path, resp.status, status, resp.body)
+	}
+	return resp
+}
+
+// expectProblem checks resp is a problem details body for status and code,
+// tied to the request by its ID.
+func expectProblem(t *testing.T, resp *response, status int, code string) *apperror.Problem {
+	t.Helper()
+
+	if got := resp.header.Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("content type %q, want application/problem+json", got)
+	}
+	var problem apperror.Problem
+	resp.decode(t, &problem)
+	if problem.Status != status || problem.Code != code {
+		t.Errorf("problem %d %s, want %d %s", problem.Status, problem.Code, status, code)
+	}
+	if problem.Title == "" || problem.Instance == "" {
+		t.Errorf("problem %+v has no title or instance", problem)
+	}
+	if id := resp.header.Get("X-Request-ID"); id == "" || problem.RequestID != id {
+		t.Errorf("request ID %q, want the X-Request-ID header %q", problem.RequestID, id)
+	}
+	return &problem
+}
+
+// signUp registers a user, logs them in and returns the access token.
+func (s *testServer) signUp(t *testing.T, fullName string) string {
+	t.Helper()
+
+	email := fmt.Sprintf("%s@example.com", uuid.NewString())
+	s.expect(t, 201, "POST", "/api/v1/auth/register", "", domain.CreateUserRequest{
+		Email:    email,
+		Password: "correct-horse",
+		FullName: fullName,
+	})
+
+	var auth domain.AuthResponse
+	s.expect(t, 200, "POST", "/api/v1/auth/login", "", domain.LoginRequest{
+		Email:    email,
+		Password: "correct-horse",
+	}).decode(t, &auth)
+	if auth.AccessToken == "" {
+		t.Fatal("login returned no access token")
+	}
+	return auth.AccessToken
+}
+
+func (s *testServer) openAccount(t *testing.T, token string) *domain.Account {
+	t.Helper()
+
+	var account domain.Account
+	s.expect(t, 201, "POST", "/api/v1/accounts", token, domain.CreateAccountRequest{
+		AccountType: domain.AccountTypeChecking,
+		Currency:    "USD",
+	}).decode(t, &account)
+	return &account
+}
+
+// fund credits an account directly, since the API has no way to pay money in.
+func (s *testServer) fund(t *testing.T, accountID uuid.UUID, amount int64) {
+	t.Helper()
+
+	err := s.deps.UnitOfWork.Do(context.Background(), func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := repos.Accounts.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		account.Balance = account.Balance.Add(decimal.NewFromInt(amount))
+		return repos.Accounts.UpdateBalances(ctx, account)
+	})
+	if err != nil {
+		t.Fatalf("fund: %v", err)
+	}
+}
+
+func (s *testServer) balance(t *testing.T, token string, accountID uuid.UUID) decimal.Decimal {
+	t.Helper()
+
+	var account domain.Account
+	s.expect(t, 200, "GET", "/api/v1/accounts/"+accountID.String(), token, nil).decode(t, &account)
+	return account.Balance
+}
+
+func transferBody(from, to *domain.Account, amount int64) *domain.TransferRequest {
+	return &domain.TransferRequest{
+		FromAccountID: from.ID.String(),
+		ToAccountID:   to.ID.String(),
+		Amount:        decimal.NewFromInt(amount),
+		Description:   "dinner",
+	}
+}
+
+func TestTransferFlow(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		// Nothing is cached yet that the transfer would leave stale
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(100)) {
+			t.Fatalf("funded balance %s, want 100", got)
+		}
+
+		var tx domain.Transaction
+		s.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 40)).decode(t, &tx)
+		if tx.Status != domain.TransactionStatusCompleted {
+			t.Errorf("transfer status %s, want completed", tx.Status)
+		}
+
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(60)) {
+			t.Errorf("sender balance %s, want 60", got)
+		}
+		if got := s.balance(t, bob, to.ID); !got.Equal(decimal.NewFromInt(40)) {
+			t.Errorf("recipient balance %s, want 40", got)
+		}
+
+		var history struct {
+			Transactions []*domain.Transaction `json:"transactions"`
+		}
+		s.expect(t, 200, "GET", "/api/v1/transactions?account_id="+to.ID.String(), bob, nil).decode(t, &history)
+		if len(history.Transactions) != 1 || history.Transactions[0].ID != tx.ID {
+			t.Errorf("recipient history %+v, want the transfer", history.Transactions)
+		}
+
+		statement := s.expect(t, 200, "GET", "/api/v1/statements/"+from.ID.String()+"/csv", alice, nil)
+		if !strings.Contains(string(statement.body), tx.Reference) {
+			t.Errorf("statement is missing %s:\n%s", tx.Reference, statement.body)
+		}
+		pdf := s.expect(t, 200, "GET", "/api/v1/statements/"+from.ID.String()+"/pdf", alice, nil)
+		if !bytes.HasPrefix(pdf.body, []byte("%PDF")) {
+			t.Errorf("PDF statement starts %q", pdf.body[:min(len(pdf.body), 8)])
+		}
+	})
+}
+
+func TestTransferErrors(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		tests := []struct {
+			name   string
+			token  string
+			body   *domain.TransferRequest
+			status int
+			code   string
+		}{
+			{"no token", "", transferBody(from, to, 10), 401, "missing_authorization"},
+			{"insufficient balance", alice, transferBody(from, to, 101), 400, "insufficient_balance"},
+			{"same account", alice, transferBody(from, from, 10), 400, "same_account"},
+			{"someone else's account", bob, transferBody(from, to, 11), 403, "account_forbidden"},
+			{"over the per-transaction limit", alice, transferBody(from, to, 10001), 400, "insufficient_balance"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp := s.expect(t, tt.status, "POST", "/api/v1/transactions/transfer", tt.token, tt.body)
+				expectProblem(t, resp, tt.status, tt.code)
+			})
+		}
+
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("balance %s after failed transfers, want 100", got)
+		}
+	})
+}
+
+func TestValidation(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+
+		transfer := func(fields map[string]interface{}) map[string]interface{} {
+			body := map[string]interface{}{
+				"from_account_id": from.ID.String(),
+				"to_account_id":   to.ID.String(),
+				"amount":          "10.00",
+			}
+			for name, value := range fields {
+				if value == nil {
+					delete(body, name)
+				} else {
+					body[name] = value
+				}
+			}
+			return body
+		}
+
+		tests := []struct {
+			name   string
+			method string
+			path   string
+			body   interface{}
+			field  string
+		}{
+			{"amount with too many places", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"amount": "10.001"}), "amount"},
+			{"negative amount", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"amount": "-5"}), "amount"},
+			{"missing amount", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"amount": nil}), "amount"},
+			{"bad source", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"from_account_id": "savings"}), "from_account_id"},
+			{"no destination", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"to_account_id": nil}), "to_account_id"},
+			{"short account number", "POST", "/api/v1/transactions/transfer", transfer(map[string]interface{}{"to_account_id": nil, "to_account_number": "12345"}), "to_account_number"},
+			{"unknown currency", "POST", "/api/v1/accounts", map[string]string{"account_type": "checking", "currency": "ABC"}, "currency"},
+			{"payee account number", "POST", "/api/v1/payees", map[string]string{"account_number": "01234x6789", "nickname": "Bob"}, "account_number"},
+			{"negative limit", "PUT", "/api/v1/accounts/" + from.ID.String() + "/limits", map[string]string{"daily": "-1"}, "daily"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp := s.expect(t, 400, tt.method, tt.path, alice, tt.body)
+				problem := expectProblem(t, resp, 400, "validation_failed")
+				if len(problem.Fields) != 1 || problem.Fields[tt.field] == "" {
+					t.Errorf("fields %v, want just %s", problem.Fields, tt.field)
+				}
+			})
+		}
+
+		// The account with a bad currency wasn't opened
+		var accounts struct {
+			Accounts []*domain.Account `json:"accounts"`
+		}
+		s.expect(t, 200, "GET", "/api/v1/accounts", alice, nil).decode(t, &accounts)
+		if len(accounts.Accounts) != 1 {
+			t.Errorf("alice has %d accounts, want 1", len(accounts.Accounts))
+		}
+	})
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		tests := []struct {
+			name    string
+			headers []string
+		}{
+			{"explicit key", []string{"Idempotency-Key", uuid.NewString()}},
+			// Transfers without a key are keyed on their body
+			{"derived key", nil},
+		}
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				body := transferBody(from, to, 10)
+				body.Description = tt.name
+
+				first := s.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, body, tt.headers...)
+				replay := s.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, body, tt.headers...)
+				if !bytes.Equal(first.body, replay.body) {
+					t.Errorf("replay returned %s, want %s", replay.body, first.body)
+				}
+				if got := replay.header.Get("Idempotent-Replayed"); got != "true" {
+					t.Errorf("Idempotent-Replayed %q, want true", got)
+				}
+				if got, want := replay.header.Get("Content-Type"), first.header.Get("Content-Type"); got != want {
+					t.Errorf("replayed Content-Type %q, want %q", got, want)
+				}
+
+				want := decimal.NewFromInt(int64(100 - 10*(i+1)))
+				if got := s.balance(t, alice, from.ID); !got.Equal(want) {
+					t.Errorf("balance %s, want %s: the replay moved money", got, want)
+				}
+			})
+		}
+
+		// A failed request is replayed too rather than retried
+		key := uuid.NewString()
+		s.expect(t, 400, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 1000), "Idempotency-Key", key)
+		s.expect(t, 400, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 1000), "Idempotency-Key", key)
+
+		// Reusing a key for a different request is refused
+		s.expect(t, 422, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 10), "Idempotency-Key", key)
+
+		// Another user's key is their own
+		bobFrom := s.openAccount(t, bob)
+		s.expect(t, 400, "POST", "/api/v1/transactions/transfer", bob, transferBody(bobFrom, from, 5), "Idempotency-Key", key)
+	})
+}
+
+func TestIdempotentInFlight(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		var user domain.User
+		s.expect(t, 200, "GET", "/api/v1/users/profile", alice, nil).decode(t, &user)
+
+		// Stand in for a first request that is still running
+		key := uuid.NewString()
+		now := time.Now()
+		created, err := s.deps.Idempotency.Create(context.Background(), &domain.IdempotencyRecord{
+			ID:             uuid.New(),
+			IdempotencyKey: key,
+			UserID:         user.ID,
+			RequestPath:    "/api/v1/transactions/transfer",
+			Status:         domain.IdempotencyStatusInFlight,
+			CreatedAt:      now,
+			ExpiresAt:      now.Add(time.Hour),
+		})
+		if err != nil || !created {
+			t.Fatalf("reserve key: created %v, err %v", created, err)
+		}
+
+		resp := s.expect(t, 409, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 10), "Idempotency-Key", key)
+		if resp.header.Get("Retry-After") == "" {
+			t.Error("409 for an in-flight key has no Retry-After")
+		}
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(100)) {
+			t.Errorf("balance %s, want 100: the duplicate ran", got)
+		}
+	})
+}
+
+func TestRateLimits(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+
+		// Same app and tokens, with limits tight enough to trip
+		deps := *s.deps
+		deps.DisableRateLimit = false
+		deps.RateLimits = ratelimit.DefaultPolicies()
+		deps.RateLimits.User = ratelimit.Policy{Limit: 2, WindowSeconds: 60}
+		limited := &testServer{Server: New(&deps), deps: &deps}
+
+		for want := 1; want >= 0; want-- {
+			resp := limited.expect(t, 200, "GET", "/api/v1/accounts", alice, nil)
+			if got := resp.header.Get("RateLimit-Remaining"); got != strconv.Itoa(want) {
+				t.Errorf("RateLimit-Remaining %q, want %d", got, want)
+			}
+			if got := resp.header.Get("RateLimit-Limit"); got != "2" {
+				t.Errorf("RateLimit-Limit %q, want 2", got)
+			}
+		}
+
+		refused := limited.expect(t, 429, "GET", "/api/v1/accounts", alice, nil)
+		if refused.header.Get("Retry-After") == "" || refused.header.Get("RateLimit-Reset") == "" {
+			t.Errorf("429 headers %v, want Retry-After and RateLimit-Reset", refused.header)
+		}
+
+		// Users are limited separately even from the same address
+		limited.expect(t, 200, "GET", "/api/v1/accounts", bob, nil)
+	})
+}
+func TestRateLimitedPaymentIsRetryable(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		deps := *s.deps
+		deps.DisableRateLimit = false
+		deps.RateLimits = ratelimit.DefaultPolicies()
+		deps.RateLimits.Payments = ratelimit.Policy{Limit: 1, WindowSeconds: 60}
+		limited := &testServer{Server: New(&deps), deps: &deps}
+
+		limited.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 10), "Idempotency-Key", uuid.NewString())
+		key := uuid.NewString()
+		limited.expect(t, 429, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 20), "Idempotency-Key", key)
+
+		// Once the window has passed the retry runs rather than replaying the 429
+		resp := s.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 20), "Idempotency-Key", key)
+		if resp.header.Get("Idempotent-Replayed") != "" {
+			t.Error("the retry was replayed")
+		}
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(70)) {
+			t.Errorf("balance %s, want 70", got)
+		}
+	})
+}
+
+// conflictingUnitOfWork gives up on its next conflicts units of work the way
+// a busy database would.
+type conflictingUnitOfWork struct {
+	repository.UnitOfWork
+	conflicts atomic.Int32
+}
+
+func (u *conflictingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	if u.conflicts.Add(-1) >= 0 {
+		return repository.ErrConflict
+	}
+	return u.UnitOfWork.Do(ctx, fn)
+}
+
+func TestConflictedPaymentIsRetryable(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		deps := *s.deps
+		uow := &conflictingUnitOfWork{UnitOfWork: deps.UnitOfWork}
+		uow.conflicts.Store(1)
+		deps.UnitOfWork = uow
+		busy := &testServer{Server: New(&deps), deps: &deps}
+
+		key := uuid.NewString()
+		resp := busy.expect(t, 409, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 20), "Idempotency-Key", key)
+		expectProblem(t, resp, 409, "concurrent_update")
+
+		resp = busy.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, transferBody(from, to, 20), "Idempotency-Key", key)
+		if resp.header.Get("Idempotent-Replayed") != "" {
+			t.Error("the retry was replayed")
+		}
+		if got := s.balance(t, alice, from.ID); !got.Equal(decimal.NewFromInt(80)) {
+			t.Errorf("balance %s, want 80", got)
+		}
+	})
+}
+func TestAccountStatusIsFreshAfterClose(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		account := s.openAccount(t, alice)
+
+		// Warm the cache, then close the account
+		path := "/api/v1/accounts/" + account.ID.String()
+		s.expect(t, 200, "GET", path, alice, nil)
+		s.expect(t, 200, "POST", path+"/close", alice, domain.CloseAccountRequest{})
+
+		var got domain.Account
+		s.expect(t, 200, "GET", path, alice, nil).decode(t, &got)
+		if got.Status != domain.AccountStatusClosed {
+			t.Errorf("status %s, want %s", got.Status, domain.AccountStatusClosed)
+		}
+	})
+}
+func TestAccountLookupHasItsOwnLimit(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, s *testServer) {
+		alice := s.signUp(t, "Alice Smith")
+		bob := s.signUp(t, "Bob Jones")
+		from := s.openAccount(t, alice)
+		to := s.openAccount(t, bob)
+		s.fund(t, from.ID, 100)
+
+		deps := *s.deps
+		deps.DisableRateLimit = false
+		deps.RateLimits = ratelimit.DefaultPolicies()
+		deps.RateLimits.Lookup = ratelimit.Policy{Limit: 1, WindowSeconds: 60}
+		limited := &testServer{Server: New(&deps), deps: &deps}
+
+		var lookup domain.AccountLookupResponse
+		limited.expect(t, 200, "GET", "/api/v1/payees/lookup?account_number="+to.AccountNumber, alice, nil).decode(t, &lookup)
+		limited.expect(t, 429, "GET", "/api/v1/payees/lookup?account_number="+to.AccountNumber, alice, nil)
+		limited.expect(t, 200, "GET", "/api/v1/payees", alice, nil)
+
+		// Paying by account number shows the recipient the same way
+		var transaction domain.Transaction
+		limited.expect(t, 201, "POST", "/api/v1/transactions/transfer", alice, &domain.TransferRequest{
+			FromAccountID:   from.ID.String(),
+			ToAccountNumber: to.AccountNumber,
+			BeneficiaryName: "Bob Jones",
+			Amount:          decimal.NewFromInt(10),
+		}, "Idempotency-Key", uuid.NewString()).decode(t, &transaction)
+		if transaction.MaskedHolderName == "" || transaction.MaskedHolderName != lookup.MaskedHolderName {
+			t.Errorf("transfer shows the recipient as %q, the lookup as %q", transaction.MaskedHolderName, lookup.MaskedHolderName)
+		}
+	})
+}
\ No newline at end of file
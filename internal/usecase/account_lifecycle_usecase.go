@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrAccountClosed         = apperror.Unprocessable("account_closed", "the account is closed")
+	ErrAccountFrozen         = apperror.Unprocessable("account_frozen", "the account is frozen")
+	ErrAccountDormant        = apperror.Unprocessable("account_dormant", "the account is dormant, reactivate it to use it again")
+	ErrAccountNotDormant     = apperror.Unprocessable("account_not_dormant", "only dormant accounts can be reactivated")
+	ErrAccountOverdrawn      = apperror.Unprocessable("account_overdrawn", "an overdrawn account can't be closed until it is paid back")
+	ErrAccountHasHolds       = apperror.Unprocessable("account_has_holds", "the account has pending card holds")
+	ErrAccountHasPockets     = apperror.Unprocessable("account_has_pockets", "convert or move the money in the account's currency pockets before closing it")
+	ErrPayoutAccountInUse    = apperror.Unprocessable("payout_account_in_use", "a running term deposit pays out to the account")
+	ErrPayoutAccountRequired = apperror.BadRequest("payout_account_required", "a payout account is needed for the remaining balance")
+	ErrReverificationFailed  = apperror.Forbidden("reverification_failed", "the password is incorrect")
+)
+
+// DefaultDormancyMonths is how long an account can go without customer
+// activity before it is marked dormant.
+const DefaultDormancyMonths = 12
+
+const dormancyBatchSize = 100
+
+// AccountLifecycleUseCase closes accounts, marks idle ones dormant and
+// brings them back, and lets operators freeze them. Accounts are never
+// deleted, so their history stays whole.
+type AccountLifecycleUseCase struct {
+	accountRepo    repository.AccountRepository
+	memberRepo     repository.AccountMemberRepository
+	userRepo       repository.UserRepository
+	uow            repository.UnitOfWork
+	dormancyMonths int
+	now            func() time.Time
+}
+
+// NewAccountLifecycleUseCase marks accounts dormant after dormancyMonths
+// without activity, DefaultDormancyMonths if it isn't positive.
+func NewAccountLifecycleUseCase(accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, userRepo repository.UserRepository, uow repository.UnitOfWork, dormancyMonths int) *AccountLifecycleUseCase {
+	if dormancyMonths <= 0 {
+		dormancyMonths = DefaultDormancyMonths
+	}
+	return &AccountLifecycleUseCase{
+		accountRepo:    accountRepo,
+		memberRepo:     memberRepo,
+		userRepo:       userRepo,
+		uow:            uow,
+		dormancyMonths: dormancyMonths,
+		now:            time.Now,
+	}
+}
+
+// CloseAccount pays the final balance out to the nominated account, empties
+// the account's pots and closes it. Only the owner can close an account,
+// and not while money is held, locked in a term deposit, owed on an
+// overdraft or left in a currency pocket.
+func (uc *AccountLifecycleUseCase) CloseAccount(ctx context.Context, userID, accountID uuid.UUID, req *domain.CloseAccountRequest) (*domain.Account, error) {
+	payoutID := uuid.Nil
+	if req.PayoutAccountID != "" {
+		var err error
+		if payoutID, err = uuid.Parse(req.PayoutAccountID); err != nil {
+			return nil, ErrAccountNotFound
+		}
+		if payoutID == accountID {
+			return nil, ErrSameAccount
+		}
+	}
+
+	var account *domain.Account
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var payout *domain.Account
+		var err error
+		if payoutID != uuid.Nil {
+			account, payout, err = lockAccounts(ctx, repos, accountID, payoutID)
+		} else {
+			account, err = lockAccount(ctx, repos, accountID)
+		}
+		if err != nil {
+			return err
+		}
+		if err := authorizeMember(ctx, repos.AccountMembers, accountID, userID, isOwner); err != nil {
+			return err
+		}
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			return err
+		}
+		if err := uc.checkClosable(ctx, repos, account); err != nil {
+			return err
+		}
+
+		// Pot money goes back to the balance before it is paid out
+		pots, err := repos.Pots.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+		for _, pot := range pots {
+			if !pot.Balance.IsPositive() {
+				continue
+			}
+			pot, err := repos.Pots.GetByIDForUpdate(ctx, pot.ID)
+			if err != nil {
+				return err
+			}
+			if err := movePot(ctx, repos, account, pot, pot.Balance.Neg(), domain.PotMovementWithdrawal, nil); err != nil {
+				return err
+			}
+		}
+
+		if account.Balance.IsPositive() {
+			if payout == nil {
+				return ErrPayoutAccountRequired
+			}
+			if err := authorizeMember(ctx, repos.AccountMembers, payout.ID, userID, domain.AccountRole.CanTransact); err != nil {
+				return err
+			}
+			if err := checkCredit(payout); err != nil {
+				return err
+			}
+			if payout.Currency != account.Currency {
+				return ErrCurrencyMismatch
+			}
+			if err := moveFunds(ctx, repos, account, payout, account.Balance, "Account closure payout"); err != nil {
+				return err
+			}
+		}
+
+		if account.OverdraftLimit.IsPositive() {
+			account.OverdraftLimit = decimal.Zero
+			if err := repos.Accounts.UpdateOverdraftLimit(ctx, account); err != nil {
+				return err
+			}
+		}
+		return uc.setStatus(ctx, repos, account, domain.AccountStatusClosed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// checkClosable stops an account closing while money is still due in or out
+// of it.
+func (uc *AccountLifecycleUseCase) checkClosable(ctx context.Context, repos *repository.Repositories, account *domain.Account) error {
+	if account.Balance.IsNegative() {
+		return ErrAccountOverdrawn
+	}
+	if account.HeldBalance.IsPositive() {
+		return ErrAccountHasHolds
+	}
+
+	pockets, err := repos.Pockets.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	for _, pocket := range pockets {
+		if !pocket.Balance.IsZero() {
+			return ErrAccountHasPockets
+		}
+	}
+
+	deposits, err := repos.TermDeposits.GetActiveByPayoutAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	if len(deposits) > 0 {
+		return ErrPayoutAccountInUse
+	}
+	return nil
+}
+
+// Reactivate brings a dormant account back into use once the customer has
+// re-entered their password.
+func (uc *AccountLifecycleUseCase) Reactivate(ctx context.Context, userID, accountID uuid.UUID, req *domain.ReactivateAccountRequest) (*domain.Account, error) {
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	if !utils.CheckPassword(req.Password, user.PasswordHash) {
+		return nil, ErrReverificationFailed
+	}
+
+	var account *domain.Account
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err = lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if account.Status != domain.AccountStatusInactive {
+			return ErrAccountNotDormant
+		}
+		return uc.setStatus(ctx, repos, account, domain.AccountStatusActive)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// SetStatus freezes or unfreezes an account. Closed accounts stay closed,
+// and dormant ones are only unfrozen by their customers reactivating them.
+func (uc *AccountLifecycleUseCase) SetStatus(ctx context.Context, accountID uuid.UUID, req *domain.AccountStatusRequest) (*domain.Account, error) {
+	var account *domain.Account
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		account, err = lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case account.Status == domain.AccountStatusClosed:
+			return ErrAccountClosed
+		case account.Status == req.Status:
+			return nil
+		case account.Status == domain.AccountStatusInactive && req.Status == domain.AccountStatusActive:
+			return ErrAccountDormant
+		}
+		return uc.setStatus(ctx, repos, account, req.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// MarkDormantAccounts marks active accounts without customer activity in
+// the dormancy period inactive. It is run periodically by the job runner.
+func (uc *AccountLifecycleUseCase) MarkDormantAccounts(ctx context.Context) error {
+	since := uc.now().AddDate(0, -uc.dormancyMonths, 0)
+
+	after := uuid.Nil
+	for {
+		accounts, err := uc.accountRepo.GetDormant(ctx, since, after, dormancyBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			// Deposit accounts sit untouched for their whole term
+			if account.AccountType == domain.AccountTypeDeposit {
+				continue
+			}
+			if err := uc.markDormant(ctx, account.ID); err != nil {
+				log.Printf("Failed to mark account %s dormant: %v", account.ID, err)
+			}
+		}
+		if len(accounts) < dormancyBatchSize {
+			break
+		}
+		after = accounts[len(accounts)-1].ID
+	}
+
+	return nil
+}
+
+func (uc *AccountLifecycleUseCase) markDormant(ctx context.Context, accountID uuid.UUID) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if account.Status != domain.AccountStatusActive {
+			return nil
+		}
+		return uc.setStatus(ctx, repos, account, domain.AccountStatusInactive)
+	})
+}
+
+func (uc *AccountLifecycleUseCase) setStatus(ctx context.Context, repos *repository.Repositories, account *domain.Account, status domain.AccountStatus) error {
+	changedAt := uc.now()
+	account.Status = status
+	account.StatusChangedAt = &changedAt
+	return repos.Accounts.UpdateStatus(ctx, account)
+}
+
+// checkDebit stops money leaving an account that isn't active.
+func checkDebit(account *domain.Account) error {
+	switch account.Status {
+	case domain.AccountStatusClosed:
+		return ErrAccountClosed
+	case domain.AccountStatusFrozen:
+		return ErrAccountFrozen
+	case domain.AccountStatusInactive:
+		return ErrAccountDormant
+	}
+	return nil
+}
+
+// checkCredit stops money reaching a closed account. Frozen and dormant
+// accounts can still be paid into.
+func checkCredit(account *domain.Account) error {
+	if account.Status == domain.AccountStatusClosed {
+		return ErrAccountClosed
+	}
+	return nil
+}
\ No newline at end of file
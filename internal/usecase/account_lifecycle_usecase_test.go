@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
+)
+
+func TestCloseAccount(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	owner := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, owner.ID, 100)
+	payout := env.newAccount(t, owner.ID, 0)
+	empty := env.newAccount(t, owner.ID, 0)
+	held := env.newAccount(t, owner.ID, 50)
+	funded := env.newAccount(t, owner.ID, 5)
+	env.newHold(t, held, 10)
+
+	pot, err := env.pots.CreatePot(ctx, owner.ID, account.ID, &domain.CreatePotRequest{Name: "Holiday"})
+	if err != nil {
+		t.Fatalf("CreatePot: %v", err)
+	}
+	if _, err := env.pots.Deposit(ctx, owner.ID, pot.ID, decimal.NewFromInt(30)); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		payoutID  string
+		wantErr   error
+	}{
+		{"someone else", uuid.New(), account.ID, payout.ID.String(), ErrUnauthorized},
+		{"missing account", owner.ID, uuid.New(), "", ErrAccountNotFound},
+		{"no payout account", owner.ID, account.ID, "", ErrPayoutAccountRequired},
+		{"paid out to itself", owner.ID, account.ID, account.ID.String(), ErrSameAccount},
+		{"pending holds", owner.ID, held.ID, payout.ID.String(), ErrAccountHasHolds},
+		{"empty account", owner.ID, empty.ID, "", nil},
+		{"with a balance", owner.ID, account.ID, payout.ID.String(), nil},
+		{"already closed", owner.ID, account.ID, payout.ID.String(), ErrAccountClosed},
+		{"paid out to a closed account", owner.ID, funded.ID, empty.ID.String(), ErrAccountClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.lifecycle.CloseAccount(ctx, tt.userID, tt.accountID, &domain.CloseAccountRequest{PayoutAccountID: tt.payoutID})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The pot is emptied and everything is paid out, but the account and
+	// its history stay
+	closed := env.account(t, account.ID)
+	if closed.Status != domain.AccountStatusClosed || closed.StatusChangedAt == nil || !closed.PotBalance.IsZero() {
+		t.Errorf("account status %s changed at %v with %s in pots, want closed and empty", closed.Status, closed.StatusChangedAt, closed.PotBalance)
+	}
+	env.assertBalances(t, account.ID, 0, 0)
+	env.assertBalances(t, payout.ID, 100, 0)
+	history, err := env.transactions.GetTransactionHistory(ctx, owner.ID, account.ID, &domain.TransactionFilter{Limit: 10})
+	if err != nil || len(history) != 1 {
+		t.Errorf("history = %v, %v; want the payout", history, err)
+	}
+
+	if _, err := env.transactions.Transfer(ctx, owner.ID, &domain.TransferRequest{FromAccountID: payout.ID.String(), ToAccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountClosed {
+		t.Errorf("Transfer to a closed account: error %v, want %v", err, ErrAccountClosed)
+	}
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountClosed {
+		t.Errorf("Deposit to a closed account: error %v, want %v", err, ErrAccountClosed)
+	}
+	if _, err := env.lifecycle.SetStatus(ctx, account.ID, &domain.AccountStatusRequest{Status: domain.AccountStatusActive}); err != ErrAccountClosed {
+		t.Errorf("reopening a closed account: error %v, want %v", err, ErrAccountClosed)
+	}
+}
+
+func TestFrozenAccount(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+	other := env.newAccount(t, bob.ID, 100)
+
+	if _, err := env.lifecycle.SetStatus(ctx, account.ID, &domain.AccountStatusRequest{Status: domain.AccountStatusFrozen}); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	// Nothing leaves a frozen account, but it can still be paid into
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: other.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountFrozen {
+		t.Errorf("Transfer: error %v, want %v", err, ErrAccountFrozen)
+	}
+	if _, err := env.transactions.Withdraw(ctx, &domain.WithdrawalRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountFrozen {
+		t.Errorf("Withdraw: error %v, want %v", err, ErrAccountFrozen)
+	}
+	if _, err := env.holds.AuthorizeHold(ctx, alice.ID, &domain.AuthorizeHoldRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountFrozen {
+		t.Errorf("AuthorizeHold: error %v, want %v", err, ErrAccountFrozen)
+	}
+	if _, err := env.lifecycle.CloseAccount(ctx, alice.ID, account.ID, &domain.CloseAccountRequest{PayoutAccountID: other.ID.String()}); err != ErrAccountFrozen {
+		t.Errorf("CloseAccount: error %v, want %v", err, ErrAccountFrozen)
+	}
+	if _, err := env.transactions.Transfer(ctx, bob.ID, &domain.TransferRequest{FromAccountID: other.ID.String(), ToAccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != nil {
+		t.Errorf("Transfer to a frozen account: %v", err)
+	}
+
+	if _, err := env.lifecycle.SetStatus(ctx, account.ID, &domain.AccountStatusRequest{Status: domain.AccountStatusActive}); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: other.ID.String(), Amount: decimal.NewFromInt(10)}); err != nil {
+		t.Errorf("Transfer after unfreezing: %v", err)
+	}
+	env.assertBalances(t, account.ID, 100, 0)
+}
+
+func TestDormantAccounts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	hash, err := utils.HashPassword("password123")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	alice := &domain.User{Email: "alice@example.com", PasswordHash: hash, FullName: "Alice Smith", Tier: domain.UserTierStandard, Role: domain.UserRoleCustomer}
+	if err := env.store.Users().Create(ctx, alice); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+	other := env.newAccount(t, bob.ID, 100)
+
+	// Nothing is dormant until the dormancy period has passed
+	if err := env.lifecycle.MarkDormantAccounts(ctx); err != nil {
+		t.Fatalf("MarkDormantAccounts: %v", err)
+	}
+	if status := env.account(t, account.ID).Status; status != domain.AccountStatusActive {
+		t.Fatalf("status %s before the dormancy period, want active", status)
+	}
+
+	env.clock.now = env.clock.now.AddDate(0, DefaultDormancyMonths, 1)
+	if err := env.lifecycle.MarkDormantAccounts(ctx); err != nil {
+		t.Fatalf("MarkDormantAccounts: %v", err)
+	}
+	if status := env.account(t, account.ID).Status; status != domain.AccountStatusInactive {
+		t.Fatalf("status %s after the dormancy period, want inactive", status)
+	}
+
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: other.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrAccountDormant {
+		t.Errorf("Transfer from a dormant account: error %v, want %v", err, ErrAccountDormant)
+	}
+	if _, err := env.lifecycle.SetStatus(ctx, account.ID, &domain.AccountStatusRequest{Status: domain.AccountStatusActive}); err != ErrAccountDormant {
+		t.Errorf("operator reactivation: error %v, want %v", err, ErrAccountDormant)
+	}
+	if _, err := env.lifecycle.Reactivate(ctx, bob.ID, account.ID, &domain.ReactivateAccountRequest{Password: "password123"}); err != ErrUnauthorized {
+		t.Errorf("outsider Reactivate: error %v, want %v", err, ErrUnauthorized)
+	}
+	if _, err := env.lifecycle.Reactivate(ctx, alice.ID, account.ID, &domain.ReactivateAccountRequest{Password: "password124"}); err != ErrReverificationFailed {
+		t.Errorf("Reactivate with the wrong password: error %v, want %v", err, ErrReverificationFailed)
+	}
+	if _, err := env.lifecycle.Reactivate(ctx, alice.ID, account.ID, &domain.ReactivateAccountRequest{Password: "password123"}); err != nil {
+		t.Fatalf("Reactivate: %v", err)
+	}
+	if _, err := env.lifecycle.Reactivate(ctx, alice.ID, account.ID, &domain.ReactivateAccountRequest{Password: "password123"}); err != ErrAccountNotDormant {
+		t.Errorf("Reactivate an active account: error %v, want %v", err, ErrAccountNotDormant)
+	}
+
+	// Reactivation starts the dormancy period again
+	if err := env.lifecycle.MarkDormantAccounts(ctx); err != nil {
+		t.Fatalf("MarkDormantAccounts: %v", err)
+	}
+	if status := env.account(t, account.ID).Status; status != domain.AccountStatusActive {
+		t.Errorf("status %s just after reactivation, want active", status)
+	}
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: other.ID.String(), Amount: decimal.NewFromInt(10)}); err != nil {
+		t.Errorf("Transfer after reactivation: %v", err)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+var (
+	ErrMemberNotFound     = apperror.NotFound("member_not_found", "member not found")
+	ErrInvitationNotFound = apperror.NotFound("invitation_not_found", "invitation not found")
+	ErrAlreadyMember      = apperror.Conflict("already_member", "the user is already a member of the account")
+	ErrOwnerNotRemovable  = apperror.Unprocessable("owner_not_removable", "the account owner can't be removed")
+	ErrTooFewApprovers    = apperror.Unprocessable("too_few_approvers", "the account has fewer members who can transact than the approvals required")
+	ErrBusinessAccount    = apperror.Unprocessable("business_account", "business accounts are shared and approved through their organization")
+)
+
+// AccountMemberUseCase shares accounts between users. The owner and joint
+// holders invite others as joint holders, signatories or viewers, and can
+// make transfers above a threshold wait for several members to approve them.
+type AccountMemberUseCase struct {
+	memberRepo  repository.AccountMemberRepository
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	now         func() time.Time
+}
+
+func NewAccountMemberUseCase(memberRepo repository.AccountMemberRepository, accountRepo repository.AccountRepository, userRepo repository.UserRepository) *AccountMemberUseCase {
+	return &AccountMemberUseCase{
+		memberRepo:  memberRepo,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		now:         time.Now,
+	}
+}
+
+// InviteMember invites the user with the given email to the account. They
+// get no access until they accept.
+func (uc *AccountMemberUseCase) InviteMember(ctx context.Context, userID, accountID uuid.UUID, req *domain.InviteMemberRequest) (*domain.AccountMember, error) {
+	if err := uc.authorizePersonal(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+
+	invitee, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if invitee == nil {
+		return nil, ErrUserNotFound
+	}
+	existing, err := uc.memberRepo.Get(ctx, accountID, invitee.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	member := &domain.AccountMember{
+		AccountID: accountID,
+		UserID:    invitee.ID,
+		Role:      req.Role,
+		Status:    domain.MemberStatusInvited,
+		InvitedBy: &userID,
+	}
+	if err := uc.memberRepo.Create(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (uc *AccountMemberUseCase) GetMembers(ctx context.Context, userID, accountID uuid.UUID) ([]*domain.AccountMember, error) {
+	if err := uc.authorize(ctx, userID, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.memberRepo.GetByAccountID(ctx, accountID)
+}
+
+func (uc *AccountMemberUseCase) GetInvitations(ctx context.Context, userID uuid.UUID) ([]*domain.AccountMember, error) {
+	return uc.memberRepo.GetInvitations(ctx, userID)
+}
+
+func (uc *AccountMemberUseCase) AcceptInvitation(ctx context.Context, userID, accountID uuid.UUID) (*domain.AccountMember, error) {
+	member, err := uc.invitation(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	member.Status = domain.MemberStatusActive
+	member.AcceptedAt = &now
+	if err := uc.memberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (uc *AccountMemberUseCase) DeclineInvitation(ctx context.Context, userID, accountID uuid.UUID) error {
+	if _, err := uc.invitation(ctx, userID, accountID); err != nil {
+		return err
+	}
+
+	return uc.memberRepo.Delete(ctx, accountID, userID)
+}
+
+func (uc *AccountMemberUseCase) invitation(ctx context.Context, userID, accountID uuid.UUID) (*domain.AccountMember, error) {
+	member, err := uc.memberRepo.Get(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Status != domain.MemberStatusInvited {
+		return nil, ErrInvitationNotFound
+	}
+	return member, nil
+}
+
+// RemoveMember takes a member off the account, or withdraws their
+// invitation. Members can always remove themselves; removing anyone else
+// takes a manager. A member can't be removed while the approval policy
+// needs them.
+func (uc *AccountMemberUseCase) RemoveMember(ctx context.Context, userID, accountID, memberID uuid.UUID) error {
+	if memberID != userID {
+		if err := uc.authorize(ctx, userID, accountID, domain.AccountRole.CanManage); err != nil {
+			return err
+		}
+	}
+
+	member, err := uc.memberRepo.Get(ctx, accountID, memberID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrMemberNotFound
+	}
+	if member.Role == domain.AccountRoleOwner {
+		return ErrOwnerNotRemovable
+	}
+
+	if member.Status == domain.MemberStatusActive && member.Role.CanTransact() {
+		policy, err := uc.memberRepo.GetApprovalPolicy(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		approvers, err := uc.approvers(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if policy != nil && approvers-1 < policy.RequiredApprovals {
+			return ErrTooFewApprovers
+		}
+	}
+
+	return uc.memberRepo.Delete(ctx, accountID, memberID)
+}
+
+// GetApprovalPolicy returns the account's policy; an account without one
+// reports a single required approval.
+func (uc *AccountMemberUseCase) GetApprovalPolicy(ctx context.Context, userID, accountID uuid.UUID) (*domain.ApprovalPolicy, error) {
+	if err := uc.authorize(ctx, userID, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.memberRepo.GetApprovalPolicy(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &domain.ApprovalPolicy{AccountID: accountID, RequiredApprovals: 1}, nil
+	}
+	return policy, nil
+}
+
+// SetApprovalPolicy makes transfers above the threshold wait for the
+// required number of approvals. Requiring one approval removes the policy.
+func (uc *AccountMemberUseCase) SetApprovalPolicy(ctx context.Context, userID, accountID uuid.UUID, req *domain.ApprovalPolicyRequest) (*domain.ApprovalPolicy, error) {
+	if err := uc.authorizePersonal(ctx, userID, accountID); err != nil {
+		return nil, err
+	}
+
+	policy := &domain.ApprovalPolicy{
+		AccountID:         accountID,
+		Threshold:         req.Threshold,
+		RequiredApprovals: req.RequiredApprovals,
+	}
+	if policy.RequiredApprovals <= 1 {
+		if err := uc.memberRepo.DeleteApprovalPolicy(ctx, accountID); err != nil {
+			return nil, err
+		}
+		policy.UpdatedAt = uc.now()
+		return policy, nil
+	}
+
+	approvers, err := uc.approvers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if approvers < policy.RequiredApprovals {
+		return nil, ErrTooFewApprovers
+	}
+
+	if err := uc.memberRepo.SetApprovalPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// approvers counts the active members who can approve transfers.
+func (uc *AccountMemberUseCase) approvers(ctx context.Context, accountID uuid.UUID) (int, error) {
+	members, err := uc.memberRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, member := range members {
+		if member.Status == domain.MemberStatusActive && member.Role.CanTransact() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (uc *AccountMemberUseCase) authorize(ctx context.Context, userID, accountID uuid.UUID, allowed func(domain.AccountRole) bool) error {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrAccountNotFound
+	}
+
+	return authorizeMember(ctx, uc.memberRepo, accountID, userID, allowed)
+}
+
+// authorizePersonal admits managers of an account that doesn't belong to an
+// organization.
+func (uc *AccountMemberUseCase) authorizePersonal(ctx context.Context, userID, accountID uuid.UUID) error {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrAccountNotFound
+	}
+	if account.OrganizationID != nil {
+		return ErrBusinessAccount
+	}
+
+	return authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanManage)
+}
+
+// authorizeMember returns ErrUnauthorized unless the user is an active member
+// of the account in a role allowed passes. A nil allowed admits any active
+// member, viewers included.
+func authorizeMember(ctx context.Context, memberRepo repository.AccountMemberRepository, accountID, userID uuid.UUID, allowed func(domain.AccountRole) bool) error {
+	ok, err := isMember(ctx, memberRepo, accountID, userID, allowed)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+func isMember(ctx context.Context, memberRepo repository.AccountMemberRepository, accountID, userID uuid.UUID, allowed func(domain.AccountRole) bool) (bool, error) {
+	member, err := memberRepo.Get(ctx, accountID, userID)
+	if err != nil {
+		return false, err
+	}
+	if member == nil || member.Status != domain.MemberStatusActive {
+		return false, nil
+	}
+	return allowed == nil || allowed(member.Role), nil
+}
\ No newline at end of file
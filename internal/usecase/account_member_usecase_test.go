@@ -0,0 +1,375 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// addMember has the account holder invite the user in the role and the user
+// accept.
+func (env *testEnv) addMember(t *testing.T, account *domain.Account, user *domain.User, role domain.AccountRole) {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, err := env.members.InviteMember(ctx, account.UserID, account.ID, &domain.InviteMemberRequest{Email: user.Email, Role: role}); err != nil {
+		t.Fatalf("InviteMember: %v", err)
+	}
+	if _, err := env.members.AcceptInvitation(ctx, user.ID, account.ID); err != nil {
+		t.Fatalf("AcceptInvitation: %v", err)
+	}
+}
+
+func TestAccountMembers(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	carol := env.newUser(t, "Carol White")
+	account := env.newAccount(t, alice.ID, 100)
+	to := env.newAccount(t, carol.ID, 0)
+
+	transfer := func(userID uuid.UUID) error {
+		_, err := env.transactions.Transfer(ctx, userID, &domain.TransferRequest{
+			FromAccountID: account.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(10),
+		})
+		return err
+	}
+
+	// An invitation gives no access until it is accepted
+	if _, err := env.members.InviteMember(ctx, alice.ID, account.ID, &domain.InviteMemberRequest{Email: bob.Email, Role: domain.AccountRoleViewer}); err != nil {
+		t.Fatalf("InviteMember: %v", err)
+	}
+	if _, err := env.members.InviteMember(ctx, alice.ID, account.ID, &domain.InviteMemberRequest{Email: bob.Email, Role: domain.AccountRoleSignatory}); err != ErrAlreadyMember {
+		t.Errorf("second invitation: error %v, want %v", err, ErrAlreadyMember)
+	}
+	if invitations, err := env.members.GetInvitations(ctx, bob.ID); err != nil || len(invitations) != 1 {
+		t.Fatalf("GetInvitations = %d invitations, %v; want 1", len(invitations), err)
+	}
+	if _, err := env.members.GetMembers(ctx, bob.ID, account.ID); err != ErrUnauthorized {
+		t.Errorf("GetMembers before accepting: error %v, want %v", err, ErrUnauthorized)
+	}
+	if _, err := env.members.AcceptInvitation(ctx, bob.ID, account.ID); err != nil {
+		t.Fatalf("AcceptInvitation: %v", err)
+	}
+	if accounts, err := env.accounts.GetUserAccounts(ctx, bob.ID); err != nil || len(accounts) != 1 || accounts[0].ID != account.ID {
+		t.Errorf("GetUserAccounts = %v, %v; want the shared account", accounts, err)
+	}
+
+	// Viewers can look but not move money or manage the account
+	if _, err := env.limits.GetAccountLimits(ctx, bob.ID, account.ID); err != nil {
+		t.Errorf("viewer GetAccountLimits: %v", err)
+	}
+	if err := transfer(bob.ID); err != ErrUnauthorized {
+		t.Errorf("viewer transfer: error %v, want %v", err, ErrUnauthorized)
+	}
+	if _, err := env.members.InviteMember(ctx, bob.ID, account.ID, &domain.InviteMemberRequest{Email: carol.Email, Role: domain.AccountRoleViewer}); err != ErrUnauthorized {
+		t.Errorf("viewer invitation: error %v, want %v", err, ErrUnauthorized)
+	}
+
+	// A signatory can move money but not close the account
+	if err := env.members.RemoveMember(ctx, bob.ID, account.ID, bob.ID); err != nil {
+		t.Fatalf("RemoveMember self: %v", err)
+	}
+	env.addMember(t, account, bob, domain.AccountRoleSignatory)
+	if err := transfer(bob.ID); err != nil {
+		t.Errorf("signatory transfer: %v", err)
+	}
+	if _, err := env.lifecycle.CloseAccount(ctx, bob.ID, account.ID, &domain.CloseAccountRequest{}); err != ErrUnauthorized {
+		t.Errorf("signatory CloseAccount: error %v, want %v", err, ErrUnauthorized)
+	}
+
+	if err := env.members.RemoveMember(ctx, bob.ID, account.ID, alice.ID); err != ErrUnauthorized {
+		t.Errorf("signatory removing the owner: error %v, want %v", err, ErrUnauthorized)
+	}
+	if err := env.members.RemoveMember(ctx, alice.ID, account.ID, alice.ID); err != ErrOwnerNotRemovable {
+		t.Errorf("owner leaving: error %v, want %v", err, ErrOwnerNotRemovable)
+	}
+	if err := env.members.RemoveMember(ctx, alice.ID, account.ID, bob.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if err := transfer(bob.ID); err != ErrUnauthorized {
+		t.Errorf("transfer after removal: error %v, want %v", err, ErrUnauthorized)
+	}
+}
+
+func TestTransferApprovals(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	carol := env.newUser(t, "Carol White")
+	viewer := env.newUser(t, "Dan Brown")
+	account := env.newAccount(t, alice.ID, 1000)
+	to := env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+
+	policy := &domain.ApprovalPolicyRequest{Threshold: decimal.NewFromInt(100), RequiredApprovals: 2}
+	if _, err := env.members.SetApprovalPolicy(ctx, alice.ID, account.ID, policy); err != ErrTooFewApprovers {
+		t.Errorf("policy with one approver: error %v, want %v", err, ErrTooFewApprovers)
+	}
+	env.addMember(t, account, bob, domain.AccountRoleJointHolder)
+	env.addMember(t, account, carol, domain.AccountRoleSignatory)
+	env.addMember(t, account, viewer, domain.AccountRoleViewer)
+	if _, err := env.members.SetApprovalPolicy(ctx, bob.ID, account.ID, policy); err != nil {
+		t.Fatalf("SetApprovalPolicy: %v", err)
+	}
+
+	req := func(amount int64) *domain.TransferRequest {
+		return &domain.TransferRequest{
+			FromAccountID: account.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(amount),
+		}
+	}
+
+	// Transfers up to the threshold go straight through; above it they
+	// need approval
+	if _, err := env.transactions.Transfer(ctx, carol.ID, req(100)); err != nil {
+		t.Fatalf("Transfer at the threshold: %v", err)
+	}
+	if _, err := env.transactions.Transfer(ctx, carol.ID, req(300)); err != ErrApprovalRequired {
+		t.Fatalf("Transfer above the threshold: error %v, want %v", err, ErrApprovalRequired)
+	}
+	if _, err := env.approvals.RequestTransfer(ctx, carol.ID, req(50)); err != ErrApprovalNotRequired {
+		t.Errorf("RequestTransfer below the threshold: error %v, want %v", err, ErrApprovalNotRequired)
+	}
+
+	approval, err := env.approvals.RequestTransfer(ctx, carol.ID, req(300))
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if _, err := env.approvals.Approve(ctx, carol.ID, approval.ID); err != ErrAlreadyApproved {
+		t.Errorf("requester approving: error %v, want %v", err, ErrAlreadyApproved)
+	}
+	if _, err := env.approvals.Approve(ctx, viewer.ID, approval.ID); err != ErrUnauthorized {
+		t.Errorf("viewer approving: error %v, want %v", err, ErrUnauthorized)
+	}
+	env.assertBalances(t, account.ID, 900, 0)
+
+	approval, err = env.approvals.Approve(ctx, alice.ID, approval.ID)
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if approval.Status != domain.TransferApprovalExecuted || approval.TransactionID == nil {
+		t.Errorf("approval %+v, want executed with its transaction", approval)
+	}
+	env.assertBalances(t, account.ID, 600, 0)
+	env.assertBalances(t, to.ID, 400, 0)
+	if _, err := env.approvals.Approve(ctx, bob.ID, approval.ID); err != ErrApprovalAlreadyDecided {
+		t.Errorf("approving an executed transfer: error %v, want %v", err, ErrApprovalAlreadyDecided)
+	}
+
+	// A rejected transfer is never made
+	rejected, err := env.approvals.RequestTransfer(ctx, alice.ID, req(200))
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if rejected, err = env.approvals.Reject(ctx, carol.ID, rejected.ID); err != nil || rejected.Status != domain.TransferApprovalRejected {
+		t.Fatalf("Reject = %+v, %v", rejected, err)
+	}
+	if _, err := env.approvals.Approve(ctx, bob.ID, rejected.ID); err != ErrApprovalAlreadyDecided {
+		t.Errorf("approving a rejected transfer: error %v, want %v", err, ErrApprovalAlreadyDecided)
+	}
+
+	// One that can no longer be paid fails when the last approval comes in
+	failing, err := env.approvals.RequestTransfer(ctx, alice.ID, req(700))
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if _, err := env.approvals.Approve(ctx, bob.ID, failing.ID); err != ErrInsufficientBalance {
+		t.Errorf("Approve unpayable transfer: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	failing, err = env.store.TransferApprovals().GetByID(ctx, failing.ID)
+	if err != nil || failing.Status != domain.TransferApprovalFailed || failing.Failure == nil {
+		t.Errorf("failed approval = %+v, %v; want failed with the reason", failing, err)
+	}
+	env.assertBalances(t, account.ID, 600, 0)
+
+	if approvals, err := env.approvals.GetAccountApprovals(ctx, viewer.ID, account.ID); err != nil || len(approvals) != 3 {
+		t.Errorf("GetAccountApprovals = %d approvals, %v; want 3", len(approvals), err)
+	}
+
+	// The policy's approvers can't leave while it needs them
+	if err := env.members.RemoveMember(ctx, alice.ID, account.ID, carol.ID); err != nil {
+		t.Fatalf("RemoveMember: %v", err)
+	}
+	if err := env.members.RemoveMember(ctx, bob.ID, account.ID, bob.ID); err != ErrTooFewApprovers {
+		t.Errorf("removing a needed approver: error %v, want %v", err, ErrTooFewApprovers)
+	}
+}
+
+func TestTransferApprovalToDeletedPayee(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 1000)
+	to := env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+	env.addMember(t, account, bob, domain.AccountRoleJointHolder)
+	if _, err := env.members.SetApprovalPolicy(ctx, alice.ID, account.ID, &domain.ApprovalPolicyRequest{
+		Threshold:         decimal.NewFromInt(100),
+		RequiredApprovals: 2,
+	}); err != nil {
+		t.Fatalf("SetApprovalPolicy: %v", err)
+	}
+
+	payee := env.payeeFor(t, alice.ID, to)
+	approval, err := env.approvals.RequestTransfer(ctx, alice.ID, &domain.TransferRequest{
+		FromAccountID: account.ID.String(),
+		PayeeID:       payee.ID.String(),
+		Amount:        decimal.NewFromInt(300),
+	})
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if approval.ToAccountID == nil || *approval.ToAccountID != to.ID {
+		t.Errorf("approval to %v, want the payee's account %s", approval.ToAccountID, to.ID)
+	}
+
+	// Deleting the payee leaves the approval going to the account it named
+	if err := env.payees.DeletePayee(ctx, alice.ID, payee.ID); err != nil {
+		t.Fatalf("DeletePayee: %v", err)
+	}
+	if approval, err = env.approvals.Approve(ctx, bob.ID, approval.ID); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if approval.Status != domain.TransferApprovalExecuted || approval.PayeeID != nil {
+		t.Errorf("approval %+v, want executed without the deleted payee", approval)
+	}
+	env.assertBalances(t, account.ID, 700, 0)
+	env.assertBalances(t, to.ID, 300, 0)
+}
+
+// conflictingUnitOfWork fails the next unit of work as if it kept colliding
+// with concurrent work, when armed.
+type conflictingUnitOfWork struct {
+	repository.UnitOfWork
+	armed bool
+}
+
+func (u *conflictingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	if u.armed {
+		u.armed = false
+		return repository.ErrConflict
+	}
+	return u.UnitOfWork.Do(ctx, fn)
+}
+
+func TestExecuteTransferApproval(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	viewer := env.newUser(t, "Dan Brown")
+	account := env.newAccount(t, alice.ID, 1000)
+	to := env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+	env.addMember(t, account, bob, domain.AccountRoleJointHolder)
+	env.addMember(t, account, viewer, domain.AccountRoleViewer)
+	if _, err := env.members.SetApprovalPolicy(ctx, alice.ID, account.ID, &domain.ApprovalPolicyRequest{
+		Threshold:         decimal.NewFromInt(100),
+		RequiredApprovals: 2,
+	}); err != nil {
+		t.Fatalf("SetApprovalPolicy: %v", err)
+	}
+
+	approval, err := env.approvals.RequestTransfer(ctx, alice.ID, &domain.TransferRequest{
+		FromAccountID: account.ID.String(),
+		ToAccountID:   to.ID.String(),
+		Amount:        decimal.NewFromInt(300),
+	})
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if _, err := env.approvals.Execute(ctx, alice.ID, approval.ID); err != ErrApprovalNotApproved {
+		t.Errorf("Execute before approval: error %v, want %v", err, ErrApprovalNotApproved)
+	}
+
+	// A transfer that collides with other work leaves the approval approved
+	uow := &conflictingUnitOfWork{UnitOfWork: env.store, armed: true}
+	env.transactions.uow = uow
+	if _, err := env.approvals.Approve(ctx, bob.ID, approval.ID); err != ErrConcurrentUpdate {
+		t.Fatalf("Approve on conflict: error %v, want %v", err, ErrConcurrentUpdate)
+	}
+	approval, err = env.store.TransferApprovals().GetByID(ctx, approval.ID)
+	if err != nil || approval.Status != domain.TransferApprovalApproved || approval.TransactionID != nil {
+		t.Fatalf("approval after conflict = %+v, %v; want approved and not made", approval, err)
+	}
+	env.assertBalances(t, account.ID, 1000, 0)
+
+	// and any member who can transact can make it
+	if _, err := env.approvals.Execute(ctx, viewer.ID, approval.ID); err != ErrUnauthorized {
+		t.Errorf("viewer executing: error %v, want %v", err, ErrUnauthorized)
+	}
+	if approval, err = env.approvals.Execute(ctx, alice.ID, approval.ID); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if approval.Status != domain.TransferApprovalExecuted || approval.TransactionID == nil {
+		t.Errorf("approval %+v, want executed with its transaction", approval)
+	}
+	if _, err := env.approvals.Execute(ctx, bob.ID, approval.ID); err != ErrApprovalNotApproved {
+		t.Errorf("executing twice: error %v, want %v", err, ErrApprovalNotApproved)
+	}
+	env.assertBalances(t, account.ID, 700, 0)
+	env.assertBalances(t, to.ID, 300, 0)
+}
+
+func TestTransferApprovalFromPocket(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+	to := env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(40), Currency: "EUR"}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	env.addMember(t, account, bob, domain.AccountRoleJointHolder)
+	if _, err := env.members.SetApprovalPolicy(ctx, alice.ID, account.ID, &domain.ApprovalPolicyRequest{
+		Threshold:         decimal.NewFromInt(30),
+		RequiredApprovals: 2,
+	}); err != nil {
+		t.Fatalf("SetApprovalPolicy: %v", err)
+	}
+
+	// 30 EUR is worth 37.50 USD, over the threshold, so it needs approval
+	// even though the amount itself isn't
+	req := &domain.TransferRequest{
+		FromAccountID: account.ID.String(),
+		ToAccountID:   to.ID.String(),
+		FromCurrency:  "EUR",
+		Amount:        decimal.NewFromInt(30),
+		DeviceID:      "phone",
+	}
+	if _, err := env.transactions.Transfer(ctx, alice.ID, req); err != ErrApprovalRequired {
+		t.Fatalf("Transfer: error %v, want %v", err, ErrApprovalRequired)
+	}
+	approval, err := env.approvals.RequestTransfer(ctx, alice.ID, req)
+	if err != nil {
+		t.Fatalf("RequestTransfer: %v", err)
+	}
+	if approval.Currency != "EUR" {
+		t.Errorf("approval currency %s, want EUR", approval.Currency)
+	}
+
+	// It is made from the pocket, from the device it was asked for on
+	if approval, err = env.approvals.Approve(ctx, bob.ID, approval.ID); err != nil || approval.Status != domain.TransferApprovalExecuted {
+		t.Fatalf("Approve = %+v, %v; want executed", approval, err)
+	}
+	env.assertBalances(t, account.ID, 100, 0)
+	env.assertPocket(t, account.ID, "EUR", "10", "0")
+	env.assertPocket(t, to.ID, "EUR", "30", "0")
+	if trusted, err := env.store.Devices().Exists(ctx, alice.ID, "phone"); err != nil || !trusted {
+		t.Errorf("device trusted = %v, %v; want true", trusted, err)
+	}
+}
\ No newline at end of file
@@ -3,32 +3,43 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
 	"github.com/shopspring/decimal"
 )
 
 var (
-	ErrAccountNotFound = errors.New("account not found")
-	ErrInsufficientBalance = errors.New("insufficient balance")
-	ErrAccountNotEmpty = errors.New("account has non-zero balance")
-	ErrUnauthorized = errors.New("unauthorized access")
+	ErrAccountNotFound = apperror.NotFound("account_not_found", "account not found")
+	ErrInsufficientBalance = apperror.BadRequest("insufficient_balance", "insufficient balance")
+	ErrUnauthorized = apperror.Forbidden("account_forbidden", "you are not allowed to do this on the account")
+	ErrInvalidAccountNumber = apperror.BadRequest("invalid_account_number", "not a valid account number at this bank")
 )
 
+// accountNumberAttempts is how many numbers createAccount tries. Only
+// numbers issued before the sequence existed can be taken.
+const accountNumberAttempts = 5
+
 type AccountUseCase struct {
 	accountRepo repository.AccountRepository
+	memberRepo  repository.AccountMemberRepository
 	userRepo    repository.UserRepository
+	numbers     accountnumber.Scheme
 }
 
-func NewAccountUseCase(accountRepo repository.AccountRepository, userRepo repository.UserRepository) *AccountUseCase {
+func NewAccountUseCase(accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, userRepo repository.UserRepository, numbers accountnumber.Scheme) *AccountUseCase {
+	if numbers == nil {
+		numbers = accountnumber.Default()
+	}
 	return &AccountUseCase{
 		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
 		userRepo:    userRepo,
+		numbers:     numbers,
 	}
 }
 
@@ -42,25 +53,24 @@ func (uc *AccountUseCase) CreateAccount(ctx context.Context, userID uuid.UUID, r
 	}
 
 	account := &domain.Account{
-		ID:            uuid.New(),
-		UserID:        userID,
-		AccountNumber: uc.generateAccountNumber(),
-		AccountType:   req.AccountType,
-		Balance:       decimal.NewFromInt(0),
-		Currency:      req.Currency,
-		Status:        domain.AccountStatusActive,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		ID:          uuid.New(),
+		UserID:      userID,
+		AccountType: req.AccountType,
+		Balance:     decimal.NewFromInt(0),
+		Currency:    req.Currency,
+		Status:      domain.AccountStatusActive,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
-	if err := uc.accountRepo.Create(ctx, account); err != nil {
+	if err := createAccount(ctx, uc.accountRepo, uc.numbers, account); err != nil {
 		return nil, err
 	}
 
 	return account, nil
 }
 
-func (uc *AccountUseCase) GetAccount(ctx context.Context, accountID uuid.UUID) (*domain.Account, error) {
+func (uc *AccountUseCase) GetAccount(ctx context.Context, userID, accountID uuid.UUID) (*domain.Account, error) {
 	account, err := uc.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return nil, err
@@ -69,6 +79,10 @@ func (uc *AccountUseCase) GetAccount(ctx context.Context, accountID uuid.UUID) (
 		return nil, ErrAccountNotFound
 	}
 
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanView); err != nil {
+		return nil, err
+	}
+
 	return account, nil
 }
 
@@ -85,18 +99,18 @@ func (uc *AccountUseCase) UpdateAccount(ctx context.Context, userID, accountID u
 		return nil, ErrAccountNotFound
 	}
 
-	// Check if user owns this account
-	if account.UserID != userID {
-		return nil, ErrUnauthorized
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanManage); err != nil {
+		return nil, err
 	}
 
 	// Update fields if provided
 	if req.AccountType != nil {
+		// Only checking accounts can keep an overdraft
+		if *req.AccountType != domain.AccountTypeChecking && account.OverdraftLimit.IsPositive() {
+			return nil, ErrOverdraftNotAllowed
+		}
 		account.AccountType = *req.AccountType
 	}
-	if req.Status != nil {
-		account.Status = *req.Status
-	}
 	account.UpdatedAt = time.Now()
 
 	if err := uc.accountRepo.Update(ctx, account); err != nil {
@@ -106,29 +120,27 @@ func (uc *AccountUseCase) UpdateAccount(ctx context.Context, userID, accountID u
 	return account, nil
 }
 
-func (uc *AccountUseCase) DeleteAccount(ctx context.Context, userID, accountID uuid.UUID) error {
-	account, err := uc.accountRepo.GetByID(ctx, accountID)
-	if err != nil {
-		return err
-	}
-	if account == nil {
-		return ErrAccountNotFound
-	}
-
-	// Check if user owns this account
-	if account.UserID != userID {
-		return ErrUnauthorized
-	}
-
-	// Safety check: don't delete accounts with balance
-	if !account.Balance.IsZero() {
-		return ErrAccountNotEmpty
-	}
-
-	return uc.accountRepo.Delete(ctx, accountID)
+func isOwner(role domain.AccountRole) bool {
+	return role == domain.AccountRoleOwner
 }
 
-func (uc *AccountUseCase) generateAccountNumber() string {
-	rand.Seed(time.Now().UnixNano())
-	return fmt.Sprintf("%010d", rand.Intn(10000000000))
+// createAccount numbers the account from the account number sequence and
+// creates it, moving on to the next number if one is taken.
+func createAccount(ctx context.Context, accountRepo repository.AccountRepository, numbers accountnumber.Scheme, account *domain.Account) error {
+	for attempt := 0; attempt < accountNumberAttempts; attempt++ {
+		seq, err := accountRepo.NextAccountNumber(ctx)
+		if err != nil {
+			return err
+		}
+		account.AccountNumber, err = numbers.Format(seq)
+		if err != nil {
+			return err
+		}
+
+		err = accountRepo.Create(ctx, account)
+		if !errors.Is(err, repository.ErrAccountNumberTaken) {
+			return err
+		}
+	}
+	return repository.ErrAccountNumberTaken
 }
\ No newline at end of file
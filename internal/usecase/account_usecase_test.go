@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+// mistype changes the number's last digit, as a slip of the finger would.
+func mistype(number string) string {
+	last := number[len(number)-1]
+	return number[:len(number)-1] + string('0'+(last-'0'+1)%10)
+}
+
+func TestCreateAccount(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"creates an empty active account", user.ID, nil},
+		{"unknown user", uuid.New(), ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, err := env.accounts.CreateAccount(context.Background(), tt.userID, &domain.CreateAccountRequest{
+				AccountType: domain.AccountTypeSavings,
+				Currency:    "USD",
+			})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if account.UserID != tt.userID || !account.Balance.IsZero() || account.Status != domain.AccountStatusActive || len(account.AccountNumber) != 10 {
+				t.Errorf("unexpected account %+v", account)
+			}
+			env.account(t, account.ID)
+		})
+	}
+}
+
+func TestCreateAccountSkipsTakenNumbers(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+
+	// An account opened before the sequence holds the number it issues next
+	seq, err := env.store.Accounts().NextAccountNumber(context.Background())
+	if err != nil {
+		t.Fatalf("NextAccountNumber: %v", err)
+	}
+	taken, _ := accountnumber.Default().Format(seq + 1)
+	want, _ := accountnumber.Default().Format(seq + 2)
+	legacy := &domain.Account{
+		UserID:        user.ID,
+		AccountNumber: taken,
+		AccountType:   domain.AccountTypeChecking,
+		Currency:      "USD",
+		Status:        domain.AccountStatusActive,
+	}
+	if err := env.store.Accounts().Create(context.Background(), legacy); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	account, err := env.accounts.CreateAccount(context.Background(), user.ID, &domain.CreateAccountRequest{
+		AccountType: domain.AccountTypeChecking,
+		Currency:    "USD",
+	})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if account.AccountNumber != want {
+		t.Errorf("account number %s, want %s", account.AccountNumber, want)
+	}
+}
+
+func TestGetAccount(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	viewer := env.newUser(t, "Bob Jones")
+	stranger := env.newUser(t, "Carol White")
+	account := env.newAccount(t, alice.ID, 10)
+	env.addMember(t, account, viewer, domain.AccountRoleViewer)
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		id      uuid.UUID
+		wantErr error
+	}{
+		{"owner", alice.ID, account.ID, nil},
+		{"viewer", viewer.ID, account.ID, nil},
+		{"someone else's account", stranger.ID, account.ID, ErrUnauthorized},
+		{"missing", alice.ID, uuid.New(), ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := env.accounts.GetAccount(context.Background(), tt.userID, tt.id)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != tt.id {
+				t.Errorf("got account %s, want %s", got.ID, tt.id)
+			}
+		})
+	}
+}
+
+func TestGetUserAccounts(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	env.newAccount(t, alice.ID, 0)
+	env.newAccount(t, alice.ID, 0)
+	env.newAccount(t, bob.ID, 0)
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		want   int
+	}{
+		{"only the user's accounts", alice.ID, 2},
+		{"other user", bob.ID, 1},
+		{"no accounts", uuid.New(), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts, err := env.accounts.GetUserAccounts(context.Background(), tt.userID)
+			if err != nil {
+				t.Fatalf("GetUserAccounts: %v", err)
+			}
+			if len(accounts) != tt.want {
+				t.Errorf("%d accounts, want %d", len(accounts), tt.want)
+			}
+			for _, account := range accounts {
+				if account.UserID != tt.userID {
+					t.Errorf("account %s belongs to %s", account.ID, account.UserID)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateAccount(t *testing.T) {
+	env := newTestEnv(t)
+	owner := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, owner.ID, 10)
+	savings := domain.AccountTypeSavings
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		wantErr   error
+	}{
+		{"owner", owner.ID, account.ID, nil},
+		{"someone else", uuid.New(), account.ID, ErrUnauthorized},
+		{"missing account", owner.ID, uuid.New(), ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := env.accounts.UpdateAccount(context.Background(), tt.userID, tt.accountID, &domain.UpdateAccountRequest{AccountType: &savings})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (got.AccountType != savings || env.account(t, account.ID).AccountType != savings) {
+				t.Errorf("account type not updated")
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -2,10 +2,10 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/infrastructure/session"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
@@ -13,22 +13,25 @@ import (
 )
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrEmailAlreadyExists = errors.New("email already exists")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "invalid email or password")
+	ErrEmailAlreadyExists = apperror.Conflict("email_already_exists", "email already exists")
+	ErrUserNotFound       = apperror.NotFound("user_not_found", "user not found")
+	ErrRegistrationBlocked = apperror.Forbidden("registration_blocked", "registration blocked by sanctions screening")
 )
 
 type AuthUseCase struct {
 	userRepo       repository.UserRepository
 	jwtManager     *utils.JWTManager
 	sessionService *session.SessionService
+	screening      *ScreeningUseCase
 }
 
-func NewAuthUseCase(userRepo repository.UserRepository, jwtManager *utils.JWTManager, sessionService *session.SessionService) *AuthUseCase {
+func NewAuthUseCase(userRepo repository.UserRepository, jwtManager *utils.JWTManager, sessionService *session.SessionService, screening *ScreeningUseCase) *AuthUseCase {
 	return &AuthUseCase{
 		userRepo:       userRepo,
 		jwtManager:     jwtManager,
 		sessionService: sessionService,
+		screening:      screening,
 	}
 }
 
@@ -41,6 +44,14 @@ func (uc *AuthUseCase) Register(ctx context.Context, req *domain.CreateUserReque
 		return nil, ErrEmailAlreadyExists
 	}
 
+	screening, err := uc.screening.Check(ctx, domain.ScreeningSubjectUser, req.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if screening.Decision == domain.RiskDecisionBlock {
+		return nil, ErrRegistrationBlocked
+	}
+
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, err
@@ -53,6 +64,8 @@ func (uc *AuthUseCase) Register(ctx context.Context, req *domain.CreateUserReque
 		FullName:     req.FullName,
 		Phone:        &req.Phone,
 		IsVerified:   false,
+		Tier:         domain.UserTierStandard,
+		Role:         domain.UserRoleCustomer,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -61,6 +74,11 @@ func (uc *AuthUseCase) Register(ctx context.Context, req *domain.CreateUserReque
 		return nil, err
 	}
 
+	// A possible match still registers; compliance picks it up from the alert
+	if err := uc.screening.Flag(ctx, domain.ScreeningSubjectUser, user.ID, user.FullName, screening); err != nil {
+		return nil, err
+	}
+
 	var accessToken, refreshToken string
 	
 	if uc.sessionService != nil {
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestRegister(t *testing.T) {
+	env := newTestEnv(t)
+	env.screener.set("Blocked Person", domain.RiskDecisionBlock)
+	env.screener.set("Possible Match", domain.RiskDecisionReview)
+	existing := env.newUser(t, "Alice Smith")
+
+	tests := []struct {
+		name      string
+		email     string
+		fullName  string
+		wantErr   error
+		wantAlert bool
+	}{
+		{"registers", "new@example.com", "New Customer", nil, false},
+		{"email taken", existing.Email, "Someone Else", ErrEmailAlreadyExists, false},
+		{"sanctioned name", "blocked@example.com", "Blocked Person", ErrRegistrationBlocked, true},
+		{"possible match registers with an alert", "match@example.com", "Possible Match", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := pendingAlerts(t, env)
+
+			resp, err := env.auth.Register(context.Background(), &domain.CreateUserRequest{
+				Email:    tt.email,
+				Password: "password123",
+				FullName: tt.fullName,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if resp.AccessToken == "" || resp.RefreshToken == "" || resp.User.PasswordHash == "password123" {
+					t.Errorf("unexpected response %+v", resp)
+				}
+				if stored, _ := env.store.Users().GetByEmail(context.Background(), tt.email); stored == nil {
+					t.Error("user not stored")
+				}
+			}
+			if got := pendingAlerts(t, env) > before; got != tt.wantAlert {
+				t.Errorf("alert recorded = %v, want %v", got, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	if _, err := env.auth.Register(ctx, &domain.CreateUserRequest{Email: "alice@example.com", Password: "password123", FullName: "Alice Smith"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		wantErr  error
+	}{
+		{"correct password", "alice@example.com", "password123", nil},
+		{"wrong password", "alice@example.com", "password124", ErrInvalidCredentials},
+		{"unknown email", "bob@example.com", "password123", ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.auth.Login(ctx, &domain.LoginRequest{Email: tt.email, Password: tt.password})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (resp.AccessToken == "" || resp.User.Email != tt.email) {
+				t.Errorf("unexpected response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	_, aliceToken, err := env.jwt.GenerateTokenPair(alice.ID, alice.Email)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair: %v", err)
+	}
+	gone := env.newUser(t, "Bob Jones")
+	_, goneToken, _ := env.jwt.GenerateTokenPair(gone.ID, gone.Email)
+	if err := env.store.Users().Delete(ctx, gone.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	accessToken, _, _ := env.jwt.GenerateTokenPair(alice.ID, alice.Email)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+		errIs   error
+	}{
+		{"valid refresh token", aliceToken, false, nil},
+		{"deleted user", goneToken, true, ErrUserNotFound},
+		{"access token", accessToken, true, nil},
+		{"garbage", "not-a-token", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.auth.RefreshToken(ctx, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error %v, want error %v", err, tt.wantErr)
+			}
+			if tt.errIs != nil && err != tt.errIs {
+				t.Errorf("error %v, want %v", err, tt.errIs)
+			}
+			if err == nil && resp.User.ID != alice.ID {
+				t.Errorf("refreshed for %s, want %s", resp.User.ID, alice.ID)
+			}
+		})
+	}
+}
+
+func pendingAlerts(t *testing.T, env *testEnv) int {
+	t.Helper()
+
+	alerts, err := env.store.ScreeningAlerts().GetPending(context.Background(), 1000, 0)
+	if err != nil {
+		t.Fatalf("GetPending: %v", err)
+	}
+	return len(alerts)
+}
\ No newline at end of file
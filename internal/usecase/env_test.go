@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/fx"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/cache"
+	"github.com/nabiilNajm26/go-bank/internal/infrastructure/session"
+	"github.com/nabiilNajm26/go-bank/internal/repository/memory"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+	"github.com/shopspring/decimal"
+)
+
+// testEnv wires every use case to one in-memory store, with a screener and
+// risk evaluator that allow everything unless a test says otherwise.
+type testEnv struct {
+	store     *memory.Store
+	screener  *stubScreener
+	risk      *stubRiskEvaluator
+	jwt       *utils.JWTManager
+	clock     *testClock
+
+	accounts     *AccountUseCase
+	lifecycle    *AccountLifecycleUseCase
+	members      *AccountMemberUseCase
+	approvals    *TransferApprovalUseCase
+	orgs         *OrganizationUseCase
+	drafts       *PaymentDraftUseCase
+	pots         *PotUseCase
+	pockets      *PocketUseCase
+	users        *UserUseCase
+	auth         *AuthUseCase
+	payees       *PayeeUseCase
+	limits       *LimitUseCase
+	holds        *HoldUseCase
+	interest     *InterestUseCase
+	termDeposits *TermDepositUseCase
+	fees         *FeeUseCase
+	overdrafts   *OverdraftUseCase
+	transactions *TransactionUseCase
+	screening    *ScreeningUseCase
+	statements   *StatementUseCase
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		store:    memory.NewStore(),
+		screener: &stubScreener{results: make(map[string]*domain.ScreeningResult)},
+		risk:     &stubRiskEvaluator{},
+		jwt:      utils.NewJWTManager("access-secret", "refresh-secret", time.Hour, 24*time.Hour),
+		clock:    &testClock{now: time.Now()},
+	}
+	s := env.store
+
+	env.screening = NewScreeningUseCase(env.screener, s.ScreeningAlerts())
+	env.accounts = NewAccountUseCase(s.Accounts(), s.AccountMembers(), s.Users(), nil)
+	env.lifecycle = NewAccountLifecycleUseCase(s.Accounts(), s.AccountMembers(), s.Users(), s, 0)
+	env.lifecycle.now = env.clock.Now
+	env.users = NewUserUseCase(s.Users(), s.Accounts(), env.screening)
+	env.auth = NewAuthUseCase(s.Users(), env.jwt, session.NewSessionService(cache.NewCacheService(cache.NewMemoryStore())), env.screening)
+	env.payees = NewPayeeUseCase(s.Payees(), s.Accounts(), s.Users(), env.screening, nil)
+	env.limits = NewLimitUseCase(s.Limits(), s.Accounts(), s.AccountMembers(), s.Users(), s.Transactions(), nil)
+	env.limits.now = env.clock.Now
+	env.holds = NewHoldUseCase(s.Holds(), s.Accounts(), s.AccountMembers(), env.limits, s)
+	env.holds.now = env.clock.Now
+	env.interest = NewInterestUseCase(s.Accounts(), s.AccountMembers(), s.Interest(), s, nil)
+	env.interest.now = env.clock.Now
+	env.termDeposits = NewTermDepositUseCase(s.Accounts(), s.TermDeposits(), s, nil, nil)
+	env.termDeposits.now = env.clock.Now
+	env.fees = NewFeeUseCase(s.Fees(), s.Accounts(), s)
+	env.fees.now = env.clock.Now
+	env.overdrafts = NewOverdraftUseCase(s.Accounts(), env.fees, s)
+	env.overdrafts.now = env.clock.Now
+	env.pockets = NewPocketUseCase(s.Accounts(), s.Pockets(), s.AccountMembers(), testRates, decimal.NewNullDecimal(testSpread), s)
+	env.transactions = NewTransactionUseCase(s.Transactions(), s.Accounts(), s.AccountMembers(), s.Payees(), s.Users(), s.Reviews(), s.Devices(), env.limits, env.fees, env.pockets, env.risk, env.screening, s, nil)
+	env.members = NewAccountMemberUseCase(s.AccountMembers(), s.Accounts(), s.Users())
+	env.members.now = env.clock.Now
+	env.approvals = NewTransferApprovalUseCase(s.TransferApprovals(), s.Accounts(), s.AccountMembers(), env.transactions, s)
+	env.approvals.now = env.clock.Now
+	env.orgs = NewOrganizationUseCase(s.Organizations(), s.Accounts(), s.Users(), nil)
+	env.orgs.now = env.clock.Now
+	env.drafts = NewPaymentDraftUseCase(s.PaymentDrafts(), s.Organizations(), s.Accounts(), env.transactions, s)
+	env.pots = NewPotUseCase(s.Pots(), s.Accounts(), s.AccountMembers(), s)
+	env.pots.now = env.clock.Now
+	env.statements = NewStatementUseCase(s.Accounts(), s.AccountMembers(), s.Transactions(), s.Pockets(), testRates)
+
+	return env
+}
+
+// testRates are round numbers so conversions are easy to check by hand.
+var (
+	testRates = &fx.Table{
+		Base: "USD",
+		Rates: map[string]decimal.Decimal{
+			"EUR": decimal.RequireFromString("0.8"),
+			"GBP": decimal.RequireFromString("0.5"),
+		},
+	}
+	testSpread = decimal.RequireFromString("0.01")
+)
+
+func (env *testEnv) newUser(t *testing.T, fullName string) *domain.User {
+	t.Helper()
+
+	user := &domain.User{
+		Email:        fmt.Sprintf("%s@example.com", uuid.NewString()),
+		PasswordHash: "x",
+		FullName:     fullName,
+		Tier:         domain.UserTierStandard,
+		Role:         domain.UserRoleCustomer,
+	}
+	if err := env.store.Users().Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+func (env *testEnv) newAccount(t *testing.T, userID uuid.UUID, balance int64) *domain.Account {
+	t.Helper()
+	return newMemoryAccount(t, env.store, userID, balance)
+}
+
+func newMemoryAccount(t *testing.T, store *memory.Store, userID uuid.UUID, balance int64) *domain.Account {
+	t.Helper()
+
+	account := &domain.Account{
+		UserID:        userID,
+		AccountNumber: nextAccountNumber(t, store),
+		AccountType:   domain.AccountTypeChecking,
+		Balance:       decimal.NewFromInt(balance),
+		Currency:      "USD",
+		Status:        domain.AccountStatusActive,
+	}
+	if err := store.Accounts().Create(context.Background(), account); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	return account
+}
+
+// nextAccountNumber issues an account number the way the use cases do, so
+// it passes their check digit validation.
+func nextAccountNumber(t *testing.T, store *memory.Store) string {
+	t.Helper()
+
+	seq, err := store.Accounts().NextAccountNumber(context.Background())
+	if err != nil {
+		t.Fatalf("NextAccountNumber: %v", err)
+	}
+	number, err := accountnumber.Default().Format(seq)
+	if err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+	return number
+}
+
+func (env *testEnv) account(t *testing.T, id uuid.UUID) *domain.Account {
+	t.Helper()
+
+	account, err := env.store.Accounts().GetByID(context.Background(), id)
+	if err != nil || account == nil {
+		t.Fatalf("GetByID(%s) = %v, %v", id, account, err)
+	}
+	return account
+}
+
+func (env *testEnv) assertBalances(t *testing.T, id uuid.UUID, balance, held int64) {
+	t.Helper()
+
+	account := env.account(t, id)
+	if !account.Balance.Equal(decimal.NewFromInt(balance)) || !account.HeldBalance.Equal(decimal.NewFromInt(held)) {
+		t.Errorf("account %s: balance %s held %s, want %d held %d", id, account.Balance, account.HeldBalance, balance, held)
+	}
+}
+
+type stubScreener struct {
+	results map[string]*domain.ScreeningResult
+}
+
+func (s *stubScreener) Screen(name string) *domain.ScreeningResult {
+	if result, ok := s.results[name]; ok {
+		return result
+	}
+	return &domain.ScreeningResult{Decision: domain.RiskDecisionAllow}
+}
+
+func (s *stubScreener) set(name string, decision domain.RiskDecision) {
+	s.results[name] = &domain.ScreeningResult{
+		Decision: decision,
+		Matches:  domain.ScreeningMatches{{EntryID: "1", Name: name, Score: 1}},
+	}
+}
+
+type stubRiskEvaluator struct {
+	mu       sync.Mutex
+	decision domain.RiskDecision
+	inputs   []*domain.RiskInput
+}
+
+func (s *stubRiskEvaluator) Evaluate(ctx context.Context, input *domain.RiskInput) (*domain.RiskAssessment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inputs = append(s.inputs, input)
+	if s.decision == "" || s.decision == domain.RiskDecisionAllow {
+		return &domain.RiskAssessment{Decision: domain.RiskDecisionAllow}, nil
+	}
+	return &domain.RiskAssessment{Decision: s.decision, Reasons: []string{"stub rule"}}, nil
+}
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
\ No newline at end of file
@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrFeeScheduleNotFound = apperror.NotFound("fee_schedule_not_found", "fee schedule not found")
+	ErrFeeWaiverNotFound   = apperror.NotFound("fee_waiver_not_found", "fee waiver not found")
+	ErrInvalidFeeSchedule  = apperror.BadRequest("invalid_fee_schedule", "max_amount cannot be below min_amount")
+	ErrInvalidExpiry       = apperror.BadRequest("invalid_expiry", "expires_at must be in the future")
+)
+
+const feeBatchSize = 100
+
+var feeDescriptions = map[domain.FeeType]string{
+	domain.FeeTypeTransfer:            "Transfer fee",
+	domain.FeeTypeFXMarkup:            "FX markup",
+	domain.FeeTypeMonthlyMaintenance:  "Monthly maintenance fee",
+	domain.FeeTypeBelowMinimumBalance: "Below minimum balance fee",
+	domain.FeeTypeOverdraftInterest:   "Overdraft interest",
+}
+
+// feeLine is one fee owed on a transaction or for a month.
+type feeLine struct {
+	feeType domain.FeeType
+	amount  decimal.Decimal
+}
+
+func feeTotal(lines []feeLine) decimal.Decimal {
+	total := decimal.Zero
+	for _, line := range lines {
+		total = total.Add(line.amount)
+	}
+	return total
+}
+
+// FeeUseCase prices fees from the schedules operators keep in the database
+// and charges them as fee transactions. Transfer fees are charged with the
+// transfer they are for; monthly fees by a background job.
+type FeeUseCase struct {
+	feeRepo     repository.FeeRepository
+	accountRepo repository.AccountRepository
+	uow         repository.UnitOfWork
+	now         func() time.Time
+}
+
+func NewFeeUseCase(feeRepo repository.FeeRepository, accountRepo repository.AccountRepository, uow repository.UnitOfWork) *FeeUseCase {
+	return &FeeUseCase{
+		feeRepo:     feeRepo,
+		accountRepo: accountRepo,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+func (uc *FeeUseCase) GetSchedules(ctx context.Context) ([]*domain.FeeSchedule, error) {
+	return uc.feeRepo.GetSchedules(ctx)
+}
+
+// SetSchedule creates or replaces the schedule for the request's fee type,
+// account type and tier.
+func (uc *FeeUseCase) SetSchedule(ctx context.Context, req *domain.FeeScheduleRequest) (*domain.FeeSchedule, error) {
+	if req.MaxAmount.Valid && req.MaxAmount.Decimal.LessThan(req.MinAmount) {
+		return nil, ErrInvalidFeeSchedule
+	}
+
+	schedule := &domain.FeeSchedule{
+		FeeType:     req.FeeType,
+		AccountType: req.AccountType,
+		UserTier:    req.UserTier,
+		FixedAmount: req.FixedAmount,
+		Rate:        req.Rate,
+		MinAmount:   req.MinAmount,
+		MaxAmount:   req.MaxAmount,
+		Threshold:   req.Threshold,
+	}
+	if err := uc.feeRepo.UpsertSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (uc *FeeUseCase) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	schedule, err := uc.feeRepo.GetScheduleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return ErrFeeScheduleNotFound
+	}
+
+	return uc.feeRepo.DeleteSchedule(ctx, id)
+}
+
+func (uc *FeeUseCase) CreateWaiver(ctx context.Context, operatorID, accountID uuid.UUID, req *domain.CreateFeeWaiverRequest) (*domain.FeeWaiver, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(uc.now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	waiver := &domain.FeeWaiver{
+		AccountID: accountID,
+		FeeType:   req.FeeType,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: operatorID,
+	}
+	if err := uc.feeRepo.CreateWaiver(ctx, waiver); err != nil {
+		return nil, err
+	}
+
+	return waiver, nil
+}
+
+func (uc *FeeUseCase) GetAccountWaivers(ctx context.Context, accountID uuid.UUID) ([]*domain.FeeWaiver, error) {
+	return uc.feeRepo.GetWaiversByAccountID(ctx, accountID)
+}
+
+func (uc *FeeUseCase) DeleteWaiver(ctx context.Context, id uuid.UUID) error {
+	waiver, err := uc.feeRepo.GetWaiverByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if waiver == nil {
+		return ErrFeeWaiverNotFound
+	}
+
+	return uc.feeRepo.DeleteWaiver(ctx, id)
+}
+
+// transferFees prices the fees on a transfer out of from in currency: the
+// transfer fee, and the FX markup if it isn't the recipient account's
+// currency. Both are charged on the amount sent, valued in from's currency.
+func (uc *FeeUseCase) transferFees(ctx context.Context, repos *repository.Repositories, from, to *domain.Account, currency string, amount decimal.Decimal) ([]feeLine, error) {
+	schedules, err := repos.Fees.GetSchedules(ctx)
+	if err != nil || len(schedules) == 0 {
+		return nil, err
+	}
+	tier, err := userTier(ctx, repos, from.UserID)
+	if err != nil {
+		return nil, err
+	}
+	waivers, err := repos.Fees.GetWaiversByAccountID(ctx, from.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	feeTypes := []domain.FeeType{domain.FeeTypeTransfer}
+	if currency != to.Currency {
+		feeTypes = append(feeTypes, domain.FeeTypeFXMarkup)
+	}
+
+	var lines []feeLine
+	now := uc.now()
+	for _, feeType := range feeTypes {
+		schedule := matchSchedule(schedules, feeType, from.AccountType, tier)
+		if schedule == nil || waived(waivers, feeType, now) {
+			continue
+		}
+		if fee := schedule.Amount(amount); fee.IsPositive() {
+			lines = append(lines, feeLine{feeType: feeType, amount: fee})
+		}
+	}
+
+	return lines, nil
+}
+
+// chargeFees debits each fee from the locked account as its own transaction,
+// linked to the transaction it was charged on if there is one.
+func chargeFees(ctx context.Context, repos *repository.Repositories, account *domain.Account, lines []feeLine, transaction *domain.Transaction, description string) ([]*domain.Transaction, error) {
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	fees := make([]*domain.Transaction, 0, len(lines))
+	for _, line := range lines {
+		fee := newCashTransaction(domain.TransactionTypeFee, account, line.amount, feeDescriptions[line.feeType]+description)
+		fee.FromAccountID = &account.ID
+		fee.Metadata = domain.Metadata{"fee_type": string(line.feeType)}
+		if transaction != nil {
+			fee.Metadata["transaction_id"] = transaction.ID.String()
+		}
+		if err := repos.Transactions.Create(ctx, fee); err != nil {
+			return nil, err
+		}
+		account.Balance = account.Balance.Sub(line.amount)
+		fees = append(fees, fee)
+	}
+
+	if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+		return nil, err
+	}
+	return fees, nil
+}
+
+// ChargeMonthlyFees charges maintenance and below-minimum-balance fees for
+// the month just ended to accounts that were open during it. It is run
+// periodically by the job runner; each account is charged once a month.
+func (uc *FeeUseCase) ChargeMonthlyFees(ctx context.Context) error {
+	monthStart := domain.CapitalizeMonthly.PeriodStart(startOfDay(uc.now()))
+	period := monthStart.AddDate(0, -1, 0)
+
+	schedules, err := uc.feeRepo.GetSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	charged := make(map[domain.AccountType]bool)
+	for _, schedule := range schedules {
+		if schedule.FeeType != domain.FeeTypeMonthlyMaintenance && schedule.FeeType != domain.FeeTypeBelowMinimumBalance {
+			continue
+		}
+		if schedule.AccountType == nil {
+			for _, accountType := range []domain.AccountType{domain.AccountTypeSavings, domain.AccountTypeChecking, domain.AccountTypeDeposit} {
+				charged[accountType] = true
+			}
+		} else {
+			charged[*schedule.AccountType] = true
+		}
+	}
+
+	for accountType := range charged {
+		after := uuid.Nil
+		for {
+			accounts, err := uc.accountRepo.GetByType(ctx, accountType, after, feeBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, account := range accounts {
+				if err := uc.chargeMonthly(ctx, account.ID, schedules, period, monthStart); err != nil {
+					log.Printf("Failed to charge monthly fees on account %s: %v", account.ID, err)
+				}
+			}
+			if len(accounts) < feeBatchSize {
+				break
+			}
+			after = accounts[len(accounts)-1].ID
+		}
+	}
+
+	return nil
+}
+
+func (uc *FeeUseCase) chargeMonthly(ctx context.Context, accountID uuid.UUID, schedules []*domain.FeeSchedule, period, periodEnd time.Time) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == domain.AccountStatusClosed || !account.CreatedAt.Before(periodEnd) {
+			return nil
+		}
+		// Term deposits are charged nothing while they run
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			if err == ErrFundsLocked {
+				return nil
+			}
+			return err
+		}
+
+		tier, err := userTier(ctx, repos, account.UserID)
+		if err != nil {
+			return err
+		}
+		waivers, err := repos.Fees.GetWaiversByAccountID(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		now := uc.now()
+		for _, feeType := range []domain.FeeType{domain.FeeTypeMonthlyMaintenance, domain.FeeTypeBelowMinimumBalance} {
+			schedule := matchSchedule(schedules, feeType, account.AccountType, tier)
+			if schedule == nil {
+				continue
+			}
+			existing, err := repos.Fees.GetCharge(ctx, account.ID, feeType, period)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				continue
+			}
+
+			// Maintenance is charged on the balance, the minimum balance fee
+			// on the shortfall
+			base := decimal.Max(account.Balance, decimal.Zero)
+			if feeType == domain.FeeTypeBelowMinimumBalance {
+				if !account.Balance.LessThan(schedule.Threshold) {
+					continue
+				}
+				base = schedule.Threshold.Sub(account.Balance)
+			}
+
+			charge := &domain.FeeCharge{AccountID: account.ID, FeeType: feeType, Period: period}
+			if !waived(waivers, feeType, now) {
+				charge.Amount = capFee(schedule.Amount(base), account.SpendableBalance())
+			}
+			if charge.Amount.IsPositive() {
+				fees, err := chargeFees(ctx, repos, account, []feeLine{{feeType: feeType, amount: charge.Amount}}, nil, " "+period.Format("2006-01"))
+				if err != nil {
+					return err
+				}
+				charge.TransactionID = &fees[0].ID
+			}
+			if err := repos.Fees.CreateCharge(ctx, charge); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func userTier(ctx context.Context, repos *repository.Repositories, userID uuid.UUID) (domain.UserTier, error) {
+	user, err := repos.Users.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user == nil {
+		return "", ErrUserNotFound
+	}
+	if user.Tier == "" {
+		return domain.UserTierStandard, nil
+	}
+	return user.Tier, nil
+}
+
+// matchSchedule picks the most specific schedule for the fee: one for the
+// account type and tier, then the account type, then the tier, then neither.
+func matchSchedule(schedules []*domain.FeeSchedule, feeType domain.FeeType, accountType domain.AccountType, tier domain.UserTier) *domain.FeeSchedule {
+	var best *domain.FeeSchedule
+	bestScore := -1
+	for _, schedule := range schedules {
+		if schedule.FeeType != feeType || !schedule.Matches(accountType, tier) {
+			continue
+		}
+		score := 0
+		if schedule.AccountType != nil {
+			score += 2
+		}
+		if schedule.UserTier != nil {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = schedule, score
+		}
+	}
+	return best
+}
+
+func waived(waivers []*domain.FeeWaiver, feeType domain.FeeType, at time.Time) bool {
+	for _, waiver := range waivers {
+		if waiver.Waives(feeType, at) {
+			return true
+		}
+	}
+	return false
+}
+
+// capFee limits a fee to what the account can spend, overdraft included, so
+// a fee never takes it past its overdraft limit.
+func capFee(fee, spendable decimal.Decimal) decimal.Decimal {
+	if spendable.IsNegative() {
+		return decimal.Zero
+	}
+	return decimal.Min(fee, spendable)
+}
+
+// capFees limits fees, in order, to what the account can spend.
+func capFees(lines []feeLine, spendable decimal.Decimal) []feeLine {
+	var capped []feeLine
+	for _, line := range lines {
+		line.amount = capFee(line.amount, spendable)
+		if !line.amount.IsPositive() {
+			break
+		}
+		spendable = spendable.Sub(line.amount)
+		capped = append(capped, line)
+	}
+	return capped
+}
+
+func feeReference(transaction *domain.Transaction) string {
+	return fmt.Sprintf(" for %s", transaction.Reference)
+}
\ No newline at end of file
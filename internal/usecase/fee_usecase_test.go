@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) setFeeSchedule(t *testing.T, req *domain.FeeScheduleRequest) *domain.FeeSchedule {
+	t.Helper()
+
+	schedule, err := env.fees.SetSchedule(context.Background(), req)
+	if err != nil {
+		t.Fatalf("SetSchedule: %v", err)
+	}
+	return schedule
+}
+
+func TestMatchSchedule(t *testing.T) {
+	checking, premium := domain.AccountTypeChecking, domain.UserTierPremium
+	schedule := func(accountType *domain.AccountType, tier *domain.UserTier, fixed int64) *domain.FeeSchedule {
+		return &domain.FeeSchedule{FeeType: domain.FeeTypeTransfer, AccountType: accountType, UserTier: tier, FixedAmount: decimal.NewFromInt(fixed)}
+	}
+	schedules := []*domain.FeeSchedule{
+		schedule(&checking, &premium, 4),
+		schedule(nil, &premium, 3),
+		schedule(&checking, nil, 2),
+		schedule(nil, nil, 1),
+	}
+
+	tests := []struct {
+		accountType domain.AccountType
+		tier        domain.UserTier
+		want        int64
+	}{
+		{domain.AccountTypeChecking, domain.UserTierPremium, 4},
+		{domain.AccountTypeChecking, domain.UserTierStandard, 2},
+		{domain.AccountTypeSavings, domain.UserTierPremium, 3},
+		{domain.AccountTypeSavings, domain.UserTierStandard, 1},
+	}
+	for _, tt := range tests {
+		got := matchSchedule(schedules, domain.FeeTypeTransfer, tt.accountType, tt.tier)
+		if got == nil || !got.FixedAmount.Equal(decimal.NewFromInt(tt.want)) {
+			t.Errorf("%s/%s: matched %+v, want the %d schedule", tt.accountType, tt.tier, got, tt.want)
+		}
+	}
+	if got := matchSchedule(schedules, domain.FeeTypeFXMarkup, checking, premium); got != nil {
+		t.Errorf("fx_markup matched %+v, want none", got)
+	}
+
+	capped := &domain.FeeSchedule{
+		Rate:      decimal.RequireFromString("0.01"),
+		MinAmount: decimal.NewFromInt(1),
+		MaxAmount: decimal.NewNullDecimal(decimal.NewFromInt(5)),
+	}
+	for base, want := range map[int64]string{10: "1", 250: "2.5", 1000: "5"} {
+		if got := capped.Amount(decimal.NewFromInt(base)); !got.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("fee on %d = %s, want %s", base, got, want)
+		}
+	}
+}
+
+func TestTransferFees(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	from := env.newAccount(t, alice.ID, 100)
+	to := env.newAccount(t, bob.ID, 0)
+	euros := &domain.Account{
+		UserID:        bob.ID,
+		AccountNumber: nextAccountNumber(t, env.store),
+		AccountType:   domain.AccountTypeChecking,
+		Balance:       decimal.Zero,
+		Currency:      "EUR",
+		Status:        domain.AccountStatusActive,
+	}
+	if err := env.store.Accounts().Create(ctx, euros); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeTransfer, FixedAmount: decimal.NewFromInt(1)})
+	// Setting the same scope again replaces the schedule
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeTransfer, FixedAmount: decimal.NewFromInt(2)})
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeFXMarkup, Rate: decimal.RequireFromString("0.01")})
+	if schedules, err := env.fees.GetSchedules(ctx); err != nil || len(schedules) != 2 {
+		t.Fatalf("GetSchedules = %d schedules, %v; want 2", len(schedules), err)
+	}
+
+	transfer := func(to *domain.Account, amount int64) (*domain.Transaction, error) {
+		return env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{
+			FromAccountID: from.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(amount),
+		})
+	}
+
+	// The fee has to fit in the balance alongside the transfer
+	if _, err := transfer(to, 99); err != ErrInsufficientBalance {
+		t.Fatalf("Transfer error %v, want %v", err, ErrInsufficientBalance)
+	}
+	env.assertBalances(t, from.ID, 100, 0)
+
+	transaction, err := transfer(to, 50)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if len(transaction.Fees) != 1 {
+		t.Fatalf("%d fees, want 1", len(transaction.Fees))
+	}
+	fee := transaction.Fees[0]
+	if fee.Type != domain.TransactionTypeFee || !fee.Amount.Equal(decimal.NewFromInt(2)) || *fee.FromAccountID != from.ID {
+		t.Errorf("fee %+v, want 2 from the sender", fee)
+	}
+	if fee.Metadata["fee_type"] != string(domain.FeeTypeTransfer) || fee.Metadata["transaction_id"] != transaction.ID.String() {
+		t.Errorf("fee metadata %v, want the fee type and transfer", fee.Metadata)
+	}
+	env.assertBalances(t, from.ID, 48, 0)
+	env.assertBalances(t, to.ID, 50, 0)
+
+	// A transfer into another currency also pays the FX markup
+	transaction, err = transfer(euros, 10)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if len(transaction.Fees) != 2 || !transaction.Fees[1].Amount.Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("fees %+v, want the transfer fee and 0.10 markup", transaction.Fees)
+	}
+	if balance := env.account(t, from.ID).Balance; !balance.Equal(decimal.RequireFromString("35.9")) {
+		t.Errorf("balance %s, want 35.90", balance)
+	}
+
+	// A waiver stops the fee until it is removed
+	waiverType := domain.FeeTypeTransfer
+	waiver, err := env.fees.CreateWaiver(ctx, uuid.New(), from.ID, &domain.CreateFeeWaiverRequest{FeeType: &waiverType, Reason: "goodwill"})
+	if err != nil {
+		t.Fatalf("CreateWaiver: %v", err)
+	}
+	if transaction, err = transfer(to, 5); err != nil || len(transaction.Fees) != 0 {
+		t.Errorf("waived transfer: %d fees, %v; want none", len(transaction.Fees), err)
+	}
+	if err := env.fees.DeleteWaiver(ctx, waiver.ID); err != nil {
+		t.Fatalf("DeleteWaiver: %v", err)
+	}
+	if transaction, err = transfer(to, 5); err != nil || len(transaction.Fees) != 1 {
+		t.Errorf("transfer after the waiver: %d fees, %v; want 1", len(transaction.Fees), err)
+	}
+
+	expired := env.clock.Now().Add(-time.Hour)
+	if _, err := env.fees.CreateWaiver(ctx, uuid.New(), from.ID, &domain.CreateFeeWaiverRequest{Reason: "late", ExpiresAt: &expired}); err != ErrInvalidExpiry {
+		t.Errorf("expired waiver: error %v, want %v", err, ErrInvalidExpiry)
+	}
+}
+
+func TestChargeMonthlyFees(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeMonthlyMaintenance, FixedAmount: decimal.NewFromInt(5)})
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeBelowMinimumBalance, FixedAmount: decimal.NewFromInt(10), Threshold: decimal.NewFromInt(100)})
+
+	user := env.newUser(t, "Alice Smith")
+	funded := env.newAccount(t, user.ID, 500)
+	low := env.newAccount(t, user.ID, 50)
+	almostEmpty := env.newAccount(t, user.ID, 3)
+	waived := env.newAccount(t, user.ID, 500)
+	if _, err := env.fees.CreateWaiver(ctx, uuid.New(), waived.ID, &domain.CreateFeeWaiverRequest{Reason: "staff account"}); err != nil {
+		t.Fatalf("CreateWaiver: %v", err)
+	}
+
+	// Nothing is charged for a month the accounts weren't open for
+	if err := env.fees.ChargeMonthlyFees(ctx); err != nil {
+		t.Fatalf("ChargeMonthlyFees: %v", err)
+	}
+	env.assertBalances(t, funded.ID, 500, 0)
+
+	// Running the job again in the same month charges nothing more
+	env.clock.now = env.clock.now.AddDate(0, 2, 0)
+	for i := 0; i < 2; i++ {
+		if err := env.fees.ChargeMonthlyFees(ctx); err != nil {
+			t.Fatalf("ChargeMonthlyFees: %v", err)
+		}
+	}
+	env.assertBalances(t, funded.ID, 495, 0)
+	env.assertBalances(t, low.ID, 35, 0)
+	env.assertBalances(t, almostEmpty.ID, 0, 0)
+	env.assertBalances(t, waived.ID, 500, 0)
+
+	period := domain.CapitalizeMonthly.PeriodStart(startOfDay(env.clock.Now())).AddDate(0, -1, 0)
+	charge, err := env.store.Fees().GetCharge(ctx, almostEmpty.ID, domain.FeeTypeBelowMinimumBalance, period)
+	if err != nil || charge == nil {
+		t.Fatalf("GetCharge = %v, %v", charge, err)
+	}
+	if !charge.Amount.IsZero() || charge.TransactionID != nil {
+		t.Errorf("charge %+v, want a zero charge with nothing left to pay it from", charge)
+	}
+
+	statement, err := env.statements.GenerateCSVStatement(ctx, user.ID, low.ID, time.Now().AddDate(0, -1, 0), env.clock.Now().Add(time.Hour), domain.StatementView{})
+	if err != nil {
+		t.Fatalf("GenerateCSVStatement: %v", err)
+	}
+	for _, line := range []string{"Monthly maintenance fee " + period.Format("2006-01"), "Below minimum balance fee"} {
+		if !bytes.Contains(statement, []byte(line)) {
+			t.Errorf("statement is missing %q:\n%s", line, statement)
+		}
+	}
+}
\ No newline at end of file
@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrHoldNotFound       = apperror.NotFound("hold_not_found", "hold not found")
+	ErrHoldNotActive      = apperror.Conflict("hold_not_active", "hold is no longer active")
+	ErrCaptureExceedsHold = apperror.BadRequest("capture_exceeds_hold", "capture amount exceeds held amount")
+)
+
+const (
+	defaultHoldTTL  = 7 * 24 * time.Hour
+	expiryBatchSize = 100
+)
+
+// HoldUseCase manages card-style authorizations: funds are reserved against
+// the available balance and later captured (debited) or voided (released).
+type HoldUseCase struct {
+	holdRepo     repository.HoldRepository
+	accountRepo  repository.AccountRepository
+	memberRepo   repository.AccountMemberRepository
+	limitUseCase *LimitUseCase
+	uow          repository.UnitOfWork
+	now          func() time.Time
+}
+
+func NewHoldUseCase(holdRepo repository.HoldRepository, accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, limitUseCase *LimitUseCase, uow repository.UnitOfWork) *HoldUseCase {
+	return &HoldUseCase{
+		holdRepo:     holdRepo,
+		accountRepo:  accountRepo,
+		memberRepo:   memberRepo,
+		limitUseCase: limitUseCase,
+		uow:          uow,
+		now:          time.Now,
+	}
+}
+
+// AuthorizeHold reserves funds for a payment out of the account. The payment
+// stays pending until the hold is captured, voided or expires.
+func (uc *HoldUseCase) AuthorizeHold(ctx context.Context, userID uuid.UUID, req *domain.AuthorizeHoldRequest) (*domain.Hold, error) {
+	accountID, err := uuid.Parse(req.AccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	var hold *domain.Hold
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		// Like transfers, business payments have to go through drafts
+		if account.OrganizationID != nil {
+			return ErrPaymentDraftRequired
+		}
+		if err := authorizeMember(ctx, repos.AccountMembers, accountID, userID, domain.AccountRole.CanTransact); err != nil {
+			return err
+		}
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			return err
+		}
+		// A payment can draw on the arranged overdraft just like a transfer
+		if account.SpendableBalance().LessThan(req.Amount) {
+			return ErrInsufficientBalance
+		}
+		if uc.limitUseCase != nil {
+			if err := uc.limitUseCase.CheckTransfer(ctx, repos, account, req.Amount); err != nil {
+				return err
+			}
+		}
+
+		transaction := &domain.Transaction{
+			FromAccountID: &account.ID,
+			Amount:        req.Amount,
+			Currency:      account.Currency,
+			Type:          domain.TransactionTypePayment,
+			Status:        domain.TransactionStatusPending,
+			Reference:     generateReference(),
+		}
+		if req.Description != "" {
+			transaction.Description = &req.Description
+		}
+		if err := repos.Transactions.Create(ctx, transaction); err != nil {
+			return err
+		}
+
+		ttl := defaultHoldTTL
+		if req.ExpiresInMinutes > 0 {
+			ttl = time.Duration(req.ExpiresInMinutes) * time.Minute
+		}
+		expiresAt := uc.now().Add(ttl)
+
+		hold, err = placeHold(ctx, repos, account, transaction, &expiresAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (uc *HoldUseCase) GetAccountHolds(ctx context.Context, userID, accountID uuid.UUID) ([]*domain.Hold, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.holdRepo.GetByAccountID(ctx, accountID)
+}
+
+// CaptureHold debits the account for the captured amount and releases the
+// rest of the hold.
+func (uc *HoldUseCase) CaptureHold(ctx context.Context, userID, holdID uuid.UUID, req *domain.CaptureHoldRequest) (*domain.Transaction, error) {
+	var transaction *domain.Transaction
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		hold, tx, err := uc.lockOwnedHold(ctx, repos, userID, holdID)
+		if err != nil {
+			return err
+		}
+
+		amount := hold.Amount
+		if req.Amount.Valid {
+			amount = req.Amount.Decimal
+		}
+		if amount.LessThanOrEqual(decimal.Zero) {
+			return ErrInvalidAmount
+		}
+		if amount.GreaterThan(hold.Amount) {
+			return ErrCaptureExceedsHold
+		}
+
+		transaction = tx
+		return captureHold(ctx, repos, hold, transaction, amount, uc.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// VoidHold releases the reserved funds without moving any money.
+func (uc *HoldUseCase) VoidHold(ctx context.Context, userID, holdID uuid.UUID) (*domain.Hold, error) {
+	var hold *domain.Hold
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var transaction *domain.Transaction
+		var err error
+		hold, transaction, err = uc.lockOwnedHold(ctx, repos, userID, holdID)
+		if err != nil {
+			return err
+		}
+
+		return releaseHold(ctx, repos, hold, transaction, domain.HoldStatusVoided, domain.TransactionStatusReversed, uc.now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// ExpireHolds releases holds whose expiry has passed and fails their
+// transactions. It is run periodically by the job runner.
+func (uc *HoldUseCase) ExpireHolds(ctx context.Context) error {
+	holds, err := uc.holdRepo.GetExpired(ctx, uc.now(), expiryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, hold := range holds {
+		if err := uc.expireHold(ctx, hold.ID); err != nil {
+			log.Printf("Failed to expire hold %s: %v", hold.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *HoldUseCase) expireHold(ctx context.Context, holdID uuid.UUID) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		hold, err := repos.Holds.GetByIDForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		// Captured or voided since the sweep was listed
+		now := uc.now()
+		if hold == nil || hold.Status != domain.HoldStatusActive || hold.ExpiresAt == nil || hold.ExpiresAt.After(now) {
+			return nil
+		}
+
+		transaction, err := lockTransaction(ctx, repos, hold.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		return releaseHold(ctx, repos, hold, transaction, domain.HoldStatusExpired, domain.TransactionStatusFailed, now)
+	})
+}
+
+// lockOwnedHold loads an active payment hold on an account the user can
+// transact on. Holds placed by risk review are settled by operators, so they
+// are reported as missing here.
+func (uc *HoldUseCase) lockOwnedHold(ctx context.Context, repos *repository.Repositories, userID, holdID uuid.UUID) (*domain.Hold, *domain.Transaction, error) {
+	hold, err := repos.Holds.GetByIDForUpdate(ctx, holdID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hold == nil {
+		return nil, nil, ErrHoldNotFound
+	}
+
+	transaction, err := lockTransaction(ctx, repos, hold.TransactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transaction.Type != domain.TransactionTypePayment {
+		return nil, nil, ErrHoldNotFound
+	}
+
+	account, err := repos.Accounts.GetByID(ctx, hold.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if account == nil {
+		return nil, nil, ErrHoldNotFound
+	}
+	canTransact, err := isMember(ctx, repos.AccountMembers, account.ID, userID, domain.AccountRole.CanTransact)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canTransact {
+		return nil, nil, ErrHoldNotFound
+	}
+
+	if hold.Status != domain.HoldStatusActive {
+		return nil, nil, ErrHoldNotActive
+	}
+
+	return hold, transaction, nil
+}
+
+func lockAccount(ctx context.Context, repos *repository.Repositories, id uuid.UUID) (*domain.Account, error) {
+	account, err := repos.Accounts.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	return account, nil
+}
+
+// lockAccounts locks two accounts and returns them in argument order. Rows are
+// always locked in ascending ID order, so two transfers going opposite ways
+// between the same accounts queue up instead of deadlocking.
+func lockAccounts(ctx context.Context, repos *repository.Repositories, a, b uuid.UUID) (*domain.Account, *domain.Account, error) {
+	if a == b {
+		account, err := lockAccount(ctx, repos, a)
+		return account, account, err
+	}
+
+	first, second := a, b
+	if bytes.Compare(b[:], a[:]) < 0 {
+		first, second = b, a
+	}
+
+	firstAccount, err := lockAccount(ctx, repos, first)
+	if err != nil {
+		return nil, nil, err
+	}
+	secondAccount, err := lockAccount(ctx, repos, second)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if first == a {
+		return firstAccount, secondAccount, nil
+	}
+	return secondAccount, firstAccount, nil
+}
+
+func lockTransaction(ctx context.Context, repos *repository.Repositories, id uuid.UUID) (*domain.Transaction, error) {
+	transaction, err := repos.Transactions.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return transaction, nil
+}
+
+// placeHold reserves the transaction amount on the locked account, in the
+// pocket for the transaction's currency if it isn't the account's own.
+func placeHold(ctx context.Context, repos *repository.Repositories, account *domain.Account, transaction *domain.Transaction, expiresAt *time.Time) (*domain.Hold, error) {
+	if err := adjustBalances(ctx, repos, account, transaction.Currency, decimal.Zero, transaction.Amount); err != nil {
+		return nil, err
+	}
+
+	hold := &domain.Hold{
+		AccountID:     account.ID,
+		TransactionID: transaction.ID,
+		Amount:        transaction.Amount,
+		Status:        domain.HoldStatusActive,
+		ExpiresAt:     expiresAt,
+	}
+	if err := repos.Holds.Create(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// captureHold settles a hold: the whole hold is released, the captured amount
+// is debited from the held account and credited to the transaction's
+// recipient, if any, and the transaction completes for the captured amount.
+func captureHold(ctx context.Context, repos *repository.Repositories, hold *domain.Hold, transaction *domain.Transaction, amount decimal.Decimal, now time.Time) error {
+	var (
+		account, recipient *domain.Account
+		err                error
+	)
+	if transaction.ToAccountID != nil {
+		account, recipient, err = lockAccounts(ctx, repos, hold.AccountID, *transaction.ToAccountID)
+	} else {
+		account, err = lockAccount(ctx, repos, hold.AccountID)
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkDebit(account); err != nil {
+		return err
+	}
+	if recipient != nil {
+		if err := checkCredit(recipient); err != nil {
+			return err
+		}
+	}
+
+	if err := adjustBalances(ctx, repos, account, transaction.Currency, amount.Neg(), hold.Amount.Neg()); err != nil {
+		return err
+	}
+
+	if recipient != nil {
+		if err := adjustBalances(ctx, repos, recipient, transaction.Currency, amount, decimal.Zero); err != nil {
+			return err
+		}
+	}
+
+	transaction.Amount = amount
+	transaction.Status = domain.TransactionStatusCompleted
+	transaction.CompletedAt = &now
+	if err := repos.Transactions.Update(ctx, transaction); err != nil {
+		return err
+	}
+
+	return setHoldStatus(ctx, repos, hold, domain.HoldStatusCaptured, now)
+}
+
+// releaseHold returns the reserved funds to the available balance and closes
+// the transaction with txStatus.
+func releaseHold(ctx context.Context, repos *repository.Repositories, hold *domain.Hold, transaction *domain.Transaction, status domain.HoldStatus, txStatus domain.TransactionStatus, now time.Time) error {
+	account, err := lockAccount(ctx, repos, hold.AccountID)
+	if err != nil {
+		return err
+	}
+	if err := adjustBalances(ctx, repos, account, transaction.Currency, decimal.Zero, hold.Amount.Neg()); err != nil {
+		return err
+	}
+
+	transaction.Status = txStatus
+	transaction.CompletedAt = &now
+	if err := repos.Transactions.Update(ctx, transaction); err != nil {
+		return err
+	}
+
+	return setHoldStatus(ctx, repos, hold, status, now)
+}
+
+func setHoldStatus(ctx context.Context, repos *repository.Repositories, hold *domain.Hold, status domain.HoldStatus, now time.Time) error {
+	hold.Status = status
+	hold.ReleasedAt = &now
+	return repos.Holds.Update(ctx, hold)
+}
\ No newline at end of file
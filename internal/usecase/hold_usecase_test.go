@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) newHold(t *testing.T, account *domain.Account, amount int64) *domain.Hold {
+	t.Helper()
+
+	hold, err := env.holds.AuthorizeHold(context.Background(), account.UserID, &domain.AuthorizeHoldRequest{
+		AccountID: account.ID.String(),
+		Amount:    decimal.NewFromInt(amount),
+	})
+	if err != nil {
+		t.Fatalf("AuthorizeHold: %v", err)
+	}
+	return hold
+}
+
+func TestAuthorizeHold(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, user.ID, 100)
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID string
+		amount    int64
+		wantErr   error
+		wantHeld  int64
+	}{
+		{"reserves funds", user.ID, account.ID.String(), 60, nil, 60},
+		{"only the available balance can be held", user.ID, account.ID.String(), 41, ErrInsufficientBalance, 60},
+		{"zero amount", user.ID, account.ID.String(), 0, ErrInvalidAmount, 60},
+		{"someone else's account", uuid.New(), account.ID.String(), 10, ErrUnauthorized, 60},
+		{"bad account ID", user.ID, "nope", 10, ErrAccountNotFound, 60},
+		{"over the per-transaction limit", user.ID, account.ID.String(), 10001, ErrInsufficientBalance, 60},
+		{"rest of the balance", user.ID, account.ID.String(), 40, nil, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold, err := env.holds.AuthorizeHold(context.Background(), tt.userID, &domain.AuthorizeHoldRequest{
+				AccountID: tt.accountID,
+				Amount:    decimal.NewFromInt(tt.amount),
+			})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if hold.Status != domain.HoldStatusActive || hold.ExpiresAt == nil || !hold.ExpiresAt.After(env.clock.Now()) {
+					t.Errorf("unexpected hold %+v", hold)
+				}
+				tx, _ := env.store.Transactions().GetByID(context.Background(), hold.TransactionID)
+				if tx == nil || tx.Status != domain.TransactionStatusPending || tx.Type != domain.TransactionTypePayment {
+					t.Errorf("hold transaction %+v, want a pending payment", tx)
+				}
+			}
+			env.assertBalances(t, account.ID, 100, tt.wantHeld)
+		})
+	}
+}
+
+func TestAuthorizeHoldChecksLimits(t *testing.T) {
+	env := newTestEnv(t)
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 20000)
+
+	_, err := env.holds.AuthorizeHold(context.Background(), account.UserID, &domain.AuthorizeHoldRequest{
+		AccountID: account.ID.String(),
+		Amount:    decimal.NewFromInt(10001),
+	})
+	if err != ErrPerTransactionLimitExceeded {
+		t.Errorf("error %v, want %v", err, ErrPerTransactionLimitExceeded)
+	}
+}
+
+func TestGetAccountHolds(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, user.ID, 100)
+	env.newHold(t, account, 10)
+	env.newHold(t, account, 20)
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		want      int
+		wantErr   error
+	}{
+		{"owner", user.ID, account.ID, 2, nil},
+		{"someone else", uuid.New(), account.ID, 0, ErrUnauthorized},
+		{"missing account", user.ID, uuid.New(), 0, ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holds, err := env.holds.GetAccountHolds(context.Background(), tt.userID, tt.accountID)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if len(holds) != tt.want {
+				t.Errorf("%d holds, want %d", len(holds), tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureHold(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      func(owner uuid.UUID) uuid.UUID
+		amount      decimal.NullDecimal
+		voidFirst   bool
+		wantErr     error
+		wantBalance int64
+		wantHeld    int64
+	}{
+		{"full capture", nil, decimal.NullDecimal{}, false, nil, 40, 0},
+		{"partial capture releases the rest", nil, nullDecimal(25), false, nil, 75, 0},
+		{"more than held", nil, nullDecimal(61), false, ErrCaptureExceedsHold, 100, 60},
+		{"zero", nil, nullDecimal(0), false, ErrInvalidAmount, 100, 60},
+		{"someone else's hold", func(uuid.UUID) uuid.UUID { return uuid.New() }, decimal.NullDecimal{}, false, ErrHoldNotFound, 100, 60},
+		{"voided hold", nil, decimal.NullDecimal{}, true, ErrHoldNotActive, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+			hold := env.newHold(t, account, 60)
+			if tt.voidFirst {
+				if _, err := env.holds.VoidHold(context.Background(), account.UserID, hold.ID); err != nil {
+					t.Fatalf("VoidHold: %v", err)
+				}
+			}
+
+			userID := account.UserID
+			if tt.userID != nil {
+				userID = tt.userID(userID)
+			}
+			tx, err := env.holds.CaptureHold(context.Background(), userID, hold.ID, &domain.CaptureHoldRequest{Amount: tt.amount})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil {
+				want := decimal.NewFromInt(100 - tt.wantBalance)
+				if tx.Status != domain.TransactionStatusCompleted || !tx.Amount.Equal(want) {
+					t.Errorf("transaction %s for %s, want completed for %s", tx.Status, tx.Amount, want)
+				}
+			}
+			env.assertBalances(t, account.ID, tt.wantBalance, tt.wantHeld)
+		})
+	}
+}
+
+func TestVoidHold(t *testing.T) {
+	env := newTestEnv(t)
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	hold := env.newHold(t, account, 60)
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		holdID  uuid.UUID
+		wantErr error
+	}{
+		{"someone else's hold", uuid.New(), hold.ID, ErrHoldNotFound},
+		{"missing hold", account.UserID, uuid.New(), ErrHoldNotFound},
+		{"voids", account.UserID, hold.ID, nil},
+		{"already voided", account.UserID, hold.ID, ErrHoldNotActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.holds.VoidHold(context.Background(), tt.userID, tt.holdID); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	env.assertBalances(t, account.ID, 100, 0)
+	tx, _ := env.store.Transactions().GetByID(context.Background(), hold.TransactionID)
+	if tx.Status != domain.TransactionStatusReversed {
+		t.Errorf("transaction %s, want %s", tx.Status, domain.TransactionStatusReversed)
+	}
+}
+
+func TestExpireHolds(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+
+	short, err := env.holds.AuthorizeHold(ctx, account.UserID, &domain.AuthorizeHoldRequest{
+		AccountID:        account.ID.String(),
+		Amount:           decimal.NewFromInt(30),
+		ExpiresInMinutes: 5,
+	})
+	if err != nil {
+		t.Fatalf("AuthorizeHold: %v", err)
+	}
+	long := env.newHold(t, account, 20)
+
+	tests := []struct {
+		name      string
+		advance   time.Duration
+		wantShort domain.HoldStatus
+		wantHeld  int64
+	}{
+		{"nothing due", time.Minute, domain.HoldStatusActive, 50},
+		{"short hold expires", 5 * time.Minute, domain.HoldStatusExpired, 20},
+		{"default TTL", defaultHoldTTL, domain.HoldStatusExpired, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env.clock.Advance(tt.advance)
+			if err := env.holds.ExpireHolds(ctx); err != nil {
+				t.Fatalf("ExpireHolds: %v", err)
+			}
+
+			got, _ := env.store.Holds().GetByID(ctx, short.ID)
+			if got.Status != tt.wantShort {
+				t.Errorf("short hold %s, want %s", got.Status, tt.wantShort)
+			}
+			env.assertBalances(t, account.ID, 100, tt.wantHeld)
+		})
+	}
+
+	got, _ := env.store.Holds().GetByID(ctx, long.ID)
+	tx, _ := env.store.Transactions().GetByID(ctx, long.TransactionID)
+	if got.Status != domain.HoldStatusExpired || tx.Status != domain.TransactionStatusFailed {
+		t.Errorf("long hold %s with transaction %s, want expired and failed", got.Status, tx.Status)
+	}
+}
+func TestAuthorizeHoldDrawsOnOverdraft(t *testing.T) {
+	env := newTestEnv(t)
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	env.setOverdraft(t, account.ID, 50)
+
+	_, err := env.holds.AuthorizeHold(context.Background(), account.UserID, &domain.AuthorizeHoldRequest{
+		AccountID: account.ID.String(),
+		Amount:    decimal.NewFromInt(151),
+	})
+	if err != ErrInsufficientBalance {
+		t.Errorf("hold over the overdraft: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	hold := env.newHold(t, account, 150)
+	env.assertBalances(t, account.ID, 100, 150)
+
+	if _, err := env.holds.CaptureHold(context.Background(), account.UserID, hold.ID, &domain.CaptureHoldRequest{}); err != nil {
+		t.Fatalf("CaptureHold: %v", err)
+	}
+	env.assertBalances(t, account.ID, -50, 0)
+}
\ No newline at end of file
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	interestBatchSize = 100
+	// A little over a year of daily accruals
+	interestHistorySize = 400
+)
+
+// InterestUseCase accrues interest daily on accounts whose type has an
+// interest product and pays it out at the end of each capitalization period.
+type InterestUseCase struct {
+	accountRepo  repository.AccountRepository
+	memberRepo   repository.AccountMemberRepository
+	interestRepo repository.InterestRepository
+	uow          repository.UnitOfWork
+	policy       *domain.InterestPolicy
+	now          func() time.Time
+}
+
+func NewInterestUseCase(accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, interestRepo repository.InterestRepository, uow repository.UnitOfWork, policy *domain.InterestPolicy) *InterestUseCase {
+	if policy == nil {
+		policy = DefaultInterestPolicy()
+	}
+	return &InterestUseCase{
+		accountRepo:  accountRepo,
+		memberRepo:   memberRepo,
+		interestRepo: interestRepo,
+		uow:          uow,
+		policy:       policy,
+		now:          time.Now,
+	}
+}
+
+// DefaultInterestPolicy pays tiered interest on savings monthly and a flat
+// rate on deposits quarterly, without withholding tax. Checking accounts
+// earn nothing. Term deposits run from three months to two years.
+func DefaultInterestPolicy() *domain.InterestPolicy {
+	return &domain.InterestPolicy{
+		Products: map[domain.AccountType]*domain.InterestProduct{
+			domain.AccountTypeSavings: {
+				DayCount:       domain.DayCountActual365,
+				Capitalization: domain.CapitalizeMonthly,
+				Tiers: []domain.InterestTier{
+					{From: decimal.Zero, Rate: decimal.RequireFromString("0.005")},
+					{From: decimal.NewFromInt(10000), Rate: decimal.RequireFromString("0.015")},
+					{From: decimal.NewFromInt(50000), Rate: decimal.RequireFromString("0.0225")},
+				},
+			},
+			domain.AccountTypeDeposit: {
+				DayCount:       domain.DayCount30360,
+				Capitalization: domain.CapitalizeQuarterly,
+				Tiers: []domain.InterestTier{
+					{From: decimal.Zero, Rate: decimal.RequireFromString("0.035")},
+				},
+			},
+		},
+		TermDeposits: &domain.TermDepositPolicy{
+			Terms: []domain.TermDepositRate{
+				{Months: 3, Rate: decimal.RequireFromString("0.03")},
+				{Months: 6, Rate: decimal.RequireFromString("0.035")},
+				{Months: 12, Rate: decimal.RequireFromString("0.04")},
+				{Months: 24, Rate: decimal.RequireFromString("0.0425")},
+			},
+			DayCount:      domain.DayCountActual365,
+			MinimumAmount: decimal.NewFromInt(1000),
+			BreakRate:     decimal.RequireFromString("0.005"),
+			BreakFeeRate:  decimal.RequireFromString("0.01"),
+		},
+	}
+}
+
+// LoadInterestPolicy reads interest products from a YAML or JSON file.
+// Account types in the file replace their default product, or stop earning
+// interest if set to null; the rest keep their defaults. A term_deposits
+// section replaces the default terms.
+func LoadInterestPolicy(path string) (*domain.InterestPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read interest products: %w", err)
+	}
+
+	var fromFile domain.InterestPolicy
+	if err := yaml.Unmarshal(data, &fromFile); err != nil {
+		return nil, fmt.Errorf("failed to parse interest products: %w", err)
+	}
+
+	policy := DefaultInterestPolicy()
+	for accountType, product := range fromFile.Products {
+		if product == nil {
+			delete(policy.Products, accountType)
+			continue
+		}
+		if err := validateInterestProduct(product); err != nil {
+			return nil, fmt.Errorf("interest product %s: %w", accountType, err)
+		}
+		policy.Products[accountType] = product
+	}
+
+	if fromFile.TermDeposits != nil {
+		if err := validateTermDepositPolicy(fromFile.TermDeposits); err != nil {
+			return nil, fmt.Errorf("term deposits: %w", err)
+		}
+		policy.TermDeposits = fromFile.TermDeposits
+	}
+
+	return policy, nil
+}
+
+func validateInterestProduct(product *domain.InterestProduct) error {
+	switch product.DayCount {
+	case domain.DayCountActual365, domain.DayCount30360:
+	default:
+		return fmt.Errorf("unknown day count %q", product.DayCount)
+	}
+	switch product.Capitalization {
+	case domain.CapitalizeMonthly, domain.CapitalizeQuarterly:
+	default:
+		return fmt.Errorf("unknown capitalization %q", product.Capitalization)
+	}
+
+	if len(product.Tiers) == 0 {
+		return fmt.Errorf("no tiers")
+	}
+	for i, tier := range product.Tiers {
+		if tier.From.IsNegative() || tier.Rate.IsNegative() {
+			return fmt.Errorf("tier %d: from and rate cannot be negative", i+1)
+		}
+		if i > 0 && !tier.From.GreaterThan(product.Tiers[i-1].From) {
+			return fmt.Errorf("tier %d: tiers must be in ascending order", i+1)
+		}
+	}
+
+	if product.WithholdingTaxRate.IsNegative() || product.WithholdingTaxRate.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("withholding_tax_rate must be between 0 and 1")
+	}
+
+	return nil
+}
+
+func validateTermDepositPolicy(policy *domain.TermDepositPolicy) error {
+	switch policy.DayCount {
+	case domain.DayCountActual365, domain.DayCount30360:
+	default:
+		return fmt.Errorf("unknown day count %q", policy.DayCount)
+	}
+
+	seen := make(map[int]bool)
+	for _, term := range policy.Terms {
+		if term.Months <= 0 || term.Rate.IsNegative() {
+			return fmt.Errorf("%d month term: months must be positive and rate not negative", term.Months)
+		}
+		if seen[term.Months] {
+			return fmt.Errorf("%d month term listed twice", term.Months)
+		}
+		seen[term.Months] = true
+	}
+
+	if policy.MinimumAmount.IsNegative() || policy.BreakRate.IsNegative() {
+		return fmt.Errorf("minimum_amount and break_rate cannot be negative")
+	}
+	if policy.BreakFeeRate.IsNegative() || policy.BreakFeeRate.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("break_fee_rate must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// GetAccountInterest shows the account's interest product, what has accrued
+// since it was last paid and the accrual history.
+func (uc *InterestUseCase) GetAccountInterest(ctx context.Context, userID, accountID uuid.UUID) (*domain.AccountInterestResponse, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	accruals, err := uc.interestRepo.GetByAccountID(ctx, accountID, interestHistorySize)
+	if err != nil {
+		return nil, err
+	}
+
+	accrued := decimal.Zero
+	for _, accrual := range accruals {
+		if accrual.CapitalizedAt == nil {
+			accrued = accrued.Add(accrual.Amount)
+		}
+	}
+
+	return &domain.AccountInterestResponse{
+		AccountID: account.ID,
+		Product:   uc.policy.Products[account.AccountType],
+		Accrued:   accrued.RoundBank(2),
+		Accruals:  accruals,
+	}, nil
+}
+
+// AccrueInterest accrues every interest-earning account up to the end of
+// yesterday (UTC) and pays out accruals from capitalization periods that
+// have ended. It is run periodically by the job runner; after the first run
+// of a day the rest find nothing to do.
+func (uc *InterestUseCase) AccrueInterest(ctx context.Context) error {
+	today := startOfDay(uc.now())
+
+	var accountTypes []domain.AccountType
+	for accountType, product := range uc.policy.Products {
+		if product != nil {
+			accountTypes = append(accountTypes, accountType)
+		}
+	}
+	// Term deposits accrue even if plain deposit accounts earn nothing
+	if uc.policy.TermDeposits != nil && uc.policy.Products[domain.AccountTypeDeposit] == nil {
+		accountTypes = append(accountTypes, domain.AccountTypeDeposit)
+	}
+
+	for _, accountType := range accountTypes {
+		after := uuid.Nil
+		for {
+			accounts, err := uc.accountRepo.GetByType(ctx, accountType, after, interestBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, account := range accounts {
+				if err := uc.accrueAccount(ctx, account.ID, today); err != nil {
+					log.Printf("Failed to accrue interest on account %s: %v", account.ID, err)
+				}
+			}
+			if len(accounts) < interestBatchSize {
+				break
+			}
+			after = accounts[len(accounts)-1].ID
+		}
+	}
+
+	return nil
+}
+
+func (uc *InterestUseCase) accrueAccount(ctx context.Context, accountID uuid.UUID, today time.Time) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if account.Status == domain.AccountStatusClosed {
+			return nil
+		}
+
+		// A term deposit earns its own rate and is paid at maturity
+		if account.AccountType == domain.AccountTypeDeposit && uc.policy.TermDeposits != nil {
+			deposit, err := repos.TermDeposits.GetActiveByAccountID(ctx, account.ID)
+			if err != nil {
+				return err
+			}
+			if deposit != nil {
+				return accrueTerm(ctx, repos, deposit, uc.policy.TermDeposits.DayCount, today)
+			}
+		}
+
+		product := uc.policy.Products[account.AccountType]
+		if product == nil {
+			return nil
+		}
+
+		// Days missed while the job wasn't running are caught up at the
+		// current balance
+		annual := product.AnnualInterest(account.Balance)
+		if err := accrueDays(ctx, repos, account.ID, account.Balance, annual, product.DayCount, startOfDay(account.CreatedAt), today); err != nil {
+			return err
+		}
+
+		return uc.capitalize(ctx, repos, account, product, today)
+	})
+}
+
+// accrueDays records a day's share of annual interest on balance for each
+// day from the one after the account's latest accrual, or from if that is
+// later, up to but not including until.
+func accrueDays(ctx context.Context, repos *repository.Repositories, accountID uuid.UUID, balance, annual decimal.Decimal, dayCount domain.DayCountConvention, from, until time.Time) error {
+	latest, err := repos.Interest.GetLatest(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	day := from
+	if latest != nil && !latest.AccrualDate.Before(from) {
+		day = startOfDay(latest.AccrualDate).AddDate(0, 0, 1)
+	}
+
+	rate := decimal.Zero
+	if balance.IsPositive() {
+		rate = annual.Div(balance).Round(6)
+	}
+	for ; day.Before(until); day = day.AddDate(0, 0, 1) {
+		accrual := &domain.InterestAccrual{
+			AccountID:   accountID,
+			AccrualDate: day,
+			Balance:     balance,
+			Rate:        rate,
+			Amount:      annual.Mul(dayCount.YearFraction(day, day.AddDate(0, 0, 1))).Round(10),
+		}
+		if err := repos.Interest.Create(ctx, accrual); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// capitalize pays out the accruals from periods before today's in one
+// interest transaction, rounded to the cent, and takes any withholding tax
+// back out in another.
+func (uc *InterestUseCase) capitalize(ctx context.Context, repos *repository.Repositories, account *domain.Account, product *domain.InterestProduct, today time.Time) error {
+	accruals, err := repos.Interest.GetUncapitalized(ctx, account.ID, product.Capitalization.PeriodStart(today))
+	if err != nil || len(accruals) == 0 {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(accruals))
+	total := decimal.Zero
+	for i, accrual := range accruals {
+		ids[i] = accrual.ID
+		total = total.Add(accrual.Amount)
+	}
+
+	now := uc.now()
+	gross := total.RoundBank(2)
+	if !gross.IsPositive() {
+		return repos.Interest.MarkCapitalized(ctx, ids, nil, now)
+	}
+
+	period := fmt.Sprintf("%s to %s", accruals[0].AccrualDate.Format("2006-01-02"), accruals[len(accruals)-1].AccrualDate.Format("2006-01-02"))
+	interest := newCashTransaction(domain.TransactionTypeInterest, account, gross, "Interest "+period)
+	interest.ToAccountID = &account.ID
+	if err := repos.Transactions.Create(ctx, interest); err != nil {
+		return err
+	}
+	account.Balance = account.Balance.Add(gross)
+
+	tax := gross.Mul(product.WithholdingTaxRate).RoundBank(2)
+	if tax.IsPositive() {
+		withholding := newCashTransaction(domain.TransactionTypeWithholdingTax, account, tax, "Withholding tax on interest "+period)
+		withholding.FromAccountID = &account.ID
+		withholding.Metadata = domain.Metadata{"interest_transaction_id": interest.ID.String()}
+		if err := repos.Transactions.Create(ctx, withholding); err != nil {
+			return err
+		}
+		account.Balance = account.Balance.Sub(tax)
+	}
+
+	if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+		return err
+	}
+
+	return repos.Interest.MarkCapitalized(ctx, ids, &interest.ID, now)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
\ No newline at end of file
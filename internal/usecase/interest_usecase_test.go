@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) newTypedAccount(t *testing.T, userID uuid.UUID, accountType domain.AccountType, balance int64) *domain.Account {
+	t.Helper()
+
+	account := &domain.Account{
+		UserID:        userID,
+		AccountNumber: nextAccountNumber(t, env.store),
+		AccountType:   accountType,
+		Balance:       decimal.NewFromInt(balance),
+		Currency:      "USD",
+		Status:        domain.AccountStatusActive,
+	}
+	if err := env.store.Accounts().Create(context.Background(), account); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	return account
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDayCountConventions(t *testing.T) {
+	// Each convention's daily fractions add up to its month
+	monthTotal := func(convention domain.DayCountConvention, start time.Time) decimal.Decimal {
+		total := decimal.Zero
+		for day := start; day.Month() == start.Month(); day = day.AddDate(0, 0, 1) {
+			total = total.Add(convention.YearFraction(day, day.AddDate(0, 0, 1)))
+		}
+		return total
+	}
+
+	tests := []struct {
+		convention domain.DayCountConvention
+		month      time.Time
+		days       int64
+		year       int64
+	}{
+		{domain.DayCountActual365, date(2025, time.January, 1), 31, 365},
+		{domain.DayCountActual365, date(2025, time.February, 1), 28, 365},
+		{domain.DayCount30360, date(2025, time.January, 1), 30, 360},
+		{domain.DayCount30360, date(2025, time.February, 1), 30, 360},
+		{domain.DayCount30360, date(2024, time.February, 1), 30, 360},
+		{domain.DayCount30360, date(2025, time.April, 1), 30, 360},
+	}
+	for _, tt := range tests {
+		got := monthTotal(tt.convention, tt.month)
+		want := decimal.NewFromInt(tt.days).Div(decimal.NewFromInt(tt.year))
+		if !got.Round(10).Equal(want.Round(10)) {
+			t.Errorf("%s over %s: %s of a year, want %d/%d", tt.convention, tt.month.Format("2006-01"), got, tt.days, tt.year)
+		}
+	}
+}
+
+func TestTieredInterest(t *testing.T) {
+	product := DefaultInterestPolicy().Products[domain.AccountTypeSavings]
+
+	tests := []struct {
+		balance int64
+		want    string
+	}{
+		{-50, "0"},
+		{0, "0"},
+		{1000, "5"},
+		{10000, "50"},
+		{20000, "200"},
+		{60000, "875"},
+	}
+	for _, tt := range tests {
+		got := product.AnnualInterest(decimal.NewFromInt(tt.balance))
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("annual interest on %d = %s, want %s", tt.balance, got, tt.want)
+		}
+	}
+}
+
+func TestAccrueAndCapitalizeInterest(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		taxRate string
+	}{
+		{"gross", "0"},
+		{"with withholding tax", "0.2"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			product := *DefaultInterestPolicy().Products[domain.AccountTypeSavings]
+			product.WithholdingTaxRate = decimal.RequireFromString(tt.taxRate)
+			env.interest.policy.Products[domain.AccountTypeSavings] = &product
+
+			ctx := context.Background()
+			user := env.newUser(t, "Alice Smith")
+			savings := env.newTypedAccount(t, user.ID, domain.AccountTypeSavings, 20000)
+			checking := env.newTypedAccount(t, user.ID, domain.AccountTypeChecking, 20000)
+
+			// Three days in, every day so far has accrued but nothing is paid
+			opened := startOfDay(savings.CreatedAt)
+			env.clock.now = opened.AddDate(0, 0, 3).Add(time.Hour)
+			if err := env.interest.AccrueInterest(ctx); err != nil {
+				t.Fatalf("AccrueInterest: %v", err)
+			}
+			interest, err := env.interest.GetAccountInterest(ctx, user.ID, savings.ID)
+			if err != nil {
+				t.Fatalf("GetAccountInterest: %v", err)
+			}
+			if len(interest.Accruals) != 3 {
+				t.Fatalf("%d accruals after three days, want 3", len(interest.Accruals))
+			}
+			// 10,000 at 0.5% and 10,000 at 1.5% is 200 a year
+			daily := decimal.NewFromInt(200).Div(decimal.NewFromInt(365)).Round(10)
+			for _, accrual := range interest.Accruals {
+				if !accrual.Amount.Equal(daily) || !accrual.Rate.Equal(decimal.RequireFromString("0.01")) {
+					t.Errorf("accrued %s at %s, want %s at 0.01", accrual.Amount, accrual.Rate, daily)
+				}
+			}
+			env.assertBalances(t, savings.ID, 20000, 0)
+
+			// Into the month after next, every accrual before this month is
+			// paid and running again pays nothing more
+			nextPeriod := domain.CapitalizeMonthly.PeriodStart(opened).AddDate(0, 2, 0)
+			env.clock.now = nextPeriod.Add(time.Hour)
+			for i := 0; i < 2; i++ {
+				if err := env.interest.AccrueInterest(ctx); err != nil {
+					t.Fatalf("AccrueInterest: %v", err)
+				}
+			}
+
+			days := int64(nextPeriod.Sub(opened).Hours() / 24)
+			gross := daily.Mul(decimal.NewFromInt(days)).RoundBank(2)
+			tax := gross.Mul(product.WithholdingTaxRate).RoundBank(2)
+			if got := env.account(t, savings.ID).Balance; !got.Equal(decimal.NewFromInt(20000).Add(gross).Sub(tax)) {
+				t.Errorf("balance %s, want 20000 + %s interest - %s tax", got, gross, tax)
+			}
+
+			transactions, err := env.store.Transactions().GetByAccountID(ctx, savings.ID, &domain.TransactionFilter{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetByAccountID: %v", err)
+			}
+			wantTransactions := 1
+			if tax.IsPositive() {
+				wantTransactions = 2
+			}
+			if len(transactions) != wantTransactions {
+				t.Fatalf("%d transactions, want %d", len(transactions), wantTransactions)
+			}
+			for _, tx := range transactions {
+				switch tx.Type {
+				case domain.TransactionTypeInterest:
+					if !tx.Amount.Equal(gross) || tx.ToAccountID == nil || *tx.ToAccountID != savings.ID {
+						t.Errorf("interest transaction %+v, want %s to the account", tx, gross)
+					}
+				case domain.TransactionTypeWithholdingTax:
+					if !tx.Amount.Equal(tax) || tx.FromAccountID == nil || *tx.FromAccountID != savings.ID {
+						t.Errorf("tax transaction %+v, want %s from the account", tx, tax)
+					}
+				default:
+					t.Errorf("unexpected %s transaction", tx.Type)
+				}
+			}
+
+			interest, err = env.interest.GetAccountInterest(ctx, user.ID, savings.ID)
+			if err != nil {
+				t.Fatalf("GetAccountInterest: %v", err)
+			}
+			if int64(len(interest.Accruals)) != days || !interest.Accrued.IsZero() {
+				t.Errorf("%d accruals with %s unpaid, want %d all paid", len(interest.Accruals), interest.Accrued, days)
+			}
+
+			// Checking accounts have no interest product
+			env.assertBalances(t, checking.ID, 20000, 0)
+			interest, err = env.interest.GetAccountInterest(ctx, user.ID, checking.ID)
+			if err != nil || interest.Product != nil || len(interest.Accruals) != 0 {
+				t.Errorf("checking interest %+v, %v; want no product or accruals", interest, err)
+			}
+		})
+	}
+}
+
+func TestGetAccountInterestOwnership(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	savings := env.newTypedAccount(t, alice.ID, domain.AccountTypeSavings, 100)
+
+	if _, err := env.interest.GetAccountInterest(context.Background(), bob.ID, savings.ID); err != ErrUnauthorized {
+		t.Errorf("error %v, want %v", err, ErrUnauthorized)
+	}
+	if _, err := env.interest.GetAccountInterest(context.Background(), alice.ID, uuid.New()); err != ErrAccountNotFound {
+		t.Errorf("error %v, want %v", err, ErrAccountNotFound)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var ledgerSeed = flag.Int64("ledger.seed", 0, "seed for TestLedgerInvariants; 0 picks one from the clock")
+
+// TestLedgerInvariants runs random sequences of account openings, deposits,
+// withdrawals and transfers, some of them invalid and some failing halfway
+// through their unit of work, and checks after every step that no money was
+// created or destroyed. A failing sequence is shrunk before it is reported;
+// rerun it with -ledger.seed.
+func TestLedgerInvariants(t *testing.T) {
+	seed := *ledgerSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	runs, length := 100, 80
+	if testing.Short() {
+		runs = 20
+	}
+
+	for i := 0; i < runs; i++ {
+		runSeed := seed + int64(i)
+		ops := generateLedgerOps(rand.New(rand.NewSource(runSeed)), length)
+
+		at, err := runLedger(t, ops)
+		if err == nil {
+			continue
+		}
+		ops = shrinkLedger(t, ops[:at+1])
+		_, err = runLedger(t, ops)
+		t.Fatalf("seed %d: %v\nshrunk to %d steps:\n%s", runSeed, err, len(ops), formatLedgerOps(ops))
+	}
+}
+
+type ledgerOpKind int
+
+const (
+	opOpenAccount ledgerOpKind = iota
+	opDeposit
+	opWithdraw
+	opTransfer
+	// opFaultyTransfer is a transfer whose second balance write fails, after
+	// the transaction record and the first balance have been written.
+	opFaultyTransfer
+)
+
+// ledgerOp refers to users and accounts by index, so a sequence still replays
+// after the shrinker drops steps. Account indexes wrap around the accounts
+// open at the time; to is -1 for an account that doesn't exist.
+type ledgerOp struct {
+	kind   ledgerOpKind
+	user   int
+	from   int
+	to     int
+	amount int64 // cents
+}
+
+func (op ledgerOp) String() string {
+	switch op.kind {
+	case opOpenAccount:
+		return fmt.Sprintf("open account for user %d", op.user)
+	case opDeposit:
+		return fmt.Sprintf("deposit %d into account %d", op.amount, op.from)
+	case opWithdraw:
+		return fmt.Sprintf("withdraw %d from account %d", op.amount, op.from)
+	case opFaultyTransfer:
+		return fmt.Sprintf("user %d transfers %d from account %d to %d, failing halfway", op.user, op.amount, op.from, op.to)
+	}
+	return fmt.Sprintf("user %d transfers %d from account %d to %d", op.user, op.amount, op.from, op.to)
+}
+
+func formatLedgerOps(ops []ledgerOp) string {
+	var b strings.Builder
+	for i, op := range ops {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, op)
+	}
+	return b.String()
+}
+
+const ledgerUsers = 3
+
+func generateLedgerOps(rng *rand.Rand, n int) []ledgerOp {
+	ops := make([]ledgerOp, 0, n)
+	accounts := 0
+	for len(ops) < n {
+		op := ledgerOp{
+			user:   rng.Intn(ledgerUsers),
+			from:   rng.Intn(8),
+			to:     rng.Intn(8),
+			amount: randomAmount(rng),
+		}
+
+		switch roll := rng.Intn(100); {
+		case accounts < 2 || roll < 10:
+			op.kind = opOpenAccount
+			accounts++
+		case roll < 30:
+			op.kind = opDeposit
+		case roll < 45:
+			op.kind = opWithdraw
+		case roll < 90:
+			op.kind = opTransfer
+			if rng.Intn(20) == 0 {
+				op.to = -1
+			}
+		default:
+			op.kind = opFaultyTransfer
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// randomAmount is usually a plausible amount, sometimes zero, negative or
+// more than any account is likely to hold.
+func randomAmount(rng *rand.Rand) int64 {
+	switch rng.Intn(20) {
+	case 0:
+		return 0
+	case 1:
+		return -rng.Int63n(10000) - 1
+	case 2:
+		return rng.Int63n(10000000) + 1000000
+	}
+	return rng.Int63n(50000) + 1
+}
+
+// shrinkLedger drops steps and halves amounts for as long as the sequence
+// keeps failing.
+func shrinkLedger(t *testing.T, ops []ledgerOp) []ledgerOp {
+	fails := func(ops []ledgerOp) bool {
+		_, err := runLedger(t, ops)
+		return err != nil
+	}
+
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for i := len(ops) - 1; i >= 0; i-- {
+			candidate := append(append([]ledgerOp{}, ops[:i]...), ops[i+1:]...)
+			if fails(candidate) {
+				ops, shrunk = candidate, true
+			}
+		}
+		for i := range ops {
+			if ops[i].amount/2 == 0 {
+				continue
+			}
+			candidate := append([]ledgerOp{}, ops...)
+			candidate[i].amount /= 2
+			if fails(candidate) {
+				ops, shrunk = candidate, true
+			}
+		}
+	}
+	return ops
+}
+
+var errInjected = errors.New("injected failure")
+
+// faultyUnitOfWork fails the second balance write of the next unit of work
+// when armed.
+type faultyUnitOfWork struct {
+	repository.UnitOfWork
+	armed bool
+}
+
+func (u *faultyUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	armed := u.armed
+	u.armed = false
+
+	return u.UnitOfWork.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		if armed {
+			faulty := *repos
+			faulty.Accounts = &faultyAccounts{AccountRepository: repos.Accounts}
+			repos = &faulty
+		}
+		return fn(ctx, repos)
+	})
+}
+
+type faultyAccounts struct {
+	repository.AccountRepository
+	writes int
+}
+
+func (a *faultyAccounts) UpdateBalances(ctx context.Context, account *domain.Account) error {
+	a.writes++
+	if a.writes == 2 {
+		return errInjected
+	}
+	return a.AccountRepository.UpdateBalances(ctx, account)
+}
+
+// ledger runs operations against the use cases and keeps a model of what
+// every balance should be.
+type ledger struct {
+	env          *testEnv
+	transactions *TransactionUseCase
+	uow          *faultyUnitOfWork
+
+	users    []*domain.User
+	accounts []*domain.Account
+	balances []int64
+	paidIn   int64
+	paidOut  int64
+	records  int
+}
+
+// runLedger returns the index of the step after which an invariant broke.
+func runLedger(t *testing.T, ops []ledgerOp) (int, error) {
+	env := newTestEnv(t)
+	uow := &faultyUnitOfWork{UnitOfWork: env.store}
+	l := &ledger{
+		env: env,
+		// No limits, risk or sanctions screening: only the ledger is
+		// under test
+		transactions: NewTransactionUseCase(env.store.Transactions(), env.store.Accounts(), env.store.AccountMembers(), env.store.Payees(), env.store.Users(), env.store.Reviews(), env.store.Devices(), nil, nil, nil, nil, nil, uow, nil),
+		uow:          uow,
+	}
+	for i := 0; i < ledgerUsers; i++ {
+		l.users = append(l.users, env.newUser(t, fmt.Sprintf("User %d", i)))
+	}
+
+	for i, op := range ops {
+		if err := l.apply(op); err != nil {
+			return i, fmt.Errorf("step %d (%s): %w", i+1, op, err)
+		}
+		if err := l.check(); err != nil {
+			return i, fmt.Errorf("after step %d (%s): %w", i+1, op, err)
+		}
+	}
+	return len(ops), nil
+}
+
+func (l *ledger) apply(op ledgerOp) error {
+	ctx := context.Background()
+	user := l.users[op.user%len(l.users)]
+
+	if op.kind == opOpenAccount {
+		account, err := l.env.accounts.CreateAccount(ctx, user.ID, &domain.CreateAccountRequest{
+			AccountType: domain.AccountTypeChecking,
+			Currency:    "USD",
+		})
+		if err != nil {
+			return err
+		}
+		l.accounts = append(l.accounts, account)
+		l.balances = append(l.balances, 0)
+		return nil
+	}
+
+	if len(l.accounts) == 0 {
+		return nil
+	}
+	from := op.from % len(l.accounts)
+	amount := decimal.New(op.amount, -2)
+
+	var (
+		err  error
+		want error
+	)
+	switch op.kind {
+	case opDeposit:
+		want = l.expect(op.amount, -1)
+		_, err = l.transactions.Deposit(ctx, &domain.DepositRequest{
+			AccountID: l.accounts[from].ID.String(),
+			Amount:    amount,
+		})
+		if err == nil {
+			l.balances[from] += op.amount
+			l.paidIn += op.amount
+		}
+
+	case opWithdraw:
+		want = l.expect(op.amount, from)
+		_, err = l.transactions.Withdraw(ctx, &domain.WithdrawalRequest{
+			AccountID: l.accounts[from].ID.String(),
+			Amount:    amount,
+		})
+		if err == nil {
+			l.balances[from] -= op.amount
+			l.paidOut += op.amount
+		}
+
+	default:
+		toID := uuid.New()
+		to := -1
+		if op.to >= 0 {
+			to = op.to % len(l.accounts)
+			toID = l.accounts[to].ID
+		}
+
+		switch {
+		case to == from:
+			want = ErrSameAccount
+		case op.amount <= 0:
+			want = ErrInvalidAmount
+		case to < 0:
+			want = ErrAccountNotFound
+		case l.accounts[from].UserID != user.ID:
+			want = ErrUnauthorized
+		case l.balances[from] < op.amount:
+			want = ErrInsufficientBalance
+		case op.kind == opFaultyTransfer:
+			want = errInjected
+		}
+
+		l.uow.armed = op.kind == opFaultyTransfer
+		_, err = l.transactions.Transfer(ctx, user.ID, &domain.TransferRequest{
+			FromAccountID: l.accounts[from].ID.String(),
+			ToAccountID:   toID.String(),
+			Amount:        amount,
+		})
+		l.uow.armed = false
+		if err == nil {
+			l.balances[from] -= op.amount
+			l.balances[to] += op.amount
+		}
+	}
+
+	if err != want {
+		return fmt.Errorf("error %v, want %v", err, want)
+	}
+	if err == nil {
+		l.records++
+	}
+	return nil
+}
+
+// expect is the outcome of a deposit, or of a withdrawal from account from.
+func (l *ledger) expect(amount int64, from int) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	if from >= 0 && l.balances[from] < amount {
+		return ErrInsufficientBalance
+	}
+	return nil
+}
+
+// check compares every account with the model and with the transaction
+// records that moved money in and out of it.
+func (l *ledger) check() error {
+	ctx := context.Background()
+
+	var total int64
+	seen := make(map[uuid.UUID]bool)
+	for i, model := range l.accounts {
+		account, err := l.env.store.Accounts().GetByID(ctx, model.ID)
+		if err != nil {
+			return err
+		}
+
+		balance := account.Balance.Shift(2).IntPart()
+		if balance < 0 || account.AvailableBalance().IsNegative() {
+			return fmt.Errorf("account %d: negative balance %s (available %s)", i, account.Balance, account.AvailableBalance())
+		}
+		if balance != l.balances[i] {
+			return fmt.Errorf("account %d: balance %d, model says %d", i, balance, l.balances[i])
+		}
+		total += balance
+
+		transactions, err := l.env.store.Transactions().GetByAccountID(ctx, model.ID, &domain.TransactionFilter{Limit: 100000})
+		if err != nil {
+			return err
+		}
+		var recorded decimal.Decimal
+		for _, tx := range transactions {
+			seen[tx.ID] = true
+			if tx.Status != domain.TransactionStatusCompleted {
+				return fmt.Errorf("account %d: transaction %s left %s", i, tx.Reference, tx.Status)
+			}
+			if tx.ToAccountID != nil && *tx.ToAccountID == model.ID {
+				recorded = recorded.Add(tx.Amount)
+			}
+			if tx.FromAccountID != nil && *tx.FromAccountID == model.ID {
+				recorded = recorded.Sub(tx.Amount)
+			}
+		}
+		if !recorded.Equal(account.Balance) {
+			return fmt.Errorf("account %d: balance %s but its transactions add up to %s", i, account.Balance, recorded)
+		}
+	}
+
+	if total != l.paidIn-l.paidOut {
+		return fmt.Errorf("accounts hold %d in total, but %d was paid in and %d paid out", total, l.paidIn, l.paidOut)
+	}
+	if len(seen) != l.records {
+		return fmt.Errorf("%d transactions recorded for %d successful operations", len(seen), l.records)
+	}
+	return nil
+}
\ No newline at end of file
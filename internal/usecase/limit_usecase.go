@@ -0,0 +1,408 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPerTransactionLimitExceeded = apperror.Unprocessable("per_transaction_limit_exceeded", "amount exceeds the per-transaction limit")
+	ErrDailyLimitExceeded          = apperror.Unprocessable("daily_limit_exceeded", "transfer would exceed the daily limit")
+	ErrMonthlyLimitExceeded        = apperror.Unprocessable("monthly_limit_exceeded", "transfer would exceed the monthly limit")
+	ErrLimitAboveAllowed           = apperror.Unprocessable("limit_above_allowed", "requested limit is above the allowed limit")
+	ErrInvalidLimit                = apperror.BadRequest("invalid_limit", "limits cannot be negative")
+)
+
+type LimitUseCase struct {
+	limitRepo       repository.LimitRepository
+	accountRepo     repository.AccountRepository
+	memberRepo      repository.AccountMemberRepository
+	userRepo        repository.UserRepository
+	transactionRepo repository.TransactionRepository
+	policy          *domain.LimitPolicy
+	now             func() time.Time
+}
+
+func NewLimitUseCase(limitRepo repository.LimitRepository, accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, userRepo repository.UserRepository, transactionRepo repository.TransactionRepository, policy *domain.LimitPolicy) *LimitUseCase {
+	return &LimitUseCase{
+		limitRepo:       limitRepo,
+		accountRepo:     accountRepo,
+		memberRepo:      memberRepo,
+		userRepo:        userRepo,
+		transactionRepo: transactionRepo,
+		policy:          withDefaultLimits(policy),
+		now:             time.Now,
+	}
+}
+
+func DefaultLimitPolicy() *domain.LimitPolicy {
+	return &domain.LimitPolicy{
+		AccountTypes: map[domain.AccountType]domain.LimitSet{
+			domain.AccountTypeChecking: newLimitSet(10000, 25000, 100000),
+			domain.AccountTypeSavings:  newLimitSet(5000, 10000, 50000),
+			domain.AccountTypeDeposit:  newLimitSet(5000, 10000, 50000),
+		},
+		UserTiers: map[domain.UserTier]domain.LimitSet{
+			domain.UserTierStandard: newLimitSet(10000, 25000, 100000),
+			domain.UserTierPremium:  newLimitSet(50000, 100000, 500000),
+		},
+	}
+}
+
+// LoadLimitPolicy reads a JSON limit policy from path. Account types and
+// tiers missing from the file keep their defaults.
+func LoadLimitPolicy(path string) (*domain.LimitPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read limit policy: %w", err)
+	}
+
+	var fromFile domain.LimitPolicy
+	if err := json.Unmarshal(data, &fromFile); err != nil {
+		return nil, fmt.Errorf("failed to parse limit policy: %w", err)
+	}
+
+	return withDefaultLimits(&fromFile), nil
+}
+
+// withDefaultLimits fills the account types and tiers policy leaves out with
+// the defaults, so no account ends up with zero limits.
+func withDefaultLimits(policy *domain.LimitPolicy) *domain.LimitPolicy {
+	merged := DefaultLimitPolicy()
+	if policy == nil {
+		return merged
+	}
+	for accountType, limits := range policy.AccountTypes {
+		merged.AccountTypes[accountType] = limits
+	}
+	for tier, limits := range policy.UserTiers {
+		merged.UserTiers[tier] = limits
+	}
+	return merged
+}
+
+// GetAccountLimits reports the effective limits on an account and how much of
+// each is left, taking the tighter of the account and user limits.
+func (uc *LimitUseCase) GetAccountLimits(ctx context.Context, userID, accountID uuid.UUID) (*domain.AccountLimitsResponse, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	repos := uc.committed()
+	accountLimits, userLimits, err := uc.effectiveLimits(ctx, repos, account)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := uc.outflows(ctx, repos, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AccountLimitsResponse{
+		AccountID:      account.ID,
+		PerTransaction: decimal.Min(accountLimits.PerTransaction, userLimits.PerTransaction),
+		Daily:          limitUsage(accountLimits.Daily, usage.accountDaily),
+		Monthly:        limitUsage(accountLimits.Monthly, usage.accountMonthly),
+		UserDaily:      limitUsage(userLimits.Daily, usage.userDaily),
+		UserMonthly:    limitUsage(userLimits.Monthly, usage.userMonthly),
+	}, nil
+}
+
+// SetUserLimits stores limits the account holder chose for themselves. They
+// can only be lower than what the bank allows; null fields mean "no custom
+// limit" and an all-null request removes the customisation.
+func (uc *LimitUseCase) SetUserLimits(ctx context.Context, userID, accountID uuid.UUID, req *domain.UpdateLimitsRequest) (*domain.AccountLimit, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	allowed, _, err := uc.bankLimits(ctx, uc.committed(), account)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []struct {
+		requested decimal.NullDecimal
+		allowed   decimal.Decimal
+	}{
+		{req.PerTransaction, allowed.PerTransaction},
+		{req.Daily, allowed.Daily},
+		{req.Monthly, allowed.Monthly},
+	}
+	for _, check := range checks {
+		if check.requested.Valid && check.requested.Decimal.GreaterThan(check.allowed) {
+			return nil, ErrLimitAboveAllowed
+		}
+	}
+
+	return uc.saveLimits(ctx, accountID, domain.LimitSourceUser, req)
+}
+
+// SetOperatorLimits overrides the default limits on an account in either
+// direction. An all-null request removes the override.
+func (uc *LimitUseCase) SetOperatorLimits(ctx context.Context, accountID uuid.UUID, req *domain.UpdateLimitsRequest) (*domain.AccountLimit, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	return uc.saveLimits(ctx, accountID, domain.LimitSourceOperator, req)
+}
+
+// CheckTransfer rejects amount if it would breach any per-transaction, daily
+// or monthly limit on account or its owner. Pending outflows count towards
+// the totals so held funds can't be spent twice. It reads through repos, so
+// the usage it sees is the unit of work's own and a concurrent transfer
+// from another of the owner's accounts makes one of the two retry.
+func (uc *LimitUseCase) CheckTransfer(ctx context.Context, repos *repository.Repositories, account *domain.Account, amount decimal.Decimal) error {
+	accountLimits, userLimits, err := uc.effectiveLimits(ctx, repos, account)
+	if err != nil {
+		return err
+	}
+
+	if amount.GreaterThan(accountLimits.PerTransaction) || amount.GreaterThan(userLimits.PerTransaction) {
+		return ErrPerTransactionLimitExceeded
+	}
+
+	usage, err := uc.outflows(ctx, repos, account)
+	if err != nil {
+		return err
+	}
+
+	if usage.accountDaily.Add(amount).GreaterThan(accountLimits.Daily) ||
+		usage.userDaily.Add(amount).GreaterThan(userLimits.Daily) {
+		return ErrDailyLimitExceeded
+	}
+	if usage.accountMonthly.Add(amount).GreaterThan(accountLimits.Monthly) ||
+		usage.userMonthly.Add(amount).GreaterThan(userLimits.Monthly) {
+		return ErrMonthlyLimitExceeded
+	}
+
+	return nil
+}
+
+// PerTransactionLimit is the largest single transfer currently allowed out of
+// account.
+func (uc *LimitUseCase) PerTransactionLimit(ctx context.Context, repos *repository.Repositories, account *domain.Account) (decimal.Decimal, error) {
+	accountLimits, userLimits, err := uc.effectiveLimits(ctx, repos, account)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.Min(accountLimits.PerTransaction, userLimits.PerTransaction), nil
+}
+
+func (uc *LimitUseCase) saveLimits(ctx context.Context, accountID uuid.UUID, source domain.LimitSource, req *domain.UpdateLimitsRequest) (*domain.AccountLimit, error) {
+	for _, value := range []decimal.NullDecimal{req.PerTransaction, req.Daily, req.Monthly} {
+		if value.Valid && value.Decimal.IsNegative() {
+			return nil, ErrInvalidLimit
+		}
+	}
+
+	if !req.PerTransaction.Valid && !req.Daily.Valid && !req.Monthly.Valid {
+		return nil, uc.limitRepo.Delete(ctx, accountID, source)
+	}
+
+	limit := &domain.AccountLimit{
+		AccountID:      accountID,
+		Source:         source,
+		PerTransaction: req.PerTransaction,
+		Daily:          req.Daily,
+		Monthly:        req.Monthly,
+	}
+	if req.Reason != "" {
+		limit.Reason = &req.Reason
+	}
+
+	if err := uc.limitRepo.Upsert(ctx, limit); err != nil {
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+// committed returns the use case's own repositories, for reads that don't
+// have to be consistent with a transfer in progress.
+func (uc *LimitUseCase) committed() *repository.Repositories {
+	return &repository.Repositories{
+		Accounts:     uc.accountRepo,
+		Transactions: uc.transactionRepo,
+		Users:        uc.userRepo,
+		Limits:       uc.limitRepo,
+	}
+}
+
+// bankLimits returns the limits the bank allows before the holder's own
+// reductions: account-type defaults with any operator override applied, and
+// the stored per-account rows for reuse.
+func (uc *LimitUseCase) bankLimits(ctx context.Context, repos *repository.Repositories, account *domain.Account) (domain.LimitSet, []*domain.AccountLimit, error) {
+	limits, ok := uc.policy.AccountTypes[account.AccountType]
+	if !ok {
+		return domain.LimitSet{}, nil, fmt.Errorf("no limits configured for %s accounts", account.AccountType)
+	}
+
+	rows, err := repos.Limits.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return domain.LimitSet{}, nil, err
+	}
+
+	for _, row := range rows {
+		if row.Source == domain.LimitSourceOperator {
+			limits = overrideLimits(limits, row)
+		}
+	}
+
+	return limits, rows, nil
+}
+
+func (uc *LimitUseCase) effectiveLimits(ctx context.Context, repos *repository.Repositories, account *domain.Account) (domain.LimitSet, domain.LimitSet, error) {
+	accountLimits, rows, err := uc.bankLimits(ctx, repos, account)
+	if err != nil {
+		return domain.LimitSet{}, domain.LimitSet{}, err
+	}
+
+	for _, row := range rows {
+		if row.Source == domain.LimitSourceUser {
+			accountLimits = lowerLimits(accountLimits, row)
+		}
+	}
+
+	user, err := repos.Users.GetByID(ctx, account.UserID)
+	if err != nil {
+		return domain.LimitSet{}, domain.LimitSet{}, err
+	}
+	if user == nil {
+		return domain.LimitSet{}, domain.LimitSet{}, ErrUserNotFound
+	}
+
+	tier := user.Tier
+	if tier == "" {
+		tier = domain.UserTierStandard
+	}
+
+	userLimits, ok := uc.policy.UserTiers[tier]
+	if !ok {
+		return domain.LimitSet{}, domain.LimitSet{}, fmt.Errorf("no limits configured for %s users", tier)
+	}
+
+	return accountLimits, userLimits, nil
+}
+
+type outflowUsage struct {
+	accountDaily   decimal.Decimal
+	accountMonthly decimal.Decimal
+	userDaily      decimal.Decimal
+	userMonthly    decimal.Decimal
+}
+
+func (uc *LimitUseCase) outflows(ctx context.Context, repos *repository.Repositories, account *domain.Account) (*outflowUsage, error) {
+	now := uc.now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	userAccounts, err := repos.Accounts.GetByUserID(ctx, account.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// User limits cover the accounts the owner owns, not ones they were
+	// only made a member of
+	userAccountIDs := make([]uuid.UUID, 0, len(userAccounts))
+	for _, a := range userAccounts {
+		if a.UserID == account.UserID {
+			userAccountIDs = append(userAccountIDs, a.ID)
+		}
+	}
+
+	var usage outflowUsage
+	queries := []struct {
+		accountIDs []uuid.UUID
+		since      time.Time
+		dest       *decimal.Decimal
+	}{
+		{[]uuid.UUID{account.ID}, dayStart, &usage.accountDaily},
+		{[]uuid.UUID{account.ID}, monthStart, &usage.accountMonthly},
+		{userAccountIDs, dayStart, &usage.userDaily},
+		{userAccountIDs, monthStart, &usage.userMonthly},
+	}
+	for _, q := range queries {
+		summary, err := repos.Transactions.GetOutflowSummary(ctx, q.accountIDs, q.since)
+		if err != nil {
+			return nil, err
+		}
+		*q.dest = summary.Total
+	}
+
+	return &usage, nil
+}
+
+func newLimitSet(perTransaction, daily, monthly int64) domain.LimitSet {
+	return domain.LimitSet{
+		PerTransaction: decimal.NewFromInt(perTransaction),
+		Daily:          decimal.NewFromInt(daily),
+		Monthly:        decimal.NewFromInt(monthly),
+	}
+}
+
+func overrideLimits(limits domain.LimitSet, row *domain.AccountLimit) domain.LimitSet {
+	if row.PerTransaction.Valid {
+		limits.PerTransaction = row.PerTransaction.Decimal
+	}
+	if row.Daily.Valid {
+		limits.Daily = row.Daily.Decimal
+	}
+	if row.Monthly.Valid {
+		limits.Monthly = row.Monthly.Decimal
+	}
+	return limits
+}
+
+func lowerLimits(limits domain.LimitSet, row *domain.AccountLimit) domain.LimitSet {
+	if row.PerTransaction.Valid {
+		limits.PerTransaction = decimal.Min(limits.PerTransaction, row.PerTransaction.Decimal)
+	}
+	if row.Daily.Valid {
+		limits.Daily = decimal.Min(limits.Daily, row.Daily.Decimal)
+	}
+	if row.Monthly.Valid {
+		limits.Monthly = decimal.Min(limits.Monthly, row.Monthly.Decimal)
+	}
+	return limits
+}
+
+func limitUsage(limit, used decimal.Decimal) domain.LimitUsage {
+	remaining := limit.Sub(used)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+	return domain.LimitUsage{
+		Limit:     limit,
+		Used:      used,
+		Remaining: remaining,
+	}
+}
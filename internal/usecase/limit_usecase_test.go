@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// newOutflow records a payment out of account. Like every row in the store
+// it is stamped with the real time, not the env clock.
+func (env *testEnv) newOutflow(t *testing.T, account *domain.Account, amount int64, status domain.TransactionStatus) {
+	t.Helper()
+
+	tx := &domain.Transaction{
+		FromAccountID: &account.ID,
+		Amount:        decimal.NewFromInt(amount),
+		Currency:      "USD",
+		Type:          domain.TransactionTypePayment,
+		Status:        status,
+		Reference:     generateReference(),
+	}
+	if err := env.store.Transactions().Create(context.Background(), tx); err != nil {
+		t.Fatalf("create transaction: %v", err)
+	}
+}
+
+func nullDecimal(v int64) decimal.NullDecimal {
+	return decimal.NewNullDecimal(decimal.NewFromInt(v))
+}
+
+func TestGetAccountLimits(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, user.ID, 0)
+	other := env.newAccount(t, user.ID, 0)
+	env.newOutflow(t, account, 1000, domain.TransactionStatusCompleted)
+	env.newOutflow(t, account, 500, domain.TransactionStatusPending)
+	env.newOutflow(t, account, 700, domain.TransactionStatusFailed)
+	env.newOutflow(t, other, 200, domain.TransactionStatusCompleted)
+	// Spending from someone else's account Alice is a joint holder of
+	// doesn't count towards her own user limits
+	shared := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+	if err := env.store.AccountMembers().Create(context.Background(), &domain.AccountMember{AccountID: shared.ID, UserID: user.ID, Role: domain.AccountRoleJointHolder, Status: domain.MemberStatusActive}); err != nil {
+		t.Fatalf("create member: %v", err)
+	}
+	env.newOutflow(t, shared, 300, domain.TransactionStatusCompleted)
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		wantErr   error
+	}{
+		{"owner", user.ID, account.ID, nil},
+		{"someone else", uuid.New(), account.ID, ErrUnauthorized},
+		{"missing account", user.ID, uuid.New(), ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := env.limits.GetAccountLimits(context.Background(), tt.userID, tt.accountID)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			checks := []struct {
+				name string
+				got  decimal.Decimal
+				want int64
+			}{
+				{"per transaction", limits.PerTransaction, 10000},
+				{"daily used", limits.Daily.Used, 1500},
+				{"daily remaining", limits.Daily.Remaining, 23500},
+				{"user daily used", limits.UserDaily.Used, 1700},
+				{"user monthly remaining", limits.UserMonthly.Remaining, 98300},
+			}
+			for _, check := range checks {
+				if !check.got.Equal(decimal.NewFromInt(check.want)) {
+					t.Errorf("%s = %s, want %d", check.name, check.got, check.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetUserLimits(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, user.ID, 0)
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		req     domain.UpdateLimitsRequest
+		wantErr error
+		wantRow bool
+	}{
+		{"lower daily", user.ID, domain.UpdateLimitsRequest{Daily: nullDecimal(500)}, nil, true},
+		{"above the bank's limit", user.ID, domain.UpdateLimitsRequest{Daily: nullDecimal(25001)}, ErrLimitAboveAllowed, true},
+		{"negative", user.ID, domain.UpdateLimitsRequest{PerTransaction: nullDecimal(-1)}, ErrInvalidLimit, true},
+		{"someone else", uuid.New(), domain.UpdateLimitsRequest{Daily: nullDecimal(100)}, ErrUnauthorized, true},
+		{"all null removes the row", user.ID, domain.UpdateLimitsRequest{}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.limits.SetUserLimits(context.Background(), tt.userID, account.ID, &tt.req); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			rows, _ := env.store.Limits().GetByAccountID(context.Background(), account.ID)
+			if (len(rows) == 1) != tt.wantRow {
+				t.Errorf("%d limit rows, want row %v", len(rows), tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestSetOperatorLimits(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, user.ID, 0)
+
+	tests := []struct {
+		name      string
+		accountID uuid.UUID
+		req       domain.UpdateLimitsRequest
+		wantErr   error
+		wantDaily int64
+	}{
+		{"raise above the default", account.ID, domain.UpdateLimitsRequest{Daily: nullDecimal(50000), Reason: "verified income"}, nil, 50000},
+		{"lower below the default", account.ID, domain.UpdateLimitsRequest{Daily: nullDecimal(100)}, nil, 100},
+		{"negative", account.ID, domain.UpdateLimitsRequest{Daily: nullDecimal(-5)}, ErrInvalidLimit, 100},
+		{"missing account", uuid.New(), domain.UpdateLimitsRequest{Daily: nullDecimal(5)}, ErrAccountNotFound, 100},
+		{"all null removes the override", account.ID, domain.UpdateLimitsRequest{}, nil, 25000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.limits.SetOperatorLimits(context.Background(), tt.accountID, &tt.req); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			limits, err := env.limits.GetAccountLimits(context.Background(), user.ID, account.ID)
+			if err != nil {
+				t.Fatalf("GetAccountLimits: %v", err)
+			}
+			if !limits.Daily.Limit.Equal(decimal.NewFromInt(tt.wantDaily)) {
+				t.Errorf("daily limit %s, want %d", limits.Daily.Limit, tt.wantDaily)
+			}
+		})
+	}
+}
+
+func TestCheckTransfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, env *testEnv, account *domain.Account)
+		amount  int64
+		wantErr error
+	}{
+		{"within limits", nil, 5000, nil},
+		{"per transaction", nil, 10001, ErrPerTransactionLimitExceeded},
+		{"daily, counting pending outflows", func(t *testing.T, env *testEnv, account *domain.Account) {
+			env.newOutflow(t, account, 9000, domain.TransactionStatusCompleted)
+			env.newOutflow(t, account, 9000, domain.TransactionStatusCompleted)
+			env.newOutflow(t, account, 5000, domain.TransactionStatusPending)
+		}, 2001, ErrDailyLimitExceeded},
+		{"failed outflows don't count", func(t *testing.T, env *testEnv, account *domain.Account) {
+			env.newOutflow(t, account, 9000, domain.TransactionStatusFailed)
+			env.newOutflow(t, account, 9000, domain.TransactionStatusFailed)
+			env.newOutflow(t, account, 9000, domain.TransactionStatusFailed)
+		}, 9000, nil},
+		{"daily across the user's accounts", func(t *testing.T, env *testEnv, account *domain.Account) {
+			sibling := env.newAccount(t, account.UserID, 0)
+			env.newOutflow(t, sibling, 9000, domain.TransactionStatusCompleted)
+			env.newOutflow(t, sibling, 9000, domain.TransactionStatusCompleted)
+		}, 8000, ErrDailyLimitExceeded},
+		{"monthly", func(t *testing.T, env *testEnv, account *domain.Account) {
+			if _, err := env.limits.SetUserLimits(context.Background(), account.UserID, account.ID, &domain.UpdateLimitsRequest{Monthly: nullDecimal(1000)}); err != nil {
+				t.Fatalf("SetUserLimits: %v", err)
+			}
+			env.newOutflow(t, account, 900, domain.TransactionStatusCompleted)
+		}, 101, ErrMonthlyLimitExceeded},
+		{"yesterday's outflows reset", func(t *testing.T, env *testEnv, account *domain.Account) {
+			env.newOutflow(t, account, 9000, domain.TransactionStatusCompleted)
+			env.newOutflow(t, account, 9000, domain.TransactionStatusCompleted)
+			env.newOutflow(t, account, 7000, domain.TransactionStatusCompleted)
+			env.clock.Advance(24 * time.Hour)
+		}, 9000, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 0)
+			if tt.setup != nil {
+				tt.setup(t, env, account)
+			}
+
+			err := env.store.Do(context.Background(), func(ctx context.Context, repos *repository.Repositories) error {
+				return env.limits.CheckTransfer(ctx, repos, account, decimal.NewFromInt(tt.amount))
+			})
+			if err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPerTransactionLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		tier     domain.UserTier
+		operator *domain.UpdateLimitsRequest
+		user     *domain.UpdateLimitsRequest
+		want     int64
+	}{
+		{"account type default", domain.UserTierStandard, nil, nil, 10000},
+		{"lowered by the holder", domain.UserTierStandard, nil, &domain.UpdateLimitsRequest{PerTransaction: nullDecimal(250)}, 250},
+		{"operator raise capped by the tier", domain.UserTierStandard, &domain.UpdateLimitsRequest{PerTransaction: nullDecimal(40000)}, nil, 10000},
+		{"operator raise on a premium user", domain.UserTierPremium, &domain.UpdateLimitsRequest{PerTransaction: nullDecimal(40000)}, nil, 40000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			user := &domain.User{Email: uuid.NewString() + "@example.com", FullName: "Alice Smith", Tier: tt.tier}
+			if err := env.store.Users().Create(context.Background(), user); err != nil {
+				t.Fatalf("create user: %v", err)
+			}
+			account := env.newAccount(t, user.ID, 0)
+
+			if tt.operator != nil {
+				if _, err := env.limits.SetOperatorLimits(context.Background(), account.ID, tt.operator); err != nil {
+					t.Fatalf("SetOperatorLimits: %v", err)
+				}
+			}
+			if tt.user != nil {
+				if _, err := env.limits.SetUserLimits(context.Background(), user.ID, account.ID, tt.user); err != nil {
+					t.Fatalf("SetUserLimits: %v", err)
+				}
+			}
+
+			got, err := env.limits.PerTransactionLimit(context.Background(), env.limits.committed(), account)
+			if err != nil {
+				t.Fatalf("PerTransactionLimit: %v", err)
+			}
+			if !got.Equal(decimal.NewFromInt(tt.want)) {
+				t.Errorf("limit %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
+func TestPartialLimitPolicyKeepsDefaults(t *testing.T) {
+	env := newTestEnv(t)
+	// Only premium users are configured; checking accounts and standard
+	// users keep the default limits instead of being blocked
+	limits := NewLimitUseCase(env.store.Limits(), env.store.Accounts(), env.store.AccountMembers(), env.store.Users(), env.store.Transactions(), &domain.LimitPolicy{
+		UserTiers: map[domain.UserTier]domain.LimitSet{domain.UserTierPremium: newLimitSet(1, 1, 1)},
+	})
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 0)
+
+	got, err := limits.PerTransactionLimit(context.Background(), limits.committed(), account)
+	if err != nil {
+		t.Fatalf("PerTransactionLimit: %v", err)
+	}
+	if !got.Equal(decimal.NewFromInt(10000)) {
+		t.Errorf("limit %s, want 10000", got)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrOrganizationNotFound      = apperror.NotFound("organization_not_found", "organization not found")
+	ErrOrganizationForbidden     = apperror.Forbidden("organization_forbidden", "you are not allowed to do this in the organization")
+	ErrAlreadyOrganizationMember = apperror.Conflict("already_organization_member", "the user is already a member of the organization")
+	ErrLastAdmin                 = apperror.Unprocessable("last_admin", "the organization needs at least one admin")
+	ErrTooFewPaymentApprovers    = apperror.Unprocessable("too_few_approvers", "the organization has fewer members who can approve payments than its approval rules require")
+	ErrDuplicateApprovalRule     = apperror.BadRequest("duplicate_approval_rule", "each approval rule needs a different minimum amount")
+)
+
+// OrganizationUseCase manages business customers: their members and roles,
+// the accounts they own and the rules deciding how many approvals a payment
+// out of those accounts needs.
+type OrganizationUseCase struct {
+	organizationRepo repository.OrganizationRepository
+	accountRepo      repository.AccountRepository
+	userRepo         repository.UserRepository
+	numbers          accountnumber.Scheme
+	now              func() time.Time
+}
+
+func NewOrganizationUseCase(organizationRepo repository.OrganizationRepository, accountRepo repository.AccountRepository, userRepo repository.UserRepository, numbers accountnumber.Scheme) *OrganizationUseCase {
+	if numbers == nil {
+		numbers = accountnumber.Default()
+	}
+	return &OrganizationUseCase{
+		organizationRepo: organizationRepo,
+		accountRepo:      accountRepo,
+		userRepo:         userRepo,
+		numbers:          numbers,
+		now:              time.Now,
+	}
+}
+
+// CreateOrganization makes the organization with the user as its first
+// admin.
+func (uc *OrganizationUseCase) CreateOrganization(ctx context.Context, userID uuid.UUID, req *domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	organization := &domain.Organization{
+		Name:               req.Name,
+		RegistrationNumber: req.RegistrationNumber,
+		CreatedBy:          userID,
+	}
+	if err := uc.organizationRepo.Create(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (uc *OrganizationUseCase) GetUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	return uc.organizationRepo.GetByUserID(ctx, userID)
+}
+
+func (uc *OrganizationUseCase) GetOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*domain.Organization, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.organizationRepo.GetByID(ctx, organizationID)
+}
+
+// AddMember adds the user with the given email to the organization. Unlike
+// account members they don't have to accept; an admin vouches for them.
+func (uc *OrganizationUseCase) AddMember(ctx context.Context, userID, organizationID uuid.UUID, req *domain.AddOrganizationMemberRequest) (*domain.OrganizationMember, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	existing, err := uc.organizationRepo.GetMember(ctx, organizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyOrganizationMember
+	}
+
+	member := &domain.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           req.Role,
+		AddedBy:        &userID,
+	}
+	if err := uc.organizationRepo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (uc *OrganizationUseCase) GetMembers(ctx context.Context, userID, organizationID uuid.UUID) ([]*domain.OrganizationMember, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.organizationRepo.GetMembers(ctx, organizationID)
+}
+
+// UpdateMember changes a member's role. The last admin can't be demoted, and
+// nobody can lose the right to approve while the rules need them.
+func (uc *OrganizationUseCase) UpdateMember(ctx context.Context, userID, organizationID, memberID uuid.UUID, req *domain.UpdateOrganizationMemberRequest) (*domain.OrganizationMember, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	member, err := uc.organizationRepo.GetMember(ctx, organizationID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	updated := *member
+	updated.Role = req.Role
+	if err := uc.checkMembers(ctx, organizationID, member, &updated); err != nil {
+		return nil, err
+	}
+	if err := uc.organizationRepo.UpdateMember(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// RemoveMember takes a member out of the organization. Members can always
+// leave; removing anyone else takes an admin.
+func (uc *OrganizationUseCase) RemoveMember(ctx context.Context, userID, organizationID, memberID uuid.UUID) error {
+	if memberID != userID {
+		if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanManage); err != nil {
+			return err
+		}
+	}
+
+	member, err := uc.organizationRepo.GetMember(ctx, organizationID, memberID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return ErrMemberNotFound
+	}
+	if err := uc.checkMembers(ctx, organizationID, member, nil); err != nil {
+		return err
+	}
+
+	return uc.organizationRepo.RemoveMember(ctx, organizationID, memberID)
+}
+
+// checkMembers makes sure the organization keeps an admin and enough
+// approvers for its rules once member is replaced by updated, or removed if
+// updated is nil.
+func (uc *OrganizationUseCase) checkMembers(ctx context.Context, organizationID uuid.UUID, member, updated *domain.OrganizationMember) error {
+	members, err := uc.organizationRepo.GetMembers(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	admins, approvers := 0, 0
+	for _, m := range members {
+		if m.UserID == member.UserID {
+			if updated == nil {
+				continue
+			}
+			m = updated
+		}
+		if m.Role.CanManage() {
+			admins++
+		}
+		if m.Role.CanApprove() {
+			approvers++
+		}
+	}
+	if admins == 0 {
+		return ErrLastAdmin
+	}
+
+	rules, err := uc.organizationRepo.GetApprovalRules(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if approvers < maxRequiredApprovals(rules) {
+		return ErrTooFewPaymentApprovers
+	}
+	return nil
+}
+
+// GetApprovalRules lists the organization's rules by minimum amount. Payments
+// below every rule need a single approval.
+func (uc *OrganizationUseCase) GetApprovalRules(ctx context.Context, userID, organizationID uuid.UUID) ([]*domain.ApprovalRule, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.organizationRepo.GetApprovalRules(ctx, organizationID)
+}
+
+// SetApprovalRules replaces the organization's rules. A rule can't ask for
+// more approvals than the organization has members who can approve.
+func (uc *OrganizationUseCase) SetApprovalRules(ctx context.Context, userID, organizationID uuid.UUID, req *domain.ApprovalRulesRequest) ([]*domain.ApprovalRule, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*domain.ApprovalRule, 0, len(req.Rules))
+	seen := make(map[string]bool)
+	for _, r := range req.Rules {
+		key := r.MinAmount.StringFixed(2)
+		if seen[key] {
+			return nil, ErrDuplicateApprovalRule
+		}
+		seen[key] = true
+		rules = append(rules, &domain.ApprovalRule{
+			OrganizationID:    organizationID,
+			MinAmount:         r.MinAmount,
+			RequiredApprovals: r.RequiredApprovals,
+		})
+	}
+
+	members, err := uc.organizationRepo.GetMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	approvers := 0
+	for _, member := range members {
+		if member.Role.CanApprove() {
+			approvers++
+		}
+	}
+	if approvers < maxRequiredApprovals(rules) {
+		return nil, ErrTooFewPaymentApprovers
+	}
+
+	if err := uc.organizationRepo.SetApprovalRules(ctx, organizationID, rules); err != nil {
+		return nil, err
+	}
+
+	return uc.organizationRepo.GetApprovalRules(ctx, organizationID)
+}
+
+// CreateAccount opens an account owned by the organization. The admin who
+// opens it is recorded as its holder, but money only leaves it through
+// payment drafts.
+func (uc *OrganizationUseCase) CreateAccount(ctx context.Context, userID, organizationID uuid.UUID, req *domain.CreateAccountRequest) (*domain.Account, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	account := &domain.Account{
+		ID:             uuid.New(),
+		UserID:         userID,
+		OrganizationID: &organizationID,
+		AccountType:    req.AccountType,
+		Balance:        decimal.NewFromInt(0),
+		Currency:       req.Currency,
+		Status:         domain.AccountStatusActive,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := createAccount(ctx, uc.accountRepo, uc.numbers, account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (uc *OrganizationUseCase) GetAccounts(ctx context.Context, userID, organizationID uuid.UUID) ([]*domain.Account, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.accountRepo.GetByOrganizationID(ctx, organizationID)
+}
+
+func maxRequiredApprovals(rules []*domain.ApprovalRule) int {
+	max := 1
+	for _, rule := range rules {
+		if rule.RequiredApprovals > max {
+			max = rule.RequiredApprovals
+		}
+	}
+	return max
+}
+
+// authorizeOrganization returns the user's membership, or
+// ErrOrganizationForbidden unless they are a member in a role allowed passes.
+// A nil allowed admits any member, viewers included. Users outside the
+// organization can't tell it from one that doesn't exist.
+func authorizeOrganization(ctx context.Context, organizationRepo repository.OrganizationRepository, organizationID, userID uuid.UUID, allowed func(domain.OrganizationRole) bool) (*domain.OrganizationMember, error) {
+	member, err := organizationRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	if allowed != nil && !allowed(member.Role) {
+		return nil, ErrOrganizationForbidden
+	}
+	return member, nil
+}
\ No newline at end of file
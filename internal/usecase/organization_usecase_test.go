@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// newOrganization has the admin create an organization and add each user in
+// the given role.
+func (env *testEnv) newOrganization(t *testing.T, admin *domain.User, members map[*domain.User]domain.OrganizationRole) *domain.Organization {
+	t.Helper()
+
+	ctx := context.Background()
+	organization, err := env.orgs.CreateOrganization(ctx, admin.ID, &domain.CreateOrganizationRequest{Name: "Acme Ltd"})
+	if err != nil {
+		t.Fatalf("CreateOrganization: %v", err)
+	}
+	for user, role := range members {
+		if _, err := env.orgs.AddMember(ctx, admin.ID, organization.ID, &domain.AddOrganizationMemberRequest{Email: user.Email, Role: role}); err != nil {
+			t.Fatalf("AddMember: %v", err)
+		}
+	}
+	return organization
+}
+
+func TestOrganizations(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	carol := env.newUser(t, "Carol White")
+	organization := env.newOrganization(t, alice, map[*domain.User]domain.OrganizationRole{bob: domain.OrganizationRoleMaker})
+
+	if _, err := env.orgs.GetOrganization(ctx, carol.ID, organization.ID); err != ErrOrganizationNotFound {
+		t.Errorf("outsider GetOrganization: error %v, want %v", err, ErrOrganizationNotFound)
+	}
+	if _, err := env.orgs.AddMember(ctx, bob.ID, organization.ID, &domain.AddOrganizationMemberRequest{Email: carol.Email, Role: domain.OrganizationRoleApprover}); err != ErrOrganizationForbidden {
+		t.Errorf("maker AddMember: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	if _, err := env.orgs.AddMember(ctx, alice.ID, organization.ID, &domain.AddOrganizationMemberRequest{Email: bob.Email, Role: domain.OrganizationRoleViewer}); err != ErrAlreadyOrganizationMember {
+		t.Errorf("second AddMember: error %v, want %v", err, ErrAlreadyOrganizationMember)
+	}
+
+	// Rules can't need more approvers than there are
+	rules := &domain.ApprovalRulesRequest{Rules: []domain.ApprovalRuleRequest{{MinAmount: decimal.NewFromInt(1000), RequiredApprovals: 2}}}
+	if _, err := env.orgs.SetApprovalRules(ctx, alice.ID, organization.ID, rules); err != ErrTooFewPaymentApprovers {
+		t.Errorf("SetApprovalRules with one approver: error %v, want %v", err, ErrTooFewPaymentApprovers)
+	}
+	if _, err := env.orgs.AddMember(ctx, alice.ID, organization.ID, &domain.AddOrganizationMemberRequest{Email: carol.Email, Role: domain.OrganizationRoleApprover}); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	if _, err := env.orgs.SetApprovalRules(ctx, alice.ID, organization.ID, rules); err != nil {
+		t.Fatalf("SetApprovalRules: %v", err)
+	}
+	if err := env.orgs.RemoveMember(ctx, carol.ID, organization.ID, carol.ID); err != ErrTooFewPaymentApprovers {
+		t.Errorf("needed approver leaving: error %v, want %v", err, ErrTooFewPaymentApprovers)
+	}
+
+	// The organization always keeps an admin
+	if _, err := env.orgs.UpdateMember(ctx, alice.ID, organization.ID, alice.ID, &domain.UpdateOrganizationMemberRequest{Role: domain.OrganizationRoleApprover}); err != ErrLastAdmin {
+		t.Errorf("demoting the last admin: error %v, want %v", err, ErrLastAdmin)
+	}
+	if err := env.orgs.RemoveMember(ctx, alice.ID, organization.ID, alice.ID); err != ErrLastAdmin {
+		t.Errorf("last admin leaving: error %v, want %v", err, ErrLastAdmin)
+	}
+
+	// Business accounts only pay out through drafts
+	account, err := env.orgs.CreateAccount(ctx, alice.ID, organization.ID, &domain.CreateAccountRequest{AccountType: domain.AccountTypeChecking, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := env.orgs.CreateAccount(ctx, bob.ID, organization.ID, &domain.CreateAccountRequest{AccountType: domain.AccountTypeChecking, Currency: "USD"}); err != ErrOrganizationForbidden {
+		t.Errorf("maker CreateAccount: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	if accounts, err := env.orgs.GetAccounts(ctx, carol.ID, organization.ID); err != nil || len(accounts) != 1 {
+		t.Errorf("GetAccounts = %d accounts, %v; want 1", len(accounts), err)
+	}
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(100)}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	to := env.newAccount(t, carol.ID, 0)
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: to.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrPaymentDraftRequired {
+		t.Errorf("direct transfer: error %v, want %v", err, ErrPaymentDraftRequired)
+	}
+	if _, err := env.holds.AuthorizeHold(ctx, alice.ID, &domain.AuthorizeHoldRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrPaymentDraftRequired {
+		t.Errorf("card payment: error %v, want %v", err, ErrPaymentDraftRequired)
+	}
+	if _, err := env.members.InviteMember(ctx, alice.ID, account.ID, &domain.InviteMemberRequest{Email: bob.Email, Role: domain.AccountRoleSignatory}); err != ErrBusinessAccount {
+		t.Errorf("InviteMember on a business account: error %v, want %v", err, ErrBusinessAccount)
+	}
+}
\ No newline at end of file
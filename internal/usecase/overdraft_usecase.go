@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+var (
+	ErrOverdraftNotAllowed = apperror.Unprocessable("overdraft_not_allowed", "only checking accounts can have an overdraft")
+	ErrOverdraftInUse      = apperror.Unprocessable("overdraft_in_use", "the account has drawn more than the new overdraft limit")
+)
+
+// OverdraftUseCase arranges overdrafts on checking accounts and charges
+// interest on overdrawn balances. The interest is priced by the
+// overdraft_interest fee schedules and can be waived like any other fee.
+type OverdraftUseCase struct {
+	accountRepo repository.AccountRepository
+	fees        *FeeUseCase
+	uow         repository.UnitOfWork
+	now         func() time.Time
+}
+
+func NewOverdraftUseCase(accountRepo repository.AccountRepository, fees *FeeUseCase, uow repository.UnitOfWork) *OverdraftUseCase {
+	return &OverdraftUseCase{
+		accountRepo: accountRepo,
+		fees:        fees,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+// SetLimit arranges, changes or, with a zero limit, removes an account's
+// overdraft. It can't be cut below what the account has already drawn.
+func (uc *OverdraftUseCase) SetLimit(ctx context.Context, accountID uuid.UUID, req *domain.OverdraftLimitRequest) (*domain.Account, error) {
+	var account *domain.Account
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		account, err = lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if req.Limit.IsPositive() && account.AccountType != domain.AccountTypeChecking {
+			return ErrOverdraftNotAllowed
+		}
+		if account.OverdraftUsed().GreaterThan(req.Limit) {
+			return ErrOverdraftInUse
+		}
+
+		account.OverdraftLimit = req.Limit
+		return repos.Accounts.UpdateOverdraftLimit(ctx, account)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// ChargeInterest charges yesterday's (UTC) interest to every overdrawn
+// checking account, on the balance it is overdrawn by now. It is run
+// periodically by the job runner; each account is charged once a day.
+func (uc *OverdraftUseCase) ChargeInterest(ctx context.Context) error {
+	today := startOfDay(uc.now())
+	day := today.AddDate(0, 0, -1)
+
+	schedules, err := uc.fees.feeRepo.GetSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	after := uuid.Nil
+	for {
+		accounts, err := uc.accountRepo.GetByType(ctx, domain.AccountTypeChecking, after, feeBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			if !account.Balance.IsNegative() {
+				continue
+			}
+			if err := uc.chargeAccount(ctx, account.ID, schedules, day); err != nil {
+				log.Printf("Failed to charge overdraft interest on account %s: %v", account.ID, err)
+			}
+		}
+		if len(accounts) < feeBatchSize {
+			break
+		}
+		after = accounts[len(accounts)-1].ID
+	}
+
+	return nil
+}
+
+func (uc *OverdraftUseCase) chargeAccount(ctx context.Context, accountID uuid.UUID, schedules []*domain.FeeSchedule, day time.Time) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if !account.Balance.IsNegative() || !account.CreatedAt.Before(day.AddDate(0, 0, 1)) {
+			return nil
+		}
+
+		tier, err := userTier(ctx, repos, account.UserID)
+		if err != nil {
+			return err
+		}
+		schedule := matchSchedule(schedules, domain.FeeTypeOverdraftInterest, account.AccountType, tier)
+		if schedule == nil {
+			return nil
+		}
+		existing, err := repos.Fees.GetCharge(ctx, account.ID, domain.FeeTypeOverdraftInterest, day)
+		if err != nil || existing != nil {
+			return err
+		}
+		waivers, err := repos.Fees.GetWaiversByAccountID(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		charge := &domain.FeeCharge{AccountID: account.ID, FeeType: domain.FeeTypeOverdraftInterest, Period: day}
+		if !waived(waivers, domain.FeeTypeOverdraftInterest, uc.now()) {
+			// A day's interest on the overdrawn balance at the annual rate
+			base := account.Balance.Neg().Mul(domain.DayCountActual365.YearFraction(day, day.AddDate(0, 0, 1)))
+			charge.Amount = capFee(schedule.Amount(base), account.SpendableBalance())
+		}
+		if charge.Amount.IsPositive() {
+			fees, err := chargeFees(ctx, repos, account, []feeLine{{feeType: domain.FeeTypeOverdraftInterest, amount: charge.Amount}}, nil, " "+day.Format("2006-01-02"))
+			if err != nil {
+				return err
+			}
+			charge.TransactionID = &fees[0].ID
+		}
+
+		return repos.Fees.CreateCharge(ctx, charge)
+	})
+}
\ No newline at end of file
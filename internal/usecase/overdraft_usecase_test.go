@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) setOverdraft(t *testing.T, accountID uuid.UUID, limit int64) {
+	t.Helper()
+
+	if _, err := env.overdrafts.SetLimit(context.Background(), accountID, &domain.OverdraftLimitRequest{Limit: decimal.NewFromInt(limit)}); err != nil {
+		t.Fatalf("SetLimit: %v", err)
+	}
+}
+
+func TestOverdraft(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	checking := env.newAccount(t, alice.ID, 100)
+	savings := env.newTypedAccount(t, alice.ID, domain.AccountTypeSavings, 100)
+	to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+
+	limit := &domain.OverdraftLimitRequest{Limit: decimal.NewFromInt(100)}
+	if _, err := env.overdrafts.SetLimit(ctx, savings.ID, limit); err != ErrOverdraftNotAllowed {
+		t.Errorf("savings overdraft: error %v, want %v", err, ErrOverdraftNotAllowed)
+	}
+	env.setOverdraft(t, checking.ID, 100)
+
+	transfer := func(amount int64) error {
+		_, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{
+			FromAccountID: checking.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(amount),
+		})
+		return err
+	}
+	if err := transfer(150); err != nil {
+		t.Fatalf("Transfer into the overdraft: %v", err)
+	}
+	env.assertBalances(t, checking.ID, -50, 0)
+	if err := transfer(51); err != ErrInsufficientBalance {
+		t.Errorf("Transfer past the overdraft: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	if _, err := env.transactions.Withdraw(ctx, &domain.WithdrawalRequest{AccountID: checking.ID.String(), Amount: decimal.NewFromInt(1)}); err != ErrInsufficientBalance {
+		t.Errorf("Withdraw from the overdraft: error %v, want %v", err, ErrInsufficientBalance)
+	}
+
+	// The account API shows the limit and how much of it is used
+	data, err := json.Marshal(env.account(t, checking.ID))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var shown struct {
+		OverdraftLimit decimal.Decimal `json:"overdraft_limit"`
+		OverdraftUsed  decimal.Decimal `json:"overdraft_used"`
+	}
+	if err := json.Unmarshal(data, &shown); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !shown.OverdraftLimit.Equal(decimal.NewFromInt(100)) || !shown.OverdraftUsed.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("overdraft limit %s used %s, want 100 used 50", shown.OverdraftLimit, shown.OverdraftUsed)
+	}
+
+	if _, err := env.overdrafts.SetLimit(ctx, checking.ID, &domain.OverdraftLimitRequest{Limit: decimal.NewFromInt(40)}); err != ErrOverdraftInUse {
+		t.Errorf("limit below what is drawn: error %v, want %v", err, ErrOverdraftInUse)
+	}
+	toSavings := domain.AccountTypeSavings
+	if _, err := env.accounts.UpdateAccount(ctx, alice.ID, checking.ID, &domain.UpdateAccountRequest{AccountType: &toSavings}); err != ErrOverdraftNotAllowed {
+		t.Errorf("overdrawn account to savings: error %v, want %v", err, ErrOverdraftNotAllowed)
+	}
+	env.setOverdraft(t, checking.ID, 50)
+	if err := transfer(1); err != ErrInsufficientBalance {
+		t.Errorf("Transfer at the new limit: error %v, want %v", err, ErrInsufficientBalance)
+	}
+}
+
+func TestChargeOverdraftInterest(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	// 36.5% a year is a thousandth of the overdrawn balance a day
+	env.setFeeSchedule(t, &domain.FeeScheduleRequest{FeeType: domain.FeeTypeOverdraftInterest, Rate: decimal.RequireFromString("0.365")})
+
+	user := env.newUser(t, "Alice Smith")
+	to := env.newAccount(t, user.ID, 0)
+	overdrawn := env.newAccount(t, user.ID, 0)
+	waived := env.newAccount(t, user.ID, 0)
+	inCredit := env.newAccount(t, user.ID, 100)
+	for _, account := range []*domain.Account{overdrawn, waived} {
+		env.setOverdraft(t, account.ID, 2000)
+		if _, err := env.transactions.Transfer(ctx, user.ID, &domain.TransferRequest{
+			FromAccountID: account.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(1000),
+		}); err != nil {
+			t.Fatalf("Transfer: %v", err)
+		}
+	}
+	interestType := domain.FeeTypeOverdraftInterest
+	if _, err := env.fees.CreateWaiver(ctx, uuid.New(), waived.ID, &domain.CreateFeeWaiverRequest{FeeType: &interestType, Reason: "hardship"}); err != nil {
+		t.Fatalf("CreateWaiver: %v", err)
+	}
+
+	// Nothing is charged for the day before the accounts were opened
+	if err := env.overdrafts.ChargeInterest(ctx); err != nil {
+		t.Fatalf("ChargeInterest: %v", err)
+	}
+	env.assertBalances(t, overdrawn.ID, -1000, 0)
+
+	// Each day is charged once however often the job runs
+	env.clock.now = env.clock.now.AddDate(0, 0, 1)
+	for i := 0; i < 2; i++ {
+		if err := env.overdrafts.ChargeInterest(ctx); err != nil {
+			t.Fatalf("ChargeInterest: %v", err)
+		}
+	}
+	env.assertBalances(t, overdrawn.ID, -1001, 0)
+	env.assertBalances(t, waived.ID, -1000, 0)
+	env.assertBalances(t, inCredit.ID, 100, 0)
+
+	env.clock.now = env.clock.now.AddDate(0, 0, 1)
+	if err := env.overdrafts.ChargeInterest(ctx); err != nil {
+		t.Fatalf("ChargeInterest: %v", err)
+	}
+	if balance := env.account(t, overdrawn.ID).Balance; !balance.Equal(decimal.RequireFromString("-1002")) {
+		t.Errorf("balance %s, want -1002.00", balance)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
+)
+
+var (
+	ErrPayeeNotFound      = apperror.NotFound("payee_not_found", "payee not found")
+	ErrPayeeAlreadyExists = apperror.Conflict("payee_already_exists", "payee already exists for this account")
+	ErrPayeeIsOwnAccount  = apperror.BadRequest("payee_is_own_account", "cannot add your own account as a payee")
+	ErrPayeeBlocked       = apperror.Forbidden("payee_blocked", "payee blocked by sanctions screening")
+)
+
+type PayeeUseCase struct {
+	payeeRepo   repository.PayeeRepository
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	screening   *ScreeningUseCase
+	numbers     accountnumber.Scheme
+}
+
+func NewPayeeUseCase(payeeRepo repository.PayeeRepository, accountRepo repository.AccountRepository, userRepo repository.UserRepository, screening *ScreeningUseCase, numbers accountnumber.Scheme) *PayeeUseCase {
+	if numbers == nil {
+		numbers = accountnumber.Default()
+	}
+	return &PayeeUseCase{
+		payeeRepo:   payeeRepo,
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		screening:   screening,
+		numbers:     numbers,
+	}
+}
+
+// LookupAccount resolves an account number to a masked holder name and, when
+// holderName is given, checks it against the real holder.
+func (uc *PayeeUseCase) LookupAccount(ctx context.Context, accountNumber, holderName string) (*domain.AccountLookupResponse, error) {
+	account, holder, err := lookupAccountHolder(ctx, uc.accountRepo, uc.userRepo, uc.numbers, accountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AccountLookupResponse{
+		AccountNumber:      account.AccountNumber,
+		MaskedHolderName:   utils.MaskName(holder.FullName),
+		Currency:           account.Currency,
+		VerificationStatus: verifyHolderName(holder, holderName),
+	}, nil
+}
+
+func (uc *PayeeUseCase) CreatePayee(ctx context.Context, userID uuid.UUID, req *domain.CreatePayeeRequest) (*domain.Payee, error) {
+	account, holder, err := lookupAccountHolder(ctx, uc.accountRepo, uc.userRepo, uc.numbers, req.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID == userID {
+		return nil, ErrPayeeIsOwnAccount
+	}
+
+	existing, err := uc.payeeRepo.GetByUserAndAccount(ctx, userID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrPayeeAlreadyExists
+	}
+
+	// Screen the real holder, not whatever name the user typed
+	screening, err := uc.screening.Check(ctx, domain.ScreeningSubjectPayee, holder.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if screening.Decision == domain.RiskDecisionBlock {
+		return nil, ErrPayeeBlocked
+	}
+
+	payee := &domain.Payee{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		AccountID:          account.ID,
+		AccountNumber:      account.AccountNumber,
+		Nickname:           req.Nickname,
+		MaskedHolderName:   utils.MaskName(holder.FullName),
+		VerificationStatus: verifyHolderName(holder, req.HolderName),
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+
+	if err := uc.payeeRepo.Create(ctx, payee); err != nil {
+		return nil, err
+	}
+
+	if err := uc.screening.Flag(ctx, domain.ScreeningSubjectPayee, payee.ID, holder.FullName, screening); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+func (uc *PayeeUseCase) GetPayees(ctx context.Context, userID uuid.UUID) ([]*domain.Payee, error) {
+	return uc.payeeRepo.GetByUserID(ctx, userID)
+}
+
+func (uc *PayeeUseCase) GetPayee(ctx context.Context, userID, payeeID uuid.UUID) (*domain.Payee, error) {
+	return getOwnedPayee(ctx, uc.payeeRepo, userID, payeeID)
+}
+
+func (uc *PayeeUseCase) UpdatePayee(ctx context.Context, userID, payeeID uuid.UUID, req *domain.UpdatePayeeRequest) (*domain.Payee, error) {
+	payee, err := getOwnedPayee(ctx, uc.payeeRepo, userID, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Nickname != nil {
+		payee.Nickname = *req.Nickname
+	}
+	payee.UpdatedAt = time.Now()
+
+	if err := uc.payeeRepo.Update(ctx, payee); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+func (uc *PayeeUseCase) DeletePayee(ctx context.Context, userID, payeeID uuid.UUID) error {
+	if _, err := getOwnedPayee(ctx, uc.payeeRepo, userID, payeeID); err != nil {
+		return err
+	}
+
+	return uc.payeeRepo.Delete(ctx, payeeID)
+}
+
+func getOwnedPayee(ctx context.Context, payeeRepo repository.PayeeRepository, userID, payeeID uuid.UUID) (*domain.Payee, error) {
+	payee, err := payeeRepo.GetByID(ctx, payeeID)
+	if err != nil {
+		return nil, err
+	}
+	// Other users' payees are reported as missing rather than forbidden so
+	// payee IDs can't be probed.
+	if payee == nil || payee.UserID != userID {
+		return nil, ErrPayeeNotFound
+	}
+
+	return payee, nil
+}
+
+// lookupAccountHolder finds the account with the number and its holder. The
+// number has to be one of the bank's, check digits and all.
+func lookupAccountHolder(ctx context.Context, accountRepo repository.AccountRepository, userRepo repository.UserRepository, numbers accountnumber.Scheme, accountNumber string) (*domain.Account, *domain.User, error) {
+	accountNumber, ok := numbers.Parse(accountNumber)
+	if !ok {
+		return nil, nil, ErrInvalidAccountNumber
+	}
+
+	account, err := accountRepo.GetByAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	if account == nil {
+		return nil, nil, ErrAccountNotFound
+	}
+	if err := checkCredit(account); err != nil {
+		return nil, nil, err
+	}
+
+	holder, err := userRepo.GetByID(ctx, account.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if holder == nil {
+		return nil, nil, ErrAccountNotFound
+	}
+
+	return account, holder, nil
+}
+
+func verifyHolderName(holder *domain.User, suppliedName string) domain.PayeeVerificationStatus {
+	if suppliedName == "" {
+		return domain.PayeeVerificationUnverified
+	}
+	if utils.NamesMatch(holder.FullName, suppliedName) {
+		return domain.PayeeVerificationVerified
+	}
+	return domain.PayeeVerificationMismatch
+}
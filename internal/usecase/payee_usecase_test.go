@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestLookupAccount(t *testing.T) {
+	env := newTestEnv(t)
+	account := env.newAccount(t, env.newUser(t, "John Doe").ID, 0)
+
+	tests := []struct {
+		name       string
+		number     string
+		holderName string
+		wantStatus domain.PayeeVerificationStatus
+		wantErr    error
+	}{
+		{"no name given", account.AccountNumber, "", domain.PayeeVerificationUnverified, nil},
+		{"matching name", account.AccountNumber, "doe, JOHN", domain.PayeeVerificationVerified, nil},
+		{"wrong name", account.AccountNumber, "Jane Doe", domain.PayeeVerificationMismatch, nil},
+		{"grouped digits", account.AccountNumber[:5] + " " + account.AccountNumber[5:], "", domain.PayeeVerificationUnverified, nil},
+		{"unknown account", "0000000000", "", "", ErrAccountNotFound},
+		{"mistyped account number", mistype(account.AccountNumber), "", "", ErrInvalidAccountNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := env.payees.LookupAccount(context.Background(), tt.number, tt.holderName)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if result.VerificationStatus != tt.wantStatus || result.MaskedHolderName != "J*** D**" {
+				t.Errorf("got %+v, want status %s and a masked name", result, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCreatePayee(t *testing.T) {
+	env := newTestEnv(t)
+	env.screener.set("Blocked Person", domain.RiskDecisionBlock)
+	env.screener.set("Possible Match", domain.RiskDecisionReview)
+
+	user := env.newUser(t, "Alice Smith")
+	own := env.newAccount(t, user.ID, 0)
+	john := env.newAccount(t, env.newUser(t, "John Doe").ID, 0)
+	blocked := env.newAccount(t, env.newUser(t, "Blocked Person").ID, 0)
+	match := env.newAccount(t, env.newUser(t, "Possible Match").ID, 0)
+
+	tests := []struct {
+		name       string
+		number     string
+		holderName string
+		wantStatus domain.PayeeVerificationStatus
+		wantErr    error
+		wantAlert  bool
+	}{
+		{"verified payee", john.AccountNumber, "John Doe", domain.PayeeVerificationVerified, nil, false},
+		{"already saved", john.AccountNumber, "", "", ErrPayeeAlreadyExists, false},
+		{"own account", own.AccountNumber, "", "", ErrPayeeIsOwnAccount, false},
+		{"unknown account", "0000000000", "", "", ErrAccountNotFound, false},
+		{"sanctioned holder", blocked.AccountNumber, "", "", ErrPayeeBlocked, true},
+		{"possible match is saved with an alert", match.AccountNumber, "", domain.PayeeVerificationUnverified, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := pendingAlerts(t, env)
+
+			payee, err := env.payees.CreatePayee(context.Background(), user.ID, &domain.CreatePayeeRequest{
+				AccountNumber: tt.number,
+				Nickname:      tt.name,
+				HolderName:    tt.holderName,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (payee.VerificationStatus != tt.wantStatus || payee.UserID != user.ID) {
+				t.Errorf("unexpected payee %+v", payee)
+			}
+			if got := pendingAlerts(t, env) > before; got != tt.wantAlert {
+				t.Errorf("alert recorded = %v, want %v", got, tt.wantAlert)
+			}
+		})
+	}
+}
+
+// newPayee saves the account of a new holder as a payee of userID.
+func (env *testEnv) newPayee(t *testing.T, userID uuid.UUID, nickname string) *domain.Payee {
+	t.Helper()
+
+	account := env.newAccount(t, env.newUser(t, "John Doe").ID, 0)
+	payee, err := env.payees.CreatePayee(context.Background(), userID, &domain.CreatePayeeRequest{
+		AccountNumber: account.AccountNumber,
+		Nickname:      nickname,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayee: %v", err)
+	}
+	return payee
+}
+
+func TestGetPayees(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	env.newPayee(t, alice.ID, "Rent")
+	env.newPayee(t, alice.ID, "Gym")
+	bob := env.newUser(t, "Bob Jones")
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		want   []string
+	}{
+		{"sorted by nickname", alice.ID, []string{"Gym", "Rent"}},
+		{"none", bob.ID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payees, err := env.payees.GetPayees(context.Background(), tt.userID)
+			if err != nil {
+				t.Fatalf("GetPayees: %v", err)
+			}
+			if len(payees) != len(tt.want) {
+				t.Fatalf("%d payees, want %d", len(payees), len(tt.want))
+			}
+			for i, payee := range payees {
+				if payee.Nickname != tt.want[i] {
+					t.Errorf("payee %d is %q, want %q", i, payee.Nickname, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetPayee(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	payee := env.newPayee(t, alice.ID, "Rent")
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		payeeID uuid.UUID
+		wantErr error
+	}{
+		{"owner", alice.ID, payee.ID, nil},
+		{"other user sees not found", uuid.New(), payee.ID, ErrPayeeNotFound},
+		{"missing", alice.ID, uuid.New(), ErrPayeeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.payees.GetPayee(context.Background(), tt.userID, tt.payeeID); err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdatePayee(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	payee := env.newPayee(t, alice.ID, "Rent")
+	nickname := "Landlord"
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"other user", uuid.New(), ErrPayeeNotFound},
+		{"owner", alice.ID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.payees.UpdatePayee(context.Background(), tt.userID, payee.ID, &domain.UpdatePayeeRequest{Nickname: &nickname}); err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	stored, _ := env.store.Payees().GetByID(context.Background(), payee.ID)
+	if stored.Nickname != nickname {
+		t.Errorf("nickname %q, want %q", stored.Nickname, nickname)
+	}
+}
+
+func TestDeletePayee(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	payee := env.newPayee(t, alice.ID, "Rent")
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"other user", uuid.New(), ErrPayeeNotFound},
+		{"owner", alice.ID, nil},
+		{"already deleted", alice.ID, ErrPayeeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := env.payees.DeletePayee(context.Background(), tt.userID, payee.ID); err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
\ No newline at end of file
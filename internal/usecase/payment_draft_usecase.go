@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+var (
+	ErrPaymentDraftNotFound    = apperror.NotFound("payment_draft_not_found", "payment draft not found")
+	ErrPaymentDraftSubmitted   = apperror.Conflict("payment_draft_submitted", "the payment has already been submitted for approval")
+	ErrPaymentDraftNotPending  = apperror.Conflict("payment_draft_not_pending", "the payment isn't waiting for approval")
+	ErrPaymentDraftNotApproved = apperror.Conflict("payment_draft_not_approved", "the payment isn't approved and waiting to be made")
+	ErrSelfApproval            = apperror.Forbidden("self_approval", "you can't approve a payment you drafted")
+)
+
+const paymentDraftHistorySize = 100
+
+// PaymentDraftUseCase is the maker-checker workflow for payments out of
+// business accounts. A maker drafts a payment and submits it; the
+// organization's rules fix how many approvers other than the maker have to
+// approve it, and the approval that completes the count makes the transfer
+// as the maker. Every step is recorded in the draft's audit trail.
+type PaymentDraftUseCase struct {
+	draftRepo        repository.PaymentDraftRepository
+	organizationRepo repository.OrganizationRepository
+	accountRepo      repository.AccountRepository
+	transactions     *TransactionUseCase
+	uow              repository.UnitOfWork
+}
+
+func NewPaymentDraftUseCase(draftRepo repository.PaymentDraftRepository, organizationRepo repository.OrganizationRepository, accountRepo repository.AccountRepository, transactions *TransactionUseCase, uow repository.UnitOfWork) *PaymentDraftUseCase {
+	return &PaymentDraftUseCase{
+		draftRepo:        draftRepo,
+		organizationRepo: organizationRepo,
+		accountRepo:      accountRepo,
+		transactions:     transactions,
+		uow:              uow,
+	}
+}
+
+// CreateDraft drafts a payment out of one of the organization's accounts.
+// The destination is resolved now, against the maker's payees, so it can't
+// change under the approvers.
+func (uc *PaymentDraftUseCase) CreateDraft(ctx context.Context, userID, organizationID uuid.UUID, req *domain.TransferRequest) (*domain.PaymentDraft, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, domain.OrganizationRole.CanDraft); err != nil {
+		return nil, err
+	}
+
+	accountID, err := uuid.Parse(req.FromAccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || account.OrganizationID == nil || *account.OrganizationID != organizationID {
+		return nil, ErrAccountNotFound
+	}
+
+	toAccountID, payee, _, err := uc.transactions.resolveDestination(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if toAccountID == accountID {
+		return nil, ErrSameAccount
+	}
+	if !req.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	draft := &domain.PaymentDraft{
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		CreatedBy:      userID,
+		ToAccountID:    &toAccountID,
+		Amount:         req.Amount,
+		Description:    req.Description,
+	}
+	if payee != nil {
+		draft.PayeeID = &payee.ID
+	}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		if err := repos.PaymentDrafts.Create(ctx, draft); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, draft, userID, domain.PaymentDraftCreated, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+func (uc *PaymentDraftUseCase) GetDrafts(ctx context.Context, userID, organizationID uuid.UUID) ([]*domain.PaymentDraft, error) {
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, organizationID, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.draftRepo.GetByOrganizationID(ctx, organizationID, paymentDraftHistorySize)
+}
+
+// GetDraft returns the draft with its audit trail.
+func (uc *PaymentDraftUseCase) GetDraft(ctx context.Context, userID, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	draft, err := uc.draftRepo.GetByID(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrPaymentDraftNotFound
+	}
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, draft.OrganizationID, userID, nil); err != nil {
+		return nil, ErrPaymentDraftNotFound
+	}
+
+	draft.Events, err = uc.draftRepo.GetEvents(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// Submit sends the maker's draft for approval, fixing the approvals it needs
+// from the organization's rules as they are now.
+func (uc *PaymentDraftUseCase) Submit(ctx context.Context, userID, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	var draft *domain.PaymentDraft
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		draft, err = uc.lock(ctx, repos, userID, draftID, domain.OrganizationRole.CanDraft)
+		if err != nil {
+			return err
+		}
+		if draft.CreatedBy != userID {
+			return ErrOrganizationForbidden
+		}
+		if draft.Status != domain.PaymentDraftStatusDraft {
+			return ErrPaymentDraftSubmitted
+		}
+
+		rules, err := uc.organizationRepo.GetApprovalRules(ctx, draft.OrganizationID)
+		if err != nil {
+			return err
+		}
+		draft.RequiredApprovals = domain.RequiredApprovals(rules, draft.Amount)
+		draft.Status = domain.PaymentDraftStatusPending
+		if err := repos.PaymentDrafts.Update(ctx, draft); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, draft, userID, domain.PaymentDraftSubmitted, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// Approve adds the user's approval. Makers can't approve their own drafts.
+// The approval that completes the count makes the transfer, as Execute does.
+func (uc *PaymentDraftUseCase) Approve(ctx context.Context, userID, draftID uuid.UUID, note string) (*domain.PaymentDraft, error) {
+	var draft *domain.PaymentDraft
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		draft, err = uc.lockPending(ctx, repos, userID, draftID)
+		if err != nil {
+			return err
+		}
+		if draft.CreatedBy == userID {
+			return ErrSelfApproval
+		}
+		if draft.HasApproved(userID) {
+			return ErrAlreadyApproved
+		}
+
+		draft.ApprovedBy = append(draft.ApprovedBy, userID)
+		if len(draft.ApprovedBy) >= draft.RequiredApprovals {
+			draft.Status = domain.PaymentDraftStatusApproved
+		}
+		if err := repos.PaymentDrafts.Update(ctx, draft); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, draft, userID, domain.PaymentDraftApproved, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != domain.PaymentDraftStatusApproved {
+		return draft, nil
+	}
+
+	return uc.execute(ctx, userID, draft)
+}
+
+// Execute makes an approved payment that hasn't been made yet, because it
+// collided with other activity on the account or was interrupted. If it
+// fails for any other reason the draft is marked failed with the reason and
+// the error is returned.
+func (uc *PaymentDraftUseCase) Execute(ctx context.Context, userID, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	draft, err := uc.draftRepo.GetByID(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrPaymentDraftNotFound
+	}
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, draft.OrganizationID, userID, domain.OrganizationRole.CanApprove); err != nil {
+		if err == ErrOrganizationNotFound {
+			return nil, ErrPaymentDraftNotFound
+		}
+		return nil, err
+	}
+	if draft.Status != domain.PaymentDraftStatusApproved {
+		return nil, ErrPaymentDraftNotApproved
+	}
+
+	return uc.execute(ctx, userID, draft)
+}
+
+// execute makes an approved payment as its maker. The draft is marked
+// executed in the transfer's own unit of work, so however often it is run
+// the payment is only made once. A transfer that collides with other
+// activity leaves the draft approved to be run again.
+func (uc *PaymentDraftUseCase) execute(ctx context.Context, userID uuid.UUID, draft *domain.PaymentDraft) (*domain.PaymentDraft, error) {
+	var executed *domain.PaymentDraft
+	_, err := uc.transactions.transfer(ctx, draft.CreatedBy, draftTransferRequest(draft), func(ctx context.Context, repos *repository.Repositories, transaction *domain.Transaction) error {
+		var err error
+		executed, err = lockApprovedDraft(ctx, repos, draft.ID)
+		if err != nil {
+			return err
+		}
+
+		executed.Status = domain.PaymentDraftStatusExecuted
+		executed.TransactionID = &transaction.ID
+		if err := repos.PaymentDrafts.Update(ctx, executed); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, executed, userID, domain.PaymentDraftExecuted, "")
+	})
+	if err == nil {
+		return executed, nil
+	}
+	if errors.Is(err, ErrConcurrentUpdate) || err == ErrPaymentDraftNotApproved {
+		return nil, err
+	}
+
+	failure := err.Error()
+	updateErr := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		failed, err := lockApprovedDraft(ctx, repos, draft.ID)
+		if err != nil {
+			return err
+		}
+
+		failed.Status = domain.PaymentDraftStatusFailed
+		failed.Failure = &failure
+		if err := repos.PaymentDrafts.Update(ctx, failed); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, failed, userID, domain.PaymentDraftFailed, failure)
+	})
+	if updateErr != nil && updateErr != ErrPaymentDraftNotApproved {
+		return nil, updateErr
+	}
+
+	return nil, err
+}
+
+// Reject turns down a pending draft so it is never paid.
+func (uc *PaymentDraftUseCase) Reject(ctx context.Context, userID, draftID uuid.UUID, note string) (*domain.PaymentDraft, error) {
+	var draft *domain.PaymentDraft
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		draft, err = uc.lockPending(ctx, repos, userID, draftID)
+		if err != nil {
+			return err
+		}
+
+		draft.Status = domain.PaymentDraftStatusRejected
+		if err := repos.PaymentDrafts.Update(ctx, draft); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, draft, userID, domain.PaymentDraftRejected, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// Cancel withdraws a draft that hasn't been decided yet. Only its maker or an
+// admin can.
+func (uc *PaymentDraftUseCase) Cancel(ctx context.Context, userID, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	var draft *domain.PaymentDraft
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		draft, err = uc.lock(ctx, repos, userID, draftID, nil)
+		if err != nil {
+			return err
+		}
+		member, err := uc.organizationRepo.GetMember(ctx, draft.OrganizationID, userID)
+		if err != nil {
+			return err
+		}
+		if draft.CreatedBy != userID && !member.Role.CanManage() {
+			return ErrOrganizationForbidden
+		}
+		if draft.Status != domain.PaymentDraftStatusDraft && draft.Status != domain.PaymentDraftStatusPending {
+			return ErrPaymentDraftNotPending
+		}
+
+		draft.Status = domain.PaymentDraftStatusCancelled
+		if err := repos.PaymentDrafts.Update(ctx, draft); err != nil {
+			return err
+		}
+		return recordDraftEvent(ctx, repos, draft, userID, domain.PaymentDraftCancelled, "")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return draft, nil
+}
+
+// lockPending loads a draft waiting for approval in an organization where
+// the user can approve payments.
+func (uc *PaymentDraftUseCase) lockPending(ctx context.Context, repos *repository.Repositories, userID, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	draft, err := uc.lock(ctx, repos, userID, draftID, domain.OrganizationRole.CanApprove)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != domain.PaymentDraftStatusPending {
+		return nil, ErrPaymentDraftNotPending
+	}
+	return draft, nil
+}
+
+// lockApprovedDraft loads a draft that is approved but whose payment hasn't
+// been made yet.
+func lockApprovedDraft(ctx context.Context, repos *repository.Repositories, draftID uuid.UUID) (*domain.PaymentDraft, error) {
+	draft, err := repos.PaymentDrafts.GetByIDForUpdate(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrPaymentDraftNotFound
+	}
+	if draft.Status != domain.PaymentDraftStatusApproved {
+		return nil, ErrPaymentDraftNotApproved
+	}
+	return draft, nil
+}
+
+// lock loads a draft in an organization where the user has a role allowed
+// passes. Users outside the organization don't learn the draft exists.
+func (uc *PaymentDraftUseCase) lock(ctx context.Context, repos *repository.Repositories, userID, draftID uuid.UUID, allowed func(domain.OrganizationRole) bool) (*domain.PaymentDraft, error) {
+	draft, err := repos.PaymentDrafts.GetByIDForUpdate(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrPaymentDraftNotFound
+	}
+	if _, err := authorizeOrganization(ctx, uc.organizationRepo, draft.OrganizationID, userID, allowed); err != nil {
+		if err == ErrOrganizationNotFound {
+			return nil, ErrPaymentDraftNotFound
+		}
+		return nil, err
+	}
+	return draft, nil
+}
+
+func recordDraftEvent(ctx context.Context, repos *repository.Repositories, draft *domain.PaymentDraft, userID uuid.UUID, action domain.PaymentDraftAction, note string) error {
+	return repos.PaymentDrafts.CreateEvent(ctx, &domain.PaymentDraftEvent{
+		DraftID: draft.ID,
+		UserID:  userID,
+		Action:  action,
+		Note:    note,
+	})
+}
+
+func draftTransferRequest(draft *domain.PaymentDraft) *domain.TransferRequest {
+	req := &domain.TransferRequest{
+		FromAccountID: draft.AccountID.String(),
+		Amount:        draft.Amount,
+		Description:   draft.Description,
+	}
+	// The payee is used while it exists so the transfer is checked as one to
+	// a payee; once it is deleted the transfer goes to the account it named
+	if draft.PayeeID != nil {
+		req.PayeeID = draft.PayeeID.String()
+	} else {
+		req.ToAccountID = draft.ToAccountID.String()
+	}
+	return req
+}
\ No newline at end of file
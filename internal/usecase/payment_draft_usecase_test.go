@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// draftEnv is an organization with a maker, an approver and a funded
+// business account, paying one approval's worth of drafts to an outside
+// account.
+type draftEnv struct {
+	*testEnv
+	admin        *domain.User
+	maker        *domain.User
+	approver     *domain.User
+	organization *domain.Organization
+	account      *domain.Account
+	to           *domain.Account
+}
+
+func newDraftEnv(t *testing.T, balance int64) *draftEnv {
+	t.Helper()
+
+	env := &draftEnv{testEnv: newTestEnv(t)}
+	ctx := context.Background()
+	env.admin = env.newUser(t, "Alice Smith")
+	env.maker = env.newUser(t, "Bob Jones")
+	env.approver = env.newUser(t, "Carol White")
+	env.organization = env.newOrganization(t, env.admin, map[*domain.User]domain.OrganizationRole{
+		env.maker:    domain.OrganizationRoleMaker,
+		env.approver: domain.OrganizationRoleApprover,
+	})
+
+	var err error
+	env.account, err = env.orgs.CreateAccount(ctx, env.admin.ID, env.organization.ID, &domain.CreateAccountRequest{AccountType: domain.AccountTypeChecking, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: env.account.ID.String(), Amount: decimal.NewFromInt(balance)}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	env.to = env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+	return env
+}
+
+// submitted has the maker draft a payment of amount and submit it.
+func (env *draftEnv) submitted(t *testing.T, amount int64) *domain.PaymentDraft {
+	t.Helper()
+
+	ctx := context.Background()
+	draft, err := env.drafts.CreateDraft(ctx, env.maker.ID, env.organization.ID, &domain.TransferRequest{
+		FromAccountID: env.account.ID.String(),
+		ToAccountID:   env.to.ID.String(),
+		Amount:        decimal.NewFromInt(amount),
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	if draft, err = env.drafts.Submit(ctx, env.maker.ID, draft.ID); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	return draft
+}
+
+func TestPaymentDrafts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	admin := env.newUser(t, "Alice Smith")
+	maker := env.newUser(t, "Bob Jones")
+	approver := env.newUser(t, "Carol White")
+	viewer := env.newUser(t, "Dan Brown")
+	organization := env.newOrganization(t, admin, map[*domain.User]domain.OrganizationRole{
+		maker:    domain.OrganizationRoleMaker,
+		approver: domain.OrganizationRoleApprover,
+		viewer:   domain.OrganizationRoleViewer,
+	})
+	rules := &domain.ApprovalRulesRequest{Rules: []domain.ApprovalRuleRequest{{MinAmount: decimal.NewFromInt(500), RequiredApprovals: 2}}}
+	if _, err := env.orgs.SetApprovalRules(ctx, admin.ID, organization.ID, rules); err != nil {
+		t.Fatalf("SetApprovalRules: %v", err)
+	}
+
+	account, err := env.orgs.CreateAccount(ctx, admin.ID, organization.ID, &domain.CreateAccountRequest{AccountType: domain.AccountTypeChecking, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: account.ID.String(), Amount: decimal.NewFromInt(1000)}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	to := env.newAccount(t, env.newUser(t, "Erin Green").ID, 0)
+
+	draftFor := func(amount int64) *domain.PaymentDraft {
+		t.Helper()
+		draft, err := env.drafts.CreateDraft(ctx, maker.ID, organization.ID, &domain.TransferRequest{
+			FromAccountID: account.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(amount),
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		return draft
+	}
+
+	if _, err := env.drafts.CreateDraft(ctx, approver.ID, organization.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: to.ID.String(), Amount: decimal.NewFromInt(10)}); err != ErrOrganizationForbidden {
+		t.Errorf("approver CreateDraft: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+
+	// A small payment needs one approval, and never the maker's own
+	small := draftFor(100)
+	if _, err := env.drafts.Approve(ctx, approver.ID, small.ID, ""); err != ErrPaymentDraftNotPending {
+		t.Errorf("approving an unsubmitted draft: error %v, want %v", err, ErrPaymentDraftNotPending)
+	}
+	if _, err := env.drafts.Submit(ctx, approver.ID, small.ID); err != ErrOrganizationForbidden {
+		t.Errorf("approver submitting: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	small, err = env.drafts.Submit(ctx, maker.ID, small.ID)
+	if err != nil || small.RequiredApprovals != 1 {
+		t.Fatalf("Submit = %+v, %v; want one approval required", small, err)
+	}
+	if _, err := env.drafts.Approve(ctx, viewer.ID, small.ID, ""); err != ErrOrganizationForbidden {
+		t.Errorf("viewer approving: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	small, err = env.drafts.Approve(ctx, approver.ID, small.ID, "looks right")
+	if err != nil || small.Status != domain.PaymentDraftStatusExecuted || small.TransactionID == nil {
+		t.Fatalf("Approve = %+v, %v; want executed", small, err)
+	}
+	env.assertBalances(t, account.ID, 900, 0)
+
+	// A large one waits for a second approver
+	large := draftFor(600)
+	if _, err := env.drafts.Submit(ctx, maker.ID, large.ID); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := env.drafts.Approve(ctx, admin.ID, large.ID, ""); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if _, err := env.drafts.Approve(ctx, admin.ID, large.ID, ""); err != ErrAlreadyApproved {
+		t.Errorf("approving twice: error %v, want %v", err, ErrAlreadyApproved)
+	}
+	env.assertBalances(t, account.ID, 900, 0)
+	if large, err = env.drafts.Approve(ctx, approver.ID, large.ID, ""); err != nil || large.Status != domain.PaymentDraftStatusExecuted {
+		t.Fatalf("second Approve = %+v, %v; want executed", large, err)
+	}
+	env.assertBalances(t, account.ID, 300, 0)
+	env.assertBalances(t, to.ID, 700, 0)
+
+	large, err = env.drafts.GetDraft(ctx, viewer.ID, large.ID)
+	if err != nil {
+		t.Fatalf("GetDraft: %v", err)
+	}
+	var actions []domain.PaymentDraftAction
+	for _, event := range large.Events {
+		actions = append(actions, event.Action)
+	}
+	want := []domain.PaymentDraftAction{domain.PaymentDraftCreated, domain.PaymentDraftSubmitted, domain.PaymentDraftApproved, domain.PaymentDraftApproved, domain.PaymentDraftExecuted}
+	if len(actions) != len(want) {
+		t.Fatalf("audit trail %v, want %v", actions, want)
+	}
+	for i := range want {
+		if actions[i] != want[i] {
+			t.Errorf("audit trail %v, want %v", actions, want)
+			break
+		}
+	}
+
+	// Rejected and cancelled drafts are never paid
+	rejected := draftFor(50)
+	if _, err := env.drafts.Submit(ctx, maker.ID, rejected.ID); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if rejected, err = env.drafts.Reject(ctx, approver.ID, rejected.ID, "unknown payee"); err != nil || rejected.Status != domain.PaymentDraftStatusRejected {
+		t.Fatalf("Reject = %+v, %v", rejected, err)
+	}
+	if _, err := env.drafts.Cancel(ctx, maker.ID, rejected.ID); err != ErrPaymentDraftNotPending {
+		t.Errorf("cancelling a rejected draft: error %v, want %v", err, ErrPaymentDraftNotPending)
+	}
+	cancelled := draftFor(50)
+	if _, err := env.drafts.Cancel(ctx, approver.ID, cancelled.ID); err != ErrOrganizationForbidden {
+		t.Errorf("approver cancelling: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	if cancelled, err = env.drafts.Cancel(ctx, maker.ID, cancelled.ID); err != nil || cancelled.Status != domain.PaymentDraftStatusCancelled {
+		t.Fatalf("Cancel = %+v, %v", cancelled, err)
+	}
+
+	// One that can no longer be paid fails when the last approval comes in
+	failing := draftFor(400)
+	if _, err := env.drafts.Submit(ctx, maker.ID, failing.ID); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := env.drafts.Approve(ctx, approver.ID, failing.ID, ""); err != ErrInsufficientBalance {
+		t.Errorf("Approve unpayable draft: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	failing, err = env.store.PaymentDrafts().GetByID(ctx, failing.ID)
+	if err != nil || failing.Status != domain.PaymentDraftStatusFailed || failing.Failure == nil {
+		t.Errorf("failed draft = %+v, %v; want failed with the reason", failing, err)
+	}
+	env.assertBalances(t, account.ID, 300, 0)
+
+	if drafts, err := env.drafts.GetDrafts(ctx, viewer.ID, organization.ID); err != nil || len(drafts) != 5 {
+		t.Errorf("GetDrafts = %d drafts, %v; want 5", len(drafts), err)
+	}
+	if _, err := env.drafts.GetDraft(ctx, env.newUser(t, "Frank Black").ID, large.ID); err != ErrPaymentDraftNotFound {
+		t.Errorf("outsider GetDraft: error %v, want %v", err, ErrPaymentDraftNotFound)
+	}
+}
+
+func TestPaymentDraftTransitions(t *testing.T) {
+	env := newDraftEnv(t, 2000)
+	ctx := context.Background()
+
+	states := []struct {
+		name  string
+		draft func() *domain.PaymentDraft
+	}{
+		{"draft", func() *domain.PaymentDraft {
+			draft, err := env.drafts.CreateDraft(ctx, env.maker.ID, env.organization.ID, &domain.TransferRequest{
+				FromAccountID: env.account.ID.String(),
+				ToAccountID:   env.to.ID.String(),
+				Amount:        decimal.NewFromInt(100),
+			})
+			if err != nil {
+				t.Fatalf("CreateDraft: %v", err)
+			}
+			return draft
+		}},
+		{"pending", func() *domain.PaymentDraft {
+			return env.submitted(t, 100)
+		}},
+		{"executed", func() *domain.PaymentDraft {
+			draft, err := env.drafts.Approve(ctx, env.approver.ID, env.submitted(t, 100).ID, "")
+			if err != nil {
+				t.Fatalf("Approve: %v", err)
+			}
+			return draft
+		}},
+		{"rejected", func() *domain.PaymentDraft {
+			draft, err := env.drafts.Reject(ctx, env.approver.ID, env.submitted(t, 100).ID, "")
+			if err != nil {
+				t.Fatalf("Reject: %v", err)
+			}
+			return draft
+		}},
+		{"failed", func() *domain.PaymentDraft {
+			draft := env.submitted(t, 5000)
+			if _, err := env.drafts.Approve(ctx, env.approver.ID, draft.ID, ""); err != ErrInsufficientBalance {
+				t.Fatalf("Approve unpayable draft: error %v, want %v", err, ErrInsufficientBalance)
+			}
+			return draft
+		}},
+	}
+	actions := []struct {
+		name string
+		do   func(id uuid.UUID) (*domain.PaymentDraft, error)
+		// status is what a draft the action succeeds on ends up in
+		status domain.PaymentDraftStatus
+	}{
+		{"submit", func(id uuid.UUID) (*domain.PaymentDraft, error) {
+			return env.drafts.Submit(ctx, env.maker.ID, id)
+		}, domain.PaymentDraftStatusPending},
+		{"approve", func(id uuid.UUID) (*domain.PaymentDraft, error) {
+			return env.drafts.Approve(ctx, env.approver.ID, id, "")
+		}, domain.PaymentDraftStatusExecuted},
+		{"reject", func(id uuid.UUID) (*domain.PaymentDraft, error) {
+			return env.drafts.Reject(ctx, env.approver.ID, id, "")
+		}, domain.PaymentDraftStatusRejected},
+		{"cancel", func(id uuid.UUID) (*domain.PaymentDraft, error) {
+			return env.drafts.Cancel(ctx, env.maker.ID, id)
+		}, domain.PaymentDraftStatusCancelled},
+		{"execute", func(id uuid.UUID) (*domain.PaymentDraft, error) {
+			return env.drafts.Execute(ctx, env.approver.ID, id)
+		}, domain.PaymentDraftStatusExecuted},
+	}
+	decided := map[string]error{
+		"submit":  ErrPaymentDraftSubmitted,
+		"approve": ErrPaymentDraftNotPending,
+		"reject":  ErrPaymentDraftNotPending,
+		"cancel":  ErrPaymentDraftNotPending,
+		"execute": ErrPaymentDraftNotApproved,
+	}
+	want := map[string]map[string]error{
+		"draft": {
+			"approve": ErrPaymentDraftNotPending,
+			"reject":  ErrPaymentDraftNotPending,
+			"execute": ErrPaymentDraftNotApproved,
+		},
+		"pending": {
+			"submit":  ErrPaymentDraftSubmitted,
+			"execute": ErrPaymentDraftNotApproved,
+		},
+		"executed": decided,
+		"rejected": decided,
+		"failed":   decided,
+	}
+
+	for _, state := range states {
+		for _, action := range actions {
+			draft := state.draft()
+			wantErr := want[state.name][action.name]
+			got, err := action.do(draft.ID)
+			if err != wantErr {
+				t.Errorf("%s a %s draft: error %v, want %v", action.name, state.name, err, wantErr)
+				continue
+			}
+			if err == nil && got.Status != action.status {
+				t.Errorf("%s a %s draft: status %s, want %s", action.name, state.name, got.Status, action.status)
+			}
+		}
+	}
+}
+
+func TestPaymentDraftsOnlyForBusinessAccounts(t *testing.T) {
+	env := newDraftEnv(t, 1000)
+	ctx := context.Background()
+
+	// Drafts are only made out of the organization's own accounts
+	personal := env.newAccount(t, env.maker.ID, 1000)
+	other := env.newOrganization(t, env.maker, nil)
+	otherAccount, err := env.orgs.CreateAccount(ctx, env.maker.ID, other.ID, &domain.CreateAccountRequest{AccountType: domain.AccountTypeChecking, Currency: "USD"})
+	if err != nil {
+		t.Fatalf("CreateAccount: %v", err)
+	}
+	for _, from := range []*domain.Account{personal, otherAccount} {
+		if _, err := env.drafts.CreateDraft(ctx, env.maker.ID, env.organization.ID, &domain.TransferRequest{
+			FromAccountID: from.ID.String(),
+			ToAccountID:   env.to.ID.String(),
+			Amount:        decimal.NewFromInt(10),
+		}); err != ErrAccountNotFound {
+			t.Errorf("CreateDraft from %s: error %v, want %v", from.ID, err, ErrAccountNotFound)
+		}
+	}
+
+	// and business accounts only pay out through drafts, even for the admin
+	transfer := &domain.TransferRequest{
+		FromAccountID: env.account.ID.String(),
+		ToAccountID:   env.to.ID.String(),
+		Amount:        decimal.NewFromInt(10),
+	}
+	if _, err := env.transactions.Transfer(ctx, env.admin.ID, transfer); err != ErrPaymentDraftRequired {
+		t.Errorf("direct transfer: error %v, want %v", err, ErrPaymentDraftRequired)
+	}
+	if _, err := env.approvals.RequestTransfer(ctx, env.admin.ID, transfer); err != ErrPaymentDraftRequired {
+		t.Errorf("RequestTransfer: error %v, want %v", err, ErrPaymentDraftRequired)
+	}
+	if _, err := env.holds.AuthorizeHold(ctx, env.admin.ID, &domain.AuthorizeHoldRequest{
+		AccountID: env.account.ID.String(),
+		Amount:    decimal.NewFromInt(10),
+	}); err != ErrPaymentDraftRequired {
+		t.Errorf("card payment: error %v, want %v", err, ErrPaymentDraftRequired)
+	}
+	env.assertBalances(t, env.account.ID, 1000, 0)
+}
+
+func TestExecutePaymentDraft(t *testing.T) {
+	env := newDraftEnv(t, 1000)
+	ctx := context.Background()
+
+	// A payment that collides with other work leaves the draft approved
+	draft := env.submitted(t, 300)
+	env.transactions.uow = &conflictingUnitOfWork{UnitOfWork: env.store, armed: true}
+	if _, err := env.drafts.Approve(ctx, env.approver.ID, draft.ID, ""); err != ErrConcurrentUpdate {
+		t.Fatalf("Approve on conflict: error %v, want %v", err, ErrConcurrentUpdate)
+	}
+	draft, err := env.store.PaymentDrafts().GetByID(ctx, draft.ID)
+	if err != nil || draft.Status != domain.PaymentDraftStatusApproved || draft.TransactionID != nil {
+		t.Fatalf("draft after conflict = %+v, %v; want approved and not paid", draft, err)
+	}
+	env.assertBalances(t, env.account.ID, 1000, 0)
+
+	// and an approver can make it, once
+	if _, err := env.drafts.Execute(ctx, env.maker.ID, draft.ID); err != ErrOrganizationForbidden {
+		t.Errorf("maker executing: error %v, want %v", err, ErrOrganizationForbidden)
+	}
+	if _, err := env.drafts.Execute(ctx, env.newUser(t, "Frank Black").ID, draft.ID); err != ErrPaymentDraftNotFound {
+		t.Errorf("outsider executing: error %v, want %v", err, ErrPaymentDraftNotFound)
+	}
+	if draft, err = env.drafts.Execute(ctx, env.approver.ID, draft.ID); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if draft.Status != domain.PaymentDraftStatusExecuted || draft.TransactionID == nil {
+		t.Errorf("draft %+v, want executed with its transaction", draft)
+	}
+	if _, err := env.drafts.Execute(ctx, env.admin.ID, draft.ID); err != ErrPaymentDraftNotApproved {
+		t.Errorf("executing twice: error %v, want %v", err, ErrPaymentDraftNotApproved)
+	}
+	env.assertBalances(t, env.account.ID, 700, 0)
+	env.assertBalances(t, env.to.ID, 300, 0)
+
+	draft, err = env.drafts.GetDraft(ctx, env.approver.ID, draft.ID)
+	if err != nil {
+		t.Fatalf("GetDraft: %v", err)
+	}
+	if last := draft.Events[len(draft.Events)-1]; last.Action != domain.PaymentDraftExecuted || last.UserID != env.approver.ID {
+		t.Errorf("last event %+v, want executed by the approver", last)
+	}
+}
\ No newline at end of file
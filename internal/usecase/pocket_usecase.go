@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/fx"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPocketNotFound      = apperror.NotFound("pocket_not_found", "the account has no pocket in that currency")
+	ErrPocketExists        = apperror.Conflict("pocket_exists", "the account already has a pocket in that currency")
+	ErrPocketNotEmpty      = apperror.Unprocessable("pocket_not_empty", "convert or move the pocket's balance out before closing it")
+	ErrAccountCurrency     = apperror.BadRequest("account_currency", "the account's own currency is kept on the account, not in a pocket")
+	ErrUnsupportedCurrency = apperror.Unprocessable("unsupported_currency", "the currency can't be exchanged")
+	ErrSameCurrency        = apperror.BadRequest("same_currency", "can't convert a currency into itself")
+	ErrConversionTooSmall  = apperror.BadRequest("conversion_too_small", "the amount converts to less than the smallest unit")
+)
+
+// DefaultFXSpread is the bank's margin on conversions, as a fraction of the
+// mid-market rate.
+var DefaultFXSpread = decimal.RequireFromString("0.005")
+
+// PocketUseCase manages the balances an account holds in currencies other
+// than its own and converts money between them. Conversions are priced at
+// the rate source's mid-market rate less the bank's spread.
+type PocketUseCase struct {
+	accountRepo repository.AccountRepository
+	pocketRepo  repository.PocketRepository
+	memberRepo  repository.AccountMemberRepository
+	rates       fx.Source
+	spread      decimal.Decimal
+	uow         repository.UnitOfWork
+}
+
+// NewPocketUseCase quotes rates from fx.DefaultTable if rates is nil, and
+// converts at DefaultFXSpread if spread isn't set.
+func NewPocketUseCase(accountRepo repository.AccountRepository, pocketRepo repository.PocketRepository, memberRepo repository.AccountMemberRepository, rates fx.Source, spread decimal.NullDecimal, uow repository.UnitOfWork) *PocketUseCase {
+	if rates == nil {
+		rates = fx.DefaultTable()
+	}
+	if !spread.Valid {
+		spread = decimal.NewNullDecimal(DefaultFXSpread)
+	}
+	return &PocketUseCase{
+		accountRepo: accountRepo,
+		pocketRepo:  pocketRepo,
+		memberRepo:  memberRepo,
+		rates:       rates,
+		spread:      spread.Decimal,
+		uow:         uow,
+	}
+}
+
+func (uc *PocketUseCase) GetPockets(ctx context.Context, userID, accountID uuid.UUID) ([]*domain.Pocket, error) {
+	if err := uc.authorize(ctx, userID, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.pocketRepo.GetByAccountID(ctx, accountID)
+}
+
+// OpenPocket opens an empty pocket. Pockets are also opened as money
+// arrives in a currency the account doesn't hold yet.
+func (uc *PocketUseCase) OpenPocket(ctx context.Context, userID, accountID uuid.UUID, req *domain.OpenPocketRequest) (*domain.Pocket, error) {
+	if err := uc.authorize(ctx, userID, accountID, domain.AccountRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	pocket := &domain.Pocket{AccountID: accountID, Currency: req.Currency}
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if err := checkCredit(account); err != nil {
+			return err
+		}
+		if req.Currency == account.Currency {
+			return ErrAccountCurrency
+		}
+		if err := uc.checkSupported(ctx, req.Currency, account.Currency); err != nil {
+			return err
+		}
+
+		existing, err := repos.Pockets.GetForUpdate(ctx, accountID, req.Currency)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrPocketExists
+		}
+		return repos.Pockets.Create(ctx, pocket)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pocket, nil
+}
+
+// ClosePocket closes an empty pocket.
+func (uc *PocketUseCase) ClosePocket(ctx context.Context, userID, accountID uuid.UUID, currency string) error {
+	if err := uc.authorize(ctx, userID, accountID, domain.AccountRole.CanManage); err != nil {
+		return err
+	}
+
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		if _, err := lockAccount(ctx, repos, accountID); err != nil {
+			return err
+		}
+		pocket, err := lockPocket(ctx, repos, accountID, currency)
+		if err != nil {
+			return err
+		}
+		if !pocket.Balance.IsZero() {
+			return ErrPocketNotEmpty
+		}
+		return repos.Pockets.Delete(ctx, accountID, currency)
+	})
+}
+
+// Quote prices converting amount of from into to at the current rate.
+func (uc *PocketUseCase) Quote(ctx context.Context, from, to string, amount decimal.Decimal) (*domain.FXQuote, error) {
+	if from == to {
+		return nil, ErrSameCurrency
+	}
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	mid, err := uc.midRate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	quote := &domain.FXQuote{
+		From:    from,
+		To:      to,
+		Amount:  amount,
+		MidRate: mid,
+		Rate:    mid.Mul(decimal.NewFromInt(1).Sub(uc.spread)).Round(8),
+	}
+	// The customer never gets a fraction of a cent more than the rate
+	quote.Converted = amount.Mul(quote.Rate).RoundFloor(2)
+	if !quote.Converted.IsPositive() {
+		return nil, ErrConversionTooSmall
+	}
+
+	return quote, nil
+}
+
+// Convert sells money in one of the account's currencies for another at
+// the quoted rate, opening a pocket for the one bought if needed.
+func (uc *PocketUseCase) Convert(ctx context.Context, userID uuid.UUID, req *domain.ConversionRequest) (*domain.Conversion, error) {
+	accountID, err := uuid.Parse(req.AccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	quote, err := uc.Quote(ctx, req.FromCurrency, req.ToCurrency, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &domain.Conversion{Quote: quote}
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeMember(ctx, repos.AccountMembers, accountID, userID, domain.AccountRole.CanTransact); err != nil {
+			return err
+		}
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			return err
+		}
+
+		available, err := availableIn(ctx, repos, account, quote.From)
+		if err != nil {
+			return err
+		}
+		if available.LessThan(quote.Amount) {
+			return ErrInsufficientBalance
+		}
+
+		description := fmt.Sprintf("Converted %s %s to %s %s", quote.Amount.StringFixed(2), quote.From, quote.Converted.StringFixed(2), quote.To)
+		metadata := domain.Metadata{
+			"from_currency": quote.From,
+			"to_currency":   quote.To,
+			"from_amount":   quote.Amount.StringFixed(2),
+			"to_amount":     quote.Converted.StringFixed(2),
+			"rate":          quote.Rate.String(),
+			"mid_rate":      quote.MidRate.String(),
+		}
+		reference := generateReference()
+
+		conversion.Debit = newCashTransaction(domain.TransactionTypeConversion, account, quote.Amount, description)
+		conversion.Debit.FromAccountID = &account.ID
+		conversion.Debit.Currency = quote.From
+		conversion.Debit.Reference = reference + "-D"
+		conversion.Debit.Metadata = metadata
+		conversion.Credit = newCashTransaction(domain.TransactionTypeConversion, account, quote.Converted, description)
+		conversion.Credit.ToAccountID = &account.ID
+		conversion.Credit.Currency = quote.To
+		conversion.Credit.Reference = reference + "-C"
+		conversion.Credit.Metadata = metadata
+		for _, leg := range []*domain.Transaction{conversion.Debit, conversion.Credit} {
+			if err := repos.Transactions.Create(ctx, leg); err != nil {
+				return err
+			}
+		}
+
+		if err := adjustBalances(ctx, repos, account, quote.From, quote.Amount.Neg(), decimal.Zero); err != nil {
+			return err
+		}
+		return adjustBalances(ctx, repos, account, quote.To, quote.Converted, decimal.Zero)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conversion, nil
+}
+
+// value is amount of currency in base at the mid-market rate, rounded to
+// the cent. Transfers out of pockets are priced and limited at their value
+// in the account's own currency.
+func (uc *PocketUseCase) value(ctx context.Context, amount decimal.Decimal, currency, base string) (decimal.Decimal, error) {
+	if currency == base {
+		return amount, nil
+	}
+	rate, err := uc.midRate(ctx, currency, base)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate).Round(2), nil
+}
+
+// checkSupported stops an account holding a currency it couldn't convert
+// back into its own.
+func (uc *PocketUseCase) checkSupported(ctx context.Context, currency, base string) error {
+	_, err := uc.midRate(ctx, currency, base)
+	return err
+}
+
+func (uc *PocketUseCase) midRate(ctx context.Context, from, to string) (decimal.Decimal, error) {
+	rate, err := uc.rates.Rate(ctx, from, to)
+	if errors.Is(err, fx.ErrUnsupportedPair) {
+		return decimal.Zero, ErrUnsupportedCurrency
+	}
+	return rate, err
+}
+
+func (uc *PocketUseCase) authorize(ctx context.Context, userID, accountID uuid.UUID, allowed func(domain.AccountRole) bool) error {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrAccountNotFound
+	}
+
+	return authorizeMember(ctx, uc.memberRepo, accountID, userID, allowed)
+}
+
+func lockPocket(ctx context.Context, repos *repository.Repositories, accountID uuid.UUID, currency string) (*domain.Pocket, error) {
+	pocket, err := repos.Pockets.GetForUpdate(ctx, accountID, currency)
+	if err != nil {
+		return nil, err
+	}
+	if pocket == nil {
+		return nil, ErrPocketNotFound
+	}
+	return pocket, nil
+}
+
+// availableIn is what the locked account can spend in currency without an
+// overdraft: its own available balance, or its pocket's.
+func availableIn(ctx context.Context, repos *repository.Repositories, account *domain.Account, currency string) (decimal.Decimal, error) {
+	if currency == account.Currency {
+		return account.AvailableBalance(), nil
+	}
+	pocket, err := lockPocket(ctx, repos, account.ID, currency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return pocket.AvailableBalance(), nil
+}
+
+// adjustBalances adds balance and held to what the locked account keeps in
+// currency: its own balances, or its pocket's. Money coming into a currency
+// the account doesn't hold yet opens a pocket for it.
+func adjustBalances(ctx context.Context, repos *repository.Repositories, account *domain.Account, currency string, balance, held decimal.Decimal) error {
+	if currency == account.Currency {
+		account.Balance = account.Balance.Add(balance)
+		account.HeldBalance = account.HeldBalance.Add(held)
+		return repos.Accounts.UpdateBalances(ctx, account)
+	}
+
+	pocket, err := repos.Pockets.GetForUpdate(ctx, account.ID, currency)
+	if err != nil {
+		return err
+	}
+	if pocket == nil {
+		if !balance.IsPositive() {
+			return ErrPocketNotFound
+		}
+		pocket = &domain.Pocket{AccountID: account.ID, Currency: currency}
+		if err := repos.Pockets.Create(ctx, pocket); err != nil {
+			return err
+		}
+	}
+	pocket.Balance = pocket.Balance.Add(balance)
+	pocket.HeldBalance = pocket.HeldBalance.Add(held)
+	return repos.Pockets.UpdateBalances(ctx, pocket)
+}
\ No newline at end of file
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) assertPocket(t *testing.T, accountID uuid.UUID, currency, balance, held string) {
+	t.Helper()
+
+	pocket, err := env.store.Pockets().Get(context.Background(), accountID, currency)
+	if err != nil || pocket == nil {
+		t.Fatalf("Get(%s, %s) = %v, %v", accountID, currency, pocket, err)
+	}
+	if !pocket.Balance.Equal(decimal.RequireFromString(balance)) || !pocket.HeldBalance.Equal(decimal.RequireFromString(held)) {
+		t.Errorf("%s pocket: balance %s held %s, want %s held %s", currency, pocket.Balance, pocket.HeldBalance, balance, held)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	quote, err := env.pockets.Quote(ctx, "USD", "EUR", decimal.NewFromInt(50))
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	// 0.8 less the 1% spread
+	if !quote.MidRate.Equal(decimal.RequireFromString("0.8")) || !quote.Rate.Equal(decimal.RequireFromString("0.792")) || !quote.Converted.Equal(decimal.RequireFromString("39.6")) {
+		t.Errorf("Quote = mid %s rate %s converted %s, want 0.8, 0.792, 39.60", quote.MidRate, quote.Rate, quote.Converted)
+	}
+
+	// Crossed through USD: 0.5 / 0.8 less the spread, rounded down to the cent
+	quote, err = env.pockets.Quote(ctx, "EUR", "GBP", decimal.RequireFromString("10.01"))
+	if err != nil {
+		t.Fatalf("cross Quote: %v", err)
+	}
+	if !quote.Rate.Equal(decimal.RequireFromString("0.61875")) || !quote.Converted.Equal(decimal.RequireFromString("6.19")) {
+		t.Errorf("cross Quote = rate %s converted %s, want 0.61875, 6.19", quote.Rate, quote.Converted)
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		amount   string
+		wantErr  error
+	}{
+		{"same currency", "USD", "USD", "10", ErrSameCurrency},
+		{"unsupported currency", "USD", "JPY", "10", ErrUnsupportedCurrency},
+		{"zero amount", "USD", "EUR", "0", ErrInvalidAmount},
+		{"less than a cent", "USD", "GBP", "0.01", ErrConversionTooSmall},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.pockets.Quote(ctx, tt.from, tt.to, decimal.RequireFromString(tt.amount)); err != tt.wantErr {
+				t.Errorf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPockets(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+
+	if _, err := env.pockets.OpenPocket(ctx, alice.ID, account.ID, &domain.OpenPocketRequest{Currency: "USD"}); err != ErrAccountCurrency {
+		t.Errorf("OpenPocket in the account's currency: error %v, want %v", err, ErrAccountCurrency)
+	}
+	if _, err := env.pockets.OpenPocket(ctx, alice.ID, account.ID, &domain.OpenPocketRequest{Currency: "JPY"}); err != ErrUnsupportedCurrency {
+		t.Errorf("OpenPocket in an unsupported currency: error %v, want %v", err, ErrUnsupportedCurrency)
+	}
+	if _, err := env.pockets.OpenPocket(ctx, bob.ID, account.ID, &domain.OpenPocketRequest{Currency: "GBP"}); err == nil {
+		t.Error("outsider OpenPocket: want an error")
+	}
+	if _, err := env.pockets.OpenPocket(ctx, alice.ID, account.ID, &domain.OpenPocketRequest{Currency: "GBP"}); err != nil {
+		t.Fatalf("OpenPocket: %v", err)
+	}
+	if _, err := env.pockets.OpenPocket(ctx, alice.ID, account.ID, &domain.OpenPocketRequest{Currency: "GBP"}); err != ErrPocketExists {
+		t.Errorf("second OpenPocket: error %v, want %v", err, ErrPocketExists)
+	}
+
+	// Buying euros opens a pocket for them
+	convert := &domain.ConversionRequest{AccountID: account.ID.String(), FromCurrency: "USD", ToCurrency: "EUR", Amount: decimal.NewFromInt(101)}
+	if _, err := env.pockets.Convert(ctx, alice.ID, convert); err != ErrInsufficientBalance {
+		t.Errorf("Convert over the balance: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	if _, err := env.pockets.Convert(ctx, bob.ID, convert); err == nil {
+		t.Error("outsider Convert: want an error")
+	}
+	convert.Amount = decimal.NewFromInt(50)
+	conversion, err := env.pockets.Convert(ctx, alice.ID, convert)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if conversion.Debit.Currency != "USD" || !conversion.Debit.Amount.Equal(decimal.NewFromInt(50)) || conversion.Credit.Currency != "EUR" || !conversion.Credit.Amount.Equal(decimal.RequireFromString("39.6")) {
+		t.Errorf("Convert legs = %s %s and %s %s, want 50 USD and 39.60 EUR", conversion.Debit.Amount, conversion.Debit.Currency, conversion.Credit.Amount, conversion.Credit.Currency)
+	}
+	env.assertBalances(t, account.ID, 50, 0)
+	env.assertPocket(t, account.ID, "EUR", "39.6", "0")
+
+	if _, err := env.pockets.Convert(ctx, alice.ID, &domain.ConversionRequest{AccountID: account.ID.String(), FromCurrency: "GBP", ToCurrency: "USD", Amount: decimal.NewFromInt(1)}); err != ErrInsufficientBalance {
+		t.Errorf("Convert from an empty pocket: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	if err := env.pockets.ClosePocket(ctx, alice.ID, account.ID, "EUR"); err != ErrPocketNotEmpty {
+		t.Errorf("ClosePocket with money in it: error %v, want %v", err, ErrPocketNotEmpty)
+	}
+	if err := env.pockets.ClosePocket(ctx, alice.ID, account.ID, "GBP"); err != nil {
+		t.Errorf("ClosePocket: %v", err)
+	}
+	if err := env.pockets.ClosePocket(ctx, alice.ID, account.ID, "GBP"); err != ErrPocketNotFound {
+		t.Errorf("second ClosePocket: error %v, want %v", err, ErrPocketNotFound)
+	}
+
+	pockets, err := env.pockets.GetPockets(ctx, alice.ID, account.ID)
+	if err != nil || len(pockets) != 1 || pockets[0].Currency != "EUR" {
+		t.Errorf("GetPockets = %v, %v; want the EUR pocket", pockets, err)
+	}
+
+	// A pocket's money keeps the account open
+	if _, err := env.lifecycle.CloseAccount(ctx, alice.ID, account.ID, &domain.CloseAccountRequest{}); err != ErrAccountHasPockets {
+		t.Errorf("CloseAccount with a funded pocket: error %v, want %v", err, ErrAccountHasPockets)
+	}
+}
+
+func TestPocketTransfers(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	from := env.newAccount(t, alice.ID, 100)
+	to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: from.ID.String(), Amount: decimal.NewFromInt(40), Currency: "EUR"}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: from.ID.String(), Amount: decimal.NewFromInt(40), Currency: "JPY"}); err != ErrUnsupportedCurrency {
+		t.Errorf("Deposit in an unsupported currency: error %v, want %v", err, ErrUnsupportedCurrency)
+	}
+
+	req := &domain.TransferRequest{FromAccountID: from.ID.String(), ToAccountID: to.ID.String(), FromCurrency: "EUR", Amount: decimal.NewFromInt(41)}
+	if _, err := env.transactions.Transfer(ctx, alice.ID, req); err != ErrInsufficientBalance {
+		t.Errorf("Transfer over the pocket balance: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	req.Amount = decimal.NewFromInt(20)
+	tx, err := env.transactions.Transfer(ctx, alice.ID, req)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if tx.Currency != "EUR" {
+		t.Errorf("transfer currency %s, want EUR", tx.Currency)
+	}
+	// The recipient is paid in the currency sent, and limits see its value
+	env.assertBalances(t, from.ID, 100, 0)
+	env.assertPocket(t, from.ID, "EUR", "20", "0")
+	env.assertBalances(t, to.ID, 0, 0)
+	env.assertPocket(t, to.ID, "EUR", "20", "0")
+	if input := env.risk.inputs[len(env.risk.inputs)-1]; !input.Amount.Equal(decimal.NewFromInt(25)) {
+		t.Errorf("risk input amount %s, want the 25 USD value", input.Amount)
+	}
+	limits, err := env.limits.GetAccountLimits(ctx, alice.ID, from.ID)
+	if err != nil {
+		t.Fatalf("GetAccountLimits: %v", err)
+	}
+	if !limits.Daily.Used.Equal(decimal.NewFromInt(25)) || !limits.UserDaily.Used.Equal(decimal.NewFromInt(25)) {
+		t.Errorf("daily usage %s and %s for the user, want the 25 USD value", limits.Daily.Used, limits.UserDaily.Used)
+	}
+
+	// A reviewed transfer holds the pocket's money until it's decided
+	env.risk.decision = domain.RiskDecisionReview
+	req.Amount = decimal.NewFromInt(15)
+	if tx, err = env.transactions.Transfer(ctx, alice.ID, req); err != nil || tx.Status != domain.TransactionStatusPending {
+		t.Fatalf("reviewed Transfer = %v, %v; want it pending", tx, err)
+	}
+	env.assertPocket(t, from.ID, "EUR", "20", "15")
+	reviews, err := env.transactions.GetPendingReviews(ctx, 10, 0)
+	if err != nil || len(reviews) != 1 {
+		t.Fatalf("GetPendingReviews = %v, %v; want one", reviews, err)
+	}
+	if _, err := env.transactions.RejectReview(ctx, uuid.New(), reviews[0].ID, "not them"); err != nil {
+		t.Fatalf("RejectReview: %v", err)
+	}
+	env.assertPocket(t, from.ID, "EUR", "20", "0")
+	env.assertPocket(t, to.ID, "EUR", "20", "0")
+
+	if _, err := env.transactions.Withdraw(ctx, &domain.WithdrawalRequest{AccountID: to.ID.String(), Amount: decimal.NewFromInt(21), Currency: "EUR"}); err != ErrInsufficientBalance {
+		t.Errorf("Withdraw over the pocket balance: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	if _, err := env.transactions.Withdraw(ctx, &domain.WithdrawalRequest{AccountID: to.ID.String(), Amount: decimal.NewFromInt(20), Currency: "EUR"}); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	env.assertPocket(t, to.ID, "EUR", "0", "0")
+	if limits, err = env.limits.GetAccountLimits(ctx, to.UserID, to.ID); err != nil || !limits.Daily.Used.Equal(decimal.NewFromInt(25)) {
+		t.Errorf("GetAccountLimits = %+v, %v; want the withdrawal's 25 USD value used", limits, err)
+	}
+}
+
+func TestPocketStatements(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	account := env.newAccount(t, alice.ID, 100)
+	_, err := env.pockets.Convert(ctx, alice.ID, &domain.ConversionRequest{AccountID: account.ID.String(), FromCurrency: "USD", ToCurrency: "EUR", Amount: decimal.NewFromInt(50)})
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	fromDate, toDate := time.Now().AddDate(0, -1, 0), time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		view     domain.StatementView
+		want     []string
+		dontWant []string
+		wantErr  error
+	}{
+		{
+			name:     "account currency",
+			want:     []string{",-50,50,50,", ",USD"},
+			dontWant: []string{"+39.6"},
+		},
+		{
+			name:     "pocket",
+			view:     domain.StatementView{Currency: "EUR"},
+			want:     []string{",+39.6,39.6,39.6,", ",EUR"},
+			dontWant: []string{",-50,"},
+		},
+		{
+			name: "consolidated",
+			view: domain.StatementView{Consolidated: true},
+			// The euros are worth 49.50 at the mid rate
+			want: []string{",+49.5,99.5,99.5,", ",USD,39.6,EUR", ",-50,99.5,99.5,"},
+		},
+		{
+			name:    "no such pocket",
+			view:    domain.StatementView{Currency: "GBP"},
+			wantErr: ErrPocketNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := env.statements.GenerateCSVStatement(ctx, alice.ID, account.ID, fromDate, toDate, tt.view)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.want {
+				if !bytes.Contains(statement, []byte(want)) {
+					t.Errorf("statement is missing %q:\n%s", want, statement)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if bytes.Contains(statement, []byte(dontWant)) {
+					t.Errorf("statement has %q:\n%s", dontWant, statement)
+				}
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPotNotFound            = apperror.NotFound("pot_not_found", "pot not found")
+	ErrPotNotEmpty            = apperror.Unprocessable("pot_not_empty", "move the pot's balance out before deleting it")
+	ErrTooManyPots            = apperror.Unprocessable("too_many_pots", "the account already has the most pots allowed")
+	ErrInsufficientPotBalance = apperror.BadRequest("insufficient_pot_balance", "insufficient balance in the pot")
+	ErrRoundUpPotExists       = apperror.Conflict("round_up_pot_exists", "another of the account's pots already collects round-ups")
+	ErrSweepTooHigh           = apperror.Unprocessable("sweep_too_high", "the account's pots can't sweep more than 100% of a deposit between them")
+	ErrInvalidTargetDate      = apperror.BadRequest("invalid_target_date", "the target date must be in the future")
+)
+
+const (
+	maxPotsPerAccount = 20
+	potHistorySize    = 100
+)
+
+// PotUseCase manages savings pots: named goals inside an account holding
+// money set aside from its main balance. Money moves in and out instantly,
+// and a pot's rules can fill it automatically by rounding up the account's
+// outgoing transfers or sweeping a share of its deposits.
+type PotUseCase struct {
+	potRepo     repository.PotRepository
+	accountRepo repository.AccountRepository
+	memberRepo  repository.AccountMemberRepository
+	uow         repository.UnitOfWork
+	now         func() time.Time
+}
+
+func NewPotUseCase(potRepo repository.PotRepository, accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, uow repository.UnitOfWork) *PotUseCase {
+	return &PotUseCase{
+		potRepo:     potRepo,
+		accountRepo: accountRepo,
+		memberRepo:  memberRepo,
+		uow:         uow,
+		now:         time.Now,
+	}
+}
+
+func (uc *PotUseCase) CreatePot(ctx context.Context, userID, accountID uuid.UUID, req *domain.CreatePotRequest) (*domain.Pot, error) {
+	if err := uc.authorize(ctx, userID, accountID, domain.AccountRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	pot := &domain.Pot{
+		AccountID:    accountID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		TargetDate:   req.TargetDate,
+		RoundUp:      req.RoundUp,
+		SweepPercent: req.SweepPercent,
+	}
+	if err := uc.checkTargetDate(pot.TargetDate); err != nil {
+		return nil, err
+	}
+
+	pots, err := uc.potRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pots) >= maxPotsPerAccount {
+		return nil, ErrTooManyPots
+	}
+	if err := checkPotRules(pots, pot); err != nil {
+		return nil, err
+	}
+
+	if err := uc.potRepo.Create(ctx, pot); err != nil {
+		return nil, err
+	}
+
+	return pot, nil
+}
+
+func (uc *PotUseCase) GetPots(ctx context.Context, userID, accountID uuid.UUID) ([]*domain.Pot, error) {
+	if err := uc.authorize(ctx, userID, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.potRepo.GetByAccountID(ctx, accountID)
+}
+
+func (uc *PotUseCase) GetPot(ctx context.Context, userID, potID uuid.UUID) (*domain.Pot, error) {
+	return uc.getPot(ctx, userID, potID, nil)
+}
+
+// GetPotMovements lists the money moved in and out of the pot, newest
+// first.
+func (uc *PotUseCase) GetPotMovements(ctx context.Context, userID, potID uuid.UUID) ([]*domain.PotMovement, error) {
+	if _, err := uc.getPot(ctx, userID, potID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.potRepo.GetMovements(ctx, potID, potHistorySize)
+}
+
+func (uc *PotUseCase) UpdatePot(ctx context.Context, userID, potID uuid.UUID, req *domain.UpdatePotRequest) (*domain.Pot, error) {
+	pot, err := uc.getPot(ctx, userID, potID, domain.AccountRole.CanManage)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		pot.Name = *req.Name
+	}
+	if req.TargetAmount.Valid {
+		pot.TargetAmount = req.TargetAmount
+		if req.TargetAmount.Decimal.IsZero() {
+			pot.TargetAmount = decimal.NullDecimal{}
+		}
+	}
+	if req.TargetDate != nil {
+		if err := uc.checkTargetDate(req.TargetDate); err != nil {
+			return nil, err
+		}
+		pot.TargetDate = req.TargetDate
+	}
+	if req.ClearTargetDate {
+		pot.TargetDate = nil
+	}
+	if req.RoundUp != nil {
+		pot.RoundUp = *req.RoundUp
+	}
+	if req.SweepPercent.Valid {
+		pot.SweepPercent = req.SweepPercent.Decimal
+	}
+
+	pots, err := uc.potRepo.GetByAccountID(ctx, pot.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPotRules(pots, pot); err != nil {
+		return nil, err
+	}
+
+	if err := uc.potRepo.Update(ctx, pot); err != nil {
+		return nil, err
+	}
+
+	return pot, nil
+}
+
+// DeletePot deletes an empty pot.
+func (uc *PotUseCase) DeletePot(ctx context.Context, userID, potID uuid.UUID) error {
+	pot, err := uc.getPot(ctx, userID, potID, domain.AccountRole.CanManage)
+	if err != nil {
+		return err
+	}
+	if !pot.Balance.IsZero() {
+		return ErrPotNotEmpty
+	}
+
+	return uc.potRepo.Delete(ctx, potID)
+}
+
+// Deposit moves money from the account's available balance into the pot.
+func (uc *PotUseCase) Deposit(ctx context.Context, userID, potID uuid.UUID, amount decimal.Decimal) (*domain.Pot, error) {
+	return uc.move(ctx, userID, potID, amount, domain.PotMovementDeposit)
+}
+
+// Withdraw moves money from the pot back to the account's available
+// balance.
+func (uc *PotUseCase) Withdraw(ctx context.Context, userID, potID uuid.UUID, amount decimal.Decimal) (*domain.Pot, error) {
+	return uc.move(ctx, userID, potID, amount.Neg(), domain.PotMovementWithdrawal)
+}
+
+func (uc *PotUseCase) move(ctx context.Context, userID, potID uuid.UUID, amount decimal.Decimal, kind domain.PotMovementKind) (*domain.Pot, error) {
+	if !amount.Abs().IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	pot, err := uc.getPot(ctx, userID, potID, domain.AccountRole.CanTransact)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, pot.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			return err
+		}
+		pot, err = repos.Pots.GetByIDForUpdate(ctx, potID)
+		if err != nil {
+			return err
+		}
+		if pot == nil {
+			return ErrPotNotFound
+		}
+
+		if amount.IsPositive() && account.AvailableBalance().LessThan(amount) {
+			return ErrInsufficientBalance
+		}
+		if pot.Balance.Add(amount).IsNegative() {
+			return ErrInsufficientPotBalance
+		}
+		return movePot(ctx, repos, account, pot, amount, kind, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pot, nil
+}
+
+func (uc *PotUseCase) getPot(ctx context.Context, userID, potID uuid.UUID, allowed func(domain.AccountRole) bool) (*domain.Pot, error) {
+	pot, err := uc.potRepo.GetByID(ctx, potID)
+	if err != nil {
+		return nil, err
+	}
+	if pot == nil {
+		return nil, ErrPotNotFound
+	}
+
+	// Users who can't see the account don't learn the pot exists
+	ok, err := isMember(ctx, uc.memberRepo, pot.AccountID, userID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrPotNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, pot.AccountID, userID, allowed); err != nil {
+		return nil, err
+	}
+	return pot, nil
+}
+
+func (uc *PotUseCase) authorize(ctx context.Context, userID, accountID uuid.UUID, allowed func(domain.AccountRole) bool) error {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrAccountNotFound
+	}
+
+	return authorizeMember(ctx, uc.memberRepo, accountID, userID, allowed)
+}
+
+func (uc *PotUseCase) checkTargetDate(date *time.Time) error {
+	if date != nil && !date.After(uc.now()) {
+		return ErrInvalidTargetDate
+	}
+	return nil
+}
+
+// checkPotRules checks pot's rules against the account's other pots: only
+// one collects round-ups, and their sweeps add up to at most the whole
+// deposit.
+func checkPotRules(pots []*domain.Pot, pot *domain.Pot) error {
+	sweep := pot.SweepPercent
+	for _, other := range pots {
+		if other.ID == pot.ID {
+			continue
+		}
+		if pot.RoundUp && other.RoundUp {
+			return ErrRoundUpPotExists
+		}
+		sweep = sweep.Add(other.SweepPercent)
+	}
+	if sweep.GreaterThan(decimal.NewFromInt(100)) {
+		return ErrSweepTooHigh
+	}
+	return nil
+}
+
+// movePot moves amount from the account's available balance into the pot,
+// or out of it if amount is negative. The account must be locked.
+func movePot(ctx context.Context, repos *repository.Repositories, account *domain.Account, pot *domain.Pot, amount decimal.Decimal, kind domain.PotMovementKind, transactionID *uuid.UUID) error {
+	pot.Balance = pot.Balance.Add(amount)
+	account.PotBalance = account.PotBalance.Add(amount)
+	if err := repos.Pots.UpdateBalance(ctx, pot); err != nil {
+		return err
+	}
+	if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+		return err
+	}
+
+	return repos.Pots.CreateMovement(ctx, &domain.PotMovement{
+		PotID:         pot.ID,
+		Kind:          kind,
+		Amount:        amount,
+		TransactionID: transactionID,
+	})
+}
+
+// roundUp sets aside the change from a transfer out of the account up to the
+// next whole unit in its round-up pot, if it has one. Round-ups the
+// available balance can't cover are skipped rather than failing the
+// transfer.
+func roundUp(ctx context.Context, repos *repository.Repositories, account *domain.Account, transaction *domain.Transaction) error {
+	change := transaction.Amount.Ceil().Sub(transaction.Amount)
+	if !change.IsPositive() || account.AvailableBalance().LessThan(change) {
+		return nil
+	}
+
+	pots, err := repos.Pots.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	for _, pot := range pots {
+		if !pot.RoundUp {
+			continue
+		}
+		pot, err := repos.Pots.GetByIDForUpdate(ctx, pot.ID)
+		if err != nil {
+			return err
+		}
+		return movePot(ctx, repos, account, pot, change, domain.PotMovementRoundUp, &transaction.ID)
+	}
+	return nil
+}
+
+// sweepDeposit sets aside each sweeping pot's share of a deposit into the
+// account, rounded down to the cent. Shares are capped at what is still
+// available, so an overdrawn account keeps the deposit.
+func sweepDeposit(ctx context.Context, repos *repository.Repositories, account *domain.Account, transaction *domain.Transaction) error {
+	pots, err := repos.Pots.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, pot := range pots {
+		if !pot.SweepPercent.IsPositive() {
+			continue
+		}
+		share := transaction.Amount.Mul(pot.SweepPercent).Div(decimal.NewFromInt(100)).RoundFloor(2)
+		share = decimal.Min(share, account.AvailableBalance())
+		if !share.IsPositive() {
+			continue
+		}
+
+		pot, err := repos.Pots.GetByIDForUpdate(ctx, pot.ID)
+		if err != nil {
+			return err
+		}
+		if err := movePot(ctx, repos, account, pot, share, domain.PotMovementSweep, &transaction.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestPots(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+
+	target := decimal.NewNullDecimal(decimal.NewFromInt(200))
+	past := env.clock.Now().Add(-time.Hour)
+	if _, err := env.pots.CreatePot(ctx, alice.ID, account.ID, &domain.CreatePotRequest{Name: "Holiday", TargetDate: &past}); err != ErrInvalidTargetDate {
+		t.Errorf("CreatePot with a past target date: error %v, want %v", err, ErrInvalidTargetDate)
+	}
+	if _, err := env.pots.CreatePot(ctx, bob.ID, account.ID, &domain.CreatePotRequest{Name: "Holiday"}); err == nil {
+		t.Error("outsider CreatePot: want an error")
+	}
+	pot, err := env.pots.CreatePot(ctx, alice.ID, account.ID, &domain.CreatePotRequest{Name: "Holiday", TargetAmount: target})
+	if err != nil {
+		t.Fatalf("CreatePot: %v", err)
+	}
+	if _, err := env.pots.GetPot(ctx, bob.ID, pot.ID); err != ErrPotNotFound {
+		t.Errorf("outsider GetPot: error %v, want %v", err, ErrPotNotFound)
+	}
+
+	// Pot money stays in the ledger balance but can't be spent
+	if _, err := env.pots.Deposit(ctx, alice.ID, pot.ID, decimal.NewFromInt(101)); err != ErrInsufficientBalance {
+		t.Errorf("Deposit over the available balance: error %v, want %v", err, ErrInsufficientBalance)
+	}
+	pot, err = env.pots.Deposit(ctx, alice.ID, pot.ID, decimal.NewFromInt(50))
+	if err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	if progress := pot.Progress(); progress == nil || !progress.Equal(decimal.RequireFromString("0.25")) {
+		t.Errorf("Progress = %v, want 0.25", progress)
+	}
+	env.assertBalances(t, account.ID, 100, 0)
+	if available := env.account(t, account.ID).AvailableBalance(); !available.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("available balance %s, want 50", available)
+	}
+	to := env.newAccount(t, bob.ID, 0)
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: to.ID.String(), Amount: decimal.NewFromInt(60)}); err != ErrInsufficientBalance {
+		t.Errorf("Transfer of pot money: error %v, want %v", err, ErrInsufficientBalance)
+	}
+
+	if _, err := env.pots.Withdraw(ctx, alice.ID, pot.ID, decimal.NewFromInt(51)); err != ErrInsufficientPotBalance {
+		t.Errorf("Withdraw over the pot balance: error %v, want %v", err, ErrInsufficientPotBalance)
+	}
+	if err := env.pots.DeletePot(ctx, alice.ID, pot.ID); err != ErrPotNotEmpty {
+		t.Errorf("DeletePot with money in it: error %v, want %v", err, ErrPotNotEmpty)
+	}
+	if _, err := env.pots.Withdraw(ctx, alice.ID, pot.ID, decimal.NewFromInt(50)); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	movements, err := env.pots.GetPotMovements(ctx, alice.ID, pot.ID)
+	if err != nil || len(movements) != 2 || movements[0].Kind != domain.PotMovementWithdrawal || !movements[0].Amount.Equal(decimal.NewFromInt(-50)) {
+		t.Errorf("GetPotMovements = %v, %v; want the withdrawal of 50 first", movements, err)
+	}
+	if err := env.pots.DeletePot(ctx, alice.ID, pot.ID); err != nil {
+		t.Errorf("DeletePot: %v", err)
+	}
+}
+
+func TestPotRules(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	account := env.newAccount(t, alice.ID, 100)
+	to := env.newAccount(t, bob.ID, 0)
+
+	roundUps, err := env.pots.CreatePot(ctx, alice.ID, account.ID, &domain.CreatePotRequest{Name: "Spare change", RoundUp: true})
+	if err != nil {
+		t.Fatalf("CreatePot: %v", err)
+	}
+	if _, err := env.pots.CreatePot(ctx, alice.ID, account.ID, &domain.CreatePotRequest{Name: "More change", RoundUp: true}); err != ErrRoundUpPotExists {
+		t.Errorf("second round-up pot: error %v, want %v", err, ErrRoundUpPotExists)
+	}
+	savings, err := env.pots.CreatePot(ctx, alice.ID, account.ID, &domain.CreatePotRequest{Name: "Savings", SweepPercent: decimal.NewFromInt(60)})
+	if err != nil {
+		t.Fatalf("CreatePot: %v", err)
+	}
+	if _, err := env.pots.UpdatePot(ctx, alice.ID, roundUps.ID, &domain.UpdatePotRequest{SweepPercent: decimal.NewNullDecimal(decimal.NewFromInt(41))}); err != ErrSweepTooHigh {
+		t.Errorf("sweeps over 100%%: error %v, want %v", err, ErrSweepTooHigh)
+	}
+
+	// A transfer of 12.30 sets aside 0.70
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{FromAccountID: account.ID.String(), ToAccountID: to.ID.String(), Amount: decimal.RequireFromString("12.30")}); err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if pot, err := env.pots.GetPot(ctx, alice.ID, roundUps.ID); err != nil || !pot.Balance.Equal(decimal.RequireFromString("0.70")) {
+		t.Errorf("round-up pot = %v, %v; want a balance of 0.70", pot, err)
+	}
+
+	// A deposit of 10.05 sweeps 6.03 into the savings pot
+	if _, err := env.transactions.Deposit(ctx, &domain.DepositRequest{AccountID: account.ID.String(), Amount: decimal.RequireFromString("10.05")}); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	if pot, err := env.pots.GetPot(ctx, alice.ID, savings.ID); err != nil || !pot.Balance.Equal(decimal.RequireFromString("6.03")) {
+		t.Errorf("sweep pot = %v, %v; want a balance of 6.03", pot, err)
+	}
+	account = env.account(t, account.ID)
+	if !account.Balance.Equal(decimal.RequireFromString("97.75")) || !account.PotBalance.Equal(decimal.RequireFromString("6.73")) {
+		t.Errorf("account balance %s in pots %s, want 97.75 and 6.73", account.Balance, account.PotBalance)
+	}
+}
\ No newline at end of file
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+var (
+	ErrAlertNotFound        = apperror.NotFound("screening_alert_not_found", "screening alert not found")
+	ErrAlertAlreadyResolved = apperror.Conflict("screening_alert_resolved", "screening alert has already been resolved")
+)
+
+// NameScreener matches a name against a sanctions watchlist.
+type NameScreener interface {
+	Screen(name string) *domain.ScreeningResult
+}
+
+// ScreeningUseCase screens names for registration, payees and transfers and
+// keeps the alerts compliance works through. A nil *ScreeningUseCase lets
+// every name through, for deployments without a watchlist.
+type ScreeningUseCase struct {
+	screener  NameScreener
+	alertRepo repository.ScreeningAlertRepository
+}
+
+func NewScreeningUseCase(screener NameScreener, alertRepo repository.ScreeningAlertRepository) *ScreeningUseCase {
+	return &ScreeningUseCase{
+		screener:  screener,
+		alertRepo: alertRepo,
+	}
+}
+
+// Check screens name. Blocked names are recorded immediately since nothing
+// will be created for them; callers that go ahead on a review decision
+// record it with Flag once the subject exists.
+func (uc *ScreeningUseCase) Check(ctx context.Context, subject domain.ScreeningSubject, name string) (*domain.ScreeningResult, error) {
+	result := uc.Screen(name)
+	if err := uc.Block(ctx, subject, name, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Screen screens name without recording anything, for callers inside a
+// unit of work that may be retried; they record the outcome with Block or
+// Flag once it is settled.
+func (uc *ScreeningUseCase) Screen(name string) *domain.ScreeningResult {
+	if uc == nil || uc.screener == nil {
+		return &domain.ScreeningResult{Decision: domain.RiskDecisionAllow}
+	}
+	return uc.screener.Screen(name)
+}
+
+// Block records an alert for a name that was blocked.
+func (uc *ScreeningUseCase) Block(ctx context.Context, subject domain.ScreeningSubject, name string, result *domain.ScreeningResult) error {
+	if uc == nil || result.Decision != domain.RiskDecisionBlock {
+		return nil
+	}
+	return uc.record(ctx, subject, nil, name, result)
+}
+
+// Flag records a review alert for a subject that was let through.
+func (uc *ScreeningUseCase) Flag(ctx context.Context, subject domain.ScreeningSubject, subjectID uuid.UUID, name string, result *domain.ScreeningResult) error {
+	if uc == nil || result.Decision != domain.RiskDecisionReview {
+		return nil
+	}
+	return uc.record(ctx, subject, &subjectID, name, result)
+}
+
+func (uc *ScreeningUseCase) record(ctx context.Context, subject domain.ScreeningSubject, subjectID *uuid.UUID, name string, result *domain.ScreeningResult) error {
+	return uc.alertRepo.Create(ctx, &domain.ScreeningAlert{
+		Subject:      subject,
+		SubjectID:    subjectID,
+		ScreenedName: name,
+		Decision:     result.Decision,
+		Matches:      result.Matches,
+		Status:       domain.ReviewStatusPending,
+	})
+}
+
+func (uc *ScreeningUseCase) GetPendingAlerts(ctx context.Context, limit, offset int) ([]*domain.ScreeningAlert, error) {
+	return uc.alertRepo.GetPending(ctx, limit, offset)
+}
+
+// ClearAlert marks an alert as a false positive.
+func (uc *ScreeningUseCase) ClearAlert(ctx context.Context, operatorID, alertID uuid.UUID, note string) (*domain.ScreeningAlert, error) {
+	return uc.resolveAlert(ctx, operatorID, alertID, note, domain.ReviewStatusApproved)
+}
+
+// ConfirmAlert marks an alert as a true match for follow-up.
+func (uc *ScreeningUseCase) ConfirmAlert(ctx context.Context, operatorID, alertID uuid.UUID, note string) (*domain.ScreeningAlert, error) {
+	return uc.resolveAlert(ctx, operatorID, alertID, note, domain.ReviewStatusRejected)
+}
+
+func (uc *ScreeningUseCase) resolveAlert(ctx context.Context, operatorID, alertID uuid.UUID, note string, status domain.ReviewStatus) (*domain.ScreeningAlert, error) {
+	alert, err := uc.alertRepo.GetByID(ctx, alertID)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+	if alert.Status != domain.ReviewStatusPending {
+		return nil, ErrAlertAlreadyResolved
+	}
+
+	now := time.Now()
+	alert.Status = status
+	alert.ReviewedBy = &operatorID
+	alert.ReviewedAt = &now
+	if note != "" {
+		alert.Note = &note
+	}
+
+	if err := uc.alertRepo.Update(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
\ No newline at end of file
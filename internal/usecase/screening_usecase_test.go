@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestScreeningCheck(t *testing.T) {
+	env := newTestEnv(t)
+	env.screener.set("Blocked Person", domain.RiskDecisionBlock)
+	env.screener.set("Possible Match", domain.RiskDecisionReview)
+
+	tests := []struct {
+		name         string
+		uc           *ScreeningUseCase
+		screened     string
+		wantDecision domain.RiskDecision
+		wantAlert    bool
+	}{
+		{"clean name", env.screening, "Alice Smith", domain.RiskDecisionAllow, false},
+		{"block is recorded straight away", env.screening, "Blocked Person", domain.RiskDecisionBlock, true},
+		{"review waits for Flag", env.screening, "Possible Match", domain.RiskDecisionReview, false},
+		{"nil use case allows everything", nil, "Blocked Person", domain.RiskDecisionAllow, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := pendingAlerts(t, env)
+
+			result, err := tt.uc.Check(context.Background(), domain.ScreeningSubjectUser, tt.screened)
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+			if result.Decision != tt.wantDecision {
+				t.Errorf("decision %s, want %s", result.Decision, tt.wantDecision)
+			}
+			if got := pendingAlerts(t, env) > before; got != tt.wantAlert {
+				t.Errorf("alert recorded = %v, want %v", got, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestScreeningFlag(t *testing.T) {
+	env := newTestEnv(t)
+	subjectID := uuid.New()
+
+	tests := []struct {
+		name      string
+		uc        *ScreeningUseCase
+		decision  domain.RiskDecision
+		wantAlert bool
+	}{
+		{"review is recorded", env.screening, domain.RiskDecisionReview, true},
+		{"allow is not", env.screening, domain.RiskDecisionAllow, false},
+		{"block is not recorded twice", env.screening, domain.RiskDecisionBlock, false},
+		{"nil use case", nil, domain.RiskDecisionReview, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := pendingAlerts(t, env)
+
+			err := tt.uc.Flag(context.Background(), domain.ScreeningSubjectPayee, subjectID, "Possible Match", &domain.ScreeningResult{Decision: tt.decision})
+			if err != nil {
+				t.Fatalf("Flag: %v", err)
+			}
+			if got := pendingAlerts(t, env) > before; got != tt.wantAlert {
+				t.Errorf("alert recorded = %v, want %v", got, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestGetPendingAlerts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.screener.set("Blocked Person", domain.RiskDecisionBlock)
+	for i := 0; i < 3; i++ {
+		if _, err := env.screening.Check(ctx, domain.ScreeningSubjectUser, "Blocked Person"); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		limit, offset int
+		want          int
+	}{
+		{"all", 10, 0, 3},
+		{"first page", 2, 0, 2},
+		{"second page", 2, 2, 1},
+		{"past the end", 10, 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerts, err := env.screening.GetPendingAlerts(ctx, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("GetPendingAlerts: %v", err)
+			}
+			if len(alerts) != tt.want {
+				t.Errorf("%d alerts, want %d", len(alerts), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveAlert(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	operatorID := uuid.New()
+
+	newAlert := func(t *testing.T) uuid.UUID {
+		t.Helper()
+		alert := &domain.ScreeningAlert{Subject: domain.ScreeningSubjectUser, ScreenedName: "Possible Match", Decision: domain.RiskDecisionReview, Status: domain.ReviewStatusPending}
+		if err := env.store.ScreeningAlerts().Create(ctx, alert); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		return alert.ID
+	}
+	resolved := newAlert(t)
+	if _, err := env.screening.ClearAlert(ctx, operatorID, resolved, ""); err != nil {
+		t.Fatalf("ClearAlert: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		resolve    func(ctx context.Context, operatorID, alertID uuid.UUID, note string) (*domain.ScreeningAlert, error)
+		alertID    uuid.UUID
+		wantStatus domain.ReviewStatus
+		wantErr    error
+	}{
+		{"clear", env.screening.ClearAlert, newAlert(t), domain.ReviewStatusApproved, nil},
+		{"confirm", env.screening.ConfirmAlert, newAlert(t), domain.ReviewStatusRejected, nil},
+		{"already resolved", env.screening.ConfirmAlert, resolved, "", ErrAlertAlreadyResolved},
+		{"missing", env.screening.ClearAlert, uuid.New(), "", ErrAlertNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alert, err := tt.resolve(ctx, operatorID, tt.alertID, "checked date of birth")
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			stored, _ := env.store.ScreeningAlerts().GetByID(ctx, alert.ID)
+			if stored.Status != tt.wantStatus || stored.ReviewedBy == nil || *stored.ReviewedBy != operatorID || stored.Note == nil {
+				t.Errorf("stored alert %+v", stored)
+			}
+		})
+	}
+}
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,22 +16,52 @@ import (
 	"github.com/johnfercher/maroto/v2/pkg/config"
 	"github.com/johnfercher/maroto/v2/pkg/props"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/fx"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
 )
 
+// StatementUseCase generates account statements in one of the account's
+// currencies, or consolidated into its own currency. Consolidated
+// statements convert at the rate source's mid-market rates when they are
+// generated, not the rates the transactions were made at.
 type StatementUseCase struct {
 	accountRepo     repository.AccountRepository
+	memberRepo      repository.AccountMemberRepository
 	transactionRepo repository.TransactionRepository
+	pocketRepo      repository.PocketRepository
+	rates           fx.Source
 }
 
-func NewStatementUseCase(accountRepo repository.AccountRepository, transactionRepo repository.TransactionRepository) *StatementUseCase {
+// NewStatementUseCase consolidates at fx.DefaultTable's rates if rates is
+// nil.
+func NewStatementUseCase(accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, transactionRepo repository.TransactionRepository, pocketRepo repository.PocketRepository, rates fx.Source) *StatementUseCase {
+	if rates == nil {
+		rates = fx.DefaultTable()
+	}
 	return &StatementUseCase{
 		accountRepo:     accountRepo,
+		memberRepo:      memberRepo,
 		transactionRepo: transactionRepo,
+		pocketRepo:      pocketRepo,
+		rates:           rates,
 	}
 }
 
-func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID uuid.UUID, fromDate, toDate time.Time) ([]byte, error) {
+// statement is what either format shows: the transactions in the
+// statement's currency and the balances the account holds in it.
+type statement struct {
+	account      *domain.Account
+	currency     string
+	balance      decimal.Decimal
+	available    decimal.Decimal
+	transactions []*domain.Transaction
+	// rates convert each currency into the account's on a consolidated
+	// statement
+	rates map[string]decimal.Decimal
+}
+
+func (uc *StatementUseCase) load(ctx context.Context, userID, accountID uuid.UUID, fromDate, toDate time.Time, view domain.StatementView) (*statement, error) {
 	account, err := uc.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return nil, err
@@ -39,6 +70,10 @@ func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID
 		return nil, ErrAccountNotFound
 	}
 
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanView); err != nil {
+		return nil, err
+	}
+
 	filter := &domain.TransactionFilter{
 		AccountID: accountID,
 		FromDate:  fromDate,
@@ -51,6 +86,92 @@ func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID
 		return nil, err
 	}
 
+	st := &statement{
+		account:   account,
+		currency:  account.Currency,
+		balance:   account.Balance,
+		available: account.AvailableBalance(),
+	}
+
+	if view.Consolidated {
+		pockets, err := uc.pocketRepo.GetByAccountID(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		st.rates = map[string]decimal.Decimal{account.Currency: decimal.NewFromInt(1)}
+		for _, pocket := range pockets {
+			rate, err := uc.rate(ctx, st.rates, pocket.Currency, account.Currency)
+			if err != nil {
+				return nil, err
+			}
+			st.balance = st.balance.Add(pocket.Balance.Mul(rate).Round(2))
+			st.available = st.available.Add(pocket.AvailableBalance().Mul(rate).Round(2))
+		}
+		for _, tx := range transactions {
+			if _, err := uc.rate(ctx, st.rates, tx.Currency, account.Currency); err != nil {
+				return nil, err
+			}
+		}
+		st.transactions = transactions
+		return st, nil
+	}
+
+	if view.Currency != "" && view.Currency != account.Currency {
+		pocket, err := uc.pocketRepo.Get(ctx, accountID, view.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if pocket == nil {
+			return nil, ErrPocketNotFound
+		}
+		st.currency = pocket.Currency
+		st.balance = pocket.Balance
+		st.available = pocket.AvailableBalance()
+	}
+	for _, tx := range transactions {
+		if tx.Currency == st.currency {
+			st.transactions = append(st.transactions, tx)
+		}
+	}
+	return st, nil
+}
+
+// rate looks up and remembers the mid-market rate from currency into base.
+func (uc *StatementUseCase) rate(ctx context.Context, rates map[string]decimal.Decimal, currency, base string) (decimal.Decimal, error) {
+	if rate, ok := rates[currency]; ok {
+		return rate, nil
+	}
+	rate, err := uc.rates.Rate(ctx, currency, base)
+	if errors.Is(err, fx.ErrUnsupportedPair) {
+		return decimal.Zero, ErrUnsupportedCurrency
+	}
+	if err != nil {
+		return decimal.Zero, err
+	}
+	rates[currency] = rate
+	return rate, nil
+}
+
+// amount is the transaction's amount in the statement's currency, signed
+// by the way it moved money.
+func (st *statement) amount(tx *domain.Transaction) string {
+	amount := tx.Amount
+	if st.rates != nil {
+		amount = amount.Mul(st.rates[tx.Currency]).Round(2)
+	}
+	if tx.FromAccountID != nil && *tx.FromAccountID == st.account.ID {
+		return "-" + amount.String()
+	}
+	return "+" + amount.String()
+}
+
+func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, userID, accountID uuid.UUID, fromDate, toDate time.Time, view domain.StatementView) ([]byte, error) {
+	st, err := uc.load(ctx, userID, accountID, fromDate, toDate, view)
+	if err != nil {
+		return nil, err
+	}
+	account := st.account
+
 	cfg := config.NewBuilder().Build()
 	mrt := maroto.New(cfg)
 
@@ -72,23 +193,35 @@ func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID
 				text.New(fmt.Sprintf("Account Number: %s", account.AccountNumber), props.Text{Size: 10}),
 			),
 			col.New(6).Add(
-				text.New(fmt.Sprintf("Current Balance: %s %s", account.Balance.String(), account.Currency), props.Text{Size: 10}),
+				text.New(fmt.Sprintf("Current Balance: %s %s", st.balance.String(), st.currency), props.Text{Size: 10}),
+			),
+		),
+		row.New(5).Add(
+			col.New(6).Add(
+				text.New(statementCurrency(st), props.Text{Size: 10}),
+			),
+			col.New(6).Add(
+				text.New(fmt.Sprintf("Available Balance: %s %s", st.available.String(), st.currency), props.Text{Size: 10}),
 			),
 		),
 	)
 
 	// Generate simple PDF content
-	for _, tx := range transactions {
+	feesCharged := decimal.Zero
+	for _, tx := range st.transactions {
 		description := "Transfer"
 		if tx.Description != nil {
 			description = *tx.Description
 		}
 
-		amount := tx.Amount.String()
-		if tx.FromAccountID != nil && *tx.FromAccountID == accountID {
-			amount = "-" + amount
-		} else {
-			amount = "+" + amount
+		amount := st.amount(tx)
+		if st.rates != nil && tx.Currency != st.currency {
+			amount += fmt.Sprintf(" (%s %s)", tx.Amount.String(), tx.Currency)
+		}
+		if tx.Status != domain.TransactionStatusCompleted {
+			amount += fmt.Sprintf(" (%s)", tx.Status)
+		} else if tx.Type == domain.TransactionTypeFee {
+			feesCharged = feesCharged.Add(tx.Amount)
 		}
 
 		mrt.AddRows(
@@ -104,6 +237,17 @@ func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID
 		)
 	}
 
+	// Fees are only ever charged in the account's own currency
+	if st.currency == account.Currency {
+		mrt.AddRows(
+			row.New(5).Add(
+				col.New(12).Add(
+					text.New(fmt.Sprintf("Fees charged: %s %s", feesCharged.StringFixed(2), account.Currency), props.Text{Size: 8}),
+				),
+			),
+		)
+	}
+
 	document, err := mrt.Generate()
 	if err != nil {
 		return nil, err
@@ -112,23 +256,11 @@ func (uc *StatementUseCase) GeneratePDFStatement(ctx context.Context, accountID
 	return document.GetBytes(), nil
 }
 
-func (uc *StatementUseCase) GenerateCSVStatement(ctx context.Context, accountID uuid.UUID, fromDate, toDate time.Time) ([]byte, error) {
-	account, err := uc.accountRepo.GetByID(ctx, accountID)
-	if err != nil {
-		return nil, err
-	}
-	if account == nil {
-		return nil, ErrAccountNotFound
-	}
-
-	filter := &domain.TransactionFilter{
-		AccountID: accountID,
-		FromDate:  fromDate,
-		ToDate:    toDate,
-		Limit:     1000,
-	}
-
-	transactions, err := uc.transactionRepo.GetByAccountID(ctx, accountID, filter)
+// GenerateCSVStatement puts the statement's currency after the reference,
+// and on a consolidated statement each transaction's own amount and
+// currency after that.
+func (uc *StatementUseCase) GenerateCSVStatement(ctx context.Context, userID, accountID uuid.UUID, fromDate, toDate time.Time, view domain.StatementView) ([]byte, error) {
+	st, err := uc.load(ctx, userID, accountID, fromDate, toDate, view)
 	if err != nil {
 		return nil, err
 	}
@@ -137,34 +269,42 @@ func (uc *StatementUseCase) GenerateCSVStatement(ctx context.Context, accountID
 	writer := csv.NewWriter(&buf)
 
 	// Header
-	headers := []string{"Date", "Type", "Description", "Amount", "Balance", "Reference"}
+	headers := []string{"Date", "Type", "Description", "Amount", "Balance", "Available Balance", "Reference", "Currency"}
+	if st.rates != nil {
+		headers = append(headers, "Original Amount", "Original Currency")
+	}
 	writer.Write(headers)
 
 	// Transactions
-	for _, tx := range transactions {
+	for _, tx := range st.transactions {
 		description := "Transfer"
 		if tx.Description != nil {
 			description = *tx.Description
 		}
 
-		amount := tx.Amount.String()
-		if tx.FromAccountID != nil && *tx.FromAccountID == accountID {
-			amount = "-" + amount
-		} else {
-			amount = "+" + amount
-		}
-
 		record := []string{
 			tx.CreatedAt.Format("2006-01-02 15:04:05"),
 			string(tx.Type),
 			description,
-			amount,
-			account.Balance.String(),
+			st.amount(tx),
+			st.balance.String(),
+			st.available.String(),
 			tx.Reference,
+			st.currency,
+		}
+		if st.rates != nil {
+			record = append(record, tx.Amount.String(), tx.Currency)
 		}
 		writer.Write(record)
 	}
 
 	writer.Flush()
 	return buf.Bytes(), writer.Error()
+}
+
+func statementCurrency(st *statement) string {
+	if st.rates != nil {
+		return fmt.Sprintf("All currencies in %s at current rates", st.currency)
+	}
+	return fmt.Sprintf("Currency: %s", st.currency)
 }
\ No newline at end of file
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestStatementAuthorization(t *testing.T) {
+	env := newTestEnv(t)
+	alice := env.newUser(t, "Alice Smith")
+	viewer := env.newUser(t, "Bob Jones")
+	stranger := env.newUser(t, "Carol White")
+	account := env.newAccount(t, alice.ID, 100)
+	env.addMember(t, account, viewer, domain.AccountRoleViewer)
+	fromDate, toDate := time.Now().AddDate(0, -1, 0), time.Now().Add(time.Hour)
+
+	generators := map[string]func(ctx context.Context, userID, accountID uuid.UUID, fromDate, toDate time.Time, view domain.StatementView) ([]byte, error){
+		"pdf": env.statements.GeneratePDFStatement,
+		"csv": env.statements.GenerateCSVStatement,
+	}
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		wantErr   error
+	}{
+		{"owner", alice.ID, account.ID, nil},
+		{"viewer", viewer.ID, account.ID, nil},
+		{"someone else's account", stranger.ID, account.ID, ErrUnauthorized},
+		{"missing account", alice.ID, uuid.New(), ErrAccountNotFound},
+	}
+	for format, generate := range generators {
+		for _, tt := range tests {
+			t.Run(format+" "+tt.name, func(t *testing.T) {
+				statement, err := generate(context.Background(), tt.userID, tt.accountID, fromDate, toDate, domain.StatementView{})
+				if err != tt.wantErr {
+					t.Fatalf("error %v, want %v", err, tt.wantErr)
+				}
+				if err == nil && len(statement) == 0 {
+					t.Error("empty statement")
+				}
+			})
+		}
+	}
+}
\ No newline at end of file
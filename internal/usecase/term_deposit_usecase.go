@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrTermDepositNotFound  = apperror.NotFound("term_deposit_not_found", "term deposit not found")
+	ErrTermDepositNotActive = apperror.Conflict("term_deposit_not_active", "term deposit has already matured or been broken")
+	ErrTermNotOffered       = apperror.BadRequest("term_not_offered", "no term deposit is offered for that many months")
+	ErrBelowMinimumDeposit  = apperror.Unprocessable("below_minimum_deposit", "amount is below the minimum term deposit")
+	ErrCurrencyMismatch     = apperror.BadRequest("currency_mismatch", "accounts must be in the same currency")
+	ErrFundsLocked          = apperror.Unprocessable("funds_locked", "funds are locked in a term deposit until it matures")
+)
+
+const maturityBatchSize = 100
+
+// TermDepositUseCase opens fixed-term deposits, each in its own deposit
+// account funded from one of the user's accounts. The funds can't be spent
+// until the term matures, when a background job pays them out to the
+// nominated account or rolls them over; breaking the term early costs the
+// interest it would have earned and a fee.
+type TermDepositUseCase struct {
+	accountRepo     repository.AccountRepository
+	termDepositRepo repository.TermDepositRepository
+	uow             repository.UnitOfWork
+	policy          *domain.TermDepositPolicy
+	numbers         accountnumber.Scheme
+	now             func() time.Time
+}
+
+func NewTermDepositUseCase(accountRepo repository.AccountRepository, termDepositRepo repository.TermDepositRepository, uow repository.UnitOfWork, policy *domain.InterestPolicy, numbers accountnumber.Scheme) *TermDepositUseCase {
+	if policy == nil || policy.TermDeposits == nil {
+		policy = DefaultInterestPolicy()
+	}
+	if numbers == nil {
+		numbers = accountnumber.Default()
+	}
+	return &TermDepositUseCase{
+		accountRepo:     accountRepo,
+		termDepositRepo: termDepositRepo,
+		uow:             uow,
+		policy:          policy.TermDeposits,
+		numbers:         numbers,
+		now:             time.Now,
+	}
+}
+
+// Open moves the amount from the source account into a new deposit account
+// and locks it there for the term at the rate currently offered.
+func (uc *TermDepositUseCase) Open(ctx context.Context, userID uuid.UUID, req *domain.OpenTermDepositRequest) (*domain.TermDeposit, error) {
+	sourceID, err := uuid.Parse(req.SourceAccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	payoutID := sourceID
+	if req.PayoutAccountID != "" {
+		if payoutID, err = uuid.Parse(req.PayoutAccountID); err != nil {
+			return nil, ErrAccountNotFound
+		}
+	}
+
+	rate, ok := uc.policy.Rate(req.TermMonths)
+	if !ok {
+		return nil, ErrTermNotOffered
+	}
+	if !req.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	if req.Amount.LessThan(uc.policy.MinimumAmount) {
+		return nil, ErrBelowMinimumDeposit
+	}
+
+	var deposit *domain.TermDeposit
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		source, err := lockAccount(ctx, repos, sourceID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeMember(ctx, repos.AccountMembers, sourceID, userID, domain.AccountRole.CanTransact); err != nil {
+			return err
+		}
+		if err := checkDebit(source); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, source); err != nil {
+			return err
+		}
+		if source.AvailableBalance().LessThan(req.Amount) {
+			return ErrInsufficientBalance
+		}
+
+		if payoutID != sourceID {
+			payout, err := repos.Accounts.GetByID(ctx, payoutID)
+			if err != nil {
+				return err
+			}
+			if payout == nil {
+				return ErrAccountNotFound
+			}
+			if err := authorizeMember(ctx, repos.AccountMembers, payoutID, userID, domain.AccountRole.CanTransact); err != nil {
+				return err
+			}
+			if err := checkCredit(payout); err != nil {
+				return err
+			}
+			if payout.Currency != source.Currency {
+				return ErrCurrencyMismatch
+			}
+		}
+
+		now := uc.now()
+		account := &domain.Account{
+			ID:          uuid.New(),
+			UserID:      userID,
+			AccountType: domain.AccountTypeDeposit,
+			Balance:     decimal.Zero,
+			Currency:    source.Currency,
+			Status:      domain.AccountStatusActive,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := createAccount(ctx, repos.Accounts, uc.numbers, account); err != nil {
+			return err
+		}
+
+		if err := moveFunds(ctx, repos, source, account, req.Amount, "Term deposit funding"); err != nil {
+			return err
+		}
+
+		deposit = uc.newTerm(userID, account.ID, payoutID, req.Amount, rate, req.TermMonths, startOfDay(now), req.MaturityInstruction)
+		return repos.TermDeposits.Create(ctx, deposit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deposit, nil
+}
+
+func (uc *TermDepositUseCase) GetTermDeposit(ctx context.Context, userID, depositID uuid.UUID) (*domain.TermDeposit, error) {
+	deposit, err := uc.termDepositRepo.GetByID(ctx, depositID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil {
+		return nil, ErrTermDepositNotFound
+	}
+	if deposit.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	return deposit, nil
+}
+
+func (uc *TermDepositUseCase) GetUserTermDeposits(ctx context.Context, userID uuid.UUID) ([]*domain.TermDeposit, error) {
+	return uc.termDepositRepo.GetByUserID(ctx, userID)
+}
+
+// Break ends a term before it matures. It pays the break rate instead of the
+// agreed one for the days the deposit ran, charges the break fee on the
+// principal and pays what is left to the payout account.
+func (uc *TermDepositUseCase) Break(ctx context.Context, userID, depositID uuid.UUID) (*domain.BreakTermDepositResponse, error) {
+	var result *domain.BreakTermDepositResponse
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		deposit, err := repos.TermDeposits.GetByIDForUpdate(ctx, depositID)
+		if err != nil {
+			return err
+		}
+		if deposit == nil {
+			return ErrTermDepositNotFound
+		}
+		if deposit.UserID != userID {
+			return ErrUnauthorized
+		}
+		// A matured deposit is only waiting for the maturity job
+		today := startOfDay(uc.now())
+		if deposit.Status != domain.TermDepositStatusActive || !today.Before(deposit.MaturityDate) {
+			return ErrTermDepositNotActive
+		}
+
+		account, payout, err := lockAccounts(ctx, repos, deposit.AccountID, deposit.PayoutAccountID)
+		if err != nil {
+			return err
+		}
+
+		// The accrual history records what the agreed rate earned so far
+		if err := accrueTerm(ctx, repos, deposit, uc.policy.DayCount, today); err != nil {
+			return err
+		}
+		interest := deposit.Principal.Mul(uc.policy.BreakRate).Mul(uc.policy.DayCount.YearFraction(deposit.StartDate, today)).RoundBank(2)
+		if err := uc.payInterest(ctx, repos, account, interest, today, "Interest on broken term deposit"); err != nil {
+			return err
+		}
+
+		penalty := decimal.Min(deposit.Principal.Mul(uc.policy.BreakFeeRate).RoundBank(2), account.Balance)
+		if penalty.IsPositive() {
+			fee := newCashTransaction(domain.TransactionTypeFee, account, penalty, "Early break fee")
+			fee.FromAccountID = &account.ID
+			if err := repos.Transactions.Create(ctx, fee); err != nil {
+				return err
+			}
+			account.Balance = account.Balance.Sub(penalty)
+			if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+				return err
+			}
+		}
+
+		result = &domain.BreakTermDepositResponse{
+			TermDeposit: deposit,
+			Interest:    interest,
+			Penalty:     penalty,
+			Payout:      account.Balance,
+		}
+		return uc.close(ctx, repos, deposit, domain.TermDepositStatusBroken, account, payout, "Term deposit broken")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ProcessMaturities pays out or rolls over deposits that have reached their
+// maturity date. It is run periodically by the job runner.
+func (uc *TermDepositUseCase) ProcessMaturities(ctx context.Context) error {
+	deposits, err := uc.termDepositRepo.GetMatured(ctx, startOfDay(uc.now()), maturityBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, deposit := range deposits {
+		if err := uc.mature(ctx, deposit.ID); err != nil {
+			log.Printf("Failed to mature term deposit %s: %v", deposit.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *TermDepositUseCase) mature(ctx context.Context, depositID uuid.UUID) error {
+	return uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		deposit, err := repos.TermDeposits.GetByIDForUpdate(ctx, depositID)
+		if err != nil {
+			return err
+		}
+		// Broken since the sweep was listed
+		if deposit == nil || deposit.Status != domain.TermDepositStatusActive {
+			return nil
+		}
+
+		account, payout, err := lockAccounts(ctx, repos, deposit.AccountID, deposit.PayoutAccountID)
+		if err != nil {
+			return err
+		}
+
+		if err := accrueTerm(ctx, repos, deposit, uc.policy.DayCount, deposit.MaturityDate); err != nil {
+			return err
+		}
+		interest := deposit.MaturityValue.Sub(deposit.Principal)
+		if err := uc.payInterest(ctx, repos, account, interest, deposit.MaturityDate, "Term deposit interest"); err != nil {
+			return err
+		}
+
+		if deposit.Instruction == domain.MaturityRollover {
+			// A term that is no longer offered pays out instead
+			if rate, ok := uc.policy.Rate(deposit.TermMonths); ok {
+				if err := uc.setStatus(ctx, repos, deposit, domain.TermDepositStatusRolledOver); err != nil {
+					return err
+				}
+				next := uc.newTerm(deposit.UserID, account.ID, deposit.PayoutAccountID, account.Balance, rate, deposit.TermMonths, deposit.MaturityDate, deposit.Instruction)
+				return repos.TermDeposits.Create(ctx, next)
+			}
+		}
+
+		return uc.close(ctx, repos, deposit, domain.TermDepositStatusMatured, account, payout, "Term deposit maturity")
+	})
+}
+
+// newTerm prices a term starting on start at rate. Terms end on the same day
+// of the month, or the month's last day if it is shorter.
+func (uc *TermDepositUseCase) newTerm(userID, accountID, payoutID uuid.UUID, principal, rate decimal.Decimal, months int, start time.Time, instruction domain.MaturityInstruction) *domain.TermDeposit {
+	maturity := start.AddDate(0, months, 0)
+	if maturity.Day() != start.Day() {
+		maturity = maturity.AddDate(0, 0, -maturity.Day())
+	}
+	interest := principal.Mul(rate).Mul(uc.policy.DayCount.YearFraction(start, maturity)).RoundBank(2)
+
+	return &domain.TermDeposit{
+		UserID:          userID,
+		AccountID:       accountID,
+		PayoutAccountID: payoutID,
+		Principal:       principal,
+		Rate:            rate,
+		TermMonths:      months,
+		StartDate:       start,
+		MaturityDate:    maturity,
+		MaturityValue:   principal.Add(interest),
+		Instruction:     instruction,
+		Status:          domain.TermDepositStatusActive,
+	}
+}
+
+// close ends the deposit, empties its account into the payout account and
+// closes it.
+func (uc *TermDepositUseCase) close(ctx context.Context, repos *repository.Repositories, deposit *domain.TermDeposit, status domain.TermDepositStatus, account, payout *domain.Account, description string) error {
+	if err := uc.setStatus(ctx, repos, deposit, status); err != nil {
+		return err
+	}
+
+	if account.Balance.IsPositive() {
+		if err := moveFunds(ctx, repos, account, payout, account.Balance, description); err != nil {
+			return err
+		}
+	}
+
+	closedAt := uc.now()
+	account.Status = domain.AccountStatusClosed
+	account.StatusChangedAt = &closedAt
+	return repos.Accounts.UpdateStatus(ctx, account)
+}
+
+func (uc *TermDepositUseCase) setStatus(ctx context.Context, repos *repository.Repositories, deposit *domain.TermDeposit, status domain.TermDepositStatus) error {
+	closedAt := uc.now()
+	deposit.Status = status
+	deposit.ClosedAt = &closedAt
+	return repos.TermDeposits.UpdateStatus(ctx, deposit)
+}
+
+// accrueTerm records daily interest at the deposit's rate on its principal
+// up to the day before until, or maturity if that comes first.
+func accrueTerm(ctx context.Context, repos *repository.Repositories, deposit *domain.TermDeposit, dayCount domain.DayCountConvention, until time.Time) error {
+	if until.After(deposit.MaturityDate) {
+		until = deposit.MaturityDate
+	}
+	annual := deposit.Principal.Mul(deposit.Rate)
+	return accrueDays(ctx, repos, deposit.AccountID, deposit.Principal, annual, dayCount, deposit.StartDate, until)
+}
+
+// payInterest credits a deposit's interest and marks its accruals before
+// before as paid by it.
+func (uc *TermDepositUseCase) payInterest(ctx context.Context, repos *repository.Repositories, account *domain.Account, amount decimal.Decimal, before time.Time, description string) error {
+	accruals, err := repos.Interest.GetUncapitalized(ctx, account.ID, before)
+	if err != nil {
+		return err
+	}
+
+	var transactionID *uuid.UUID
+	if amount.IsPositive() {
+		interest := newCashTransaction(domain.TransactionTypeInterest, account, amount, description)
+		interest.ToAccountID = &account.ID
+		if err := repos.Transactions.Create(ctx, interest); err != nil {
+			return err
+		}
+		account.Balance = account.Balance.Add(amount)
+		if err := repos.Accounts.UpdateBalances(ctx, account); err != nil {
+			return err
+		}
+		transactionID = &interest.ID
+	}
+
+	if len(accruals) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(accruals))
+	for i, accrual := range accruals {
+		ids[i] = accrual.ID
+	}
+	return repos.Interest.MarkCapitalized(ctx, ids, transactionID, uc.now())
+}
+
+// moveFunds posts a completed transfer between two locked accounts.
+func moveFunds(ctx context.Context, repos *repository.Repositories, from, to *domain.Account, amount decimal.Decimal, description string) error {
+	transaction := newCashTransaction(domain.TransactionTypeTransfer, from, amount, description)
+	transaction.FromAccountID = &from.ID
+	transaction.ToAccountID = &to.ID
+	if err := repos.Transactions.Create(ctx, transaction); err != nil {
+		return err
+	}
+
+	from.Balance = from.Balance.Sub(amount)
+	to.Balance = to.Balance.Add(amount)
+	if err := repos.Accounts.UpdateBalances(ctx, from); err != nil {
+		return err
+	}
+	return repos.Accounts.UpdateBalances(ctx, to)
+}
+
+// checkUnlocked stops money leaving an account while a term deposit holds it;
+// it only leaves at maturity or when the term is broken. The account type is
+// not consulted, since it can be changed while the deposit is running.
+func checkUnlocked(ctx context.Context, repos *repository.Repositories, account *domain.Account) error {
+	deposit, err := repos.TermDeposits.GetActiveByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	if deposit != nil {
+		return ErrFundsLocked
+	}
+	return nil
+}
\ No newline at end of file
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func (env *testEnv) openTermDeposit(t *testing.T, req *domain.OpenTermDepositRequest, user *domain.User) *domain.TermDeposit {
+	t.Helper()
+
+	deposit, err := env.termDeposits.Open(context.Background(), user.ID, req)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return deposit
+}
+
+func TestOpenTermDeposit(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.clock.now = date(2025, time.January, 31).Add(10 * time.Hour)
+
+	alice := env.newUser(t, "Alice Smith")
+	bob := env.newUser(t, "Bob Jones")
+	checking := env.newAccount(t, alice.ID, 5000)
+	bobs := env.newAccount(t, bob.ID, 0)
+
+	open := func(amount int64, months int, payout string) *domain.OpenTermDepositRequest {
+		return &domain.OpenTermDepositRequest{
+			SourceAccountID:     checking.ID.String(),
+			Amount:              decimal.NewFromInt(amount),
+			TermMonths:          months,
+			MaturityInstruction: domain.MaturityPayout,
+			PayoutAccountID:     payout,
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		req  *domain.OpenTermDepositRequest
+		want error
+	}{
+		{"term not offered", open(2000, 7, ""), ErrTermNotOffered},
+		{"below minimum", open(500, 3, ""), ErrBelowMinimumDeposit},
+		{"insufficient balance", open(6000, 3, ""), ErrInsufficientBalance},
+		{"someone else's payout account", open(2000, 3, bobs.ID.String()), ErrUnauthorized},
+	} {
+		if _, err := env.termDeposits.Open(ctx, alice.ID, tt.req); err != tt.want {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	env.assertBalances(t, checking.ID, 5000, 0)
+
+	// Three months from the 31st of January ends on the last day of April:
+	// 89 days at 3% on 2,000
+	deposit := env.openTermDeposit(t, open(2000, 3, ""), alice)
+	if !deposit.StartDate.Equal(date(2025, time.January, 31)) || !deposit.MaturityDate.Equal(date(2025, time.April, 30)) {
+		t.Errorf("term %s to %s, want 2025-01-31 to 2025-04-30", deposit.StartDate, deposit.MaturityDate)
+	}
+	if !deposit.Rate.Equal(decimal.RequireFromString("0.03")) || !deposit.MaturityValue.Equal(decimal.RequireFromString("2014.63")) {
+		t.Errorf("%s at %s, want 2014.63 at 0.03", deposit.MaturityValue, deposit.Rate)
+	}
+	if deposit.PayoutAccountID != checking.ID || deposit.Status != domain.TermDepositStatusActive {
+		t.Errorf("deposit %+v, want active paying out to the source account", deposit)
+	}
+	env.assertBalances(t, checking.ID, 3000, 0)
+	env.assertBalances(t, deposit.AccountID, 2000, 0)
+	if account := env.account(t, deposit.AccountID); account.AccountType != domain.AccountTypeDeposit || account.UserID != alice.ID {
+		t.Errorf("deposit account %+v, want one of Alice's deposit accounts", account)
+	}
+
+	// Nothing can leave the deposit account before maturity
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{
+		FromAccountID: deposit.AccountID.String(),
+		ToAccountID:   checking.ID.String(),
+		Amount:        decimal.NewFromInt(100),
+	}); err != ErrFundsLocked {
+		t.Errorf("transfer out: error %v, want %v", err, ErrFundsLocked)
+	}
+	if _, err := env.holds.AuthorizeHold(ctx, alice.ID, &domain.AuthorizeHoldRequest{
+		AccountID: deposit.AccountID.String(),
+		Amount:    decimal.NewFromInt(100),
+	}); err != ErrFundsLocked {
+		t.Errorf("hold: error %v, want %v", err, ErrFundsLocked)
+	}
+	if _, err := env.transactions.Withdraw(ctx, &domain.WithdrawalRequest{
+		AccountID: deposit.AccountID.String(),
+		Amount:    decimal.NewFromInt(100),
+	}); err != ErrFundsLocked {
+		t.Errorf("withdrawal: error %v, want %v", err, ErrFundsLocked)
+	}
+	env.assertBalances(t, deposit.AccountID, 2000, 0)
+
+	// Nor once the deposit account has been turned into a checking account
+	checkingType := domain.AccountTypeChecking
+	if _, err := env.accounts.UpdateAccount(ctx, alice.ID, deposit.AccountID, &domain.UpdateAccountRequest{
+		AccountType: &checkingType,
+	}); err != nil {
+		t.Fatalf("UpdateAccount: %v", err)
+	}
+	if _, err := env.transactions.Transfer(ctx, alice.ID, &domain.TransferRequest{
+		FromAccountID: deposit.AccountID.String(),
+		ToAccountID:   checking.ID.String(),
+		Amount:        decimal.NewFromInt(100),
+	}); err != ErrFundsLocked {
+		t.Errorf("transfer out after changing the type: error %v, want %v", err, ErrFundsLocked)
+	}
+	env.assertBalances(t, deposit.AccountID, 2000, 0)
+
+	if _, err := env.termDeposits.GetTermDeposit(ctx, bob.ID, deposit.ID); err != ErrUnauthorized {
+		t.Errorf("GetTermDeposit by another user: error %v, want %v", err, ErrUnauthorized)
+	}
+}
+
+func TestTermDepositMaturity(t *testing.T) {
+	for _, instruction := range []domain.MaturityInstruction{domain.MaturityPayout, domain.MaturityRollover} {
+		t.Run(string(instruction), func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			env.clock.now = date(2025, time.January, 31).Add(10 * time.Hour)
+
+			user := env.newUser(t, "Alice Smith")
+			checking := env.newAccount(t, user.ID, 5000)
+			deposit := env.openTermDeposit(t, &domain.OpenTermDepositRequest{
+				SourceAccountID:     checking.ID.String(),
+				Amount:              decimal.NewFromInt(2000),
+				TermMonths:          3,
+				MaturityInstruction: instruction,
+			}, user)
+
+			// Midway the term rate accrues daily but nothing is paid
+			env.clock.now = date(2025, time.March, 1).Add(time.Hour)
+			if err := env.interest.AccrueInterest(ctx); err != nil {
+				t.Fatalf("AccrueInterest: %v", err)
+			}
+			interest, err := env.interest.GetAccountInterest(ctx, user.ID, deposit.AccountID)
+			if err != nil {
+				t.Fatalf("GetAccountInterest: %v", err)
+			}
+			if len(interest.Accruals) != 29 || !interest.Accruals[0].Rate.Equal(deposit.Rate) {
+				t.Fatalf("%d accruals, want 29 at %s", len(interest.Accruals), deposit.Rate)
+			}
+			env.assertBalances(t, deposit.AccountID, 2000, 0)
+
+			// Not yet due
+			if err := env.termDeposits.ProcessMaturities(ctx); err != nil {
+				t.Fatalf("ProcessMaturities: %v", err)
+			}
+			env.assertBalances(t, deposit.AccountID, 2000, 0)
+
+			env.clock.now = date(2025, time.April, 30).Add(time.Hour)
+			for i := 0; i < 2; i++ {
+				if err := env.termDeposits.ProcessMaturities(ctx); err != nil {
+					t.Fatalf("ProcessMaturities: %v", err)
+				}
+			}
+
+			matured, err := env.termDeposits.GetTermDeposit(ctx, user.ID, deposit.ID)
+			if err != nil {
+				t.Fatalf("GetTermDeposit: %v", err)
+			}
+			interest, err = env.interest.GetAccountInterest(ctx, user.ID, deposit.AccountID)
+			if err != nil {
+				t.Fatalf("GetAccountInterest: %v", err)
+			}
+			if len(interest.Accruals) != 89 || !interest.Accrued.IsZero() {
+				t.Errorf("%d accruals with %s unpaid, want 89 all paid", len(interest.Accruals), interest.Accrued)
+			}
+
+			deposits, err := env.termDeposits.GetUserTermDeposits(ctx, user.ID)
+			if err != nil {
+				t.Fatalf("GetUserTermDeposits: %v", err)
+			}
+
+			switch instruction {
+			case domain.MaturityPayout:
+				if matured.Status != domain.TermDepositStatusMatured || len(deposits) != 1 {
+					t.Errorf("status %s with %d deposits, want matured and 1", matured.Status, len(deposits))
+				}
+				if got := env.account(t, checking.ID).Balance; !got.Equal(decimal.RequireFromString("5014.63")) {
+					t.Errorf("checking balance %s, want 5014.63", got)
+				}
+				if account := env.account(t, deposit.AccountID); !account.Balance.IsZero() || account.Status != domain.AccountStatusClosed {
+					t.Errorf("deposit account %s with %s, want closed and empty", account.Status, account.Balance)
+				}
+
+			case domain.MaturityRollover:
+				if matured.Status != domain.TermDepositStatusRolledOver || len(deposits) != 2 {
+					t.Fatalf("status %s with %d deposits, want rolled_over and 2", matured.Status, len(deposits))
+				}
+				next := deposits[0]
+				if next.ID == deposit.ID {
+					next = deposits[1]
+				}
+				if next.Status != domain.TermDepositStatusActive || !next.Principal.Equal(decimal.RequireFromString("2014.63")) ||
+					!next.StartDate.Equal(date(2025, time.April, 30)) || next.AccountID != deposit.AccountID {
+					t.Errorf("next term %+v, want 2014.63 from 2025-04-30 on the same account", next)
+				}
+				if got := env.account(t, deposit.AccountID).Balance; !got.Equal(next.Principal) {
+					t.Errorf("deposit account balance %s, want %s", got, next.Principal)
+				}
+				env.assertBalances(t, checking.ID, 3000, 0)
+			}
+		})
+	}
+}
+
+func TestBreakTermDeposit(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.clock.now = date(2025, time.January, 31).Add(10 * time.Hour)
+
+	user := env.newUser(t, "Alice Smith")
+	other := env.newUser(t, "Bob Jones")
+	checking := env.newAccount(t, user.ID, 10000)
+	savings := env.newTypedAccount(t, user.ID, domain.AccountTypeSavings, 0)
+	deposit := env.openTermDeposit(t, &domain.OpenTermDepositRequest{
+		SourceAccountID:     checking.ID.String(),
+		Amount:              decimal.NewFromInt(10000),
+		TermMonths:          12,
+		MaturityInstruction: domain.MaturityRollover,
+		PayoutAccountID:     savings.ID.String(),
+	}, user)
+
+	if _, err := env.termDeposits.Break(ctx, other.ID, deposit.ID); err != ErrUnauthorized {
+		t.Errorf("break by another user: error %v, want %v", err, ErrUnauthorized)
+	}
+
+	// 30 days at the 0.5% break rate instead of 4%, less the 1% fee
+	env.clock.now = date(2025, time.March, 2).Add(time.Hour)
+	result, err := env.termDeposits.Break(ctx, user.ID, deposit.ID)
+	if err != nil {
+		t.Fatalf("Break: %v", err)
+	}
+	if !result.Interest.Equal(decimal.RequireFromString("4.11")) || !result.Penalty.Equal(decimal.NewFromInt(100)) ||
+		!result.Payout.Equal(decimal.RequireFromString("9904.11")) {
+		t.Errorf("interest %s, penalty %s, payout %s; want 4.11, 100, 9904.11", result.Interest, result.Penalty, result.Payout)
+	}
+	if result.TermDeposit.Status != domain.TermDepositStatusBroken {
+		t.Errorf("status %s, want broken", result.TermDeposit.Status)
+	}
+
+	if got := env.account(t, savings.ID).Balance; !got.Equal(result.Payout) {
+		t.Errorf("payout account balance %s, want %s", got, result.Payout)
+	}
+	env.assertBalances(t, checking.ID, 0, 0)
+	if account := env.account(t, deposit.AccountID); !account.Balance.IsZero() || account.Status != domain.AccountStatusClosed {
+		t.Errorf("deposit account %s with %s, want closed and empty", account.Status, account.Balance)
+	}
+
+	transactions, err := env.store.Transactions().GetByAccountID(ctx, deposit.AccountID, &domain.TransactionFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetByAccountID: %v", err)
+	}
+	fees := 0
+	for _, tx := range transactions {
+		if tx.Type == domain.TransactionTypeFee {
+			fees++
+		}
+	}
+	if len(transactions) != 4 || fees != 1 {
+		t.Errorf("%d transactions with %d fees, want funding, interest, fee and payout", len(transactions), fees)
+	}
+
+	if _, err := env.termDeposits.Break(ctx, user.ID, deposit.ID); err != ErrTermDepositNotActive {
+		t.Errorf("second break: error %v, want %v", err, ErrTermDepositNotActive)
+	}
+	// The closed account no longer accrues
+	if err := env.interest.AccrueInterest(ctx); err != nil {
+		t.Fatalf("AccrueInterest: %v", err)
+	}
+	interest, err := env.interest.GetAccountInterest(ctx, user.ID, deposit.AccountID)
+	if err != nil || len(interest.Accruals) != 30 || !interest.Accrued.IsZero() {
+		t.Errorf("interest %+v, %v; want 30 paid accruals", interest, err)
+	}
+}
\ No newline at end of file
@@ -2,40 +2,106 @@ package usecase
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
+	"github.com/nabiilNajm26/go-bank/internal/accountnumber"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/pkg/utils"
 	"github.com/shopspring/decimal"
 )
 
 var (
-	ErrSameAccount = errors.New("cannot transfer to same account")
-	ErrInvalidAmount = errors.New("invalid transfer amount")
+	ErrSameAccount = apperror.BadRequest("same_account", "cannot transfer to same account")
+	ErrInvalidAmount = apperror.BadRequest("invalid_amount", "invalid transfer amount")
+	ErrInvalidDestination = apperror.BadRequest("invalid_destination", "exactly one of to_account_id, to_account_number or payee_id is required")
+	ErrBeneficiaryNameMismatch = apperror.Unprocessable("beneficiary_name_mismatch", "beneficiary name does not match account holder")
+	ErrTransferBlocked = apperror.Forbidden("transfer_blocked", "transfer blocked by risk screening")
+	ErrReviewNotFound = apperror.NotFound("review_not_found", "review not found")
+	ErrReviewAlreadyDecided = apperror.Conflict("review_already_decided", "review has already been decided")
+	ErrTransactionNotFound = apperror.NotFound("transaction_not_found", "transaction not found")
+	ErrApprovalRequired = apperror.Unprocessable("approval_required", "transfers of this amount out of the account need its members' approval")
+	ErrPaymentDraftRequired = apperror.Unprocessable("payment_draft_required", "payments out of a business account have to be drafted and approved")
+	// ErrConcurrentUpdate means the accounts were too busy for the work to
+	// commit even after retrying; the caller can safely try again.
+	ErrConcurrentUpdate = repository.ErrConflict
 )
 
+// RiskEvaluator screens a transfer before it is committed. A review decision
+// leaves the transfer pending with the funds held on the sender's account
+// until an operator approves or rejects it; block rejects it outright.
+type RiskEvaluator interface {
+	Evaluate(ctx context.Context, input *domain.RiskInput) (*domain.RiskAssessment, error)
+}
+
 type TransactionUseCase struct {
 	transactionRepo repository.TransactionRepository
 	accountRepo     repository.AccountRepository
-	db              *sqlx.DB
+	memberRepo      repository.AccountMemberRepository
+	payeeRepo       repository.PayeeRepository
+	userRepo        repository.UserRepository
+	reviewRepo      repository.ReviewRepository
+	deviceRepo      repository.DeviceRepository
+	limitUseCase    *LimitUseCase
+	fees            *FeeUseCase
+	pockets         *PocketUseCase
+	riskEvaluator   RiskEvaluator
+	screening       *ScreeningUseCase
+	uow             repository.UnitOfWork
+	numbers         accountnumber.Scheme
 }
 
-func NewTransactionUseCase(transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository, db *sqlx.DB) *TransactionUseCase {
+func NewTransactionUseCase(transactionRepo repository.TransactionRepository, accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, payeeRepo repository.PayeeRepository, userRepo repository.UserRepository, reviewRepo repository.ReviewRepository, deviceRepo repository.DeviceRepository, limitUseCase *LimitUseCase, fees *FeeUseCase, pockets *PocketUseCase, riskEvaluator RiskEvaluator, screening *ScreeningUseCase, uow repository.UnitOfWork, numbers accountnumber.Scheme) *TransactionUseCase {
+	if numbers == nil {
+		numbers = accountnumber.Default()
+	}
 	return &TransactionUseCase{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
-		db:              db,
+		memberRepo:      memberRepo,
+		payeeRepo:       payeeRepo,
+		userRepo:        userRepo,
+		reviewRepo:      reviewRepo,
+		deviceRepo:      deviceRepo,
+		limitUseCase:    limitUseCase,
+		fees:            fees,
+		pockets:         pockets,
+		riskEvaluator:   riskEvaluator,
+		screening:       screening,
+		uow:             uow,
+		numbers:         numbers,
 	}
 }
 
-func (uc *TransactionUseCase) Transfer(ctx context.Context, req *domain.TransferRequest) (*domain.Transaction, error) {
-	fromAccountID, _ := uuid.Parse(req.FromAccountID)
-	toAccountID, _ := uuid.Parse(req.ToAccountID)
+// Transfer moves money out of an account the user can transact on. Transfers
+// the account's approval policy covers have to go through a TransferApproval.
+func (uc *TransactionUseCase) Transfer(ctx context.Context, userID uuid.UUID, req *domain.TransferRequest) (*domain.Transaction, error) {
+	return uc.transfer(ctx, userID, req, nil)
+}
+
+// approvedFunc marks whatever approved a transfer as made by transaction. It
+// runs in the transfer's unit of work, so the two commit together.
+type approvedFunc func(ctx context.Context, repos *repository.Repositories, transaction *domain.Transaction) error
+
+// transfer makes the transfer. Approved ones, which pass approved, have
+// already been approved by enough of the account's members, or of its
+// organization's for a business account.
+func (uc *TransactionUseCase) transfer(ctx context.Context, userID uuid.UUID, req *domain.TransferRequest, approved approvedFunc) (*domain.Transaction, error) {
+	fromAccountID, err := uuid.Parse(req.FromAccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+
+	toAccountID, payee, holderName, err := uc.resolveDestination(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
 
 	if fromAccountID == toAccountID {
 		return nil, ErrSameAccount
@@ -45,85 +111,619 @@ func (uc *TransactionUseCase) Transfer(ctx context.Context, req *domain.Transfer
 		return nil, ErrInvalidAmount
 	}
 
-	tx, err := uc.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	var (
+		transaction   *domain.Transaction
+		held          bool
+		screening     *domain.ScreeningResult
+		recipientName string
+	)
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		fromAccount, toAccount, err := lockAccounts(ctx, repos, fromAccountID, toAccountID)
+		if err != nil {
+			return err
+		}
+		currency, value, err := uc.transferValue(ctx, fromAccount, req)
+		if err != nil {
+			return err
+		}
+
+		// Business accounts only pay out through payment drafts, which
+		// check the organization's members themselves
+		if fromAccount.OrganizationID != nil {
+			if approved == nil {
+				return ErrPaymentDraftRequired
+			}
+		} else {
+			// Viewers can't move money out of an account
+			if err := authorizeMember(ctx, repos.AccountMembers, fromAccountID, userID, domain.AccountRole.CanTransact); err != nil {
+				return err
+			}
+			if approved == nil {
+				policy, err := repos.AccountMembers.GetApprovalPolicy(ctx, fromAccountID)
+				if err != nil {
+					return err
+				}
+				if policy.Requires(value) {
+					return ErrApprovalRequired
+				}
+			}
+		}
+
+		if err := checkDebit(fromAccount); err != nil {
+			return err
+		}
+		if err := checkCredit(toAccount); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, fromAccount); err != nil {
+			return err
+		}
+
+		// Fees are paid from the account's own balance, even on transfers
+		// out of a pocket
+		var fees []feeLine
+		if uc.fees != nil {
+			fees, err = uc.fees.transferFees(ctx, repos, fromAccount, toAccount, currency, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Funds already reserved by holds can't be spent again; an arranged
+		// overdraft can be, but only in the account's own currency
+		if currency == fromAccount.Currency {
+			if fromAccount.SpendableBalance().LessThan(req.Amount.Add(feeTotal(fees))) {
+				return ErrInsufficientBalance
+			}
+		} else {
+			available, err := availableIn(ctx, repos, fromAccount, currency)
+			if err != nil {
+				return err
+			}
+			if available.LessThan(req.Amount) || fromAccount.SpendableBalance().LessThan(feeTotal(fees)) {
+				return ErrInsufficientBalance
+			}
+		}
+
+		// Limits are checked against usage read in this unit of work, so of
+		// two concurrent transfers that only fit the daily headroom one at a
+		// time, even from different accounts of the same owner, one ends up
+		// seeing the other's outflow
+		if uc.limitUseCase != nil {
+			if err := uc.limitUseCase.CheckTransfer(ctx, repos, fromAccount, value); err != nil {
+				return err
+			}
+		}
+
+		// Risk screening
+		assessment, err := uc.assessRisk(ctx, repos, userID, fromAccount, toAccount, payee, req, value)
+		if err != nil {
+			return err
+		}
+
+		// Sanctions screening of the recipient. A possible match is held for
+		// review like any other risk hit; alerts are only recorded once the
+		// unit of work is done, since it may be run more than once.
+		screening, recipientName, err = uc.screenRecipient(ctx, repos, toAccount)
+		if err != nil {
+			return err
+		}
+		if screening.Decision.Severity() > assessment.Decision.Severity() {
+			assessment.Decision = screening.Decision
+		}
+		if screening.Decision != domain.RiskDecisionAllow {
+			assessment.Reasons = append(assessment.Reasons, "recipient matches sanctions watchlist")
+		}
+
+		if assessment.Decision == domain.RiskDecisionBlock {
+			return ErrTransferBlocked
+		}
+		held = assessment.Decision == domain.RiskDecisionReview
+
+		// Create transaction record
+		transaction = &domain.Transaction{
+			FromAccountID: &fromAccountID,
+			ToAccountID:   &toAccountID,
+			Amount:        req.Amount,
+			Currency:      currency,
+			Type:          domain.TransactionTypeTransfer,
+			Status:        domain.TransactionStatusCompleted,
+			Reference:     generateReference(),
+			Description:   &req.Description,
+			Metadata:      transferMetadata(userID, req, assessment),
+		}
+		if currency != fromAccount.Currency {
+			transaction.Value = &value
+		}
+
+		if held {
+			transaction.Status = domain.TransactionStatusPending
+		} else {
+			completedAt := time.Now()
+			transaction.CompletedAt = &completedAt
+		}
+
+		if err := repos.Transactions.Create(ctx, transaction); err != nil {
+			return err
+		}
+		if approved != nil {
+			if err := approved(ctx, repos, transaction); err != nil {
+				return err
+			}
+		}
+
+		if held {
+			// A held transfer only reserves the funds; nothing moves until
+			// an operator approves it. Review holds don't expire.
+			if _, err := placeHold(ctx, repos, fromAccount, transaction, nil); err != nil {
+				return err
+			}
+
+			return repos.Reviews.Create(ctx, &domain.TransactionReview{
+				TransactionID: transaction.ID,
+				Reasons:       assessment.Reasons,
+				Status:        domain.ReviewStatusPending,
+			})
+		}
+
+		// The recipient is paid in the currency sent, into a pocket if it
+		// isn't their account's own
+		if err := adjustBalances(ctx, repos, fromAccount, currency, req.Amount.Neg(), decimal.Zero); err != nil {
+			return err
+		}
+		if err := adjustBalances(ctx, repos, toAccount, currency, req.Amount, decimal.Zero); err != nil {
+			return err
+		}
+
+		transaction.Fees, err = chargeFees(ctx, repos, fromAccount, fees, transaction, feeReference(transaction))
+		if err != nil {
+			return err
+		}
+		if currency != fromAccount.Currency {
+			return nil
+		}
+		return roundUp(ctx, repos, fromAccount, transaction)
+	})
+	if errors.Is(err, ErrTransferBlocked) && screening != nil {
+		if err := uc.screening.Block(ctx, domain.ScreeningSubjectTransfer, recipientName, screening); err != nil {
+			log.Printf("Failed to record screening alert for blocked transfer to %s: %v", toAccountID, err)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
-	// Lock and get from account
-	var fromAccount domain.Account
-	err = tx.Get(&fromAccount, "SELECT * FROM accounts WHERE id = $1 FOR UPDATE", fromAccountID)
+	transaction.MaskedHolderName = holderName
+
+	if held {
+		if err := uc.screening.Flag(ctx, domain.ScreeningSubjectTransfer, transaction.ID, recipientName, screening); err != nil {
+			log.Printf("Failed to record screening alert for transaction %s: %v", transaction.ID, err)
+		}
+	}
+
+	// A device becomes trusted once it has completed a transfer
+	if !held && req.DeviceID != "" && uc.deviceRepo != nil {
+		if err := uc.deviceRepo.Touch(ctx, userID, req.DeviceID); err != nil {
+			log.Printf("Failed to record device for user %s: %v", userID, err)
+		}
+	}
+
+	return transaction, nil
+}
+
+// transferValue is the currency the transfer is paid in and its value in
+// the from account's own currency, which it is priced, limited and screened
+// at.
+func (uc *TransactionUseCase) transferValue(ctx context.Context, fromAccount *domain.Account, req *domain.TransferRequest) (string, decimal.Decimal, error) {
+	if req.FromCurrency == "" || req.FromCurrency == fromAccount.Currency {
+		return fromAccount.Currency, req.Amount, nil
+	}
+	if uc.pockets == nil {
+		return "", decimal.Zero, ErrUnsupportedCurrency
+	}
+
+	value, err := uc.pockets.value(ctx, req.Amount, req.FromCurrency, fromAccount.Currency)
 	if err != nil {
-		return nil, err
+		return "", decimal.Zero, err
+	}
+	return req.FromCurrency, value, nil
+}
+
+func (uc *TransactionUseCase) assessRisk(ctx context.Context, repos *repository.Repositories, userID uuid.UUID, fromAccount, toAccount *domain.Account, payee *domain.Payee, req *domain.TransferRequest, value decimal.Decimal) (*domain.RiskAssessment, error) {
+	if uc.riskEvaluator == nil {
+		return &domain.RiskAssessment{Decision: domain.RiskDecisionAllow}, nil
+	}
+
+	input := &domain.RiskInput{
+		UserID:      userID,
+		FromAccount: fromAccount,
+		ToAccount:   toAccount,
+		Amount:      value,
+		DeviceID:    req.DeviceID,
+		At:          time.Now(),
+	}
+	if payee != nil {
+		input.PayeeCreatedAt = &payee.CreatedAt
+	}
+	if uc.limitUseCase != nil {
+		limit, err := uc.limitUseCase.PerTransactionLimit(ctx, repos, fromAccount)
+		if err != nil {
+			return nil, err
+		}
+		input.PerTransactionLimit = limit
+	}
+
+	return uc.riskEvaluator.Evaluate(ctx, input)
+}
+
+func (uc *TransactionUseCase) screenRecipient(ctx context.Context, repos *repository.Repositories, toAccount *domain.Account) (*domain.ScreeningResult, string, error) {
+	if uc.screening == nil {
+		return &domain.ScreeningResult{Decision: domain.RiskDecisionAllow}, "", nil
 	}
 
-	// Lock and get to account
-	var toAccount domain.Account
-	err = tx.Get(&toAccount, "SELECT * FROM accounts WHERE id = $1 FOR UPDATE", toAccountID)
+	recipient, err := repos.Users.GetByID(ctx, toAccount.UserID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if recipient == nil {
+		return nil, "", ErrAccountNotFound
+	}
+
+	return uc.screening.Screen(recipient.FullName), recipient.FullName, nil
+}
+
+// transferMetadata records the device the transfer was made from, with the
+// user it belongs to so a held transfer can trust it once approved, and why
+// risk screening flagged it.
+func transferMetadata(userID uuid.UUID, req *domain.TransferRequest, assessment *domain.RiskAssessment) domain.Metadata {
+	metadata := domain.Metadata{}
+	if req.DeviceID != "" {
+		metadata["device_id"] = req.DeviceID
+		metadata["device_user_id"] = userID.String()
+	}
+	if len(assessment.Reasons) > 0 {
+		metadata["risk_decision"] = assessment.Decision
+		metadata["risk_reasons"] = assessment.Reasons
+	}
+	if len(metadata) == 0 {
+		return nil
 	}
+	return metadata
+}
 
-	// Check balance
-	if fromAccount.Balance.LessThan(req.Amount) {
-		return nil, ErrInsufficientBalance
+// Deposit credits money paid in from outside the bank.
+func (uc *TransactionUseCase) Deposit(ctx context.Context, req *domain.DepositRequest) (*domain.Transaction, error) {
+	accountID, err := uuid.Parse(req.AccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
 	}
 
-	// Update balances
-	fromAccount.Balance = fromAccount.Balance.Sub(req.Amount)
-	toAccount.Balance = toAccount.Balance.Add(req.Amount)
+	var transaction *domain.Transaction
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if err := checkCredit(account); err != nil {
+			return err
+		}
+		currency, err := uc.cashCurrency(ctx, account, req.Currency)
+		if err != nil {
+			return err
+		}
+
+		transaction = newCashTransaction(domain.TransactionTypeDeposit, account, req.Amount, req.Description)
+		transaction.ToAccountID = &accountID
+		transaction.Currency = currency
+		if err := repos.Transactions.Create(ctx, transaction); err != nil {
+			return err
+		}
 
-	_, err = tx.Exec("UPDATE accounts SET balance = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
-		fromAccount.Balance, fromAccount.ID)
+		if err := adjustBalances(ctx, repos, account, currency, req.Amount, decimal.Zero); err != nil {
+			return err
+		}
+		// Pots only sweep deposits in the account's own currency
+		if currency != account.Currency {
+			return nil
+		}
+		return sweepDeposit(ctx, repos, account, transaction)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = tx.Exec("UPDATE accounts SET balance = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
-		toAccount.Balance, toAccount.ID)
+	return transaction, nil
+}
+
+// Withdraw debits money paid out to outside the bank. Unlike a transfer it
+// can only spend the available balance: cash and payments out of the bank
+// deliberately can't be drawn from an arranged overdraft.
+func (uc *TransactionUseCase) Withdraw(ctx context.Context, req *domain.WithdrawalRequest) (*domain.Transaction, error) {
+	accountID, err := uuid.Parse(req.AccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+
+	var transaction *domain.Transaction
+	err = uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		account, err := lockAccount(ctx, repos, accountID)
+		if err != nil {
+			return err
+		}
+		if err := checkDebit(account); err != nil {
+			return err
+		}
+		if err := checkUnlocked(ctx, repos, account); err != nil {
+			return err
+		}
+		currency, err := uc.cashCurrency(ctx, account, req.Currency)
+		if err != nil {
+			return err
+		}
+		available, err := availableIn(ctx, repos, account, currency)
+		if err != nil {
+			return err
+		}
+		if available.LessThan(req.Amount) {
+			return ErrInsufficientBalance
+		}
+
+		transaction = newCashTransaction(domain.TransactionTypeWithdrawal, account, req.Amount, req.Description)
+		transaction.FromAccountID = &accountID
+		transaction.Currency = currency
+		if currency != account.Currency {
+			value, err := uc.pockets.value(ctx, req.Amount, currency, account.Currency)
+			if err != nil {
+				return err
+			}
+			transaction.Value = &value
+		}
+		if err := repos.Transactions.Create(ctx, transaction); err != nil {
+			return err
+		}
+
+		return adjustBalances(ctx, repos, account, currency, req.Amount.Neg(), decimal.Zero)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Create transaction record
-	transaction := &domain.Transaction{
-		ID:            uuid.New(),
-		FromAccountID: &fromAccountID,
-		ToAccountID:   &toAccountID,
-		Amount:        req.Amount,
-		Currency:      fromAccount.Currency,
-		Type:          domain.TransactionTypeTransfer,
-		Status:        domain.TransactionStatusCompleted,
-		Reference:     uc.generateReference(),
-		Description:   &req.Description,
-		CreatedAt:     time.Now(),
+	return transaction, nil
+}
+
+// cashCurrency is the currency a deposit or withdrawal is in: the account's
+// own unless the request names one it can hold a pocket in.
+func (uc *TransactionUseCase) cashCurrency(ctx context.Context, account *domain.Account, currency string) (string, error) {
+	if currency == "" || currency == account.Currency {
+		return account.Currency, nil
 	}
+	if uc.pockets == nil {
+		return "", ErrUnsupportedCurrency
+	}
+	if err := uc.pockets.checkSupported(ctx, currency, account.Currency); err != nil {
+		return "", err
+	}
+	return currency, nil
+}
 
+func newCashTransaction(transactionType domain.TransactionType, account *domain.Account, amount decimal.Decimal, description string) *domain.Transaction {
 	completedAt := time.Now()
-	transaction.CompletedAt = &completedAt
-
-	_, err = tx.Exec(`
-		INSERT INTO transactions (id, from_account_id, to_account_id, amount, currency, type, status, reference, description, created_at, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
-		transaction.ID, transaction.FromAccountID, transaction.ToAccountID, transaction.Amount,
-		transaction.Currency, transaction.Type, transaction.Status, transaction.Reference,
-		transaction.Description, transaction.CreatedAt, transaction.CompletedAt)
+	transaction := &domain.Transaction{
+		Amount:      amount,
+		Currency:    account.Currency,
+		Type:        transactionType,
+		Status:      domain.TransactionStatusCompleted,
+		Reference:   generateReference(),
+		CompletedAt: &completedAt,
+	}
+	if description != "" {
+		transaction.Description = &description
+	}
+	return transaction
+}
+
+func (uc *TransactionUseCase) GetPendingReviews(ctx context.Context, limit, offset int) ([]*domain.TransactionReview, error) {
+	return uc.reviewRepo.GetPending(ctx, limit, offset)
+}
+
+// ApproveReview completes a held transfer by capturing its hold.
+func (uc *TransactionUseCase) ApproveReview(ctx context.Context, operatorID, reviewID uuid.UUID, note string) (*domain.Transaction, error) {
+	return uc.decideReview(ctx, operatorID, reviewID, note, true)
+}
+
+// RejectReview fails a held transfer and releases its hold.
+func (uc *TransactionUseCase) RejectReview(ctx context.Context, operatorID, reviewID uuid.UUID, note string) (*domain.Transaction, error) {
+	return uc.decideReview(ctx, operatorID, reviewID, note, false)
+}
+
+func (uc *TransactionUseCase) decideReview(ctx context.Context, operatorID, reviewID uuid.UUID, note string, approve bool) (*domain.Transaction, error) {
+	var transaction *domain.Transaction
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		review, err := repos.Reviews.GetByIDForUpdate(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+		if review == nil {
+			return ErrReviewNotFound
+		}
+		if review.Status != domain.ReviewStatusPending {
+			return ErrReviewAlreadyDecided
+		}
+
+		transaction, err = lockTransaction(ctx, repos, review.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		hold, err := repos.Holds.GetByTransactionIDForUpdate(ctx, transaction.ID)
+		if err != nil {
+			return err
+		}
+		if hold == nil {
+			return ErrHoldNotFound
+		}
+
+		// Approval captures the hold and credits the recipient, rejection
+		// releases it back to the sender
+		now := time.Now()
+		if approve {
+			review.Status = domain.ReviewStatusApproved
+			err = captureHold(ctx, repos, hold, transaction, hold.Amount, now)
+			if err == nil {
+				err = uc.chargeApprovedFees(ctx, repos, transaction)
+			}
+			if err == nil {
+				err = roundUpApproved(ctx, repos, transaction)
+			}
+		} else {
+			review.Status = domain.ReviewStatusRejected
+			err = releaseHold(ctx, repos, hold, transaction, domain.HoldStatusVoided, domain.TransactionStatusFailed, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		review.ReviewedBy = &operatorID
+		review.ReviewedAt = &now
+		if note != "" {
+			review.Note = &note
+		}
+		return repos.Reviews.Update(ctx, review)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err = tx.Commit(); err != nil {
-		return nil, err
+	// The device the transfer was made from is trusted once it goes through
+	if approve {
+		uc.touchDevice(ctx, transaction)
 	}
 
 	return transaction, nil
 }
 
-func (uc *TransactionUseCase) GetTransactionHistory(ctx context.Context, accountID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+// touchDevice trusts the device a held transfer was made from.
+func (uc *TransactionUseCase) touchDevice(ctx context.Context, transaction *domain.Transaction) {
+	deviceID, _ := transaction.Metadata["device_id"].(string)
+	userID, _ := transaction.Metadata["device_user_id"].(string)
+	if deviceID == "" || uc.deviceRepo == nil {
+		return
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	if err := uc.deviceRepo.Touch(ctx, id, deviceID); err != nil {
+		log.Printf("Failed to record device for user %s: %v", id, err)
+	}
+}
+
+// chargeApprovedFees charges the fees on a held transfer once it is
+// approved. They weren't reserved with it, so they take no more than the
+// sender has available by then.
+func (uc *TransactionUseCase) chargeApprovedFees(ctx context.Context, repos *repository.Repositories, transaction *domain.Transaction) error {
+	if uc.fees == nil || transaction.FromAccountID == nil || transaction.ToAccountID == nil {
+		return nil
+	}
+
+	fromAccount, toAccount, err := lockAccounts(ctx, repos, *transaction.FromAccountID, *transaction.ToAccountID)
+	if err != nil {
+		return err
+	}
+	value := transaction.Amount
+	if transaction.Currency != fromAccount.Currency && uc.pockets != nil {
+		if value, err = uc.pockets.value(ctx, transaction.Amount, transaction.Currency, fromAccount.Currency); err != nil {
+			return err
+		}
+	}
+	fees, err := uc.fees.transferFees(ctx, repos, fromAccount, toAccount, transaction.Currency, value)
+	if err != nil {
+		return err
+	}
+
+	transaction.Fees, err = chargeFees(ctx, repos, fromAccount, capFees(fees, fromAccount.SpendableBalance()), transaction, feeReference(transaction))
+	return err
+}
+
+// roundUpApproved rounds up a held transfer once an operator has approved
+// it. Transfers out of pockets aren't rounded up.
+func roundUpApproved(ctx context.Context, repos *repository.Repositories, transaction *domain.Transaction) error {
+	if transaction.FromAccountID == nil {
+		return nil
+	}
+
+	account, err := lockAccount(ctx, repos, *transaction.FromAccountID)
+	if err != nil {
+		return err
+	}
+	if transaction.Currency != account.Currency {
+		return nil
+	}
+	return roundUp(ctx, repos, account, transaction)
+}
+
+// resolveDestination turns whichever destination the request names into an
+// account ID, checking payee ownership and the beneficiary name on the way.
+// A destination named by account number or payee also comes with its
+// holder's masked name, as an account lookup shows it.
+func (uc *TransactionUseCase) resolveDestination(ctx context.Context, userID uuid.UUID, req *domain.TransferRequest) (uuid.UUID, *domain.Payee, string, error) {
+	given := 0
+	for _, v := range []string{req.ToAccountID, req.ToAccountNumber, req.PayeeID} {
+		if v != "" {
+			given++
+		}
+	}
+	if given != 1 {
+		return uuid.Nil, nil, "", ErrInvalidDestination
+	}
+
+	switch {
+	case req.PayeeID != "":
+		payeeID, err := uuid.Parse(req.PayeeID)
+		if err != nil {
+			return uuid.Nil, nil, "", ErrPayeeNotFound
+		}
+		payee, err := getOwnedPayee(ctx, uc.payeeRepo, userID, payeeID)
+		if err != nil {
+			return uuid.Nil, nil, "", err
+		}
+		return payee.AccountID, payee, payee.MaskedHolderName, nil
+
+	case req.ToAccountNumber != "":
+		account, holder, err := lookupAccountHolder(ctx, uc.accountRepo, uc.userRepo, uc.numbers, req.ToAccountNumber)
+		if err != nil {
+			return uuid.Nil, nil, "", err
+		}
+		if verifyHolderName(holder, req.BeneficiaryName) == domain.PayeeVerificationMismatch {
+			return uuid.Nil, nil, "", ErrBeneficiaryNameMismatch
+		}
+		return account.ID, nil, utils.MaskName(holder.FullName), nil
+
+	default:
+		toAccountID, err := uuid.Parse(req.ToAccountID)
+		if err != nil {
+			return uuid.Nil, nil, "", ErrAccountNotFound
+		}
+		return toAccountID, nil, "", nil
+	}
+}
+
+func (uc *TransactionUseCase) GetTransactionHistory(ctx context.Context, userID, accountID uuid.UUID, filter *domain.TransactionFilter) ([]*domain.Transaction, error) {
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, domain.AccountRole.CanView); err != nil {
+		return nil, err
+	}
+
 	return uc.transactionRepo.GetByAccountID(ctx, accountID, filter)
 }
 
-func (uc *TransactionUseCase) generateReference() string {
-	return fmt.Sprintf("TXN%d", time.Now().Unix())
+// generateReference returns a unique transaction reference. The random
+// suffix keeps references apart when several are made in the same second.
+func generateReference() string {
+	return fmt.Sprintf("TXN%d%s", time.Now().Unix(), strings.ToUpper(uuid.NewString()[:8]))
 }
\ No newline at end of file
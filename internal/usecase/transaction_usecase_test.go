@@ -0,0 +1,527 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/shopspring/decimal"
+)
+
+// payeeFor saves account as a payee of userID.
+func (env *testEnv) payeeFor(t *testing.T, userID uuid.UUID, account *domain.Account) *domain.Payee {
+	t.Helper()
+
+	payee, err := env.payees.CreatePayee(context.Background(), userID, &domain.CreatePayeeRequest{
+		AccountNumber: account.AccountNumber,
+		Nickname:      "Bob",
+	})
+	if err != nil {
+		t.Fatalf("CreatePayee: %v", err)
+	}
+	return payee
+}
+
+func TestTransfer(t *testing.T) {
+	tests := []struct {
+		name string
+		// req fills in the destination and anything else the case needs; the
+		// from account and an amount of 40 are set beforehand.
+		req        func(env *testEnv, from, to *domain.Account) *domain.TransferRequest
+		wantErr    error
+		wantFrom   int64
+		wantTo     int64
+		wantHeld   int64
+		wantStatus domain.TransactionStatus
+	}{
+		{
+			name: "by account ID",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantFrom: 60, wantTo: 40, wantStatus: domain.TransactionStatusCompleted,
+		},
+		{
+			name: "by account number with matching beneficiary",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountNumber: to.AccountNumber, BeneficiaryName: "bob jones"}
+			},
+			wantFrom: 60, wantTo: 40, wantStatus: domain.TransactionStatusCompleted,
+		},
+		{
+			name: "beneficiary mismatch",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountNumber: to.AccountNumber, BeneficiaryName: "Carol King"}
+			},
+			wantErr: ErrBeneficiaryNameMismatch, wantFrom: 100,
+		},
+		{
+			name: "unknown account number",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountNumber: "0000000000"}
+			},
+			wantErr: ErrAccountNotFound, wantFrom: 100,
+		},
+		{
+			name: "by payee",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				payee := env.payeeFor(t, from.UserID, to)
+				return &domain.TransferRequest{PayeeID: payee.ID.String()}
+			},
+			wantFrom: 60, wantTo: 40, wantStatus: domain.TransactionStatusCompleted,
+		},
+		{
+			name: "someone else's payee",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				payee := env.payeeFor(t, env.newUser(t, "Carol King").ID, to)
+				return &domain.TransferRequest{PayeeID: payee.ID.String()}
+			},
+			wantErr: ErrPayeeNotFound, wantFrom: 100,
+		},
+		{
+			name: "no destination",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{}
+			},
+			wantErr: ErrInvalidDestination, wantFrom: 100,
+		},
+		{
+			name: "two destinations",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: to.ID.String(), ToAccountNumber: to.AccountNumber}
+			},
+			wantErr: ErrInvalidDestination, wantFrom: 100,
+		},
+		{
+			name: "same account",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: from.ID.String()}
+			},
+			wantErr: ErrSameAccount, wantFrom: 100,
+		},
+		{
+			name: "missing destination account",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: uuid.NewString()}
+			},
+			wantErr: ErrAccountNotFound, wantFrom: 100,
+		},
+		{
+			name: "zero amount",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: to.ID.String(), Amount: decimal.Zero}
+			},
+			wantErr: ErrInvalidAmount, wantFrom: 100,
+		},
+		{
+			name: "more than the balance",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{ToAccountID: to.ID.String(), Amount: decimal.NewFromInt(101)}
+			},
+			wantErr: ErrInsufficientBalance, wantFrom: 100,
+		},
+		{
+			name: "held funds can't be spent",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				env.newHold(t, from, 70)
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantErr: ErrInsufficientBalance, wantFrom: 100, wantHeld: 70,
+		},
+		{
+			name: "from someone else's account",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				return &domain.TransferRequest{FromAccountID: to.ID.String(), ToAccountID: from.ID.String()}
+			},
+			wantErr: ErrUnauthorized, wantFrom: 100,
+		},
+		{
+			name: "risk review holds the funds",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				env.risk.decision = domain.RiskDecisionReview
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantFrom: 100, wantHeld: 40, wantStatus: domain.TransactionStatusPending,
+		},
+		{
+			name: "risk block",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				env.risk.decision = domain.RiskDecisionBlock
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantErr: ErrTransferBlocked, wantFrom: 100,
+		},
+		{
+			name: "recipient possibly sanctioned",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				env.screener.set("Bob Jones", domain.RiskDecisionReview)
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantFrom: 100, wantHeld: 40, wantStatus: domain.TransactionStatusPending,
+		},
+		{
+			name: "recipient sanctioned",
+			req: func(env *testEnv, from, to *domain.Account) *domain.TransferRequest {
+				env.screener.set("Bob Jones", domain.RiskDecisionBlock)
+				return &domain.TransferRequest{ToAccountID: to.ID.String()}
+			},
+			wantErr: ErrTransferBlocked, wantFrom: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+			to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+
+			req := tt.req(env, from, to)
+			if req.FromAccountID == "" {
+				req.FromAccountID = from.ID.String()
+			}
+			if req.Amount.IsZero() && tt.wantErr != ErrInvalidAmount {
+				req.Amount = decimal.NewFromInt(40)
+			}
+
+			tx, err := env.transactions.Transfer(context.Background(), from.UserID, req)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tx.Status != tt.wantStatus {
+				t.Errorf("status %s, want %s", tx.Status, tt.wantStatus)
+			}
+			env.assertBalances(t, from.ID, tt.wantFrom, tt.wantHeld)
+			env.assertBalances(t, to.ID, tt.wantTo, 0)
+		})
+	}
+}
+
+func TestTransferPassesRiskInput(t *testing.T) {
+	env := newTestEnv(t)
+	from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+	payee := env.payeeFor(t, from.UserID, to)
+
+	_, err := env.transactions.Transfer(context.Background(), from.UserID, &domain.TransferRequest{
+		FromAccountID: from.ID.String(),
+		PayeeID:       payee.ID.String(),
+		Amount:        decimal.NewFromInt(10),
+		DeviceID:      "phone",
+	})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	if len(env.risk.inputs) != 1 {
+		t.Fatalf("%d risk evaluations, want 1", len(env.risk.inputs))
+	}
+	input := env.risk.inputs[0]
+	if input.DeviceID != "phone" || input.PayeeCreatedAt == nil || !input.PerTransactionLimit.Equal(decimal.NewFromInt(10000)) {
+		t.Errorf("unexpected risk input %+v", input)
+	}
+}
+
+// retryingUnitOfWork rolls back the first run of every unit of work and
+// runs it again, as a unit of work that collided with concurrent work does.
+type retryingUnitOfWork struct {
+	repository.UnitOfWork
+}
+
+func (u *retryingUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	_ = u.UnitOfWork.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		if err := fn(ctx, repos); err != nil {
+			return err
+		}
+		return repository.ErrConflict
+	})
+	return u.UnitOfWork.Do(ctx, fn)
+}
+
+func TestBlockedTransferRecordsOneAlert(t *testing.T) {
+	tests := []struct {
+		name     string
+		screened domain.RiskDecision
+		risk     domain.RiskDecision
+		want     int
+	}{
+		{"recipient sanctioned", domain.RiskDecisionBlock, domain.RiskDecisionAllow, 1},
+		{"blocked by risk rules", domain.RiskDecisionAllow, domain.RiskDecisionBlock, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.transactions.uow = &retryingUnitOfWork{UnitOfWork: env.store}
+			from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+			to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+			env.screener.set("Bob Jones", tt.screened)
+			env.risk.decision = tt.risk
+
+			_, err := env.transactions.Transfer(context.Background(), from.UserID, &domain.TransferRequest{
+				FromAccountID: from.ID.String(),
+				ToAccountID:   to.ID.String(),
+				Amount:        decimal.NewFromInt(10),
+			})
+			if err != ErrTransferBlocked {
+				t.Fatalf("error %v, want %v", err, ErrTransferBlocked)
+			}
+			if got := pendingAlerts(t, env); got != tt.want {
+				t.Errorf("%d alerts, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferTrustsDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision domain.RiskDecision
+		// review, if set, approves or rejects the held transfer
+		review string
+		want   bool
+	}{
+		{"completed transfer", domain.RiskDecisionAllow, "", true},
+		{"held transfer", domain.RiskDecisionReview, "", false},
+		{"approved held transfer", domain.RiskDecisionReview, "approve", true},
+		{"rejected held transfer", domain.RiskDecisionReview, "reject", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+			to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+			env.risk.decision = tt.decision
+
+			_, err := env.transactions.Transfer(context.Background(), from.UserID, &domain.TransferRequest{
+				FromAccountID: from.ID.String(),
+				ToAccountID:   to.ID.String(),
+				Amount:        decimal.NewFromInt(10),
+				DeviceID:      "phone",
+			})
+			if err != nil {
+				t.Fatalf("Transfer: %v", err)
+			}
+			if tt.review != "" {
+				reviews, err := env.transactions.GetPendingReviews(context.Background(), 10, 0)
+				if err != nil || len(reviews) != 1 {
+					t.Fatalf("GetPendingReviews = %v, %v; want one", reviews, err)
+				}
+				decide := env.transactions.RejectReview
+				if tt.review == "approve" {
+					decide = env.transactions.ApproveReview
+				}
+				if _, err := decide(context.Background(), uuid.New(), reviews[0].ID, ""); err != nil {
+					t.Fatalf("deciding the review: %v", err)
+				}
+			}
+
+			known, _ := env.store.Devices().Exists(context.Background(), from.UserID, "phone")
+			if known != tt.want {
+				t.Errorf("device known = %v, want %v", known, tt.want)
+			}
+		})
+	}
+}
+
+// heldTransfer makes a transfer of 40 that is held for review and returns
+// the pending review.
+func (env *testEnv) heldTransfer(t *testing.T, from, to *domain.Account) *domain.TransactionReview {
+	t.Helper()
+
+	env.risk.decision = domain.RiskDecisionReview
+	defer func() { env.risk.decision = "" }()
+
+	tx, err := env.transactions.Transfer(context.Background(), from.UserID, &domain.TransferRequest{
+		FromAccountID: from.ID.String(),
+		ToAccountID:   to.ID.String(),
+		Amount:        decimal.NewFromInt(40),
+	})
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+
+	reviews, err := env.store.Reviews().GetPending(context.Background(), 100, 0)
+	if err != nil {
+		t.Fatalf("GetPending: %v", err)
+	}
+	for _, review := range reviews {
+		if review.TransactionID == tx.ID {
+			return review
+		}
+	}
+	t.Fatalf("no pending review for transaction %s", tx.ID)
+	return nil
+}
+
+func TestGetPendingReviews(t *testing.T) {
+	env := newTestEnv(t)
+	from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+	env.heldTransfer(t, from, to)
+	decided := env.heldTransfer(t, from, to)
+	if _, err := env.transactions.RejectReview(context.Background(), uuid.New(), decided.ID, ""); err != nil {
+		t.Fatalf("RejectReview: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		limit, offset int
+		want          int
+	}{
+		{"decided reviews are left out", 10, 0, 1},
+		{"past the end", 10, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews, err := env.transactions.GetPendingReviews(context.Background(), tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("GetPendingReviews: %v", err)
+			}
+			if len(reviews) != tt.want {
+				t.Errorf("%d reviews, want %d", len(reviews), tt.want)
+			}
+		})
+	}
+}
+
+func TestDecideReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		approve    bool
+		wantStatus domain.TransactionStatus
+		wantReview domain.ReviewStatus
+		wantFrom   int64
+		wantTo     int64
+	}{
+		{"approve", true, domain.TransactionStatusCompleted, domain.ReviewStatusApproved, 60, 40},
+		{"reject", false, domain.TransactionStatusFailed, domain.ReviewStatusRejected, 100, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			ctx := context.Background()
+			from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+			to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+			review := env.heldTransfer(t, from, to)
+			operatorID := uuid.New()
+
+			decide := env.transactions.RejectReview
+			if tt.approve {
+				decide = env.transactions.ApproveReview
+			}
+
+			tx, err := decide(ctx, operatorID, review.ID, "checked")
+			if err != nil {
+				t.Fatalf("decide: %v", err)
+			}
+			if tx.Status != tt.wantStatus {
+				t.Errorf("transaction %s, want %s", tx.Status, tt.wantStatus)
+			}
+			env.assertBalances(t, from.ID, tt.wantFrom, 0)
+			env.assertBalances(t, to.ID, tt.wantTo, 0)
+
+			got, _ := env.store.Reviews().GetByID(ctx, review.ID)
+			if got.Status != tt.wantReview || got.ReviewedBy == nil || *got.ReviewedBy != operatorID || got.Note == nil {
+				t.Errorf("unexpected review %+v", got)
+			}
+
+			if _, err := decide(ctx, operatorID, review.ID, ""); err != ErrReviewAlreadyDecided {
+				t.Errorf("deciding twice: error %v, want %v", err, ErrReviewAlreadyDecided)
+			}
+			if _, err := decide(ctx, operatorID, uuid.New(), ""); err != ErrReviewNotFound {
+				t.Errorf("missing review: error %v, want %v", err, ErrReviewNotFound)
+			}
+		})
+	}
+}
+
+func TestGetTransactionHistory(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	from := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	to := env.newAccount(t, env.newUser(t, "Bob Jones").ID, 0)
+	other := env.newAccount(t, to.UserID, 0)
+	for i := 0; i < 3; i++ {
+		_, err := env.transactions.Transfer(ctx, from.UserID, &domain.TransferRequest{
+			FromAccountID: from.ID.String(),
+			ToAccountID:   to.ID.String(),
+			Amount:        decimal.NewFromInt(10),
+		})
+		if err != nil {
+			t.Fatalf("Transfer: %v", err)
+		}
+	}
+
+	viewer := env.newUser(t, "Carol White")
+	env.addMember(t, from, viewer, domain.AccountRoleViewer)
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		accountID uuid.UUID
+		filter    *domain.TransactionFilter
+		want      int
+		wantErr   error
+	}{
+		{"sender", from.UserID, from.ID, nil, 3, nil},
+		{"recipient", to.UserID, to.ID, nil, 3, nil},
+		{"uninvolved account", to.UserID, other.ID, nil, 0, nil},
+		{"paged", from.UserID, from.ID, &domain.TransactionFilter{Limit: 2, Offset: 2}, 1, nil},
+		{"viewer", viewer.ID, from.ID, nil, 3, nil},
+		{"someone else's account", from.UserID, to.ID, nil, 0, ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions, err := env.transactions.GetTransactionHistory(ctx, tt.userID, tt.accountID, tt.filter)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if len(transactions) != tt.want {
+				t.Errorf("%d transactions, want %d", len(transactions), tt.want)
+			}
+		})
+	}
+}
+
+func TestDepositAndWithdraw(t *testing.T) {
+	env := newTestEnv(t)
+	account := env.newAccount(t, env.newUser(t, "Alice Smith").ID, 100)
+	env.newHold(t, account, 30)
+
+	deposit := func(accountID string, amount int64) (*domain.Transaction, error) {
+		return env.transactions.Deposit(context.Background(), &domain.DepositRequest{AccountID: accountID, Amount: decimal.NewFromInt(amount)})
+	}
+	withdraw := func(accountID string, amount int64) (*domain.Transaction, error) {
+		return env.transactions.Withdraw(context.Background(), &domain.WithdrawalRequest{AccountID: accountID, Amount: decimal.NewFromInt(amount)})
+	}
+
+	tests := []struct {
+		name        string
+		do          func(accountID string, amount int64) (*domain.Transaction, error)
+		accountID   string
+		amount      int64
+		wantErr     error
+		wantType    domain.TransactionType
+		wantBalance int64
+	}{
+		{"deposit", deposit, account.ID.String(), 50, nil, domain.TransactionTypeDeposit, 150},
+		{"zero deposit", deposit, account.ID.String(), 0, ErrInvalidAmount, "", 150},
+		{"deposit to missing account", deposit, uuid.NewString(), 50, ErrAccountNotFound, "", 150},
+		{"withdrawal", withdraw, account.ID.String(), 60, nil, domain.TransactionTypeWithdrawal, 90},
+		{"held funds can't be withdrawn", withdraw, account.ID.String(), 61, ErrInsufficientBalance, "", 90},
+		{"negative withdrawal", withdraw, account.ID.String(), -1, ErrInvalidAmount, "", 90},
+		{"withdrawal from bad account ID", withdraw, "nope", 1, ErrAccountNotFound, "", 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := tt.do(tt.accountID, tt.amount)
+			if err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (tx.Type != tt.wantType || tx.Status != domain.TransactionStatusCompleted) {
+				t.Errorf("%s transaction %s, want completed %s", tx.Status, tx.Type, tt.wantType)
+			}
+			env.assertBalances(t, account.ID, tt.wantBalance, 30)
+		})
+	}
+}
\ No newline at end of file
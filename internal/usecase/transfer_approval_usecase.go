@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+)
+
+var (
+	ErrTransferApprovalNotFound = apperror.NotFound("transfer_approval_not_found", "transfer approval not found")
+	ErrApprovalNotRequired      = apperror.Unprocessable("approval_not_required", "the transfer doesn't need approval and can be made directly")
+	ErrApprovalAlreadyDecided   = apperror.Conflict("approval_already_decided", "the transfer has already been decided")
+	ErrAlreadyApproved          = apperror.Conflict("already_approved", "you have already approved the transfer")
+	ErrApprovalNotApproved      = apperror.Conflict("approval_not_approved", "the transfer isn't approved and waiting to be made")
+)
+
+const approvalHistorySize = 100
+
+// TransferApprovalUseCase runs transfers that an account's approval policy
+// holds back. The member asking for the transfer counts as its first
+// approval; once enough members who can transact have approved it, it is
+// made as the member who asked, and any member who can transact may reject
+// it before then.
+type TransferApprovalUseCase struct {
+	approvalRepo repository.TransferApprovalRepository
+	accountRepo  repository.AccountRepository
+	memberRepo   repository.AccountMemberRepository
+	transactions *TransactionUseCase
+	uow          repository.UnitOfWork
+	now          func() time.Time
+}
+
+func NewTransferApprovalUseCase(approvalRepo repository.TransferApprovalRepository, accountRepo repository.AccountRepository, memberRepo repository.AccountMemberRepository, transactions *TransactionUseCase, uow repository.UnitOfWork) *TransferApprovalUseCase {
+	return &TransferApprovalUseCase{
+		approvalRepo: approvalRepo,
+		accountRepo:  accountRepo,
+		memberRepo:   memberRepo,
+		transactions: transactions,
+		uow:          uow,
+		now:          time.Now,
+	}
+}
+
+// RequestTransfer asks the account's members to approve a transfer. The
+// destination is resolved now, so a payee or beneficiary name is checked
+// when the transfer is asked for rather than when it is made. Like a
+// direct transfer, the policy is applied to what the amount is worth in the
+// account's own currency.
+func (uc *TransferApprovalUseCase) RequestTransfer(ctx context.Context, userID uuid.UUID, req *domain.TransferRequest) (*domain.TransferApproval, error) {
+	accountID, err := uuid.Parse(req.FromAccountID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+	account, err := uc.authorize(ctx, userID, accountID, domain.AccountRole.CanTransact)
+	if err != nil {
+		return nil, err
+	}
+
+	toAccountID, payee, _, err := uc.transactions.resolveDestination(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if toAccountID == accountID {
+		return nil, ErrSameAccount
+	}
+	if !req.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	currency, value, err := uc.transactions.transferValue(ctx, account, req)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := uc.memberRepo.GetApprovalPolicy(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Requires(value) {
+		return nil, ErrApprovalNotRequired
+	}
+
+	approval := &domain.TransferApproval{
+		AccountID:         accountID,
+		RequestedBy:       userID,
+		ToAccountID:       &toAccountID,
+		Amount:            req.Amount,
+		Currency:          currency,
+		Description:       req.Description,
+		DeviceID:          req.DeviceID,
+		RequiredApprovals: policy.RequiredApprovals,
+		ApprovedBy:        domain.IDList{userID},
+	}
+	if payee != nil {
+		approval.PayeeID = &payee.ID
+	}
+	if err := uc.approvalRepo.Create(ctx, approval); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (uc *TransferApprovalUseCase) GetAccountApprovals(ctx context.Context, userID, accountID uuid.UUID) ([]*domain.TransferApproval, error) {
+	if _, err := uc.authorize(ctx, userID, accountID, nil); err != nil {
+		return nil, err
+	}
+
+	return uc.approvalRepo.GetByAccountID(ctx, accountID, approvalHistorySize)
+}
+
+// Approve adds the user's approval. The approval that completes the count
+// makes the transfer, as Execute does.
+func (uc *TransferApprovalUseCase) Approve(ctx context.Context, userID, approvalID uuid.UUID) (*domain.TransferApproval, error) {
+	var approval *domain.TransferApproval
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		approval, err = uc.lockPending(ctx, repos, userID, approvalID)
+		if err != nil {
+			return err
+		}
+		if approval.HasApproved(userID) {
+			return ErrAlreadyApproved
+		}
+
+		approval.ApprovedBy = append(approval.ApprovedBy, userID)
+		if len(approval.ApprovedBy) >= approval.RequiredApprovals {
+			approval.Status = domain.TransferApprovalApproved
+		}
+		return repos.TransferApprovals.Update(ctx, approval)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != domain.TransferApprovalApproved {
+		return approval, nil
+	}
+
+	return uc.execute(ctx, approval)
+}
+
+// Execute makes an approved transfer that hasn't been made yet, because it
+// collided with other activity on the account or was interrupted. If it
+// fails for any other reason the approval is marked failed with the reason
+// and the error is returned.
+func (uc *TransferApprovalUseCase) Execute(ctx context.Context, userID, approvalID uuid.UUID) (*domain.TransferApproval, error) {
+	approval, err := uc.approvalRepo.GetByID(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, ErrTransferApprovalNotFound
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, approval.AccountID, userID, domain.AccountRole.CanTransact); err != nil {
+		return nil, err
+	}
+	if approval.Status != domain.TransferApprovalApproved {
+		return nil, ErrApprovalNotApproved
+	}
+
+	return uc.execute(ctx, approval)
+}
+
+// execute makes an approved transfer. The approval is marked executed in the
+// transfer's own unit of work, so however often it is run the transfer is
+// only made once. A transfer that collides with other activity leaves the
+// approval approved to be run again.
+func (uc *TransferApprovalUseCase) execute(ctx context.Context, approval *domain.TransferApproval) (*domain.TransferApproval, error) {
+	var executed *domain.TransferApproval
+	_, err := uc.transactions.transfer(ctx, approval.RequestedBy, approvalTransferRequest(approval), func(ctx context.Context, repos *repository.Repositories, transaction *domain.Transaction) error {
+		var err error
+		executed, err = uc.lockApproved(ctx, repos, approval.ID)
+		if err != nil {
+			return err
+		}
+
+		now := uc.now()
+		executed.Status = domain.TransferApprovalExecuted
+		executed.TransactionID = &transaction.ID
+		executed.DecidedAt = &now
+		return repos.TransferApprovals.Update(ctx, executed)
+	})
+	if err == nil {
+		return executed, nil
+	}
+	if errors.Is(err, ErrConcurrentUpdate) || err == ErrApprovalAlreadyDecided {
+		return nil, err
+	}
+
+	failure := err.Error()
+	updateErr := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		failed, err := uc.lockApproved(ctx, repos, approval.ID)
+		if err != nil {
+			return err
+		}
+
+		now := uc.now()
+		failed.Status = domain.TransferApprovalFailed
+		failed.Failure = &failure
+		failed.DecidedAt = &now
+		return repos.TransferApprovals.Update(ctx, failed)
+	})
+	if updateErr != nil && updateErr != ErrApprovalAlreadyDecided {
+		return nil, updateErr
+	}
+
+	return nil, err
+}
+
+func (uc *TransferApprovalUseCase) Reject(ctx context.Context, userID, approvalID uuid.UUID) (*domain.TransferApproval, error) {
+	var approval *domain.TransferApproval
+	err := uc.uow.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		var err error
+		approval, err = uc.lockPending(ctx, repos, userID, approvalID)
+		if err != nil {
+			return err
+		}
+
+		now := uc.now()
+		approval.Status = domain.TransferApprovalRejected
+		approval.RejectedBy = &userID
+		approval.DecidedAt = &now
+		return repos.TransferApprovals.Update(ctx, approval)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// lockApproved loads an approval that is approved but whose transfer
+// hasn't been made yet.
+func (uc *TransferApprovalUseCase) lockApproved(ctx context.Context, repos *repository.Repositories, approvalID uuid.UUID) (*domain.TransferApproval, error) {
+	approval, err := repos.TransferApprovals.GetByIDForUpdate(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, ErrTransferApprovalNotFound
+	}
+	if approval.Status != domain.TransferApprovalApproved {
+		return nil, ErrApprovalAlreadyDecided
+	}
+
+	return approval, nil
+}
+
+// lockPending loads a pending approval on an account the user can transact
+// on.
+func (uc *TransferApprovalUseCase) lockPending(ctx context.Context, repos *repository.Repositories, userID, approvalID uuid.UUID) (*domain.TransferApproval, error) {
+	approval, err := repos.TransferApprovals.GetByIDForUpdate(ctx, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, ErrTransferApprovalNotFound
+	}
+	if err := authorizeMember(ctx, repos.AccountMembers, approval.AccountID, userID, domain.AccountRole.CanTransact); err != nil {
+		return nil, err
+	}
+	if approval.Status != domain.TransferApprovalPending {
+		return nil, ErrApprovalAlreadyDecided
+	}
+
+	return approval, nil
+}
+
+func (uc *TransferApprovalUseCase) authorize(ctx context.Context, userID, accountID uuid.UUID, allowed func(domain.AccountRole) bool) (*domain.Account, error) {
+	account, err := uc.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrAccountNotFound
+	}
+	if allowed != nil && account.OrganizationID != nil {
+		return nil, ErrPaymentDraftRequired
+	}
+	if err := authorizeMember(ctx, uc.memberRepo, accountID, userID, allowed); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func approvalTransferRequest(approval *domain.TransferApproval) *domain.TransferRequest {
+	req := &domain.TransferRequest{
+		FromAccountID: approval.AccountID.String(),
+		FromCurrency:  approval.Currency,
+		Amount:        approval.Amount,
+		Description:   approval.Description,
+		DeviceID:      approval.DeviceID,
+	}
+	// The payee is used while it exists so the transfer is checked as one to
+	// a payee; once it is deleted the transfer goes to the account it named
+	if approval.PayeeID != nil {
+		req.PayeeID = approval.PayeeID.String()
+	} else {
+		req.ToAccountID = approval.ToAccountID.String()
+	}
+	return req
+}
\ No newline at end of file
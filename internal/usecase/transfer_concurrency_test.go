@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+	"github.com/nabiilNajm26/go-bank/internal/repository"
+	"github.com/nabiilNajm26/go-bank/internal/repository/memory"
+	"github.com/nabiilNajm26/go-bank/internal/repository/postgres"
+	"github.com/shopspring/decimal"
+)
+
+// crossingTransfers fires transfers between random pairs of accounts from
+// several goroutines, so the same pair is regularly crossed in both
+// directions at once. It fails the test on anything but a clean outcome, an
+// insufficient balance, or a transfer still conflicting after its retries,
+// which moves no money and is the caller's to retry.
+func crossingTransfers(t *testing.T, transactionUseCase *TransactionUseCase, accounts []*domain.Account, transfers, workers int) {
+	t.Helper()
+
+	jobs := make(chan int)
+	errs := make(chan error, transfers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for range jobs {
+				from := accounts[rng.Intn(len(accounts))]
+				to := accounts[rng.Intn(len(accounts))]
+				if from.ID == to.ID {
+					continue
+				}
+
+				_, err := transactionUseCase.Transfer(context.Background(), from.UserID, &domain.TransferRequest{
+					FromAccountID: from.ID.String(),
+					ToAccountID:   to.ID.String(),
+					Amount:        decimal.NewFromInt(int64(rng.Intn(20) + 1)),
+				})
+				if err != nil && err != ErrInsufficientBalance && err != ErrConcurrentUpdate {
+					errs <- err
+				}
+			}
+		}(int64(w))
+	}
+
+	for i := 0; i < transfers; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Transfer: %v", err)
+	}
+}
+
+func assertMoneyConserved(t *testing.T, accountRepo repository.AccountRepository, accounts []*domain.Account, want decimal.Decimal) {
+	t.Helper()
+
+	total := decimal.Zero
+	for _, account := range accounts {
+		got, err := accountRepo.GetByID(context.Background(), account.ID)
+		if err != nil {
+			t.Fatalf("GetByID: %v", err)
+		}
+		if got.Balance.IsNegative() {
+			t.Errorf("account %s went negative: %s", account.ID, got.Balance)
+		}
+		total = total.Add(got.Balance)
+	}
+	if !total.Equal(want) {
+		t.Errorf("total balance %s, want %s", total, want)
+	}
+}
+
+// lockOrderUnitOfWork records the accounts each unit of work locked, in the
+// order it locked them.
+type lockOrderUnitOfWork struct {
+	repository.UnitOfWork
+
+	mu    sync.Mutex
+	locks [][]uuid.UUID
+}
+
+func (u *lockOrderUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repos *repository.Repositories) error) error {
+	var locked []uuid.UUID
+	err := u.UnitOfWork.Do(ctx, func(ctx context.Context, repos *repository.Repositories) error {
+		locked = nil
+		recording := *repos
+		recording.Accounts = &lockRecordingAccounts{AccountRepository: repos.Accounts, locked: &locked}
+		return fn(ctx, &recording)
+	})
+
+	u.mu.Lock()
+	u.locks = append(u.locks, locked)
+	u.mu.Unlock()
+	return err
+}
+
+type lockRecordingAccounts struct {
+	repository.AccountRepository
+	locked *[]uuid.UUID
+}
+
+func (r *lockRecordingAccounts) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	*r.locked = append(*r.locked, id)
+	return r.AccountRepository.GetByIDForUpdate(ctx, id)
+}
+
+// assertLockedInOrder fails the test if any unit of work locked an account
+// with a lower ID after one with a higher ID, which could deadlock against a
+// transfer going the other way.
+func assertLockedInOrder(t *testing.T, locks [][]uuid.UUID) {
+	t.Helper()
+
+	for _, locked := range locks {
+		for i := 1; i < len(locked); i++ {
+			if bytes.Compare(locked[i-1][:], locked[i][:]) >= 0 {
+				t.Errorf("accounts locked in order %v, want ascending IDs", locked)
+				break
+			}
+		}
+	}
+}
+
+func TestLockAccounts(t *testing.T) {
+	store := memory.NewStore()
+	uow := &lockOrderUnitOfWork{UnitOfWork: store}
+	a := newMemoryAccount(t, store, uuid.New(), 10)
+	b := newMemoryAccount(t, store, uuid.New(), 20)
+
+	for _, pair := range [][2]*domain.Account{{a, b}, {b, a}, {a, a}} {
+		err := uow.Do(context.Background(), func(ctx context.Context, repos *repository.Repositories) error {
+			first, second, err := lockAccounts(ctx, repos, pair[0].ID, pair[1].ID)
+			if err != nil {
+				return err
+			}
+			// Whatever order they are locked in, they come back in the
+			// order asked for
+			if first.ID != pair[0].ID || second.ID != pair[1].ID {
+				t.Errorf("lockAccounts(%s, %s) returned %s, %s", pair[0].ID, pair[1].ID, first.ID, second.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("lockAccounts: %v", err)
+		}
+	}
+
+	assertLockedInOrder(t, uow.locks)
+	if len(uow.locks[2]) != 1 {
+		t.Errorf("the same account locked %d times, want once", len(uow.locks[2]))
+	}
+}
+
+// The memory store runs one unit of work at a time, so this can't deadlock;
+// it checks that crossing transfers conserve money and only ever lock
+// accounts in ID order, which is what keeps them from deadlocking in
+// Postgres.
+func TestCrossingTransfersInMemory(t *testing.T) {
+	store := memory.NewStore()
+	uow := &lockOrderUnitOfWork{UnitOfWork: store}
+	transactionUseCase := NewTransactionUseCase(store.Transactions(), store.Accounts(), store.AccountMembers(), nil, nil, store.Reviews(), nil, nil, nil, nil, nil, nil, uow, nil)
+
+	var accounts []*domain.Account
+	for i := 0; i < 5; i++ {
+		accounts = append(accounts, newMemoryAccount(t, store, uuid.New(), 100))
+	}
+
+	crossingTransfers(t, transactionUseCase, accounts, 2000, 16)
+	assertMoneyConserved(t, store.Accounts(), accounts, decimal.NewFromInt(500))
+	assertLockedInOrder(t, uow.locks)
+}
+
+func TestCrossingTransfersConserveMoney(t *testing.T) {
+	db := testPostgres(t)
+	if testing.Short() {
+		t.Skip("skipping stress test in short mode")
+	}
+
+	accountRepo := postgres.NewAccountRepository(db)
+	transactionUseCase := NewTransactionUseCase(
+		postgres.NewTransactionRepository(db), accountRepo, postgres.NewAccountMemberRepository(db), postgres.NewPayeeRepository(db), postgres.NewUserRepository(db),
+		postgres.NewReviewRepository(db), postgres.NewDeviceRepository(db), nil, nil, nil, nil, nil, postgres.NewUnitOfWork(db), nil)
+
+	var accounts []*domain.Account
+	for i := 0; i < 4; i++ {
+		accounts = append(accounts, createFundedAccount(t, db, decimal.NewFromInt(100)))
+	}
+
+	crossingTransfers(t, transactionUseCase, accounts, 2000, 16)
+	assertMoneyConserved(t, accountRepo, accounts, decimal.NewFromInt(400))
+}
+
+func TestConcurrentTransfersShareUserDailyLimit(t *testing.T) {
+	db := testPostgres(t)
+	ctx := context.Background()
+
+	limits := newLimitSet(100, 100, 1000)
+	limitUseCase := NewLimitUseCase(
+		postgres.NewLimitRepository(db), postgres.NewAccountRepository(db), postgres.NewAccountMemberRepository(db), postgres.NewUserRepository(db), postgres.NewTransactionRepository(db),
+		&domain.LimitPolicy{
+			AccountTypes: map[domain.AccountType]domain.LimitSet{domain.AccountTypeChecking: limits},
+			UserTiers:    map[domain.UserTier]domain.LimitSet{domain.UserTierStandard: limits},
+		})
+	transactionUseCase := NewTransactionUseCase(
+		postgres.NewTransactionRepository(db), postgres.NewAccountRepository(db), postgres.NewAccountMemberRepository(db), postgres.NewPayeeRepository(db), postgres.NewUserRepository(db),
+		postgres.NewReviewRepository(db), postgres.NewDeviceRepository(db), limitUseCase, nil, nil, nil, nil, postgres.NewUnitOfWork(db), nil)
+
+	for round := 0; round < 10; round++ {
+		first := createFundedAccount(t, db, decimal.NewFromInt(100))
+		second := &domain.Account{
+			UserID:        first.UserID,
+			AccountNumber: fmt.Sprintf("%010d", rand.Int63n(1e10)),
+			AccountType:   domain.AccountTypeChecking,
+			Balance:       decimal.NewFromInt(100),
+			Currency:      "USD",
+			Status:        domain.AccountStatusActive,
+		}
+		if err := postgres.NewAccountRepository(db).Create(ctx, second); err != nil {
+			t.Fatalf("create account: %v", err)
+		}
+		to := createFundedAccount(t, db, decimal.Zero)
+
+		// Each transfer fits the user's daily limit on its own, but not both
+		errs := make(chan error, 2)
+		for _, from := range []*domain.Account{first, second} {
+			go func(from *domain.Account) {
+				_, err := transactionUseCase.Transfer(ctx, from.UserID, &domain.TransferRequest{
+					FromAccountID: from.ID.String(),
+					ToAccountID:   to.ID.String(),
+					Amount:        decimal.NewFromInt(60),
+				})
+				errs <- err
+			}(from)
+		}
+
+		var succeeded, limited int
+		for i := 0; i < 2; i++ {
+			switch err := <-errs; err {
+			case nil:
+				succeeded++
+			case ErrDailyLimitExceeded:
+				limited++
+			default:
+				t.Fatalf("Transfer: %v", err)
+			}
+		}
+		if succeeded != 1 || limited != 1 {
+			t.Fatalf("round %d: %d transfers succeeded and %d hit the daily limit, want 1 and 1", round, succeeded, limited)
+		}
+	}
+}
\ No newline at end of file
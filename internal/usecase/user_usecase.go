@@ -2,27 +2,30 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/apperror"
 	"github.com/nabiilNajm26/go-bank/internal/domain"
 	"github.com/nabiilNajm26/go-bank/internal/repository"
 )
 
 var (
-	ErrUserHasActiveAccounts = errors.New("user has active accounts")
+	ErrUserHasActiveAccounts = apperror.Unprocessable("user_has_active_accounts", "close the user's accounts or leave the ones shared with them first")
+	ErrNameChangeBlocked     = apperror.Forbidden("name_change_blocked", "name change blocked by sanctions screening")
 )
 
 type UserUseCase struct {
 	userRepo    repository.UserRepository
 	accountRepo repository.AccountRepository
+	screening   *ScreeningUseCase
 }
 
-func NewUserUseCase(userRepo repository.UserRepository, accountRepo repository.AccountRepository) *UserUseCase {
+func NewUserUseCase(userRepo repository.UserRepository, accountRepo repository.AccountRepository, screening *ScreeningUseCase) *UserUseCase {
 	return &UserUseCase{
 		userRepo:    userRepo,
 		accountRepo: accountRepo,
+		screening:   screening,
 	}
 }
 
@@ -43,6 +46,9 @@ func (uc *UserUseCase) UpdateProfileImage(ctx context.Context, userID uuid.UUID,
 	return uc.userRepo.Update(ctx, user)
 }
 
+// UpdateUser changes the user's details. A new name is screened like one
+// given at registration: a blocked name is refused and a possible match is
+// let through with an alert.
 func (uc *UserUseCase) UpdateUser(ctx context.Context, userID uuid.UUID, req *domain.UpdateUserRequest) (*domain.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -64,10 +70,19 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, userID uuid.UUID, req *do
 		user.Email = *req.Email
 	}
 
-	// Update other fields if provided
-	if req.FullName != nil {
+	screening := &domain.ScreeningResult{Decision: domain.RiskDecisionAllow}
+	if req.FullName != nil && *req.FullName != user.FullName {
+		screening, err = uc.screening.Check(ctx, domain.ScreeningSubjectUser, *req.FullName)
+		if err != nil {
+			return nil, err
+		}
+		if screening.Decision == domain.RiskDecisionBlock {
+			return nil, ErrNameChangeBlocked
+		}
 		user.FullName = *req.FullName
 	}
+
+	// Update other fields if provided
 	if req.Phone != nil {
 		user.Phone = req.Phone
 	}
@@ -77,20 +92,33 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, userID uuid.UUID, req *do
 		return nil, err
 	}
 
+	if err := uc.screening.Flag(ctx, domain.ScreeningSubjectUser, user.ID, user.FullName, screening); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
+// DeleteUser deletes a user once all their accounts are closed. Closed
+// accounts and their transactions stay on the books, so a user who had any
+// is anonymized rather than removed: their personal details and password
+// are erased and they can no longer log in.
 func (uc *UserUseCase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	// Check if user has active accounts
+	var accounts []*domain.Account
 	if uc.accountRepo != nil {
-		accounts, err := uc.accountRepo.GetByUserID(ctx, userID)
-		if err != nil {
+		var err error
+		if accounts, err = uc.accountRepo.GetByUserID(ctx, userID); err != nil {
 			return err
 		}
-		if len(accounts) > 0 {
+	}
+	for _, account := range accounts {
+		if account.Status != domain.AccountStatusClosed {
 			return ErrUserHasActiveAccounts
 		}
 	}
 
+	if len(accounts) > 0 {
+		return uc.userRepo.Anonymize(ctx, userID)
+	}
 	return uc.userRepo.Delete(ctx, userID)
 }
\ No newline at end of file
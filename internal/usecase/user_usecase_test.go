@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nabiilNajm26/go-bank/internal/domain"
+)
+
+func TestUserUseCaseGetByID(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+
+	tests := []struct {
+		name  string
+		id    uuid.UUID
+		found bool
+	}{
+		{"found", user.ID, true},
+		{"missing is nil", uuid.New(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := env.users.GetByID(context.Background(), tt.id)
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			if (got != nil) != tt.found {
+				t.Errorf("GetByID = %+v, want found %v", got, tt.found)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileImage(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	const imageURL = "https://example.com/alice.png"
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"existing user", user.ID, nil},
+		{"missing user", uuid.New(), ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := env.users.UpdateProfileImage(context.Background(), tt.userID, imageURL); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, _ := env.store.Users().GetByID(context.Background(), user.ID)
+	if got.ProfileImageURL == nil || *got.ProfileImageURL != imageURL {
+		t.Errorf("profile image %v, want %s", got.ProfileImageURL, imageURL)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.newUser(t, "Alice Smith")
+	other := env.newUser(t, "Bob Jones")
+
+	newName := "Alice Brown"
+	newEmail := "alice.brown@example.com"
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		req     domain.UpdateUserRequest
+		wantErr error
+	}{
+		{"rename", user.ID, domain.UpdateUserRequest{FullName: &newName}, nil},
+		{"new email", user.ID, domain.UpdateUserRequest{Email: &newEmail}, nil},
+		{"same email", user.ID, domain.UpdateUserRequest{Email: &newEmail}, nil},
+		{"email taken", user.ID, domain.UpdateUserRequest{Email: &other.Email}, ErrEmailAlreadyExists},
+		{"missing user", uuid.New(), domain.UpdateUserRequest{FullName: &newName}, ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := env.users.UpdateUser(context.Background(), tt.userID, &tt.req); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	got, _ := env.store.Users().GetByID(context.Background(), user.ID)
+	if got.FullName != newName || got.Email != newEmail {
+		t.Errorf("stored %q <%s>, want %q <%s>", got.FullName, got.Email, newName, newEmail)
+	}
+}
+
+func TestUpdateUserScreensName(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.screener.set("Blocked Person", domain.RiskDecisionBlock)
+	env.screener.set("Possible Match", domain.RiskDecisionReview)
+	user := env.newUser(t, "Alice Smith")
+
+	tests := []struct {
+		name      string
+		fullName  string
+		wantErr   error
+		wantName  string
+		wantAlert bool
+	}{
+		{"clean name", "Alice Brown", nil, "Alice Brown", false},
+		{"blocked name is refused", "Blocked Person", ErrNameChangeBlocked, "Alice Brown", true},
+		{"possible match is flagged", "Possible Match", nil, "Possible Match", true},
+		{"unchanged name isn't screened again", "Possible Match", nil, "Possible Match", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := pendingAlerts(t, env)
+
+			if _, err := env.users.UpdateUser(ctx, user.ID, &domain.UpdateUserRequest{FullName: &tt.fullName}); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+			if got, _ := env.store.Users().GetByID(ctx, user.ID); got.FullName != tt.wantName {
+				t.Errorf("name %q, want %q", got.FullName, tt.wantName)
+			}
+			if got := pendingAlerts(t, env) > before; got != tt.wantAlert {
+				t.Errorf("alert recorded = %v, want %v", got, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	withAccount := env.newUser(t, "Alice Smith")
+	env.newAccount(t, withAccount.ID, 0)
+	withoutAccount := env.newUser(t, "Bob Jones")
+	withClosedAccount := env.newUser(t, "Carol White")
+	closed := env.newAccount(t, withClosedAccount.ID, 0)
+	if _, err := env.lifecycle.CloseAccount(ctx, withClosedAccount.ID, closed.ID, &domain.CloseAccountRequest{}); err != nil {
+		t.Fatalf("CloseAccount: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"has accounts", withAccount.ID, ErrUserHasActiveAccounts},
+		{"no accounts", withoutAccount.ID, nil},
+		{"only closed accounts", withClosedAccount.ID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := env.users.DeleteUser(context.Background(), tt.userID); err != tt.wantErr {
+				t.Fatalf("error %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got, _ := env.store.Users().GetByID(ctx, withoutAccount.ID); got != nil {
+		t.Error("deleted user still exists")
+	}
+
+	// The closed account's history still has a user to point to
+	got, _ := env.store.Users().GetByID(ctx, withClosedAccount.ID)
+	if got == nil || got.FullName != domain.DeletedUserName || got.Email == withClosedAccount.Email || got.PasswordHash != "" {
+		t.Errorf("user with a closed account = %+v, want them anonymized", got)
+	}
+}
\ No newline at end of file
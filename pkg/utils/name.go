@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// NormalizeName lowercases a person's name, drops punctuation and collapses
+// whitespace so "  O'Brien,  JOHN " and "obrien john" compare equal.
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// NamesMatch reports whether two names refer to the same holder, ignoring
+// case, punctuation and the order of the name parts.
+func NamesMatch(a, b string) bool {
+	aParts := strings.Fields(NormalizeName(a))
+	bParts := strings.Fields(NormalizeName(b))
+	if len(aParts) == 0 || len(aParts) != len(bParts) {
+		return false
+	}
+
+	sort.Strings(aParts)
+	sort.Strings(bParts)
+	for i := range aParts {
+		if aParts[i] != bParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MaskName keeps the first letter of each name part: "John Doe" -> "J*** D**".
+func MaskName(name string) string {
+	parts := strings.Fields(name)
+	for i, part := range parts {
+		runes := []rune(part)
+		parts[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(parts, " ")
+}